@@ -30,6 +30,7 @@ type AvailabilityMode string
 
 const (
 	SourceTypeImage SourceType = "Image"
+	SourceTypeGit   SourceType = "Git"
 
 	MetadataNameLabel = "olm.operatorframework.io/metadata.name"
 
@@ -37,12 +38,27 @@ const (
 	AvailabilityModeUnavailable AvailabilityMode = "Unavailable"
 
 	// Condition types
-	TypeServing = "Serving"
+	TypeServing  = "Serving"
+	TypeValid    = "Valid"
+	TypeDegraded = "Degraded"
 
 	// Serving Reasons
 	ReasonAvailable                = "Available"
 	ReasonUnavailable              = "Unavailable"
 	ReasonUserSpecifiedUnavailable = "UserSpecifiedUnavailable"
+
+	// Valid Reasons
+	ReasonInvalidContent = "InvalidContent"
+
+	// Degraded Reasons
+	ReasonServingLastKnownGood = "ServingLastKnownGood"
+
+	// InvalidContentPolicyKeepServing keeps serving the last valid content of
+	// a catalog when the content of its source is invalid.
+	InvalidContentPolicyKeepServing = "KeepServing"
+	// InvalidContentPolicyStopServing stops serving the content of a catalog
+	// when the content of its source is invalid.
+	InvalidContentPolicyStopServing = "StopServing"
 )
 
 // +genclient
@@ -109,6 +125,7 @@ type ClusterCatalogSpec struct {
 	//      ref: quay.io/operatorhubio/catalog:latest
 	//
 	// +required
+	// <opcon:experimental:validation:XValidation:rule="has(self.type) && self.type == 'Git' ? has(self.git) : !has(self.git)",message="git is required when source type is Git, and forbidden otherwise">
 	Source CatalogSource `json:"source"`
 
 	// priority is an optional field that defines a priority for this ClusterCatalog.
@@ -150,6 +167,24 @@ type ClusterCatalogSpec struct {
 	// +kubebuilder:default:="Available"
 	// +optional
 	AvailabilityMode AvailabilityMode `json:"availabilityMode,omitempty"`
+
+	// invalidContentPolicy is optional and configures what happens when the content of the catalog source
+	// fails validation, for instance because a channel references a bundle that does not exist, or the
+	// version of a bundle is not a valid semantic version.
+	// Allowed values are "KeepServing" and "StopServing". When omitted, the default is "KeepServing".
+	//
+	// When set to "KeepServing", the invalid content is not stored, and the content that was served before
+	// keeps being served until the catalog source provides valid content.
+	//
+	// When set to "StopServing", the content of the catalog is no longer served until the catalog source
+	// provides valid content.
+	//
+	// In both cases, the Valid condition reports the validation errors.
+	//
+	// +kubebuilder:validation:Enum=KeepServing;StopServing
+	// +optional
+	// <opcon:experimental>
+	InvalidContentPolicy string `json:"invalidContentPolicy,omitempty"`
 }
 
 // ClusterCatalogStatus defines the observed state of ClusterCatalog
@@ -171,6 +206,17 @@ type ClusterCatalogStatus struct {
 	// If the system initially fetched contents and polling identifies updates, both conditions can be active simultaneously:
 	//   - The Serving condition remains True with reason Available because the previous contents are still served via the HTTP(S) web server.
 	//   - The Progressing condition is True with reason Retrying because the system is working to serve the new version.
+	// <opcon:experimental:description>
+	//
+	// When the validation of catalog contents is enabled, the Valid condition represents whether the contents of the catalog source are valid:
+	//   - When status is True and reason is Succeeded, the contents passed validation.
+	//   - When status is False and reason is InvalidContent, the contents failed validation, and the message lists the validation errors.
+	//
+	// When the reporting of degraded catalogs is enabled, the Degraded condition represents whether the contents of the latest resolved source are served:
+	//   - When status is True and reason is ServingLastKnownGood, the contents of a newer source could not be unpacked, validated or stored.
+	//     The previous contents, described by resolvedSource, are still served, and the message explains why the newer contents are not.
+	//   - When status is False and reason is Succeeded, the contents of the latest resolved source are served.
+	// </opcon:experimental:description>
 	//
 	// +listType=map
 	// +listMapKey=type
@@ -179,6 +225,13 @@ type ClusterCatalogStatus struct {
 	// resolvedSource contains information about the resolved source based on the source type.
 	// +optional
 	ResolvedSource *ResolvedCatalogSource `json:"resolvedSource,omitempty"`
+	// failedSource contains information about the latest resolved source whose contents could not be served.
+	// It is set when the contents of a newer source than resolvedSource failed to be unpacked, validated or stored,
+	// and is unset once the contents of a resolved source are served.
+	// It is not set when the failure happened before the source could be resolved.
+	// +optional
+	// <opcon:experimental>
+	FailedSource *ResolvedCatalogSource `json:"failedSource,omitempty"`
 	// urls contains the URLs that can be used to access the catalog.
 	// +optional
 	URLs *ClusterCatalogURLs `json:"urls,omitempty"`
@@ -216,19 +269,35 @@ type ClusterCatalogURLs struct {
 type CatalogSource struct {
 	// type is a required field that specifies the type of source for the catalog.
 	//
+	// <opcon:standard:description>
 	// The only allowed value is "Image".
+	// </opcon:standard:description>
+	// <opcon:experimental:description>
+	// Allowed values are "Image" and "Git".
+	// </opcon:experimental:description>
 	//
 	// When set to "Image", the ClusterCatalog content is sourced from an OCI image.
 	// When using an image source, the image field must be set and must be the only field defined for this type.
+	// <opcon:experimental:description>
+	//
+	// When set to "Git", the ClusterCatalog content is sourced from a git repository.
+	// When using a git source, the git field must be set and must be the only field defined for this type.
+	// </opcon:experimental:description>
 	//
 	// +unionDiscriminator
 	// +kubebuilder:validation:Enum:="Image"
+	// <opcon:experimental:validation:Enum=Image;Git>
 	// +required
 	Type SourceType `json:"type"`
 	// image configures how catalog contents are sourced from an OCI image.
 	// It is required when type is Image, and forbidden otherwise.
 	// +optional
 	Image *ImageSource `json:"image,omitempty"`
+	// git configures how catalog contents are sourced from a git repository.
+	// It is required when type is Git, and forbidden otherwise.
+	// +optional
+	// <opcon:experimental>
+	Git *GitSource `json:"git,omitempty"`
 }
 
 // ResolvedCatalogSource is a discriminated union of resolution information for a Catalog.
@@ -238,17 +307,43 @@ type CatalogSource struct {
 type ResolvedCatalogSource struct {
 	// type is a required field that specifies the type of source for the catalog.
 	//
+	// <opcon:standard:description>
 	// The only allowed value is "Image".
+	// </opcon:standard:description>
+	// <opcon:experimental:description>
+	// Allowed values are "Image" and "Git".
+	// </opcon:experimental:description>
 	//
 	// When set to "Image", information about the resolved image source is set in the image field.
+	// <opcon:experimental:description>
+	// When set to "Git", information about the resolved git source is set in the git field.
+	// </opcon:experimental:description>
 	//
 	// +unionDiscriminator
 	// +kubebuilder:validation:Enum:="Image"
+	// <opcon:experimental:validation:Enum=Image;Git>
 	// +required
 	Type SourceType `json:"type"`
 	// image contains resolution information for a catalog sourced from an image.
 	// It must be set when type is Image, and forbidden otherwise.
+	// +required
+	// <opcon:experimental:validation:Optional>
 	Image *ResolvedImageSource `json:"image"`
+	// git contains resolution information for a catalog sourced from a git repository.
+	// It must be set when type is Git, and forbidden otherwise.
+	// +optional
+	// <opcon:experimental>
+	Git *ResolvedGitSource `json:"git,omitempty"`
+}
+
+// ResolvedGitSource provides information about the resolved source of a Catalog sourced from a git repository.
+type ResolvedGitSource struct {
+	// commit is the SHA of the commit the catalog contents were extracted from.
+	// +required
+	// +kubebuilder:validation:MinLength:=40
+	// +kubebuilder:validation:MaxLength:=64
+	// +kubebuilder:validation:XValidation:rule="self.matches('^[0-9a-f]+$')",message="commit must only contain lowercase hex characters (a-f, 0-9)"
+	Commit string `json:"commit"`
 }
 
 // ResolvedImageSource provides information about the resolved source of a Catalog sourced from an image.
@@ -331,6 +426,79 @@ type ImageSource struct {
 	PollIntervalMinutes *int `json:"pollIntervalMinutes,omitempty"`
 }
 
+// GitSource enables users to define the information required for sourcing a Catalog from a git repository.
+//
+// There is no use in polling a repository when ref is a commit SHA, so pollIntervalMinutes is rejected in that case.
+// +kubebuilder:validation:XValidation:rule="has(self.ref) && self.ref.matches('^[0-9a-f]{40}$') ? !has(self.pollIntervalMinutes) : true",message="cannot specify pollIntervalMinutes while using a commit SHA ref"
+type GitSource struct {
+	// url is a required field that defines the location of the git repository containing the catalog contents.
+	// It cannot be more than 2048 characters.
+	//
+	// The http, https and ssh schemes are supported, as well as the scp-like "user@host:path" syntax for ssh.
+	// Some examples of valid urls are "https://github.com/my-org/my-catalog.git" and "git@github.com:my-org/my-catalog.git".
+	//
+	// +required
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=2048
+	// +kubebuilder:validation:XValidation:rule="self.matches('^(https?|ssh)://') || (!self.contains('://') && self.matches('^([^@/:]+@)?[^@/:]+:'))",message="url must use the http, https or ssh scheme, or the scp-like user@host:path syntax"
+	URL string `json:"url"`
+
+	// ref is an optional field that selects the branch, tag or commit SHA to source the catalog contents from.
+	// Branches and tags may be given either by name, such as "main" or "v1.0.0", or as a fully qualified
+	// reference, such as "refs/heads/main". A commit must be given as its full 40 character SHA.
+	// It cannot be more than 255 characters.
+	//
+	// When omitted, the default branch of the repository is used.
+	//
+	// +kubebuilder:validation:MaxLength:=255
+	// +optional
+	Ref string `json:"ref,omitempty"`
+
+	// directory is an optional field that defines the path, relative to the root of the repository,
+	// of the directory containing the catalog contents.
+	// It must be a relative path that does not contain ".." elements, and cannot be more than 1024 characters.
+	//
+	// When omitted, the root of the repository is used.
+	//
+	// +kubebuilder:validation:MaxLength:=1024
+	// +kubebuilder:validation:XValidation:rule="!self.startsWith('/') && !self.split('/').exists(e, e == '..')",message="directory must be a relative path that does not contain '..' elements"
+	// +optional
+	Directory string `json:"directory,omitempty"`
+
+	// authSecret is an optional reference to a Secret, in the namespace catalogd is running in,
+	// holding the credentials used to access the repository.
+	//
+	// For http(s) urls, the Secret must contain the "username" and "password" keys,
+	// as in a Secret of type kubernetes.io/basic-auth.
+	// For ssh urls, the Secret must contain the "ssh-privatekey" key, as in a Secret of type
+	// kubernetes.io/ssh-auth, and the "known_hosts" key used to verify the host key of the server.
+	//
+	// When omitted, the repository is accessed anonymously, which is only supported for http(s) urls.
+	//
+	// +optional
+	AuthSecret *GitAuthSecretReference `json:"authSecret,omitempty"`
+
+	// pollIntervalMinutes is an optional field that sets the interval, in minutes, at which the repository
+	// is polled for new commits.
+	// You cannot specify pollIntervalMinutes when ref is a commit SHA.
+	//
+	// When omitted, the repository is not polled for new content.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	PollIntervalMinutes *int `json:"pollIntervalMinutes,omitempty"`
+}
+
+// GitAuthSecretReference references a Secret holding the credentials used to access a git repository.
+type GitAuthSecretReference struct {
+	// name is a required field that identifies the Secret, in the namespace catalogd is running in.
+	// It must be a valid DNS1123 subdomain and cannot be more than 253 characters.
+	//
+	// +required
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:XValidation:rule="self.matches(\"^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$\")",message="name must be a valid DNS1123 subdomain. It must contain only lowercase alphanumeric characters, hyphens (-) or periods (.), start and end with an alphanumeric character, and be no longer than 253 characters"
+	Name string `json:"name"`
+}
+
 func init() {
 	SchemeBuilder.Register(func(s *runtime.Scheme) error {
 		s.AddKnownTypes(GroupVersion, &ClusterCatalog{}, &ClusterCatalogList{})
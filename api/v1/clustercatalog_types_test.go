@@ -20,7 +20,10 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
-const crdFilePath = "../../helm/olmv1/base/catalogd/crd/standard/olm.operatorframework.io_clustercatalogs.yaml"
+const (
+	crdFilePath             = "../../helm/olmv1/base/catalogd/crd/standard/olm.operatorframework.io_clustercatalogs.yaml"
+	experimentalCRDFilePath = "../../helm/olmv1/base/catalogd/crd/experimental/olm.operatorframework.io_clustercatalogs.yaml"
+)
 
 func TestImageSourceCELValidationRules(t *testing.T) {
 	validators := fieldValidatorsFromFile(t, crdFilePath)
@@ -377,6 +380,35 @@ func TestResolvedSourceCELValidation(t *testing.T) {
 	}
 }
 
+func TestGitSourceURLCELValidation(t *testing.T) {
+	validators := fieldValidatorsFromFile(t, experimentalCRDFilePath)
+	pth := "openAPIV3Schema.properties.spec.properties.source.properties.git.properties.url"
+	validator, found := validators[GroupVersion.Version][pth]
+	require.True(t, found)
+	for name, tc := range map[string]struct {
+		url     string
+		wantErr bool
+	}{
+		"https url":      {url: "https://github.com/my-org/my-catalog.git"},
+		"http url":       {url: "http://git.example.com/my-catalog.git"},
+		"ssh url":        {url: "ssh://git@github.com/my-org/my-catalog.git"},
+		"scp-like url":   {url: "git@github.com:my-org/my-catalog.git"},
+		"file url":       {url: "file:///var/lib/catalog", wantErr: true},
+		"local path":     {url: "/var/lib/catalog", wantErr: true},
+		"unknown scheme": {url: "git://github.com/my-org/my-catalog.git", wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			errs := validator(tc.url, nil)
+			if !tc.wantErr {
+				require.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Equal(t, fmt.Sprintf("%s: Invalid value: %q: url must use the http, https or ssh scheme, or the scp-like user@host:path syntax", pth, tc.url), errs[0].Error())
+		})
+	}
+}
+
 // fieldValidatorsFromFile extracts the CEL validators by version and JSONPath from a CRD file and returns
 // a validator func for testing against samples.
 // nolint:unparam
@@ -27,6 +27,7 @@ var ClusterExtensionKind = "ClusterExtension"
 type (
 	UpgradeConstraintPolicy     string
 	CRDUpgradeSafetyEnforcement string
+	DependencyPolicy            string
 
 	ClusterExtensionConfigType string
 )
@@ -44,7 +45,18 @@ const (
 	// disastrous results such as data loss.
 	UpgradeConstraintPolicySelfCertified UpgradeConstraintPolicy = "SelfCertified"
 
-	ClusterExtensionConfigTypeInline ClusterExtensionConfigType = "Inline"
+	// Dependencies of the resolved bundle must already be satisfied by
+	// other installed ClusterExtensions.
+	DependencyPolicyRequireInstalled DependencyPolicy = "RequireInstalled"
+
+	// Dependencies of the resolved bundle that are not satisfied by
+	// installed ClusterExtensions are resolved from catalogs and installed
+	// as ClusterExtensions of their own.
+	DependencyPolicyInstall DependencyPolicy = "Install"
+
+	ClusterExtensionConfigTypeInline       ClusterExtensionConfigType = "Inline"
+	ClusterExtensionConfigTypeConfigMapRef ClusterExtensionConfigType = "ConfigMapRef"
+	ClusterExtensionConfigTypeSecretRef    ClusterExtensionConfigType = "SecretRef"
 )
 
 // ClusterExtensionSpec defines the desired state of ClusterExtension
@@ -79,8 +91,14 @@ type ClusterExtensionSpec struct {
 	// source is required and selects the installation source of content for this ClusterExtension.
 	// Set the sourceType field to perform the selection.
 	//
+	// <opcon:standard:description>
 	// Catalog is currently the only implemented sourceType.
 	// Setting sourceType to "Catalog" requires the catalog field to also be defined.
+	// </opcon:standard:description>
+	// <opcon:experimental:description>
+	// Setting sourceType to "Catalog" requires the catalog field to also be defined.
+	// Setting sourceType to "Image" requires the image field to also be defined.
+	// </opcon:experimental:description>
 	//
 	// Below is a minimal example of a source definition (in yaml):
 	//
@@ -90,6 +108,7 @@ type ClusterExtensionSpec struct {
 	//     packageName: example-package
 	//
 	// +required
+	// <opcon:experimental:validation:XValidation:rule="has(self.sourceType) && self.sourceType == 'Image' ? has(self.image) : !has(self.image)",message="image is required when sourceType is Image, and forbidden otherwise">
 	Source SourceConfig `json:"source"`
 
 	// install is optional and configures installation options for the ClusterExtension,
@@ -110,6 +129,20 @@ type ClusterExtensionSpec struct {
 	// +optional
 	Config *ClusterExtensionConfig `json:"config,omitempty"`
 
+	// additionalConfigs is optional and lists configuration sources that are merged, in order,
+	// on top of config. The values of a later source take precedence over the values of an
+	// earlier one. Objects are merged key by key, and any other value, including a list,
+	// replaces the value of an earlier source.
+	//
+	// The merged configuration is validated against the configuration schema of the resolved
+	// bundle, as config is.
+	//
+	// +kubebuilder:validation:MaxItems:=8
+	// +listType=atomic
+	// +optional
+	// <opcon:experimental>
+	AdditionalConfigs []ClusterExtensionConfig `json:"additionalConfigs,omitempty"`
+
 	// progressDeadlineMinutes is an optional field that defines the maximum period
 	// of time in minutes after which an installation should be considered failed and
 	// require manual intervention. This functionality is disabled when no value
@@ -120,9 +153,190 @@ type ClusterExtensionSpec struct {
 	// +optional
 	// <opcon:experimental>
 	ProgressDeadlineMinutes int32 `json:"progressDeadlineMinutes,omitempty"`
+
+	// rolloutMode is optional and controls whether the resolved bundle is rolled out.
+	// Allowed values are "Apply" and "Plan". When omitted, the default is "Apply".
+	//
+	// When set to "Apply", the resolved bundle is installed or upgraded to.
+	//
+	// When set to "Plan", the resolved bundle is rendered and the preflight checks are run
+	// as for an installation or upgrade, but nothing is applied to the cluster. Instead, the
+	// objects that would be created, updated and deleted are reported in status.plan.
+	// Setting rolloutMode back to "Apply" rolls out the bundle.
+	//
+	// +kubebuilder:validation:Enum=Apply;Plan
+	// +optional
+	// <opcon:experimental>
+	RolloutMode string `json:"rolloutMode,omitempty"`
+
+	// upgradeApproval is optional and configures whether upgrades to a newly resolved
+	// bundle are rolled out automatically or must be approved first.
+	// When omitted, upgrades are rolled out automatically.
+	//
+	// +optional
+	// <opcon:experimental>
+	UpgradeApproval *UpgradeApproval `json:"upgradeApproval,omitempty"`
+
+	// maintenanceWindows is optional and restricts the rollout of upgrades to a newly resolved
+	// bundle to the given windows. An upgrade resolved outside of the windows is reported in
+	// status.pendingUpgrade and rolled out when the next window opens.
+	// When omitted, upgrades are rolled out as soon as they are resolved.
+	//
+	// The initial installation of a ClusterExtension, and upgrades that have already started
+	// rolling out, are not restricted to the windows.
+	//
+	// +optional
+	// <opcon:experimental>
+	MaintenanceWindows *MaintenanceWindows `json:"maintenanceWindows,omitempty"`
+
+	// rollbackPolicy is optional and configures what happens when a new revision of the
+	// ClusterExtension fails to roll out within progressDeadlineMinutes.
+	// Allowed values are "None" and "Automatic". When omitted, the default is "None".
+	//
+	// When set to "None", the failed revision is left in place and requires manual intervention.
+	//
+	// When set to "Automatic", the failed revision is archived, and the objects it took over are
+	// handed back to the latest previous revision that rolled out successfully. The RolledBack
+	// condition then reports the reason of the failure. The bundle of the failed revision is not
+	// rolled out again until a different bundle or configuration is resolved.
+	// "Automatic" requires progressDeadlineMinutes to be set.
+	//
+	// +kubebuilder:validation:Enum=None;Automatic
+	// +optional
+	// <opcon:experimental>
+	RollbackPolicy string `json:"rollbackPolicy,omitempty"`
+
+	// pinnedRevision is optional and pins the ClusterExtension to the content of one of its
+	// ClusterObjectSet revisions, identified by its spec.revision number. It can be used to roll
+	// back to a previous revision, including one that is already archived.
+	//
+	// When set, the bundle is not resolved from the catalogs, and the upgrade constraints are not
+	// checked. A new revision is created from the objects of the pinned revision after running the
+	// preflight checks, unless the latest revision already has the same content. Upgrade approval
+	// and maintenance windows do not hold the rollout of a pinned revision.
+	// When unset again, the bundle is resolved from the catalogs, and may be upgraded.
+	//
+	// The pinned revision must still exist. Only the most recent archived revisions are kept.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	// <opcon:experimental>
+	PinnedRevision int64 `json:"pinnedRevision,omitempty"`
+}
+
+// MaintenanceWindows configures the windows during which upgrades may be rolled out.
+type MaintenanceWindows struct {
+	// timeZone is optional and is the name of the time zone the schedules of the windows
+	// are evaluated in, as found in the IANA time zone database, such as "Europe/Berlin".
+	// When omitted, the schedules are evaluated in UTC.
+	//
+	// +kubebuilder:validation:MaxLength:=64
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// windows is required and lists the maintenance windows.
+	// Upgrades may be rolled out while any of the windows is open.
+	//
+	// +kubebuilder:validation:MinItems:=1
+	// +kubebuilder:validation:MaxItems:=16
+	// +listType=atomic
+	// +required
+	Windows []MaintenanceWindow `json:"windows"`
+}
+
+// MaintenanceWindow is a recurring window during which upgrades may be rolled out.
+type MaintenanceWindow struct {
+	// schedule is required and is the cron expression at which the window opens,
+	// in the five field format used by Kubernetes CronJobs, such as "0 2 * * 6"
+	// for every Saturday at 02:00.
+	//
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=128
+	// +required
+	Schedule string `json:"schedule"`
+
+	// durationMinutes is required and is the number of minutes the window stays open.
+	// It must be between 1 and 10080 (one week).
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=10080
+	// +required
+	DurationMinutes int32 `json:"durationMinutes"`
+}
+
+const (
+	// UpgradeScopePatch only upgrades to bundles with the same major and minor version as the installed bundle.
+	UpgradeScopePatch = "Patch"
+	// UpgradeScopeMinor only upgrades to bundles with the same major version as the installed bundle.
+	UpgradeScopeMinor = "Minor"
+	// UpgradeScopeAny upgrades regardless of the version of the installed bundle.
+	UpgradeScopeAny = "Any"
+)
+
+const (
+	// UpgradeApprovalAutomatic rolls out upgrades as soon as they are resolved.
+	UpgradeApprovalAutomatic = "Automatic"
+	// UpgradeApprovalManual rolls out upgrades once their version is approved.
+	UpgradeApprovalManual = "Manual"
+)
+
+// UpgradeApproval configures the approval of the upgrades of a ClusterExtension.
+type UpgradeApproval struct {
+	// policy is required and configures whether upgrades must be approved.
+	// Allowed values are "Automatic" and "Manual".
+	//
+	// When set to "Automatic", a newly resolved bundle is rolled out as soon as it is resolved.
+	//
+	// When set to "Manual", a newly resolved bundle is not rolled out. It is reported in
+	// status.pendingUpgrade until its version and release are set in approvedVersion and
+	// approvedRelease. The initial installation of a ClusterExtension does not need to be approved.
+	//
+	// +kubebuilder:validation:Enum=Automatic;Manual
+	// +required
+	Policy string `json:"policy"`
+
+	// approvedVersion is optional and is the version of the bundle approved to be upgraded to
+	// when policy is "Manual". A pending upgrade is rolled out once approvedVersion is set to
+	// the version reported in status.pendingUpgrade.bundle.version.
+	// approvedVersion has no effect when policy is "Automatic".
+	//
+	// +kubebuilder:validation:MaxLength:=64
+	// +optional
+	ApprovedVersion string `json:"approvedVersion,omitempty"`
+
+	// approvedRelease is optional and is the release of the bundle approved to be upgraded to
+	// when policy is "Manual". A pending upgrade is only rolled out once approvedRelease is also
+	// set to the release reported in status.pendingUpgrade.bundle.release, or left unset when
+	// the pending bundle has no release.
+	// approvedRelease has no effect when policy is "Automatic".
+	//
+	// +kubebuilder:validation:MaxLength:=20
+	// +optional
+	ApprovedRelease string `json:"approvedRelease,omitempty"`
 }
 
-const SourceTypeCatalog = "Catalog"
+const (
+	// RollbackPolicyNone leaves a revision that failed to roll out in place.
+	RollbackPolicyNone = "None"
+	// RollbackPolicyAutomatic archives a revision that failed to roll out in
+	// favour of the latest previous revision that rolled out successfully.
+	RollbackPolicyAutomatic = "Automatic"
+)
+
+const (
+	// RolloutModeApply rolls out the resolved bundle.
+	RolloutModeApply = "Apply"
+	// RolloutModePlan reports the changes rolling out the resolved bundle
+	// would make in the status, without applying them.
+	RolloutModePlan = "Plan"
+)
+
+const (
+	SourceTypeCatalog = "Catalog"
+	// SourceTypeBundleImage is the sourceType of a ClusterExtension that installs
+	// a bundle image directly, without resolving it from a catalog.
+	SourceTypeBundleImage = "Image"
+)
 
 // SourceConfig is a discriminated union which selects the installation source.
 //
@@ -131,14 +345,26 @@ const SourceTypeCatalog = "Catalog"
 type SourceConfig struct {
 	// sourceType is required and specifies the type of install source.
 	//
+	// <opcon:standard:description>
 	// The only allowed value is "Catalog".
+	// </opcon:standard:description>
+	// <opcon:experimental:description>
+	// Allowed values are "Catalog" and "Image".
+	// </opcon:experimental:description>
 	//
 	// When set to "Catalog", information for determining the appropriate bundle of content to install
 	// is fetched from ClusterCatalog resources on the cluster.
 	// When using the Catalog sourceType, the catalog field must also be set.
+	// <opcon:experimental:description>
+	//
+	// When set to "Image", the bundle image referenced by the image field is installed directly,
+	// without consulting any ClusterCatalog.
+	// When using the Image sourceType, the image field must also be set.
+	// </opcon:experimental:description>
 	//
 	// +unionDiscriminator
 	// +kubebuilder:validation:Enum:="Catalog"
+	// <opcon:experimental:validation:Enum=Catalog;Image>
 	// +required
 	SourceType string `json:"sourceType"`
 
@@ -147,6 +373,52 @@ type SourceConfig struct {
 	//
 	// +optional
 	Catalog *CatalogFilter `json:"catalog,omitempty"`
+
+	// image configures the bundle image to install.
+	// It is required when sourceType is "Image", and forbidden otherwise.
+	//
+	// +optional
+	// <opcon:experimental>
+	Image *BundleImageSource `json:"image,omitempty"`
+}
+
+// BundleImageSource defines the bundle image installed by a ClusterExtension.
+//
+// A digest-based image reference always points to the same content, so polling it is rejected.
+// +kubebuilder:validation:XValidation:rule="self.ref.find('(@.*:)') != \"\" ? !has(self.pollIntervalMinutes) : true",message="cannot specify pollIntervalMinutes while using digest-based image"
+type BundleImageSource struct {
+	// ref is a required field that defines the reference to a container image containing
+	// a bundle, either in the registry+v1 format or as a Helm chart.
+	// It cannot be more than 1000 characters.
+	//
+	// The reference must end with a tag or a digest, and follows the same format as the
+	// ref field of a ClusterCatalog image source.
+	//
+	// An example of a valid digest-based image reference is "quay.io/example/bundle@sha256:200d4ddb2a73594b91358fe6397424e975205bfbe44614f5846033cad64b3f05"
+	// An example of a valid tag-based image reference is "quay.io/example/bundle:v1.0.0"
+	//
+	// +required
+	// +kubebuilder:validation:MaxLength:=1000
+	// +kubebuilder:validation:XValidation:rule="self.matches('^([a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])((\\\\.([a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]))+)?(:[0-9]+)?\\\\b')",message="must start with a valid domain. valid domains must be alphanumeric characters (lowercase and uppercase) separated by the \".\" character."
+	// +kubebuilder:validation:XValidation:rule="self.find('(\\\\/[a-z0-9]+((([._]|__|[-]*)[a-z0-9]+)+)?((\\\\/[a-z0-9]+((([._]|__|[-]*)[a-z0-9]+)+)?)+)?)') != \"\"",message="a valid name is required. valid names must contain lowercase alphanumeric characters separated only by the \".\", \"_\", \"__\", \"-\" characters."
+	// +kubebuilder:validation:XValidation:rule="self.find('(@.*:)') != \"\" || self.find(':.*$') != \"\"",message="must end with a digest or a tag"
+	// +kubebuilder:validation:XValidation:rule="self.find('(@.*:)') == \"\" ? (self.find(':.*$') != \"\" ? self.find(':.*$').substring(1).size() <= 127 : true) : true",message="tag is invalid. the tag must not be more than 127 characters"
+	// +kubebuilder:validation:XValidation:rule="self.find('(@.*:)') == \"\" ? (self.find(':.*$') != \"\" ? self.find(':.*$').matches(':[\\\\w][\\\\w.-]*$') : true) : true",message="tag is invalid. valid tags must begin with a word character (alphanumeric + \"_\") followed by word characters or \".\", and \"-\" characters"
+	// +kubebuilder:validation:XValidation:rule="self.find('(@.*:)') != \"\" ? self.find('(@.*:)').matches('(@[A-Za-z][A-Za-z0-9]*([-_+.][A-Za-z][A-Za-z0-9]*)*[:])') : true",message="digest algorithm is not valid. valid algorithms must start with an uppercase or lowercase alpha character followed by alphanumeric characters and may contain the \"-\", \"_\", \"+\", and \".\" characters."
+	// +kubebuilder:validation:XValidation:rule="self.find('(@.*:)') != \"\" ? self.find(':.*$').substring(1).size() >= 32 : true",message="digest is not valid. the encoded string must be at least 32 characters"
+	// +kubebuilder:validation:XValidation:rule="self.find('(@.*:)') != \"\" ? self.find(':.*$').matches(':[0-9A-Fa-f]*$') : true",message="digest is not valid. the encoded string must only contain hex characters (A-F, a-f, 0-9)"
+	Ref string `json:"ref"`
+
+	// pollIntervalMinutes is an optional field that sets the interval, in minutes, at which a
+	// tag-based ref is resolved again to detect that the tag was moved to a new image.
+	// You cannot specify pollIntervalMinutes when ref is a digest-based reference.
+	//
+	// When omitted, the tag is not polled. It is only resolved again when the ref is changed
+	// or when operator-controller restarts.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	PollIntervalMinutes *int `json:"pollIntervalMinutes,omitempty"`
 }
 
 // ClusterExtensionInstallConfig is a union which selects the clusterExtension installation config.
@@ -169,16 +441,24 @@ type ClusterExtensionInstallConfig struct {
 // the ClusterExtension's rendered manifests.
 //
 // +kubebuilder:validation:XValidation:rule="has(self.configType) && self.configType == 'Inline' ?has(self.inline) : !has(self.inline)",message="inline is required when configType is Inline, and forbidden otherwise"
+// +kubebuilder:validation:XValidation:rule="has(self.configType) && self.configType == 'ConfigMapRef' ?has(self.configMapRef) : !has(self.configMapRef)",message="configMapRef is required when configType is ConfigMapRef, and forbidden otherwise"
+// +kubebuilder:validation:XValidation:rule="has(self.configType) && self.configType == 'SecretRef' ?has(self.secretRef) : !has(self.secretRef)",message="secretRef is required when configType is SecretRef, and forbidden otherwise"
 // +union
 type ClusterExtensionConfig struct {
 	// configType is required and specifies the type of configuration source.
 	//
-	// The only allowed value is "Inline".
+	// Allowed values are "Inline", "ConfigMapRef" and "SecretRef".
 	//
 	// When set to "Inline", the cluster extension configuration is defined inline within the ClusterExtension resource.
 	//
+	// When set to "ConfigMapRef", the cluster extension configuration is read from a key of a ConfigMap
+	// in the namespace of the ClusterExtension.
+	//
+	// When set to "SecretRef", the cluster extension configuration is read from a key of a Secret
+	// in the namespace of the ClusterExtension. Use it for sensitive values, such as credentials.
+	//
 	// +unionDiscriminator
-	// +kubebuilder:validation:Enum:="Inline"
+	// +kubebuilder:validation:Enum:="Inline";"ConfigMapRef";"SecretRef"
 	// +required
 	ConfigType ClusterExtensionConfigType `json:"configType"`
 
@@ -187,12 +467,55 @@ type ClusterExtensionConfig struct {
 	// It is used to specify arbitrary configuration values for the ClusterExtension.
 	// It must be set if configType is 'Inline' and must be a valid JSON/YAML object containing at least one property.
 	// The configuration values are validated at runtime against a JSON schema provided by the bundle.
+	// For Helm chart bundles, they are passed to the chart as its values.
 	//
 	// +kubebuilder:validation:Type=object
 	// +kubebuilder:validation:MinProperties=1
 	// +optional
 	// +unionMember
 	Inline *apiextensionsv1.JSON `json:"inline,omitempty"`
+
+	// configMapRef references the key of a ConfigMap holding JSON or YAML configuration values.
+	// It must be set if configType is 'ConfigMapRef', and forbidden otherwise.
+	// The ConfigMap must be in the namespace of the ClusterExtension and readable by its service account,
+	// and the value of the key must be an object.
+	// Changes to the ConfigMap are rolled out like changes to the ClusterExtension.
+	//
+	// +optional
+	// +unionMember
+	ConfigMapRef *ConfigKeyReference `json:"configMapRef,omitempty"`
+
+	// secretRef references the key of a Secret holding JSON or YAML configuration values.
+	// It must be set if configType is 'SecretRef', and forbidden otherwise.
+	// The Secret must be in the namespace of the ClusterExtension and readable by its service account,
+	// and the value of the key must be an object.
+	// The Secret is not watched, it is read again every 5 minutes and its changes are then rolled out.
+	//
+	// +optional
+	// +unionMember
+	SecretRef *ConfigKeyReference `json:"secretRef,omitempty"`
+}
+
+// ConfigKeyReference references a key of a ConfigMap or Secret in the namespace of the ClusterExtension.
+type ConfigKeyReference struct {
+	// name is required and is the name of the ConfigMap or Secret.
+	//
+	// It must follow the DNS subdomain standard as defined in [RFC 1123].
+	//
+	// [RFC 1123]: https://tools.ietf.org/html/rfc1123
+	//
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:XValidation:rule="self.matches(\"^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$\")",message="name must be a valid DNS1123 subdomain. It must contain only lowercase alphanumeric characters, hyphens (-) or periods (.), start and end with an alphanumeric character, and be no longer than 253 characters"
+	// +required
+	Name string `json:"name"`
+
+	// key is required and is the key of the ConfigMap or Secret holding the configuration values.
+	//
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=`^[-._a-zA-Z0-9]+$`
+	// +required
+	Key string `json:"key"`
 }
 
 // CatalogFilter defines the attributes used to identify and filter content from a catalog.
@@ -250,6 +573,9 @@ type CatalogFilter struct {
 	// "0.6.0", which means "only install version 0.6.0 and never
 	// upgrade from this version".
 	//
+	// A pinned version can also carry a release as its build metadata, such as
+	// "0.6.0+2", which means "only install release 2 of version 0.6.0".
+	//
 	// # Basic Comparison Operators
 	//
 	// The basic comparison operators and their meanings are:
@@ -376,6 +702,88 @@ type CatalogFilter struct {
 	// +kubebuilder:default:=CatalogProvided
 	// +optional
 	UpgradeConstraintPolicy UpgradeConstraintPolicy `json:"upgradeConstraintPolicy,omitempty"`
+
+	// upgradeScope is optional and limits how far automatic upgrades may move away from the
+	// version of the installed bundle.
+	//
+	// Allowed values are "Patch", "Minor", "Any", or omitted.
+	//
+	// When set to "Patch", only bundles with the same major and minor version as the installed bundle
+	// are installable, so that only patch releases are rolled out automatically.
+	//
+	// When set to "Minor", only bundles with the same major version as the installed bundle are installable.
+	//
+	// When set to "Any" or omitted, the version of the installed bundle does not limit upgrades.
+	//
+	// The scope applies in addition to the upgradeConstraintPolicy, version and channels fields.
+	// When a newer bundle is only excluded because of the scope, it is reported in status.outOfScopeUpgrade.
+	// The scope does not apply until a bundle is installed.
+	//
+	// +kubebuilder:validation:Enum:=Patch;Minor;Any
+	// +optional
+	// <opcon:experimental>
+	UpgradeScope string `json:"upgradeScope,omitempty"`
+
+	// soakTimeMinutes is optional and is the number of minutes a bundle must have been continuously
+	// available in a ClusterCatalog before the ClusterExtension is automatically upgraded to it.
+	// It must be between 1 and 525600 (365 days).
+	//
+	// The time a bundle became available is recorded by catalogd when its BundleFirstSeen feature is
+	// enabled. The ClusterExtension is not upgraded to bundles without such a record.
+	// The soak time does not apply until a bundle is installed.
+	//
+	// When omitted, bundles can be upgraded to as soon as they are available.
+	//
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=525600
+	// +optional
+	// <opcon:experimental>
+	SoakTimeMinutes int32 `json:"soakTimeMinutes,omitempty"`
+
+	// dependencyPolicy is optional and controls how the dependencies declared by the resolved bundle
+	// (via olm.package.required, olm.gvk.required and olm.constraint properties) are satisfied.
+	//
+	// Allowed values are "RequireInstalled", "Install", or omitted.
+	//
+	// When set to "RequireInstalled", every dependency must already be satisfied by another
+	// ClusterExtension installed on the cluster. Installation is held until that is the case.
+	//
+	// When set to "Install", dependencies on the packages listed in the installableDependencies field
+	// that are not satisfied by installed ClusterExtensions are resolved from the ClusterCatalogs selected
+	// by the selector field, and a ClusterExtension is created for each of them using the namespace and
+	// serviceAccount of this ClusterExtension. The permissions granted to that ServiceAccount are then
+	// used to install these packages too. Dependencies on other packages must be satisfied by installed
+	// ClusterExtensions.
+	//
+	// When omitted, the default value is "RequireInstalled".
+	//
+	// +kubebuilder:validation:Enum:=RequireInstalled;Install
+	// +optional
+	// <opcon:experimental>
+	DependencyPolicy DependencyPolicy `json:"dependencyPolicy,omitempty"`
+
+	// installableDependencies is optional and lists the packages that may be installed as dependencies
+	// of the resolved bundle when the dependencyPolicy field is set to "Install".
+	//
+	// The ClusterExtensions created for these packages use the namespace and serviceAccount of this
+	// ClusterExtension, so the ServiceAccount must be granted the permissions needed to install them.
+	// Only list packages that this ServiceAccount is meant to install.
+	//
+	// Each entry must be a package name following the DNS subdomain standard as defined in [RFC 1123].
+	// You can specify no more than 64 packages.
+	//
+	// When omitted, no dependency is installed and every dependency must be satisfied by installed
+	// ClusterExtensions.
+	//
+	// [RFC 1123]: https://tools.ietf.org/html/rfc1123
+	//
+	// +kubebuilder:validation:items:MaxLength:=253
+	// +kubebuilder:validation:MaxItems:=64
+	// +kubebuilder:validation:items:XValidation:rule="self.matches(\"^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$\")",message="installableDependencies entries must be valid DNS1123 subdomains"
+	// +listType=set
+	// +optional
+	// <opcon:experimental>
+	InstallableDependencies []string `json:"installableDependencies,omitempty"`
 }
 
 // ServiceAccountReference identifies the serviceAccount used fo install a ClusterExtension.
@@ -445,6 +853,14 @@ const (
 	TypeChannelDeprecated = "ChannelDeprecated"
 	TypeBundleDeprecated  = "BundleDeprecated"
 
+	// TypeKubernetesUpgradeable reports whether the bundle resolved for the
+	// ClusterExtension supports the next minor version of Kubernetes.
+	TypeKubernetesUpgradeable = "KubernetesUpgradeable"
+
+	// KubernetesUpgradeable reasons
+	ReasonCompatible   = "Compatible"
+	ReasonIncompatible = "Incompatible"
+
 	// None will not perform CRD upgrade safety checks.
 	CRDUpgradeSafetyEnforcementNone CRDUpgradeSafetyEnforcement = "None"
 	// Strict will enforce the CRD upgrade safety check and block the upgrade if the CRD would not pass the check.
@@ -490,6 +906,14 @@ type BundleMetadata struct {
 	// +kubebuilder:validation:MaxLength=20
 	// +kubebuilder:validation:XValidation:rule="self.matches(\"^$|^(0|[1-9][0-9]*|[0-9]*[A-Za-z-][0-9A-Za-z-]*)(\\\\.(0|[1-9][0-9]*|[0-9]*[A-Za-z-][0-9A-Za-z-]*))*$\")",message="release must be empty or consist of dot-separated identifiers (numeric without leading zeros, or alphanumeric)"
 	Release *string `json:"release,omitempty"`
+
+	// ref is the digest-based reference of the installed bundle image.
+	// It is only set for ClusterExtensions whose sourceType is "Image".
+	//
+	// +optional
+	// <opcon:experimental>
+	// +kubebuilder:validation:MaxLength=1000
+	Ref string `json:"ref,omitempty"`
 }
 
 // RevisionStatus defines the observed state of a ClusterObjectSet.
@@ -523,6 +947,19 @@ type ClusterExtensionStatus struct {
 	// When Progressing is False and the Reason is Blocked, the ClusterExtension has encountered an error that requires manual intervention for recovery.
 	// <opcon:experimental:description>
 	// When Progressing is True and Reason is RollingOut, the ClusterExtension has one or more ClusterObjectSets in active roll out.
+	// When Progressing is True and Reason is Planned, the changes rolling out the resolved bundle would make are reported in the plan field.
+	// When Progressing is False and Reason is UpgradePending, the resolved bundle is an upgrade that has not been approved yet and is reported in the pendingUpgrade field.
+	// When Progressing is False and Reason is AwaitingMaintenanceWindow, the resolved bundle is an upgrade that is held until the next maintenance window opens, at the time reported in the message, and is reported in the pendingUpgrade field.
+	// When Progressing is False and Reason is PolicyViolation, the ClusterExtension or the bundles it could be resolved to violate an ExtensionPolicy, as reported in the message.
+	//
+	// The RolledBack condition is present when spec.rollbackPolicy is "Automatic" and the latest revision failed to roll out.
+	// Its Reason is the reason of the failure, such as ProgressDeadlineExceeded, and its message reports the revision that was rolled back to.
+	//
+	// The KubernetesUpgradeable condition is present when the ClusterExtension is sourced from a catalog and
+	// the compatibility of bundles with the Kubernetes version of the cluster is checked:
+	//   - When KubernetesUpgradeable is True and the Reason is Compatible, the resolved bundle supports the next minor version of Kubernetes.
+	//   - When KubernetesUpgradeable is False and the Reason is Incompatible, the olm.maxKubeVersion property of the resolved bundle
+	//     blocks moving the cluster to the Kubernetes version reported in the message.
 	// </opcon:experimental:description>
 	//
 	// When the ClusterExtension is sourced from a catalog, it surfaces deprecation conditions based on catalog metadata.
@@ -549,6 +986,267 @@ type ClusterExtensionStatus struct {
 	// +optional
 	// <opcon:experimental>
 	ActiveRevisions []RevisionStatus `json:"activeRevisions,omitempty"`
+
+	// resolvedDependencies lists the packages selected to satisfy the dependencies
+	// declared by the resolved bundle, including transitive dependencies.
+	//
+	// +listType=map
+	// +listMapKey=packageName
+	// +optional
+	// <opcon:experimental>
+	ResolvedDependencies []ResolvedDependency `json:"resolvedDependencies,omitempty"`
+
+	// plan lists the changes rolling out the resolved bundle would make to the cluster.
+	// It is only set when spec.rolloutMode is "Plan".
+	//
+	// +optional
+	// <opcon:experimental>
+	Plan *ClusterExtensionPlan `json:"plan,omitempty"`
+
+	// pendingUpgrade is the upgrade that is held, either because it is waiting to be
+	// approved when spec.upgradeApproval.policy is "Manual", or because it is waiting
+	// for the next window of spec.maintenanceWindows to open.
+	//
+	// +optional
+	// <opcon:experimental>
+	PendingUpgrade *PendingUpgrade `json:"pendingUpgrade,omitempty"`
+
+	// resolution explains how the bundle was resolved from the catalogs the last time
+	// it was resolved, or why no bundle could be resolved.
+	// It is omitted when the bundle is not resolved from the catalogs.
+	//
+	// +optional
+	// <opcon:experimental>
+	Resolution *ClusterExtensionResolution `json:"resolution,omitempty"`
+
+	// outOfScopeUpgrade is the newest bundle the ClusterExtension would be upgraded to if
+	// spec.source.catalog.upgradeScope did not exclude it, from the catalog the bundle was
+	// resolved from.
+	// It is omitted when no newer bundle is excluded by the upgrade scope.
+	//
+	// +optional
+	// <opcon:experimental>
+	OutOfScopeUpgrade *BundleMetadata `json:"outOfScopeUpgrade,omitempty"`
+}
+
+// ClusterExtensionResolution explains how a bundle was resolved from the catalogs.
+type ClusterExtensionResolution struct {
+	// bundle is the name of the resolved bundle. It is omitted when no bundle could be resolved.
+	//
+	// +optional
+	Bundle string `json:"bundle,omitempty"`
+
+	// catalog is the name of the ClusterCatalog the bundle was resolved from.
+	// It is omitted when no bundle could be resolved.
+	//
+	// +optional
+	Catalog string `json:"catalog,omitempty"`
+
+	// message summarizes why the bundle was resolved from its catalog rather than
+	// from the other catalogs, or why no bundle could be resolved.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// catalogs lists the ClusterCatalogs that were considered, in the order they were considered.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Catalogs []CatalogResolution `json:"catalogs,omitempty"`
+}
+
+const (
+	CatalogResolutionSelected        = "Selected"
+	CatalogResolutionPackageNotFound = "PackageNotFound"
+	CatalogResolutionNoCandidates    = "NoCandidates"
+	CatalogResolutionLowerPriority   = "LowerPriority"
+	CatalogResolutionDeprecated      = "Deprecated"
+	CatalogResolutionAmbiguous       = "Ambiguous"
+
+	ResolutionFilterChannel           = "Channel"
+	ResolutionFilterVersionRange      = "VersionRange"
+	ResolutionFilterSuccessor         = "Successor"
+	ResolutionFilterUpgradeScope      = "UpgradeScope"
+	ResolutionFilterSoakTime          = "SoakTime"
+	ResolutionFilterExtensionPolicy   = "ExtensionPolicy"
+	ResolutionFilterKubernetesVersion = "KubernetesVersion"
+	ResolutionFilterConstraints       = "Constraints"
+)
+
+// CatalogResolution explains how the bundles of the package in a ClusterCatalog were considered.
+type CatalogResolution struct {
+	// name is the name of the ClusterCatalog.
+	//
+	// +required
+	Name string `json:"name"`
+
+	// priority is the priority of the ClusterCatalog.
+	//
+	// +required
+	Priority int32 `json:"priority"`
+
+	// result is the outcome of the resolution for the catalog.
+	// Allowed values are:
+	//   - "Selected": the bundle was resolved from this catalog.
+	//   - "PackageNotFound": the catalog does not provide the package.
+	//   - "NoCandidates": every bundle of the package was eliminated by the filters.
+	//   - "LowerPriority": another catalog providing candidates has a higher priority.
+	//   - "Deprecated": the best candidate of the catalog is deprecated, and another catalog provides a candidate that is not.
+	//   - "Ambiguous": other catalogs with the same priority provide candidates as well.
+	//
+	// +kubebuilder:validation:Enum=Selected;PackageNotFound;NoCandidates;LowerPriority;Deprecated;Ambiguous
+	// +required
+	Result string `json:"result"`
+
+	// bundles is the number of bundles of the package in the catalog.
+	//
+	// +required
+	Bundles int32 `json:"bundles"`
+
+	// filters lists the filters applied to the bundles of the package, in the order they were applied,
+	// along with the number of candidates each of them eliminated.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Filters []ResolutionFilter `json:"filters,omitempty"`
+
+	// candidates is the number of bundles left once the filters are applied.
+	//
+	// +required
+	Candidates int32 `json:"candidates"`
+
+	// deprecatedCandidates is the number of candidates that are deprecated.
+	// A deprecated candidate is only chosen when no other candidate is available.
+	//
+	// +optional
+	DeprecatedCandidates int32 `json:"deprecatedCandidates,omitempty"`
+
+	// bestCandidate is the name of the candidate that is chosen when the bundle is resolved from
+	// this catalog: the candidate with the highest version, unless it is deprecated and another is not.
+	// It is omitted when the catalog has no candidates.
+	//
+	// +optional
+	BestCandidate string `json:"bestCandidate,omitempty"`
+
+	// outOfScopeCandidate is the newest bundle of the package in the catalog that is only eliminated
+	// by the UpgradeScope filter, when it is newer than bestCandidate.
+	// It is omitted when the upgrade scope does not eliminate a newer bundle.
+	//
+	// +optional
+	OutOfScopeCandidate *BundleMetadata `json:"outOfScopeCandidate,omitempty"`
+}
+
+// ResolutionFilter is a filter applied to the bundles of a package during resolution.
+type ResolutionFilter struct {
+	// name identifies the filter.
+	// Allowed values are:
+	//   - "Channel": the bundle must be in one of spec.source.catalog.channels.
+	//   - "VersionRange": the version of the bundle must be in spec.source.catalog.version.
+	//   - "Successor": the bundle must be a successor of the installed bundle.
+	//   - "UpgradeScope": the version of the bundle must be in spec.source.catalog.upgradeScope of the installed bundle.
+	//   - "SoakTime": the bundle must have been available for spec.source.catalog.soakTimeMinutes.
+	//   - "ExtensionPolicy": the bundle must satisfy the ExtensionPolicies of the cluster.
+	//   - "KubernetesVersion": the bundle must support the Kubernetes version of the cluster.
+	//   - "Constraints": the olm.constraint properties of the bundle must be satisfiable.
+	//
+	// +kubebuilder:validation:Enum=Channel;VersionRange;Successor;UpgradeScope;SoakTime;ExtensionPolicy;KubernetesVersion;Constraints
+	// +required
+	Name string `json:"name"`
+
+	// eliminated is the number of candidates the filter eliminated.
+	//
+	// +required
+	Eliminated int32 `json:"eliminated"`
+}
+
+// PendingUpgrade is an upgrade of a ClusterExtension that is held before being rolled out.
+type PendingUpgrade struct {
+	// bundle identifies the bundle the ClusterExtension would be upgraded to.
+	//
+	// +required
+	Bundle BundleMetadata `json:"bundle"`
+}
+
+// ClusterExtensionPlan describes the changes rolling out a bundle would make to the cluster.
+type ClusterExtensionPlan struct {
+	// bundle identifies the bundle the plan was computed for.
+	//
+	// +required
+	Bundle BundleMetadata `json:"bundle"`
+
+	// changes lists the objects that would be created, updated or deleted.
+	// Objects that would be left unchanged are not listed. At most 512 changes
+	// are listed, the number of changes is reported in the Progressing condition.
+	//
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems:=512
+	// +optional
+	Changes []PlannedObjectChange `json:"changes,omitempty"`
+}
+
+const (
+	PlannedActionCreate = "Create"
+	PlannedActionUpdate = "Update"
+	PlannedActionDelete = "Delete"
+)
+
+// PlannedObjectChange is a change to an object of a plan.
+type PlannedObjectChange struct {
+	// action is the change that would be made to the object.
+	// Allowed values are "Create", "Update" and "Delete".
+	//
+	// +kubebuilder:validation:Enum=Create;Update;Delete
+	// +required
+	Action string `json:"action"`
+
+	// apiVersion is the API version of the object.
+	//
+	// +required
+	APIVersion string `json:"apiVersion"`
+
+	// kind is the kind of the object.
+	//
+	// +required
+	Kind string `json:"kind"`
+
+	// namespace is the namespace of the object. It is omitted for cluster-scoped objects.
+	//
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// name is the name of the object.
+	//
+	// +required
+	Name string `json:"name"`
+}
+
+// ResolvedDependency is a package selected to satisfy one or more dependencies of the resolved bundle.
+type ResolvedDependency struct {
+	// packageName is the name of the package that satisfies the dependency.
+	//
+	// +required
+	PackageName string `json:"packageName"`
+
+	// bundle identifies the bundle that satisfies the dependency. It is the installed bundle when the
+	// dependency is provided by an installed ClusterExtension, and the bundle selected from a catalog otherwise.
+	// It is omitted while the ClusterExtension providing the dependency has not installed a bundle yet.
+	//
+	// +optional
+	Bundle *BundleMetadata `json:"bundle,omitempty"`
+
+	// catalog is the name of the ClusterCatalog the bundle was selected from.
+	// It is omitted when the dependency is provided by an installed ClusterExtension.
+	//
+	// +optional
+	Catalog string `json:"catalog,omitempty"`
+
+	// clusterExtensionName is the name of the ClusterExtension that provides the dependency.
+	// It is omitted when the dependency is not provided by any ClusterExtension yet.
+	//
+	// +optional
+	ClusterExtensionName string `json:"clusterExtensionName,omitempty"`
 }
 
 // ClusterExtensionInstallStatus is a representation of the status of the identified bundle.
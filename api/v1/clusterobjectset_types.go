@@ -29,6 +29,7 @@ const (
 	ClusterObjectSetTypeAvailable   = "Available"
 	ClusterObjectSetTypeProgressing = "Progressing"
 	ClusterObjectSetTypeSucceeded   = "Succeeded"
+	ClusterObjectSetTypeRolledBack  = "RolledBack"
 
 	// Condition Reasons
 	ClusterObjectSetReasonArchived        = "Archived"
@@ -107,6 +108,20 @@ type ClusterObjectSetSpec struct {
 	// <opcon:experimental>
 	ProgressDeadlineMinutes int32 `json:"progressDeadlineMinutes,omitempty"`
 
+	// rollbackPolicy is an optional field that configures what happens when the revision
+	// fails to roll out within progressDeadlineMinutes.
+	// Allowed values are "None" and "Automatic". When omitted, the default is "None".
+	//
+	// When set to "Automatic", a revision that exceeded its progress deadline is archived
+	// if a previous revision of the same ClusterExtension is still active and has succeeded.
+	// Before it is archived, the RolledBack condition is set to True with the reason of the
+	// failure, and the objects the revision took over are handed back to the previous revision.
+	//
+	// +kubebuilder:validation:Enum=None;Automatic
+	// +optional
+	// <opcon:experimental>
+	RollbackPolicy string `json:"rollbackPolicy,omitempty"`
+
 	// progressionProbes is an optional field which provides the ability to define custom readiness probes
 	// for objects defined within spec.phases. As documented in that field, most kubernetes-native objects
 	// within the phases already have some kind of readiness check built-in, but this field allows for checks
@@ -507,6 +522,9 @@ type ClusterObjectSetStatus struct {
 	// The Succeeded condition represents whether the revision has successfully completed its rollout:
 	//   - When status is True and reason is Succeeded, the ClusterObjectSet has successfully completed its rollout. This condition is set once and persists even if the revision later becomes unavailable.
 	//
+	// The RolledBack condition represents whether the revision was rolled back after failing to roll out:
+	//   - When status is True and reason is ProgressDeadlineExceeded, the ClusterObjectSet did not roll out within progressDeadlineMinutes and is archived in favour of the previous revision reported in the message.
+	//
 	// +listType=map
 	// +listMapKey=type
 	// +optional
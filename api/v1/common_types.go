@@ -19,15 +19,20 @@ package v1
 const (
 	TypeInstalled   = "Installed"
 	TypeProgressing = "Progressing"
+	TypeRolledBack  = "RolledBack"
 
 	// Installed reasons
 	ReasonAbsent = "Absent"
 
 	// Progressing reasons
-	ReasonRollingOut           = "RollingOut"
-	ReasonRetrying             = "Retrying"
-	ReasonBlocked              = "Blocked"
-	ReasonInvalidConfiguration = "InvalidConfiguration"
+	ReasonRollingOut                = "RollingOut"
+	ReasonRetrying                  = "Retrying"
+	ReasonBlocked                   = "Blocked"
+	ReasonInvalidConfiguration      = "InvalidConfiguration"
+	ReasonPlanned                   = "Planned"
+	ReasonUpgradePending            = "UpgradePending"
+	ReasonAwaitingMaintenanceWindow = "AwaitingMaintenanceWindow"
+	ReasonPolicyViolation           = "PolicyViolation"
 
 	// Deprecation reasons
 	ReasonDeprecated               = "Deprecated"
@@ -0,0 +1,164 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const ExtensionPolicyKind = "ExtensionPolicy"
+
+const (
+	// DeprecatedBundlesAllow allows deprecated bundles to be installed.
+	DeprecatedBundlesAllow = "Allow"
+	// DeprecatedBundlesForbid prevents deprecated bundles from being installed.
+	DeprecatedBundlesForbid = "Forbid"
+)
+
+const (
+	// CRDUpgradeSafetyOptional lets ClusterExtensions disable the CRD Upgrade Safety pre-flight check.
+	CRDUpgradeSafetyOptional = "Optional"
+	// CRDUpgradeSafetyRequired prevents ClusterExtensions from disabling the CRD Upgrade Safety pre-flight check.
+	CRDUpgradeSafetyRequired = "Required"
+)
+
+// ExtensionPolicySpec defines the constraints that ClusterExtensions must satisfy.
+type ExtensionPolicySpec struct {
+	// allowedPackages is an optional list of the names of the packages that ClusterExtensions may install.
+	//
+	// When set, ClusterExtensions must be sourced from catalogs, and the packageName of their catalog source
+	// must be one of the listed packages.
+	// When omitted, ClusterExtensions may install any package.
+	//
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=256
+	// +kubebuilder:validation:items:MaxLength=253
+	// +kubebuilder:validation:items:XValidation:rule="self.matches(\"^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$\")",message="allowedPackages must be valid DNS1123 subdomains"
+	AllowedPackages []string `json:"allowedPackages,omitempty"`
+
+	// allowedCatalogs is an optional list of the names of the ClusterCatalogs that bundles may be resolved from.
+	//
+	// When set, ClusterExtensions must be sourced from catalogs, and only the bundles of the listed
+	// ClusterCatalogs are candidates for installation.
+	// When omitted, bundles may be resolved from any ClusterCatalog.
+	//
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:MaxLength=253
+	AllowedCatalogs []string `json:"allowedCatalogs,omitempty"`
+
+	// maxVersions is an optional list of the highest versions of packages that ClusterExtensions may install.
+	//
+	// Bundles of a listed package with a higher version are not candidates for installation.
+	// Each package may only be listed once.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=packageName
+	// +kubebuilder:validation:MaxItems=256
+	MaxVersions []PackageMaxVersion `json:"maxVersions,omitempty"`
+
+	// deprecatedBundles is optional and controls whether deprecated bundles may be installed.
+	//
+	// Allowed values are "Allow" and "Forbid". The default value is "Allow".
+	//
+	// When set to "Forbid", bundles that are marked deprecated in their catalog are not candidates
+	// for installation.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Allow;Forbid
+	DeprecatedBundles string `json:"deprecatedBundles,omitempty"`
+
+	// crdUpgradeSafety is optional and controls whether ClusterExtensions may disable the
+	// CRD Upgrade Safety pre-flight check.
+	//
+	// Allowed values are "Optional" and "Required". The default value is "Optional".
+	//
+	// When set to "Required", ClusterExtensions must not set the enforcement of
+	// spec.install.preflight.crdUpgradeSafety to "None".
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Optional;Required
+	CRDUpgradeSafety string `json:"crdUpgradeSafety,omitempty"`
+}
+
+// PackageMaxVersion is the highest version of a package that ClusterExtensions may install.
+type PackageMaxVersion struct {
+	// packageName is the name of the package.
+	//
+	// +required
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:XValidation:rule="self.matches(\"^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$\")",message="packageName must be a valid DNS1123 subdomain. It must contain only lowercase alphanumeric characters, hyphens (-) or periods (.), start and end with an alphanumeric character, and be no longer than 253 characters"
+	PackageName string `json:"packageName"`
+
+	// version is the highest version of the package that may be installed, such as "1.8.0".
+	// The release of bundles is not taken into account.
+	//
+	// +required
+	// +kubebuilder:validation:MaxLength:=64
+	// +kubebuilder:validation:XValidation:rule="self.matches(\"^([0-9]+)(\\\\.[0-9]+)?(\\\\.[0-9]+)?(-([-0-9A-Za-z]+(\\\\.[-0-9A-Za-z]+)*))?(\\\\+([-0-9A-Za-z]+(-\\\\.[-0-9A-Za-z]+)*))?$\")",message="version must be well-formed semver"
+	Version string `json:"version"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name=Age,type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ExtensionPolicy constrains what ClusterExtensions may install on the cluster.
+// ClusterExtensions must satisfy every ExtensionPolicy: each one can only further
+// restrict the packages, catalogs, and versions that may be installed.
+type ExtensionPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the constraints of the ExtensionPolicy.
+	// +optional
+	Spec ExtensionPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ExtensionPolicyList contains a list of ExtensionPolicy
+type ExtensionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// items is a required list of ExtensionPolicy objects.
+	//
+	// +required
+	Items []ExtensionPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(GroupVersion, &ExtensionPolicy{}, &ExtensionPolicyList{})
+		return nil
+	})
+}
@@ -43,6 +43,26 @@ func (in *Assertion) DeepCopy() *Assertion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleImageSource) DeepCopyInto(out *BundleImageSource) {
+	*out = *in
+	if in.PollIntervalMinutes != nil {
+		in, out := &in.PollIntervalMinutes, &out.PollIntervalMinutes
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleImageSource.
+func (in *BundleImageSource) DeepCopy() *BundleImageSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleImageSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BundleMetadata) DeepCopyInto(out *BundleMetadata) {
 	*out = *in
@@ -90,6 +110,11 @@ func (in *CatalogFilter) DeepCopyInto(out *CatalogFilter) {
 		in, out := &in.Selector, &out.Selector
 		*out = (*in).DeepCopy()
 	}
+	if in.InstallableDependencies != nil {
+		in, out := &in.InstallableDependencies, &out.InstallableDependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogFilter.
@@ -102,6 +127,31 @@ func (in *CatalogFilter) DeepCopy() *CatalogFilter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatalogResolution) DeepCopyInto(out *CatalogResolution) {
+	*out = *in
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]ResolutionFilter, len(*in))
+		copy(*out, *in)
+	}
+	if in.OutOfScopeCandidate != nil {
+		in, out := &in.OutOfScopeCandidate, &out.OutOfScopeCandidate
+		*out = new(BundleMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogResolution.
+func (in *CatalogResolution) DeepCopy() *CatalogResolution {
+	if in == nil {
+		return nil
+	}
+	out := new(CatalogResolution)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CatalogSource) DeepCopyInto(out *CatalogSource) {
 	*out = *in
@@ -110,6 +160,11 @@ func (in *CatalogSource) DeepCopyInto(out *CatalogSource) {
 		*out = new(ImageSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(GitSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogSource.
@@ -212,6 +267,11 @@ func (in *ClusterCatalogStatus) DeepCopyInto(out *ClusterCatalogStatus) {
 		*out = new(ResolvedCatalogSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FailedSource != nil {
+		in, out := &in.FailedSource, &out.FailedSource
+		*out = new(ResolvedCatalogSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.URLs != nil {
 		in, out := &in.URLs, &out.URLs
 		*out = new(ClusterCatalogURLs)
@@ -282,6 +342,16 @@ func (in *ClusterExtensionConfig) DeepCopyInto(out *ClusterExtensionConfig) {
 		in, out := &in.Inline, &out.Inline
 		*out = (*in).DeepCopy()
 	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(ConfigKeyReference)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(ConfigKeyReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExtensionConfig.
@@ -362,6 +432,49 @@ func (in *ClusterExtensionList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExtensionPlan) DeepCopyInto(out *ClusterExtensionPlan) {
+	*out = *in
+	in.Bundle.DeepCopyInto(&out.Bundle)
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]PlannedObjectChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExtensionPlan.
+func (in *ClusterExtensionPlan) DeepCopy() *ClusterExtensionPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExtensionPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExtensionResolution) DeepCopyInto(out *ClusterExtensionResolution) {
+	*out = *in
+	if in.Catalogs != nil {
+		in, out := &in.Catalogs, &out.Catalogs
+		*out = make([]CatalogResolution, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExtensionResolution.
+func (in *ClusterExtensionResolution) DeepCopy() *ClusterExtensionResolution {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExtensionResolution)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterExtensionSpec) DeepCopyInto(out *ClusterExtensionSpec) {
 	*out = *in
@@ -377,6 +490,23 @@ func (in *ClusterExtensionSpec) DeepCopyInto(out *ClusterExtensionSpec) {
 		*out = new(ClusterExtensionConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalConfigs != nil {
+		in, out := &in.AdditionalConfigs, &out.AdditionalConfigs
+		*out = make([]ClusterExtensionConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UpgradeApproval != nil {
+		in, out := &in.UpgradeApproval, &out.UpgradeApproval
+		*out = new(UpgradeApproval)
+		**out = **in
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = new(MaintenanceWindows)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExtensionSpec.
@@ -411,6 +541,33 @@ func (in *ClusterExtensionStatus) DeepCopyInto(out *ClusterExtensionStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ResolvedDependencies != nil {
+		in, out := &in.ResolvedDependencies, &out.ResolvedDependencies
+		*out = make([]ResolvedDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(ClusterExtensionPlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingUpgrade != nil {
+		in, out := &in.PendingUpgrade, &out.PendingUpgrade
+		*out = new(PendingUpgrade)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resolution != nil {
+		in, out := &in.Resolution, &out.Resolution
+		*out = new(ClusterExtensionResolution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OutOfScopeUpgrade != nil {
+		in, out := &in.OutOfScopeUpgrade, &out.OutOfScopeUpgrade
+		*out = new(BundleMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExtensionStatus.
@@ -592,6 +749,109 @@ func (in *ConditionEqualProbe) DeepCopy() *ConditionEqualProbe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigKeyReference) DeepCopyInto(out *ConfigKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigKeyReference.
+func (in *ConfigKeyReference) DeepCopy() *ConfigKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionPolicy) DeepCopyInto(out *ExtensionPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionPolicy.
+func (in *ExtensionPolicy) DeepCopy() *ExtensionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExtensionPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionPolicyList) DeepCopyInto(out *ExtensionPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ExtensionPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionPolicyList.
+func (in *ExtensionPolicyList) DeepCopy() *ExtensionPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExtensionPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionPolicySpec) DeepCopyInto(out *ExtensionPolicySpec) {
+	*out = *in
+	if in.AllowedPackages != nil {
+		in, out := &in.AllowedPackages, &out.AllowedPackages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedCatalogs != nil {
+		in, out := &in.AllowedCatalogs, &out.AllowedCatalogs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxVersions != nil {
+		in, out := &in.MaxVersions, &out.MaxVersions
+		*out = make([]PackageMaxVersion, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionPolicySpec.
+func (in *ExtensionPolicySpec) DeepCopy() *ExtensionPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FieldValueProbe) DeepCopyInto(out *FieldValueProbe) {
 	*out = *in
@@ -622,6 +882,46 @@ func (in *FieldsEqualProbe) DeepCopy() *FieldsEqualProbe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitAuthSecretReference) DeepCopyInto(out *GitAuthSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitAuthSecretReference.
+func (in *GitAuthSecretReference) DeepCopy() *GitAuthSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GitAuthSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitSource) DeepCopyInto(out *GitSource) {
+	*out = *in
+	if in.AuthSecret != nil {
+		in, out := &in.AuthSecret, &out.AuthSecret
+		*out = new(GitAuthSecretReference)
+		**out = **in
+	}
+	if in.PollIntervalMinutes != nil {
+		in, out := &in.PollIntervalMinutes, &out.PollIntervalMinutes
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSource.
+func (in *GitSource) DeepCopy() *GitSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GitSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageSource) DeepCopyInto(out *ImageSource) {
 	*out = *in
@@ -642,6 +942,41 @@ func (in *ImageSource) DeepCopy() *ImageSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindows) DeepCopyInto(out *MaintenanceWindows) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindows.
+func (in *MaintenanceWindows) DeepCopy() *MaintenanceWindows {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindows)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectSelector) DeepCopyInto(out *ObjectSelector) {
 	*out = *in
@@ -689,6 +1024,52 @@ func (in *ObservedPhase) DeepCopy() *ObservedPhase {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageMaxVersion) DeepCopyInto(out *PackageMaxVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageMaxVersion.
+func (in *PackageMaxVersion) DeepCopy() *PackageMaxVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageMaxVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingUpgrade) DeepCopyInto(out *PendingUpgrade) {
+	*out = *in
+	in.Bundle.DeepCopyInto(&out.Bundle)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingUpgrade.
+func (in *PendingUpgrade) DeepCopy() *PendingUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedObjectChange) DeepCopyInto(out *PlannedObjectChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedObjectChange.
+func (in *PlannedObjectChange) DeepCopy() *PlannedObjectChange {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedObjectChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PreflightConfig) DeepCopyInto(out *PreflightConfig) {
 	*out = *in
@@ -730,6 +1111,21 @@ func (in *ProgressionProbe) DeepCopy() *ProgressionProbe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolutionFilter) DeepCopyInto(out *ResolutionFilter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolutionFilter.
+func (in *ResolutionFilter) DeepCopy() *ResolutionFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolutionFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResolvedCatalogSource) DeepCopyInto(out *ResolvedCatalogSource) {
 	*out = *in
@@ -738,6 +1134,11 @@ func (in *ResolvedCatalogSource) DeepCopyInto(out *ResolvedCatalogSource) {
 		*out = new(ResolvedImageSource)
 		**out = **in
 	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(ResolvedGitSource)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedCatalogSource.
@@ -750,6 +1151,41 @@ func (in *ResolvedCatalogSource) DeepCopy() *ResolvedCatalogSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedDependency) DeepCopyInto(out *ResolvedDependency) {
+	*out = *in
+	if in.Bundle != nil {
+		in, out := &in.Bundle, &out.Bundle
+		*out = new(BundleMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedDependency.
+func (in *ResolvedDependency) DeepCopy() *ResolvedDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedGitSource) DeepCopyInto(out *ResolvedGitSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedGitSource.
+func (in *ResolvedGitSource) DeepCopy() *ResolvedGitSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedGitSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResolvedImageSource) DeepCopyInto(out *ResolvedImageSource) {
 	*out = *in
@@ -810,6 +1246,11 @@ func (in *SourceConfig) DeepCopyInto(out *SourceConfig) {
 		*out = new(CatalogFilter)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(BundleImageSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceConfig.
@@ -821,3 +1262,18 @@ func (in *SourceConfig) DeepCopy() *SourceConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeApproval) DeepCopyInto(out *UpgradeApproval) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeApproval.
+func (in *UpgradeApproval) DeepCopy() *UpgradeApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeApproval)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -48,6 +48,11 @@ type BundleMetadataApplyConfiguration struct {
 	//
 	// <opcon:experimental>
 	Release *string `json:"release,omitempty"`
+	// ref is the digest-based reference of the installed bundle image.
+	// It is only set for ClusterExtensions whose sourceType is "Image".
+	//
+	// <opcon:experimental>
+	Ref *string `json:"ref,omitempty"`
 }
 
 // BundleMetadataApplyConfiguration constructs a declarative configuration of the BundleMetadata type for use with
@@ -79,3 +84,11 @@ func (b *BundleMetadataApplyConfiguration) WithRelease(value string) *BundleMeta
 	b.Release = &value
 	return b
 }
+
+// WithRef sets the Ref field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ref field is set to the value of the last call.
+func (b *BundleMetadataApplyConfiguration) WithRef(value string) *BundleMetadataApplyConfiguration {
+	b.Ref = &value
+	return b
+}
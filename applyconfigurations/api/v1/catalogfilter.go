@@ -73,6 +73,9 @@ type CatalogFilterApplyConfiguration struct {
 	// "0.6.0", which means "only install version 0.6.0 and never
 	// upgrade from this version".
 	//
+	// A pinned version can also carry a release as its build metadata, such as
+	// "0.6.0+2", which means "only install release 2 of version 0.6.0".
+	//
 	// # Basic Comparison Operators
 	//
 	// The basic comparison operators and their meanings are:
@@ -181,6 +184,72 @@ type CatalogFilterApplyConfiguration struct {
 	//
 	// When omitted, the default value is "CatalogProvided".
 	UpgradeConstraintPolicy *apiv1.UpgradeConstraintPolicy `json:"upgradeConstraintPolicy,omitempty"`
+	// upgradeScope is optional and limits how far automatic upgrades may move away from the
+	// version of the installed bundle.
+	//
+	// Allowed values are "Patch", "Minor", "Any", or omitted.
+	//
+	// When set to "Patch", only bundles with the same major and minor version as the installed bundle
+	// are installable, so that only patch releases are rolled out automatically.
+	//
+	// When set to "Minor", only bundles with the same major version as the installed bundle are installable.
+	//
+	// When set to "Any" or omitted, the version of the installed bundle does not limit upgrades.
+	//
+	// The scope applies in addition to the upgradeConstraintPolicy, version and channels fields.
+	// When a newer bundle is only excluded because of the scope, it is reported in status.outOfScopeUpgrade.
+	// The scope does not apply until a bundle is installed.
+	//
+	// <opcon:experimental>
+	UpgradeScope *string `json:"upgradeScope,omitempty"`
+	// soakTimeMinutes is optional and is the number of minutes a bundle must have been continuously
+	// available in a ClusterCatalog before the ClusterExtension is automatically upgraded to it.
+	// It must be between 1 and 525600 (365 days).
+	//
+	// The time a bundle became available is recorded by catalogd when its BundleFirstSeen feature is
+	// enabled. The ClusterExtension is not upgraded to bundles without such a record.
+	// The soak time does not apply until a bundle is installed.
+	//
+	// When omitted, bundles can be upgraded to as soon as they are available.
+	//
+	// <opcon:experimental>
+	SoakTimeMinutes *int32 `json:"soakTimeMinutes,omitempty"`
+	// dependencyPolicy is optional and controls how the dependencies declared by the resolved bundle
+	// (via olm.package.required, olm.gvk.required and olm.constraint properties) are satisfied.
+	//
+	// Allowed values are "RequireInstalled", "Install", or omitted.
+	//
+	// When set to "RequireInstalled", every dependency must already be satisfied by another
+	// ClusterExtension installed on the cluster. Installation is held until that is the case.
+	//
+	// When set to "Install", dependencies on the packages listed in the installableDependencies field
+	// that are not satisfied by installed ClusterExtensions are resolved from the ClusterCatalogs selected
+	// by the selector field, and a ClusterExtension is created for each of them using the namespace and
+	// serviceAccount of this ClusterExtension. The permissions granted to that ServiceAccount are then
+	// used to install these packages too. Dependencies on other packages must be satisfied by installed
+	// ClusterExtensions.
+	//
+	// When omitted, the default value is "RequireInstalled".
+	//
+	// <opcon:experimental>
+	DependencyPolicy *apiv1.DependencyPolicy `json:"dependencyPolicy,omitempty"`
+	// installableDependencies is optional and lists the packages that may be installed as dependencies
+	// of the resolved bundle when the dependencyPolicy field is set to "Install".
+	//
+	// The ClusterExtensions created for these packages use the namespace and serviceAccount of this
+	// ClusterExtension, so the ServiceAccount must be granted the permissions needed to install them.
+	// Only list packages that this ServiceAccount is meant to install.
+	//
+	// Each entry must be a package name following the DNS subdomain standard as defined in [RFC 1123].
+	// You can specify no more than 64 packages.
+	//
+	// When omitted, no dependency is installed and every dependency must be satisfied by installed
+	// ClusterExtensions.
+	//
+	// [RFC 1123]: https://tools.ietf.org/html/rfc1123
+	//
+	// <opcon:experimental>
+	InstallableDependencies []string `json:"installableDependencies,omitempty"`
 }
 
 // CatalogFilterApplyConfiguration constructs a declarative configuration of the CatalogFilter type for use with
@@ -230,3 +299,37 @@ func (b *CatalogFilterApplyConfiguration) WithUpgradeConstraintPolicy(value apiv
 	b.UpgradeConstraintPolicy = &value
 	return b
 }
+
+// WithUpgradeScope sets the UpgradeScope field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpgradeScope field is set to the value of the last call.
+func (b *CatalogFilterApplyConfiguration) WithUpgradeScope(value string) *CatalogFilterApplyConfiguration {
+	b.UpgradeScope = &value
+	return b
+}
+
+// WithSoakTimeMinutes sets the SoakTimeMinutes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SoakTimeMinutes field is set to the value of the last call.
+func (b *CatalogFilterApplyConfiguration) WithSoakTimeMinutes(value int32) *CatalogFilterApplyConfiguration {
+	b.SoakTimeMinutes = &value
+	return b
+}
+
+// WithDependencyPolicy sets the DependencyPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DependencyPolicy field is set to the value of the last call.
+func (b *CatalogFilterApplyConfiguration) WithDependencyPolicy(value apiv1.DependencyPolicy) *CatalogFilterApplyConfiguration {
+	b.DependencyPolicy = &value
+	return b
+}
+
+// WithInstallableDependencies adds the given value to the InstallableDependencies field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the InstallableDependencies field.
+func (b *CatalogFilterApplyConfiguration) WithInstallableDependencies(values ...string) *CatalogFilterApplyConfiguration {
+	for i := range values {
+		b.InstallableDependencies = append(b.InstallableDependencies, values[i])
+	}
+	return b
+}
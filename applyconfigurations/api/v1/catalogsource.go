@@ -29,14 +29,30 @@ import (
 type CatalogSourceApplyConfiguration struct {
 	// type is a required field that specifies the type of source for the catalog.
 	//
+	// <opcon:standard:description>
 	// The only allowed value is "Image".
+	// </opcon:standard:description>
+	// <opcon:experimental:description>
+	// Allowed values are "Image" and "Git".
+	// </opcon:experimental:description>
 	//
 	// When set to "Image", the ClusterCatalog content is sourced from an OCI image.
 	// When using an image source, the image field must be set and must be the only field defined for this type.
+	// <opcon:experimental:description>
+	//
+	// When set to "Git", the ClusterCatalog content is sourced from a git repository.
+	// When using a git source, the git field must be set and must be the only field defined for this type.
+	// </opcon:experimental:description>
+	//
+	// <opcon:experimental:validation:Enum=Image;Git>
 	Type *apiv1.SourceType `json:"type,omitempty"`
 	// image configures how catalog contents are sourced from an OCI image.
 	// It is required when type is Image, and forbidden otherwise.
 	Image *ImageSourceApplyConfiguration `json:"image,omitempty"`
+	// git configures how catalog contents are sourced from a git repository.
+	// It is required when type is Git, and forbidden otherwise.
+	// <opcon:experimental>
+	Git *GitSourceApplyConfiguration `json:"git,omitempty"`
 }
 
 // CatalogSourceApplyConfiguration constructs a declarative configuration of the CatalogSource type for use with
@@ -60,3 +76,11 @@ func (b *CatalogSourceApplyConfiguration) WithImage(value *ImageSourceApplyConfi
 	b.Image = value
 	return b
 }
+
+// WithGit sets the Git field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Git field is set to the value of the last call.
+func (b *CatalogSourceApplyConfiguration) WithGit(value *GitSourceApplyConfiguration) *CatalogSourceApplyConfiguration {
+	b.Git = value
+	return b
+}
@@ -39,6 +39,8 @@ type ClusterCatalogSpecApplyConfiguration struct {
 	// type: Image
 	// image:
 	// ref: quay.io/operatorhubio/catalog:latest
+	//
+	// <opcon:experimental:validation:XValidation:rule="has(self.type) && self.type == 'Git' ? has(self.git) : !has(self.git)",message="git is required when source type is Git, and forbidden otherwise">
 	Source *CatalogSourceApplyConfiguration `json:"source,omitempty"`
 	// priority is an optional field that defines a priority for this ClusterCatalog.
 	//
@@ -69,6 +71,21 @@ type ClusterCatalogSpecApplyConfiguration struct {
 	// Treat this the same as if the ClusterCatalog does not exist.
 	// Use "Unavailable" when you want to keep the ClusterCatalog but treat it as if it doesn't exist.
 	AvailabilityMode *apiv1.AvailabilityMode `json:"availabilityMode,omitempty"`
+	// invalidContentPolicy is optional and configures what happens when the content of the catalog source
+	// fails validation, for instance because a channel references a bundle that does not exist, or the
+	// version of a bundle is not a valid semantic version.
+	// Allowed values are "KeepServing" and "StopServing". When omitted, the default is "KeepServing".
+	//
+	// When set to "KeepServing", the invalid content is not stored, and the content that was served before
+	// keeps being served until the catalog source provides valid content.
+	//
+	// When set to "StopServing", the content of the catalog is no longer served until the catalog source
+	// provides valid content.
+	//
+	// In both cases, the Valid condition reports the validation errors.
+	//
+	// <opcon:experimental>
+	InvalidContentPolicy *string `json:"invalidContentPolicy,omitempty"`
 }
 
 // ClusterCatalogSpecApplyConfiguration constructs a declarative configuration of the ClusterCatalogSpec type for use with
@@ -100,3 +117,11 @@ func (b *ClusterCatalogSpecApplyConfiguration) WithAvailabilityMode(value apiv1.
 	b.AvailabilityMode = &value
 	return b
 }
+
+// WithInvalidContentPolicy sets the InvalidContentPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InvalidContentPolicy field is set to the value of the last call.
+func (b *ClusterCatalogSpecApplyConfiguration) WithInvalidContentPolicy(value string) *ClusterCatalogSpecApplyConfiguration {
+	b.InvalidContentPolicy = &value
+	return b
+}
@@ -44,9 +44,26 @@ type ClusterCatalogStatusApplyConfiguration struct {
 	// If the system initially fetched contents and polling identifies updates, both conditions can be active simultaneously:
 	// - The Serving condition remains True with reason Available because the previous contents are still served via the HTTP(S) web server.
 	// - The Progressing condition is True with reason Retrying because the system is working to serve the new version.
+	// <opcon:experimental:description>
+	//
+	// When the validation of catalog contents is enabled, the Valid condition represents whether the contents of the catalog source are valid:
+	// - When status is True and reason is Succeeded, the contents passed validation.
+	// - When status is False and reason is InvalidContent, the contents failed validation, and the message lists the validation errors.
+	//
+	// When the reporting of degraded catalogs is enabled, the Degraded condition represents whether the contents of the latest resolved source are served:
+	// - When status is True and reason is ServingLastKnownGood, the contents of a newer source could not be unpacked, validated or stored.
+	// The previous contents, described by resolvedSource, are still served, and the message explains why the newer contents are not.
+	// - When status is False and reason is Succeeded, the contents of the latest resolved source are served.
+	// </opcon:experimental:description>
 	Conditions []metav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
 	// resolvedSource contains information about the resolved source based on the source type.
 	ResolvedSource *ResolvedCatalogSourceApplyConfiguration `json:"resolvedSource,omitempty"`
+	// failedSource contains information about the latest resolved source whose contents could not be served.
+	// It is set when the contents of a newer source than resolvedSource failed to be unpacked, validated or stored,
+	// and is unset once the contents of a resolved source are served.
+	// It is not set when the failure happened before the source could be resolved.
+	// <opcon:experimental>
+	FailedSource *ResolvedCatalogSourceApplyConfiguration `json:"failedSource,omitempty"`
 	// urls contains the URLs that can be used to access the catalog.
 	URLs *ClusterCatalogURLsApplyConfiguration `json:"urls,omitempty"`
 	// lastUnpacked represents the last time the catalog contents were extracted from their source format.
@@ -82,6 +99,14 @@ func (b *ClusterCatalogStatusApplyConfiguration) WithResolvedSource(value *Resol
 	return b
 }
 
+// WithFailedSource sets the FailedSource field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailedSource field is set to the value of the last call.
+func (b *ClusterCatalogStatusApplyConfiguration) WithFailedSource(value *ResolvedCatalogSourceApplyConfiguration) *ClusterCatalogStatusApplyConfiguration {
+	b.FailedSource = value
+	return b
+}
+
 // WithURLs sets the URLs field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the URLs field is set to the value of the last call.
@@ -30,16 +30,35 @@ import (
 type ClusterExtensionConfigApplyConfiguration struct {
 	// configType is required and specifies the type of configuration source.
 	//
-	// The only allowed value is "Inline".
+	// Allowed values are "Inline", "ConfigMapRef" and "SecretRef".
 	//
 	// When set to "Inline", the cluster extension configuration is defined inline within the ClusterExtension resource.
+	//
+	// When set to "ConfigMapRef", the cluster extension configuration is read from a key of a ConfigMap
+	// in the namespace of the ClusterExtension.
+	//
+	// When set to "SecretRef", the cluster extension configuration is read from a key of a Secret
+	// in the namespace of the ClusterExtension. Use it for sensitive values, such as credentials.
 	ConfigType *apiv1.ClusterExtensionConfigType `json:"configType,omitempty"`
 	// inline contains JSON or YAML values specified directly in the ClusterExtension.
 	//
 	// It is used to specify arbitrary configuration values for the ClusterExtension.
 	// It must be set if configType is 'Inline' and must be a valid JSON/YAML object containing at least one property.
 	// The configuration values are validated at runtime against a JSON schema provided by the bundle.
+	// For Helm chart bundles, they are passed to the chart as its values.
 	Inline *apiextensionsv1.JSON `json:"inline,omitempty"`
+	// configMapRef references the key of a ConfigMap holding JSON or YAML configuration values.
+	// It must be set if configType is 'ConfigMapRef', and forbidden otherwise.
+	// The ConfigMap must be in the namespace of the ClusterExtension and readable by its service account,
+	// and the value of the key must be an object.
+	// Changes to the ConfigMap are rolled out like changes to the ClusterExtension.
+	ConfigMapRef *ConfigKeyReferenceApplyConfiguration `json:"configMapRef,omitempty"`
+	// secretRef references the key of a Secret holding JSON or YAML configuration values.
+	// It must be set if configType is 'SecretRef', and forbidden otherwise.
+	// The Secret must be in the namespace of the ClusterExtension and readable by its service account,
+	// and the value of the key must be an object.
+	// The Secret is not watched, it is read again every 5 minutes and its changes are then rolled out.
+	SecretRef *ConfigKeyReferenceApplyConfiguration `json:"secretRef,omitempty"`
 }
 
 // ClusterExtensionConfigApplyConfiguration constructs a declarative configuration of the ClusterExtensionConfig type for use with
@@ -63,3 +82,19 @@ func (b *ClusterExtensionConfigApplyConfiguration) WithInline(value apiextension
 	b.Inline = &value
 	return b
 }
+
+// WithConfigMapRef sets the ConfigMapRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConfigMapRef field is set to the value of the last call.
+func (b *ClusterExtensionConfigApplyConfiguration) WithConfigMapRef(value *ConfigKeyReferenceApplyConfiguration) *ClusterExtensionConfigApplyConfiguration {
+	b.ConfigMapRef = value
+	return b
+}
+
+// WithSecretRef sets the SecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecretRef field is set to the value of the last call.
+func (b *ClusterExtensionConfigApplyConfiguration) WithSecretRef(value *ConfigKeyReferenceApplyConfiguration) *ClusterExtensionConfigApplyConfiguration {
+	b.SecretRef = value
+	return b
+}
@@ -43,8 +43,14 @@ type ClusterExtensionSpecApplyConfiguration struct {
 	// source is required and selects the installation source of content for this ClusterExtension.
 	// Set the sourceType field to perform the selection.
 	//
+	// <opcon:standard:description>
 	// Catalog is currently the only implemented sourceType.
 	// Setting sourceType to "Catalog" requires the catalog field to also be defined.
+	// </opcon:standard:description>
+	// <opcon:experimental:description>
+	// Setting sourceType to "Catalog" requires the catalog field to also be defined.
+	// Setting sourceType to "Image" requires the image field to also be defined.
+	// </opcon:experimental:description>
 	//
 	// Below is a minimal example of a source definition (in yaml):
 	//
@@ -52,6 +58,8 @@ type ClusterExtensionSpecApplyConfiguration struct {
 	// sourceType: Catalog
 	// catalog:
 	// packageName: example-package
+	//
+	// <opcon:experimental:validation:XValidation:rule="has(self.sourceType) && self.sourceType == 'Image' ? has(self.image) : !has(self.image)",message="image is required when sourceType is Image, and forbidden otherwise">
 	Source *SourceConfigApplyConfiguration `json:"source,omitempty"`
 	// install is optional and configures installation options for the ClusterExtension,
 	// such as the pre-flight check configuration.
@@ -66,6 +74,16 @@ type ClusterExtensionSpecApplyConfiguration struct {
 	//
 	// <opcon:experimental>
 	Config *ClusterExtensionConfigApplyConfiguration `json:"config,omitempty"`
+	// additionalConfigs is optional and lists configuration sources that are merged, in order,
+	// on top of config. The values of a later source take precedence over the values of an
+	// earlier one. Objects are merged key by key, and any other value, including a list,
+	// replaces the value of an earlier source.
+	//
+	// The merged configuration is validated against the configuration schema of the resolved
+	// bundle, as config is.
+	//
+	// <opcon:experimental>
+	AdditionalConfigs []ClusterExtensionConfigApplyConfiguration `json:"additionalConfigs,omitempty"`
 	// progressDeadlineMinutes is an optional field that defines the maximum period
 	// of time in minutes after which an installation should be considered failed and
 	// require manual intervention. This functionality is disabled when no value
@@ -73,6 +91,62 @@ type ClusterExtensionSpecApplyConfiguration struct {
 	//
 	// <opcon:experimental>
 	ProgressDeadlineMinutes *int32 `json:"progressDeadlineMinutes,omitempty"`
+	// rolloutMode is optional and controls whether the resolved bundle is rolled out.
+	// Allowed values are "Apply" and "Plan". When omitted, the default is "Apply".
+	//
+	// When set to "Apply", the resolved bundle is installed or upgraded to.
+	//
+	// When set to "Plan", the resolved bundle is rendered and the preflight checks are run
+	// as for an installation or upgrade, but nothing is applied to the cluster. Instead, the
+	// objects that would be created, updated and deleted are reported in status.plan.
+	// Setting rolloutMode back to "Apply" rolls out the bundle.
+	//
+	// <opcon:experimental>
+	RolloutMode *string `json:"rolloutMode,omitempty"`
+	// upgradeApproval is optional and configures whether upgrades to a newly resolved
+	// bundle are rolled out automatically or must be approved first.
+	// When omitted, upgrades are rolled out automatically.
+	//
+	// <opcon:experimental>
+	UpgradeApproval *UpgradeApprovalApplyConfiguration `json:"upgradeApproval,omitempty"`
+	// maintenanceWindows is optional and restricts the rollout of upgrades to a newly resolved
+	// bundle to the given windows. An upgrade resolved outside of the windows is reported in
+	// status.pendingUpgrade and rolled out when the next window opens.
+	// When omitted, upgrades are rolled out as soon as they are resolved.
+	//
+	// The initial installation of a ClusterExtension, and upgrades that have already started
+	// rolling out, are not restricted to the windows.
+	//
+	// <opcon:experimental>
+	MaintenanceWindows *MaintenanceWindowsApplyConfiguration `json:"maintenanceWindows,omitempty"`
+	// rollbackPolicy is optional and configures what happens when a new revision of the
+	// ClusterExtension fails to roll out within progressDeadlineMinutes.
+	// Allowed values are "None" and "Automatic". When omitted, the default is "None".
+	//
+	// When set to "None", the failed revision is left in place and requires manual intervention.
+	//
+	// When set to "Automatic", the failed revision is archived, and the objects it took over are
+	// handed back to the latest previous revision that rolled out successfully. The RolledBack
+	// condition then reports the reason of the failure. The bundle of the failed revision is not
+	// rolled out again until a different bundle or configuration is resolved.
+	// "Automatic" requires progressDeadlineMinutes to be set.
+	//
+	// <opcon:experimental>
+	RollbackPolicy *string `json:"rollbackPolicy,omitempty"`
+	// pinnedRevision is optional and pins the ClusterExtension to the content of one of its
+	// ClusterObjectSet revisions, identified by its spec.revision number. It can be used to roll
+	// back to a previous revision, including one that is already archived.
+	//
+	// When set, the bundle is not resolved from the catalogs, and the upgrade constraints are not
+	// checked. A new revision is created from the objects of the pinned revision after running the
+	// preflight checks, unless the latest revision already has the same content. Upgrade approval
+	// and maintenance windows do not hold the rollout of a pinned revision.
+	// When unset again, the bundle is resolved from the catalogs, and may be upgraded.
+	//
+	// The pinned revision must still exist. Only the most recent archived revisions are kept.
+	//
+	// <opcon:experimental>
+	PinnedRevision *int64 `json:"pinnedRevision,omitempty"`
 }
 
 // ClusterExtensionSpecApplyConfiguration constructs a declarative configuration of the ClusterExtensionSpec type for use with
@@ -121,6 +195,19 @@ func (b *ClusterExtensionSpecApplyConfiguration) WithConfig(value *ClusterExtens
 	return b
 }
 
+// WithAdditionalConfigs adds the given value to the AdditionalConfigs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AdditionalConfigs field.
+func (b *ClusterExtensionSpecApplyConfiguration) WithAdditionalConfigs(values ...*ClusterExtensionConfigApplyConfiguration) *ClusterExtensionSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAdditionalConfigs")
+		}
+		b.AdditionalConfigs = append(b.AdditionalConfigs, *values[i])
+	}
+	return b
+}
+
 // WithProgressDeadlineMinutes sets the ProgressDeadlineMinutes field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ProgressDeadlineMinutes field is set to the value of the last call.
@@ -128,3 +215,43 @@ func (b *ClusterExtensionSpecApplyConfiguration) WithProgressDeadlineMinutes(val
 	b.ProgressDeadlineMinutes = &value
 	return b
 }
+
+// WithRolloutMode sets the RolloutMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RolloutMode field is set to the value of the last call.
+func (b *ClusterExtensionSpecApplyConfiguration) WithRolloutMode(value string) *ClusterExtensionSpecApplyConfiguration {
+	b.RolloutMode = &value
+	return b
+}
+
+// WithUpgradeApproval sets the UpgradeApproval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpgradeApproval field is set to the value of the last call.
+func (b *ClusterExtensionSpecApplyConfiguration) WithUpgradeApproval(value *UpgradeApprovalApplyConfiguration) *ClusterExtensionSpecApplyConfiguration {
+	b.UpgradeApproval = value
+	return b
+}
+
+// WithMaintenanceWindows sets the MaintenanceWindows field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaintenanceWindows field is set to the value of the last call.
+func (b *ClusterExtensionSpecApplyConfiguration) WithMaintenanceWindows(value *MaintenanceWindowsApplyConfiguration) *ClusterExtensionSpecApplyConfiguration {
+	b.MaintenanceWindows = value
+	return b
+}
+
+// WithRollbackPolicy sets the RollbackPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RollbackPolicy field is set to the value of the last call.
+func (b *ClusterExtensionSpecApplyConfiguration) WithRollbackPolicy(value string) *ClusterExtensionSpecApplyConfiguration {
+	b.RollbackPolicy = &value
+	return b
+}
+
+// WithPinnedRevision sets the PinnedRevision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PinnedRevision field is set to the value of the last call.
+func (b *ClusterExtensionSpecApplyConfiguration) WithPinnedRevision(value int64) *ClusterExtensionSpecApplyConfiguration {
+	b.PinnedRevision = &value
+	return b
+}
@@ -40,6 +40,19 @@ type ClusterExtensionStatusApplyConfiguration struct {
 	// When Progressing is False and the Reason is Blocked, the ClusterExtension has encountered an error that requires manual intervention for recovery.
 	// <opcon:experimental:description>
 	// When Progressing is True and Reason is RollingOut, the ClusterExtension has one or more ClusterObjectSets in active roll out.
+	// When Progressing is True and Reason is Planned, the changes rolling out the resolved bundle would make are reported in the plan field.
+	// When Progressing is False and Reason is UpgradePending, the resolved bundle is an upgrade that has not been approved yet and is reported in the pendingUpgrade field.
+	// When Progressing is False and Reason is AwaitingMaintenanceWindow, the resolved bundle is an upgrade that is held until the next maintenance window opens, at the time reported in the message, and is reported in the pendingUpgrade field.
+	// When Progressing is False and Reason is PolicyViolation, the ClusterExtension or the bundles it could be resolved to violate an ExtensionPolicy, as reported in the message.
+	//
+	// The RolledBack condition is present when spec.rollbackPolicy is "Automatic" and the latest revision failed to roll out.
+	// Its Reason is the reason of the failure, such as ProgressDeadlineExceeded, and its message reports the revision that was rolled back to.
+	//
+	// The KubernetesUpgradeable condition is present when the ClusterExtension is sourced from a catalog and
+	// the compatibility of bundles with the Kubernetes version of the cluster is checked:
+	// - When KubernetesUpgradeable is True and the Reason is Compatible, the resolved bundle supports the next minor version of Kubernetes.
+	// - When KubernetesUpgradeable is False and the Reason is Incompatible, the olm.maxKubeVersion property of the resolved bundle
+	// blocks moving the cluster to the Kubernetes version reported in the message.
 	// </opcon:experimental:description>
 	//
 	// When the ClusterExtension is sourced from a catalog, it surfaces deprecation conditions based on catalog metadata.
@@ -55,6 +68,35 @@ type ClusterExtensionStatusApplyConfiguration struct {
 	// including both installed and rolling out revisions.
 	// <opcon:experimental>
 	ActiveRevisions []RevisionStatusApplyConfiguration `json:"activeRevisions,omitempty"`
+	// resolvedDependencies lists the packages selected to satisfy the dependencies
+	// declared by the resolved bundle, including transitive dependencies.
+	//
+	// <opcon:experimental>
+	ResolvedDependencies []ResolvedDependencyApplyConfiguration `json:"resolvedDependencies,omitempty"`
+	// plan lists the changes rolling out the resolved bundle would make to the cluster.
+	// It is only set when spec.rolloutMode is "Plan".
+	//
+	// <opcon:experimental>
+	Plan *ClusterExtensionPlanApplyConfiguration `json:"plan,omitempty"`
+	// pendingUpgrade is the upgrade that is held, either because it is waiting to be
+	// approved when spec.upgradeApproval.policy is "Manual", or because it is waiting
+	// for the next window of spec.maintenanceWindows to open.
+	//
+	// <opcon:experimental>
+	PendingUpgrade *PendingUpgradeApplyConfiguration `json:"pendingUpgrade,omitempty"`
+	// resolution explains how the bundle was resolved from the catalogs the last time
+	// it was resolved, or why no bundle could be resolved.
+	// It is omitted when the bundle is not resolved from the catalogs.
+	//
+	// <opcon:experimental>
+	Resolution *ClusterExtensionResolutionApplyConfiguration `json:"resolution,omitempty"`
+	// outOfScopeUpgrade is the newest bundle the ClusterExtension would be upgraded to if
+	// spec.source.catalog.upgradeScope did not exclude it, from the catalog the bundle was
+	// resolved from.
+	// It is omitted when no newer bundle is excluded by the upgrade scope.
+	//
+	// <opcon:experimental>
+	OutOfScopeUpgrade *BundleMetadataApplyConfiguration `json:"outOfScopeUpgrade,omitempty"`
 }
 
 // ClusterExtensionStatusApplyConfiguration constructs a declarative configuration of the ClusterExtensionStatus type for use with
@@ -96,3 +138,48 @@ func (b *ClusterExtensionStatusApplyConfiguration) WithActiveRevisions(values ..
 	}
 	return b
 }
+
+// WithResolvedDependencies adds the given value to the ResolvedDependencies field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ResolvedDependencies field.
+func (b *ClusterExtensionStatusApplyConfiguration) WithResolvedDependencies(values ...*ResolvedDependencyApplyConfiguration) *ClusterExtensionStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResolvedDependencies")
+		}
+		b.ResolvedDependencies = append(b.ResolvedDependencies, *values[i])
+	}
+	return b
+}
+
+// WithPlan sets the Plan field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Plan field is set to the value of the last call.
+func (b *ClusterExtensionStatusApplyConfiguration) WithPlan(value *ClusterExtensionPlanApplyConfiguration) *ClusterExtensionStatusApplyConfiguration {
+	b.Plan = value
+	return b
+}
+
+// WithPendingUpgrade sets the PendingUpgrade field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PendingUpgrade field is set to the value of the last call.
+func (b *ClusterExtensionStatusApplyConfiguration) WithPendingUpgrade(value *PendingUpgradeApplyConfiguration) *ClusterExtensionStatusApplyConfiguration {
+	b.PendingUpgrade = value
+	return b
+}
+
+// WithResolution sets the Resolution field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resolution field is set to the value of the last call.
+func (b *ClusterExtensionStatusApplyConfiguration) WithResolution(value *ClusterExtensionResolutionApplyConfiguration) *ClusterExtensionStatusApplyConfiguration {
+	b.Resolution = value
+	return b
+}
+
+// WithOutOfScopeUpgrade sets the OutOfScopeUpgrade field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OutOfScopeUpgrade field is set to the value of the last call.
+func (b *ClusterExtensionStatusApplyConfiguration) WithOutOfScopeUpgrade(value *BundleMetadataApplyConfiguration) *ClusterExtensionStatusApplyConfiguration {
+	b.OutOfScopeUpgrade = value
+	return b
+}
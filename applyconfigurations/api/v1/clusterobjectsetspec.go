@@ -71,6 +71,17 @@ type ClusterObjectSetSpecApplyConfiguration struct {
 	//
 	// <opcon:experimental>
 	ProgressDeadlineMinutes *int32 `json:"progressDeadlineMinutes,omitempty"`
+	// rollbackPolicy is an optional field that configures what happens when the revision
+	// fails to roll out within progressDeadlineMinutes.
+	// Allowed values are "None" and "Automatic". When omitted, the default is "None".
+	//
+	// When set to "Automatic", a revision that exceeded its progress deadline is archived
+	// if a previous revision of the same ClusterExtension is still active and has succeeded.
+	// Before it is archived, the RolledBack condition is set to True with the reason of the
+	// failure, and the objects the revision took over are handed back to the previous revision.
+	//
+	// <opcon:experimental>
+	RollbackPolicy *string `json:"rollbackPolicy,omitempty"`
 	// progressionProbes is an optional field which provides the ability to define custom readiness probes
 	// for objects defined within spec.phases. As documented in that field, most kubernetes-native objects
 	// within the phases already have some kind of readiness check built-in, but this field allows for checks
@@ -138,6 +149,14 @@ func (b *ClusterObjectSetSpecApplyConfiguration) WithProgressDeadlineMinutes(val
 	return b
 }
 
+// WithRollbackPolicy sets the RollbackPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RollbackPolicy field is set to the value of the last call.
+func (b *ClusterObjectSetSpecApplyConfiguration) WithRollbackPolicy(value string) *ClusterObjectSetSpecApplyConfiguration {
+	b.RollbackPolicy = &value
+	return b
+}
+
 // WithProgressionProbes adds the given value to the ProgressionProbes field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the ProgressionProbes field.
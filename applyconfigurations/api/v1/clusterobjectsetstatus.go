@@ -45,6 +45,9 @@ type ClusterObjectSetStatusApplyConfiguration struct {
 	//
 	// The Succeeded condition represents whether the revision has successfully completed its rollout:
 	// - When status is True and reason is Succeeded, the ClusterObjectSet has successfully completed its rollout. This condition is set once and persists even if the revision later becomes unavailable.
+	//
+	// The RolledBack condition represents whether the revision was rolled back after failing to roll out:
+	// - When status is True and reason is ProgressDeadlineExceeded, the ClusterObjectSet did not roll out within progressDeadlineMinutes and is archived in favour of the previous revision reported in the message.
 	Conditions []metav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
 	// observedPhases records the content hashes of resolved phases
 	// at first successful reconciliation. This is used to detect if
@@ -29,13 +29,28 @@ import (
 type ResolvedCatalogSourceApplyConfiguration struct {
 	// type is a required field that specifies the type of source for the catalog.
 	//
+	// <opcon:standard:description>
 	// The only allowed value is "Image".
+	// </opcon:standard:description>
+	// <opcon:experimental:description>
+	// Allowed values are "Image" and "Git".
+	// </opcon:experimental:description>
 	//
 	// When set to "Image", information about the resolved image source is set in the image field.
+	// <opcon:experimental:description>
+	// When set to "Git", information about the resolved git source is set in the git field.
+	// </opcon:experimental:description>
+	//
+	// <opcon:experimental:validation:Enum=Image;Git>
 	Type *apiv1.SourceType `json:"type,omitempty"`
 	// image contains resolution information for a catalog sourced from an image.
 	// It must be set when type is Image, and forbidden otherwise.
+	// <opcon:experimental:validation:Optional>
 	Image *ResolvedImageSourceApplyConfiguration `json:"image,omitempty"`
+	// git contains resolution information for a catalog sourced from a git repository.
+	// It must be set when type is Git, and forbidden otherwise.
+	// <opcon:experimental>
+	Git *ResolvedGitSourceApplyConfiguration `json:"git,omitempty"`
 }
 
 // ResolvedCatalogSourceApplyConfiguration constructs a declarative configuration of the ResolvedCatalogSource type for use with
@@ -59,3 +74,11 @@ func (b *ResolvedCatalogSourceApplyConfiguration) WithImage(value *ResolvedImage
 	b.Image = value
 	return b
 }
+
+// WithGit sets the Git field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Git field is set to the value of the last call.
+func (b *ResolvedCatalogSourceApplyConfiguration) WithGit(value *ResolvedGitSourceApplyConfiguration) *ResolvedCatalogSourceApplyConfiguration {
+	b.Git = value
+	return b
+}
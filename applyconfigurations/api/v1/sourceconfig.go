@@ -24,15 +24,33 @@ package v1
 type SourceConfigApplyConfiguration struct {
 	// sourceType is required and specifies the type of install source.
 	//
+	// <opcon:standard:description>
 	// The only allowed value is "Catalog".
+	// </opcon:standard:description>
+	// <opcon:experimental:description>
+	// Allowed values are "Catalog" and "Image".
+	// </opcon:experimental:description>
 	//
 	// When set to "Catalog", information for determining the appropriate bundle of content to install
 	// is fetched from ClusterCatalog resources on the cluster.
 	// When using the Catalog sourceType, the catalog field must also be set.
+	// <opcon:experimental:description>
+	//
+	// When set to "Image", the bundle image referenced by the image field is installed directly,
+	// without consulting any ClusterCatalog.
+	// When using the Image sourceType, the image field must also be set.
+	// </opcon:experimental:description>
+	//
+	// <opcon:experimental:validation:Enum=Catalog;Image>
 	SourceType *string `json:"sourceType,omitempty"`
 	// catalog configures how information is sourced from a catalog.
 	// It is required when sourceType is "Catalog", and forbidden otherwise.
 	Catalog *CatalogFilterApplyConfiguration `json:"catalog,omitempty"`
+	// image configures the bundle image to install.
+	// It is required when sourceType is "Image", and forbidden otherwise.
+	//
+	// <opcon:experimental>
+	Image *BundleImageSourceApplyConfiguration `json:"image,omitempty"`
 }
 
 // SourceConfigApplyConfiguration constructs a declarative configuration of the SourceConfig type for use with
@@ -56,3 +74,11 @@ func (b *SourceConfigApplyConfiguration) WithCatalog(value *CatalogFilterApplyCo
 	b.Catalog = value
 	return b
 }
+
+// WithImage sets the Image field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Image field is set to the value of the last call.
+func (b *SourceConfigApplyConfiguration) WithImage(value *BundleImageSourceApplyConfiguration) *SourceConfigApplyConfiguration {
+	b.Image = value
+	return b
+}
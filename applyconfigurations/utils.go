@@ -33,10 +33,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 	// Group=olm.operatorframework.io, Version=v1
 	case v1.SchemeGroupVersion.WithKind("Assertion"):
 		return &apiv1.AssertionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("BundleImageSource"):
+		return &apiv1.BundleImageSourceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("BundleMetadata"):
 		return &apiv1.BundleMetadataApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CatalogFilter"):
 		return &apiv1.CatalogFilterApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("CatalogResolution"):
+		return &apiv1.CatalogResolutionApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CatalogSource"):
 		return &apiv1.CatalogSourceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ClusterCatalog"):
@@ -55,6 +59,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1.ClusterExtensionInstallConfigApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ClusterExtensionInstallStatus"):
 		return &apiv1.ClusterExtensionInstallStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ClusterExtensionPlan"):
+		return &apiv1.ClusterExtensionPlanApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ClusterExtensionResolution"):
+		return &apiv1.ClusterExtensionResolutionApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ClusterExtensionSpec"):
 		return &apiv1.ClusterExtensionSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ClusterExtensionStatus"):
@@ -71,26 +79,52 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1.ClusterObjectSetStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ConditionEqualProbe"):
 		return &apiv1.ConditionEqualProbeApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ConfigKeyReference"):
+		return &apiv1.ConfigKeyReferenceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CRDUpgradeSafetyPreflightConfig"):
 		return &apiv1.CRDUpgradeSafetyPreflightConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ExtensionPolicy"):
+		return &apiv1.ExtensionPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ExtensionPolicySpec"):
+		return &apiv1.ExtensionPolicySpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("FieldsEqualProbe"):
 		return &apiv1.FieldsEqualProbeApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("FieldValueProbe"):
 		return &apiv1.FieldValueProbeApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("GitAuthSecretReference"):
+		return &apiv1.GitAuthSecretReferenceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("GitSource"):
+		return &apiv1.GitSourceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ImageSource"):
 		return &apiv1.ImageSourceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MaintenanceWindow"):
+		return &apiv1.MaintenanceWindowApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MaintenanceWindows"):
+		return &apiv1.MaintenanceWindowsApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ObjectSelector"):
 		return &apiv1.ObjectSelectorApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ObjectSourceRef"):
 		return &apiv1.ObjectSourceRefApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ObservedPhase"):
 		return &apiv1.ObservedPhaseApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PackageMaxVersion"):
+		return &apiv1.PackageMaxVersionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PendingUpgrade"):
+		return &apiv1.PendingUpgradeApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PlannedObjectChange"):
+		return &apiv1.PlannedObjectChangeApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PreflightConfig"):
 		return &apiv1.PreflightConfigApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ProgressionProbe"):
 		return &apiv1.ProgressionProbeApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ResolutionFilter"):
+		return &apiv1.ResolutionFilterApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ResolvedCatalogSource"):
 		return &apiv1.ResolvedCatalogSourceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ResolvedDependency"):
+		return &apiv1.ResolvedDependencyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ResolvedGitSource"):
+		return &apiv1.ResolvedGitSourceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ResolvedImageSource"):
 		return &apiv1.ResolvedImageSourceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RevisionStatus"):
@@ -99,6 +133,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1.ServiceAccountReferenceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("SourceConfig"):
 		return &apiv1.SourceConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("UpgradeApproval"):
+		return &apiv1.UpgradeApprovalApplyConfiguration{}
 
 	}
 	return nil
@@ -22,12 +22,15 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/spf13/cobra"
 	"go.podman.io/image/v5/types"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -61,6 +64,7 @@ import (
 	sharedcontrollers "github.com/operator-framework/operator-controller/internal/shared/controllers"
 	cacheutil "github.com/operator-framework/operator-controller/internal/shared/util/cache"
 	fsutil "github.com/operator-framework/operator-controller/internal/shared/util/fs"
+	gitutil "github.com/operator-framework/operator-controller/internal/shared/util/git"
 	httputil "github.com/operator-framework/operator-controller/internal/shared/util/http"
 	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
 	"github.com/operator-framework/operator-controller/internal/shared/util/pullsecretcache"
@@ -95,6 +99,13 @@ type config struct {
 	webhookPort          int
 	pullCasDir           string
 	globalPullSecret     string
+	persistedQueriesDir  string
+	s3Endpoint           string
+	s3Bucket             string
+	s3Prefix             string
+	s3Region             string
+	s3Insecure           bool
+	s3RequestTimeout     time.Duration
 	// Generated config
 	globalPullSecretKey *k8stypes.NamespacedName
 }
@@ -136,6 +147,13 @@ func init() {
 	flags.IntVar(&cfg.webhookPort, "webhook-server-port", 9443, "Webhook server port")
 	flags.StringVar(&cfg.pullCasDir, "pull-cas-dir", "", "The directory of TLS certificate authorities to use for verifying HTTPS connections to image registries.")
 	flags.StringVar(&cfg.globalPullSecret, "global-pull-secret", "", "Global pull secret (<namespace>/<name>)")
+	flags.StringVar(&cfg.persistedQueriesDir, "graphql-persisted-queries-dir", "", "The directory of persisted GraphQL queries, one .graphql file per query. Requires the GraphQLCatalogQueries feature gate.")
+	flags.StringVar(&cfg.s3Endpoint, "s3-endpoint", "", "The endpoint (<host>[:<port>]) of the S3-compatible object storage catalogs' content is stored in. Requires the S3CatalogStorage feature gate.")
+	flags.StringVar(&cfg.s3Bucket, "s3-bucket", "", "The bucket catalogs' content is stored in. Requires the S3CatalogStorage feature gate.")
+	flags.StringVar(&cfg.s3Prefix, "s3-prefix", "catalogs", "The prefix of the keys of the objects catalogs' content is stored in.")
+	flags.StringVar(&cfg.s3Region, "s3-region", "", "The region of the bucket catalogs' content is stored in. The region is looked up when empty.")
+	flags.BoolVar(&cfg.s3Insecure, "s3-insecure", false, "Connect to the S3-compatible object storage over plain HTTP.")
+	flags.DurationVar(&cfg.s3RequestTimeout, "s3-request-timeout", storage.DefaultS3RequestTimeout, "The timeout of the requests made to the S3-compatible object storage to look catalogs' content up and delete it.")
 
 	// adds version subcommand
 	catalogdCmd.AddCommand(versionCommand)
@@ -188,6 +206,13 @@ func validateConfig(cfg *config) error {
 		cfg.globalPullSecretKey = &k8stypes.NamespacedName{Name: secretParts[1], Namespace: secretParts[0]}
 	}
 
+	if features.CatalogdFeatureGate.Enabled(features.S3CatalogStorage) && (cfg.s3Endpoint == "" || cfg.s3Bucket == "") {
+		err := errors.New("s3-endpoint and s3-bucket flags are required by the S3CatalogStorage feature gate")
+		setupLog.Error(err, "missing S3 configuration",
+			"s3Endpoint", cfg.s3Endpoint, "s3Bucket", cfg.s3Bucket)
+		return err
+	}
+
 	return nil
 }
 
@@ -350,6 +375,18 @@ func run(ctx context.Context) error {
 		},
 	}
 
+	cachePaths := []string{unpackCacheBasePath}
+	var gitPuller gitutil.Puller
+	if features.CatalogdFeatureGate.Enabled(features.GitCatalogSource) {
+		gitCacheBasePath := filepath.Join(cfg.cacheDir, "git")
+		if err := os.MkdirAll(gitCacheBasePath, 0770); err != nil {
+			setupLog.Error(err, "unable to create cache directory for git checkouts")
+			return err
+		}
+		gitPuller = &gitutil.GoGitPuller{BasePath: gitCacheBasePath}
+		cachePaths = append(cachePaths, gitCacheBasePath)
+	}
+
 	var localStorage storage.Instance
 	metrics.Registry.MustRegister(catalogdmetrics.RequestDurationMetric)
 
@@ -379,12 +416,74 @@ func run(ctx context.Context) error {
 		graphqlMode = storage.GraphQLQueriesDisabled
 	}
 
-	localStorage = storage.NewLocalDirV1(
-		storeDir,
-		baseStorageURL,
-		metasMode,
-		graphqlMode,
-	)
+	var diffMode storage.DiffHandlerMode
+	if features.CatalogdFeatureGate.Enabled(features.APIV1DiffHandler) {
+		diffMode = storage.DiffHandlerEnabled
+	} else {
+		diffMode = storage.DiffHandlerDisabled
+	}
+
+	var queryMode storage.QueryHandlerMode
+	if features.CatalogdFeatureGate.Enabled(features.APIV1QueryHandler) {
+		queryMode = storage.QueryHandlerEnabled
+	} else {
+		queryMode = storage.QueryHandlerDisabled
+	}
+
+	catalogLister := &storage.ClusterCatalogLister{Reader: mgr.GetClient()}
+	var queryRegistry interface{ RegisterPersistedQueries(fs.FS) error }
+	// When bundles were first seen is recorded by all the replicas in ConfigMaps, which
+	// are not read from the cache of the manager.
+	var firstSeen *storage.FirstSeenRecorder
+	if features.CatalogdFeatureGate.Enabled(features.BundleFirstSeen) {
+		firstSeenClient, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+		if err != nil {
+			setupLog.Error(err, "unable to create client recording when bundles were first seen")
+			return err
+		}
+		firstSeen = storage.NewFirstSeenRecorder(firstSeenClient, cfg.systemNamespace)
+	}
+	s3Storage := features.CatalogdFeatureGate.Enabled(features.S3CatalogStorage)
+	if s3Storage {
+		s3Client, err := newS3Client(ctx)
+		if err != nil {
+			setupLog.Error(err, "unable to create S3 client", "endpoint", cfg.s3Endpoint, "bucket", cfg.s3Bucket)
+			return err
+		}
+		s3 := storage.NewS3V1(
+			s3Client,
+			cfg.s3Bucket,
+			cfg.s3Prefix,
+			storeDir,
+			baseStorageURL,
+			metasMode,
+			graphqlMode,
+			diffMode,
+			queryMode,
+		)
+		s3.Catalogs = catalogLister
+		s3.RequestTimeout = cfg.s3RequestTimeout
+		s3.FirstSeen = firstSeen
+		localStorage, queryRegistry = s3, s3
+	} else {
+		localDir := storage.NewLocalDirV1(
+			storeDir,
+			baseStorageURL,
+			metasMode,
+			graphqlMode,
+			diffMode,
+			queryMode,
+		)
+		localDir.Catalogs = catalogLister
+		localDir.FirstSeen = firstSeen
+		localStorage, queryRegistry = localDir, localDir
+	}
+	if cfg.persistedQueriesDir != "" {
+		if err := queryRegistry.RegisterPersistedQueries(os.DirFS(cfg.persistedQueriesDir)); err != nil {
+			setupLog.Error(err, "unable to register persisted GraphQL queries")
+			return err
+		}
+	}
 
 	// Config for the catalogd web server
 	catalogServerConfig := serverutil.CatalogServerConfig{
@@ -393,6 +492,7 @@ func run(ctx context.Context) error {
 		CertFile:     cfg.certFile,
 		KeyFile:      cfg.keyFile,
 		LocalStorage: localStorage,
+		Catalogs:     mgr.GetClient(),
 		TLSOpts:      []func(*tls.Config){tlsOpts, tlsProfile},
 	}
 
@@ -402,15 +502,29 @@ func run(ctx context.Context) error {
 		return err
 	}
 
-	if err = (&corecontrollers.ClusterCatalogReconciler{
-		Client:      mgr.GetClient(),
-		ImageCache:  imageCache,
-		ImagePuller: imagePuller,
-		Storage:     localStorage,
-	}).SetupWithManager(mgr); err != nil {
+	clusterCatalogReconciler := &corecontrollers.ClusterCatalogReconciler{
+		Client:           mgr.GetClient(),
+		ImageCache:       imageCache,
+		ImagePuller:      imagePuller,
+		GitPuller:        gitPuller,
+		GitAuthNamespace: cfg.systemNamespace,
+		Storage:          localStorage,
+		// Content stored in a bucket outlives catalogd pods.
+		ReuseStoredContent: s3Storage,
+		ValidateContent:    features.CatalogdFeatureGate.Enabled(features.CatalogValidation),
+		ReportDegraded:     features.CatalogdFeatureGate.Enabled(features.DegradedCatalogStatus),
+	}
+	if err = clusterCatalogReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterCatalog")
 		return err
 	}
+	// Replicas serve the content the leader stores in the bucket, so they do not need to store it themselves.
+	if !s3Storage {
+		if err = clusterCatalogReconciler.SetupFollowerWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterCatalogFollower")
+			return err
+		}
+	}
 
 	setupLog.Info("creating SecretSyncer controller for watching secret", "Secret", cfg.globalPullSecret)
 	err = (&sharedcontrollers.PullSecretReconciler{
@@ -441,7 +555,7 @@ func run(ctx context.Context) error {
 	}
 
 	gc := &garbagecollection.GarbageCollector{
-		CachePaths:     []string{unpackCacheBasePath, storeDir},
+		CachePaths:     append(cachePaths, storeDir),
 		Logger:         ctrl.Log.WithName("garbage-collector"),
 		MetadataClient: metaClient,
 		Interval:       cfg.gcInterval,
@@ -476,3 +590,30 @@ func podNamespace() string {
 	}
 	return string(namespace)
 }
+
+// newS3Client returns a client of the S3-compatible object storage catalogs' content
+// is stored in, after checking the bucket exists. Credentials are read from the
+// AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables, the AWS
+// credentials file, or the IAM role of the pod.
+func newS3Client(ctx context.Context) (*minio.Client, error) {
+	client, err := minio.New(cfg.s3Endpoint, &minio.Options{
+		Creds: credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.FileAWSCredentials{},
+			&credentials.IAM{},
+		}),
+		Secure: !cfg.s3Insecure,
+		Region: cfg.s3Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	exists, err := client.BucketExists(ctx, cfg.s3Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("error checking bucket %q exists: %w", cfg.s3Bucket, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("bucket %q does not exist", cfg.s3Bucket)
+	}
+	return client, nil
+}
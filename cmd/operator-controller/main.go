@@ -27,7 +27,9 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+	_ "time/tzdata" // the time zones of maintenance windows must be loadable from distroless images
 
+	bsemver "github.com/blang/semver/v4"
 	"github.com/spf13/cobra"
 	"go.podman.io/image/v5/types"
 	corev1 "k8s.io/api/core/v1"
@@ -41,7 +43,9 @@ import (
 	"k8s.io/client-go/discovery/cached/memory"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	"pkg.package-operator.run/boxcutter/managedcache"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -107,6 +111,15 @@ type config struct {
 	globalPullSecret     string
 }
 
+// resolveBundleStep returns the step resolving bundles, which reports how they were
+// resolved when reporter is set.
+func resolveBundleStep(resolver resolve.Resolver, reporter resolve.ReportingResolver, c client.Client) controllers.ReconcileStepFunc {
+	if reporter != nil {
+		return controllers.ResolveBundleWithReport(reporter, c)
+	}
+	return controllers.ResolveBundle(resolver, c)
+}
+
 type reconcilerConfigurator interface {
 	Configure(cer *controllers.ClusterExtensionReconciler) error
 }
@@ -116,8 +129,19 @@ type boxcutterReconcilerConfigurator struct {
 	preflights            []applier.Preflight
 	regv1ManifestProvider applier.ManifestProvider
 	resolver              resolve.Resolver
+	resolutionReporter    resolve.ReportingResolver
+	dependencyResolver    resolve.DependencyResolver
+	sourceTypes           []string
+	rolloutModes          []string
+	upgradeApprovals      []string
+	upgradeScopes         []string
+	listPolicies          func(context.Context) ([]ocv1.ExtensionPolicy, error)
+	kubeVersion           func(context.Context) (*bsemver.Version, error)
+	rollbackPolicies      []string
+	configTypes           []ocv1.ClusterExtensionConfigType
 	imageCache            imageutil.Cache
 	imagePuller           imageutil.Puller
+	bundleImages          *controllers.BundleImageResolver
 	finalizers            crfinalizer.Finalizers
 }
 
@@ -126,8 +150,18 @@ type helmReconcilerConfigurator struct {
 	preflights            []applier.Preflight
 	regv1ManifestProvider applier.ManifestProvider
 	resolver              resolve.Resolver
+	resolutionReporter    resolve.ReportingResolver
+	dependencyResolver    resolve.DependencyResolver
+	sourceTypes           []string
+	rolloutModes          []string
+	upgradeApprovals      []string
+	upgradeScopes         []string
+	listPolicies          func(context.Context) ([]ocv1.ExtensionPolicy, error)
+	kubeVersion           func(context.Context) (*bsemver.Version, error)
+	configTypes           []ocv1.ClusterExtensionConfigType
 	imageCache            imageutil.Cache
 	imagePuller           imageutil.Puller
+	bundleImages          *controllers.BundleImageResolver
 	finalizers            crfinalizer.Finalizers
 	watcher               cmcache.Watcher
 }
@@ -414,8 +448,11 @@ func run() error {
 		},
 	}
 
+	bundleImages := controllers.NewBundleImageResolver(imagePuller, imageCache)
+
 	clusterExtensionFinalizers := crfinalizer.NewFinalizers()
 	if err := clusterExtensionFinalizers.Register(controllers.ClusterExtensionCleanupUnpackCacheFinalizer, finalizers.FinalizerFunc(func(ctx context.Context, obj client.Object) (crfinalizer.Result, error) {
+		bundleImages.Forget(obj.GetName())
 		return crfinalizer.Result{}, imageCache.Delete(ctx, obj.GetName())
 	})); err != nil {
 		setupLog.Error(err, "unable to register finalizer", "finalizerKey", controllers.ClusterExtensionCleanupUnpackCacheFinalizer)
@@ -433,21 +470,103 @@ func run() error {
 	catalogClient := catalogclient.New(catalogClientBackend, func() (*http.Client, error) {
 		return catalogclient.BuildHTTPClient(cpwCatalogd)
 	})
+	// Only the packages resolved from the catalogs are fetched when the feature is enabled
+	if features.OperatorControllerFeatureGate.Enabled(features.PerPackageCatalogFetching) {
+		catalogClient = catalogClient.WithPackageCache(catalogClientBackend)
+	}
 
+	listCatalogs := func(ctx context.Context, option ...client.ListOption) ([]ocv1.ClusterCatalog, error) {
+		var catalogs ocv1.ClusterCatalogList
+		if err := cl.List(ctx, &catalogs, option...); err != nil {
+			return nil, err
+		}
+		return catalogs.Items, nil
+	}
 	resolver := &resolve.CatalogResolver{
-		WalkCatalogsFunc: resolve.CatalogWalker(
-			func(ctx context.Context, option ...client.ListOption) ([]ocv1.ClusterCatalog, error) {
-				var catalogs ocv1.ClusterCatalogList
-				if err := cl.List(ctx, &catalogs, option...); err != nil {
-					return nil, err
-				}
-				return catalogs.Items, nil
-			},
-			catalogClient.GetPackage,
-		),
-		Validations: []resolve.ValidationFunc{
-			resolve.NoDependencyValidation,
-		},
+		WalkCatalogsFunc:       resolve.CatalogWalker(listCatalogs, catalogClient.GetPackage),
+		WalkCatalogIndexesFunc: resolve.CatalogIndexWalker(listCatalogs, catalogClient.GetPackage),
+		Clock:                  clock.RealClock{},
+	}
+	// Bundles declaring dependencies are rejected unless dependency resolution is enabled
+	var dependencyResolver resolve.DependencyResolver
+	if features.OperatorControllerFeatureGate.Enabled(features.BundleDependencyResolution) {
+		dependencyResolver = resolver
+		resolver.ListExtensionsFunc = func(ctx context.Context) ([]ocv1.ClusterExtension, error) {
+			var extList ocv1.ClusterExtensionList
+			if err := cl.List(ctx, &extList); err != nil {
+				return nil, err
+			}
+			return extList.Items, nil
+		}
+	} else {
+		resolver.Validations = append(resolver.Validations, resolve.NoDependencyValidation)
+	}
+
+	// Resolutions are only reported in the status when the feature is enabled
+	var resolutionReporter resolve.ReportingResolver
+	if features.OperatorControllerFeatureGate.Enabled(features.ResolutionReport) {
+		resolutionReporter = resolver
+	}
+
+	// Bundle images can only be installed directly when the feature is enabled
+	sourceTypes := []string{ocv1.SourceTypeCatalog}
+	if features.OperatorControllerFeatureGate.Enabled(features.BundleImageSource) {
+		sourceTypes = append(sourceTypes, ocv1.SourceTypeBundleImage)
+	}
+
+	// Rollouts can only be planned without being applied when the feature is enabled
+	rolloutModes := []string{ocv1.RolloutModeApply}
+	if features.OperatorControllerFeatureGate.Enabled(features.RolloutPlan) {
+		rolloutModes = append(rolloutModes, ocv1.RolloutModePlan)
+	}
+
+	// Upgrades can only be held for approval when the feature is enabled
+	upgradeApprovals := []string{ocv1.UpgradeApprovalAutomatic}
+	if features.OperatorControllerFeatureGate.Enabled(features.UpgradeApproval) {
+		upgradeApprovals = append(upgradeApprovals, ocv1.UpgradeApprovalManual)
+	}
+
+	// Upgrades can only be limited to a scope of the installed version when the feature is enabled
+	upgradeScopes := []string{ocv1.UpgradeScopeAny}
+	if features.OperatorControllerFeatureGate.Enabled(features.UpgradeScope) {
+		upgradeScopes = append(upgradeScopes, ocv1.UpgradeScopeMinor, ocv1.UpgradeScopePatch)
+	}
+
+	// ExtensionPolicies are only enforced when the feature is enabled
+	var listPolicies func(context.Context) ([]ocv1.ExtensionPolicy, error)
+	if features.OperatorControllerFeatureGate.Enabled(features.ExtensionPolicy) {
+		listPolicies = func(ctx context.Context) ([]ocv1.ExtensionPolicy, error) {
+			var policyList ocv1.ExtensionPolicyList
+			if err := cl.List(ctx, &policyList); err != nil {
+				return nil, err
+			}
+			return policyList.Items, nil
+		}
+		resolver.ListExtensionPoliciesFunc = listPolicies
+	}
+
+	// Bundles are only checked against the Kubernetes version of the cluster when the feature is enabled
+	var kubeVersion func(context.Context) (*bsemver.Version, error)
+	if features.OperatorControllerFeatureGate.Enabled(features.KubernetesVersionCompatibility) {
+		discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+		if err != nil {
+			setupLog.Error(err, "unable to create discovery client")
+			return err
+		}
+		kubeVersion = resolve.DiscoverKubernetesVersion(discoveryClient)
+		resolver.KubernetesVersionFunc = kubeVersion
+	}
+
+	// Revisions can only be rolled back automatically when the feature is enabled
+	rollbackPolicies := []string{ocv1.RollbackPolicyNone}
+	if features.OperatorControllerFeatureGate.Enabled(features.AutomaticRollback) {
+		rollbackPolicies = append(rollbackPolicies, ocv1.RollbackPolicyAutomatic)
+	}
+
+	// Configuration can only be read from ConfigMaps and Secrets when the feature is enabled
+	configTypes := []ocv1.ClusterExtensionConfigType{ocv1.ClusterExtensionConfigTypeInline}
+	if features.OperatorControllerFeatureGate.Enabled(features.ConfigSourceReferences) {
+		configTypes = append(configTypes, ocv1.ClusterExtensionConfigTypeConfigMapRef, ocv1.ClusterExtensionConfigTypeSecretRef)
 	}
 
 	aeClient, err := apiextensionsv1client.NewForConfig(mgr.GetConfig())
@@ -464,6 +583,28 @@ func run() error {
 	if features.OperatorControllerFeatureGate.Enabled(features.BoxcutterRuntime) {
 		ctrlBuilderOpts = append(ctrlBuilderOpts, controllers.WithOwns(&ocv1.ClusterObjectSet{}))
 	}
+	if features.OperatorControllerFeatureGate.Enabled(features.ExtensionPolicy) {
+		ctrlBuilderOpts = append(ctrlBuilderOpts, controllers.WithExtensionPolicyWatches(mgr.GetClient()))
+	}
+	if features.OperatorControllerFeatureGate.Enabled(features.ConfigSourceReferences) {
+		// ConfigMaps referenced by ClusterExtensions may be in any namespace, which the
+		// manager cache does not watch. Their metadata is watched in a cache of its own, and
+		// their data is read with the service account of the ClusterExtension when it is needed.
+		configSourceCache, err := crcache.New(mgr.GetConfig(), crcache.Options{
+			Scheme:           mgr.GetScheme(),
+			Mapper:           mgr.GetRESTMapper(),
+			DefaultTransform: cacheutil.StripAnnotations(),
+		})
+		if err != nil {
+			setupLog.Error(err, "unable to create configuration source cache")
+			return err
+		}
+		if err := mgr.Add(configSourceCache); err != nil {
+			setupLog.Error(err, "unable to add configuration source cache to manager")
+			return err
+		}
+		ctrlBuilderOpts = append(ctrlBuilderOpts, controllers.WithConfigSourceWatches(configSourceCache, mgr.GetClient()))
+	}
 
 	ceReconciler := &controllers.ClusterExtensionReconciler{
 		Client: cl,
@@ -489,8 +630,19 @@ func run() error {
 			preflights:            preflights,
 			regv1ManifestProvider: regv1ManifestProvider,
 			resolver:              resolver,
+			resolutionReporter:    resolutionReporter,
+			dependencyResolver:    dependencyResolver,
+			sourceTypes:           sourceTypes,
+			rolloutModes:          rolloutModes,
+			upgradeApprovals:      upgradeApprovals,
+			upgradeScopes:         upgradeScopes,
+			listPolicies:          listPolicies,
+			kubeVersion:           kubeVersion,
+			rollbackPolicies:      rollbackPolicies,
+			configTypes:           configTypes,
 			imageCache:            imageCache,
 			imagePuller:           imagePuller,
+			bundleImages:          bundleImages,
 			finalizers:            clusterExtensionFinalizers,
 		}
 	} else {
@@ -499,8 +651,18 @@ func run() error {
 			preflights:            preflights,
 			regv1ManifestProvider: regv1ManifestProvider,
 			resolver:              resolver,
+			resolutionReporter:    resolutionReporter,
+			dependencyResolver:    dependencyResolver,
+			sourceTypes:           sourceTypes,
+			rolloutModes:          rolloutModes,
+			upgradeApprovals:      upgradeApprovals,
+			upgradeScopes:         upgradeScopes,
+			listPolicies:          listPolicies,
+			kubeVersion:           kubeVersion,
+			configTypes:           configTypes,
 			imageCache:            imageCache,
 			imagePuller:           imagePuller,
+			bundleImages:          bundleImages,
 			finalizers:            clusterExtensionFinalizers,
 			watcher:               ceController,
 		}
@@ -587,19 +749,6 @@ func (c *boxcutterReconcilerConfigurator) Configure(ceReconciler *controllers.Cl
 		return fmt.Errorf("unable to create helm action client getter: %w", err)
 	}
 
-	// Register a no-op finalizer handler for cleanup-contentmanager-cache.
-	// This finalizer was added by the Helm applier for ClusterExtensions created
-	// before BoxcutterRuntime was enabled. Boxcutter doesn't use contentmanager,
-	// so we just need to acknowledge the finalizer to allow deletion to proceed.
-	err = c.finalizers.Register(controllers.ClusterExtensionCleanupContentManagerCacheFinalizer, finalizers.FinalizerFunc(func(ctx context.Context, obj client.Object) (crfinalizer.Result, error) {
-		// No-op: Boxcutter doesn't use contentmanager, so no cleanup is needed
-		return crfinalizer.Result{}, nil
-	}))
-	if err != nil {
-		setupLog.Error(err, "unable to register content manager cleanup finalizer for boxcutter")
-		return err
-	}
-
 	// determine if PreAuthorizer should be enabled based on feature gate
 	var preAuth authorization.PreAuthorizer
 	if features.OperatorControllerFeatureGate.Enabled(features.PreflightPermissions) {
@@ -622,6 +771,15 @@ func (c *boxcutterReconcilerConfigurator) Configure(ceReconciler *controllers.Cl
 		FieldOwner:        fieldOwner,
 		SystemNamespace:   cfg.systemNamespace,
 	}
+	cerCoreClient, err := corev1client.NewForConfig(c.mgr.GetConfig())
+	if err != nil {
+		return fmt.Errorf("unable to create client for ClusterObjectSet controller: %w", err)
+	}
+	cerTokenGetter := authentication.NewTokenGetter(cerCoreClient, authentication.WithExpirationDuration(1*time.Hour))
+	// Plans are computed with the same service account the revision engine applies the objects with
+	planClients := newPlanClientGetter(c.mgr, action.ServiceAccountRestConfigMapper(cerTokenGetter))
+	appl.PlanClientGetter = planClients
+
 	revisionStatesGetter := &controllers.BoxcutterRevisionStatesGetter{Reader: c.mgr.GetClient()}
 	storageMigrator := &applier.BoxcutterStorageMigrator{
 		Client:             c.mgr.GetClient(),
@@ -634,13 +792,38 @@ func (c *boxcutterReconcilerConfigurator) Configure(ceReconciler *controllers.Cl
 		controllers.HandleFinalizers(c.finalizers),
 		controllers.ValidateClusterExtension(
 			controllers.ServiceAccountValidator(coreClient),
+			controllers.SourceTypeValidator(c.sourceTypes...),
+			controllers.RolloutModeValidator(c.rolloutModes...),
+			controllers.UpgradeApprovalValidator(c.upgradeApprovals...),
+			controllers.UpgradeScopeValidator(c.upgradeScopes...),
+			controllers.SoakTimeValidator(features.OperatorControllerFeatureGate.Enabled(features.SoakTime)),
+			controllers.ExtensionPolicyValidator(c.listPolicies),
+			controllers.ConfigTypeValidator(c.configTypes...),
+			controllers.MaintenanceWindowsValidator(features.OperatorControllerFeatureGate.Enabled(features.MaintenanceWindows)),
+			controllers.RollbackPolicyValidator(c.rollbackPolicies...),
+			controllers.RevisionPinningValidator(features.OperatorControllerFeatureGate.Enabled(features.RevisionPinning)),
 		),
 		controllers.MigrateStorage(storageMigrator),
 		controllers.RetrieveRevisionStates(revisionStatesGetter),
-		controllers.ResolveBundle(c.resolver, c.mgr.GetClient()),
-		controllers.UnpackBundle(c.imagePuller, c.imageCache),
-		controllers.ApplyBundleWithBoxcutter(appl.Apply),
+		resolveBundleStep(c.resolver, c.resolutionReporter, c.mgr.GetClient()),
+	}
+	if c.kubeVersion != nil {
+		ceReconciler.ReconcileSteps = append(ceReconciler.ReconcileSteps, controllers.CheckKubernetesUpgradeable(c.kubeVersion))
 	}
+	if c.dependencyResolver != nil {
+		ceReconciler.ReconcileSteps = append(ceReconciler.ReconcileSteps, controllers.ResolveDependencies(c.dependencyResolver))
+	}
+	ceReconciler.ReconcileSteps = append(ceReconciler.ReconcileSteps,
+		controllers.UnpackBundle(c.imagePuller, c.imageCache, c.bundleImages),
+		controllers.ResolveConfig(configReaderGetter(planClients)),
+		controllers.PlanBundle(appl),
+		controllers.ApproveUpgrade(),
+		controllers.AwaitMaintenanceWindow(clock.RealClock{}),
+	)
+	if c.dependencyResolver != nil {
+		ceReconciler.ReconcileSteps = append(ceReconciler.ReconcileSteps, controllers.InstallDependencies(c.mgr.GetClient()))
+	}
+	ceReconciler.ReconcileSteps = append(ceReconciler.ReconcileSteps, controllers.ApplyBundleWithBoxcutter(appl.Apply))
 
 	baseDiscoveryClient, err := discovery.NewDiscoveryClientForConfig(c.mgr.GetConfig())
 	if err != nil {
@@ -664,11 +847,18 @@ func (c *boxcutterReconcilerConfigurator) Configure(ceReconciler *controllers.Cl
 		return fmt.Errorf("unable to add tracking cache to manager: %v", err)
 	}
 
-	cerCoreClient, err := corev1client.NewForConfig(c.mgr.GetConfig())
+	// Register a finalizer handler for cleanup-contentmanager-cache.
+	// This finalizer was added by the Helm applier for ClusterExtensions created
+	// before BoxcutterRuntime was enabled. Boxcutter doesn't use contentmanager,
+	// so only the plan client of the ClusterExtension needs to be dropped.
+	err = c.finalizers.Register(controllers.ClusterExtensionCleanupContentManagerCacheFinalizer, finalizers.FinalizerFunc(func(ctx context.Context, obj client.Object) (crfinalizer.Result, error) {
+		planClients.Forget(obj.GetName())
+		return crfinalizer.Result{}, nil
+	}))
 	if err != nil {
-		return fmt.Errorf("unable to create client for ClusterObjectSet controller: %w", err)
+		setupLog.Error(err, "unable to register content manager cleanup finalizer for boxcutter")
+		return err
 	}
-	cerTokenGetter := authentication.NewTokenGetter(cerCoreClient, authentication.WithExpirationDuration(1*time.Hour))
 
 	revisionEngineFactory, err := controllers.NewDefaultRevisionEngineFactory(
 		c.mgr.GetScheme(),
@@ -739,8 +929,10 @@ func (c *helmReconcilerConfigurator) Configure(ceReconciler *controllers.Cluster
 	}
 
 	cm := contentmanager.NewManager(clientRestConfigMapper, c.mgr.GetConfig(), c.mgr.GetRESTMapper())
+	planClients := newPlanClientGetter(c.mgr, clientRestConfigMapper)
 	err = c.finalizers.Register(controllers.ClusterExtensionCleanupContentManagerCacheFinalizer, finalizers.FinalizerFunc(func(ctx context.Context, obj client.Object) (crfinalizer.Result, error) {
 		ext := obj.(*ocv1.ClusterExtension)
+		planClients.Forget(ext.GetName())
 		err := cm.Delete(ext)
 		return crfinalizer.Result{}, err
 	}))
@@ -760,22 +952,65 @@ func (c *helmReconcilerConfigurator) Configure(ceReconciler *controllers.Cluster
 		PreAuthorizer:                 preAuth,
 		Watcher:                       c.watcher,
 		Manager:                       cm,
+		PlanClientGetter:              planClients,
 	}
 	revisionStatesGetter := &controllers.HelmRevisionStatesGetter{ActionClientGetter: acg}
 	ceReconciler.ReconcileSteps = []controllers.ReconcileStepFunc{
 		controllers.HandleFinalizers(c.finalizers),
 		controllers.ValidateClusterExtension(
 			controllers.ServiceAccountValidator(coreClient),
+			controllers.SourceTypeValidator(c.sourceTypes...),
+			controllers.RolloutModeValidator(c.rolloutModes...),
+			controllers.UpgradeApprovalValidator(c.upgradeApprovals...),
+			controllers.UpgradeScopeValidator(c.upgradeScopes...),
+			controllers.SoakTimeValidator(features.OperatorControllerFeatureGate.Enabled(features.SoakTime)),
+			controllers.ExtensionPolicyValidator(c.listPolicies),
+			controllers.ConfigTypeValidator(c.configTypes...),
+			controllers.MaintenanceWindowsValidator(features.OperatorControllerFeatureGate.Enabled(features.MaintenanceWindows)),
+			// Only revisions of the boxcutter runtime can be rolled back automatically
+			controllers.RollbackPolicyValidator(ocv1.RollbackPolicyNone),
+			// Only revisions of the boxcutter runtime can be pinned
+			controllers.RevisionPinningValidator(false),
 		),
 		controllers.RetrieveRevisionStates(revisionStatesGetter),
-		controllers.ResolveBundle(c.resolver, c.mgr.GetClient()),
-		controllers.UnpackBundle(c.imagePuller, c.imageCache),
-		controllers.ApplyBundle(appl),
+		resolveBundleStep(c.resolver, c.resolutionReporter, c.mgr.GetClient()),
+	}
+	if c.kubeVersion != nil {
+		ceReconciler.ReconcileSteps = append(ceReconciler.ReconcileSteps, controllers.CheckKubernetesUpgradeable(c.kubeVersion))
+	}
+	if c.dependencyResolver != nil {
+		ceReconciler.ReconcileSteps = append(ceReconciler.ReconcileSteps, controllers.ResolveDependencies(c.dependencyResolver))
+	}
+	ceReconciler.ReconcileSteps = append(ceReconciler.ReconcileSteps,
+		controllers.UnpackBundle(c.imagePuller, c.imageCache, c.bundleImages),
+		controllers.ResolveConfig(configReaderGetter(planClients)),
+		controllers.PlanBundle(appl),
+		controllers.ApproveUpgrade(),
+		controllers.AwaitMaintenanceWindow(clock.RealClock{}),
+	)
+	if c.dependencyResolver != nil {
+		ceReconciler.ReconcileSteps = append(ceReconciler.ReconcileSteps, controllers.InstallDependencies(c.mgr.GetClient()))
 	}
+	ceReconciler.ReconcileSteps = append(ceReconciler.ReconcileSteps, controllers.ApplyBundle(appl))
 
 	return nil
 }
 
+// newPlanClientGetter returns a PlanClientGetter whose clients are configured by
+// restConfigMapper, so that plans are computed with the permissions of the
+// ClusterExtension they are computed for.
+func newPlanClientGetter(mgr manager.Manager, restConfigMapper func(context.Context, client.Object, *rest.Config) (*rest.Config, error)) *applier.RestConfigPlanClientGetter {
+	return applier.NewRestConfigPlanClientGetter(mgr.GetConfig(), restConfigMapper, client.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+}
+
+// configReaderGetter returns a ConfigReaderGetter that reads the configuration sources of a
+// ClusterExtension with the client of its service account that its rollouts are planned with.
+func configReaderGetter(planClients applier.PlanClientGetter) controllers.ConfigReaderGetter {
+	return controllers.ConfigReaderGetterFunc(func(ctx context.Context, ext *ocv1.ClusterExtension) (client.Reader, error) {
+		return planClients.PlanClientFor(ctx, ext)
+	})
+}
+
 func main() {
 	if err := operatorControllerCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
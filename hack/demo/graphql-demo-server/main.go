@@ -19,7 +19,7 @@ type demoCatalogStore struct {
 	catalogs map[string]fs.FS
 }
 
-func (s *demoCatalogStore) GetCatalogData(catalog string) (*os.File, os.FileInfo, error) {
+func (s *demoCatalogStore) GetCatalogData(catalog string) (server.CatalogFile, os.FileInfo, error) {
 	return nil, nil, fmt.Errorf("not implemented for demo")
 }
 
@@ -35,6 +35,10 @@ func (s *demoCatalogStore) GetIndex(catalog string) (server.Index, error) {
 	return nil, fmt.Errorf("not implemented for demo")
 }
 
+func (s *demoCatalogStore) GetCatalogDiff(catalog string) (server.CatalogFile, os.FileInfo, error) {
+	return nil, nil, fmt.Errorf("not implemented for demo")
+}
+
 func main() {
 	addr := ":9376"
 	if v := os.Getenv("PORT"); v != "" {
@@ -55,6 +59,7 @@ func main() {
 		rootURL,
 		server.MetasHandlerDisabled,
 		server.GraphQLQueriesEnabled,
+		server.DiffHandlerDisabled,
 	)
 
 	mux := http.NewServeMux()
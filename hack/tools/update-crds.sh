@@ -8,11 +8,12 @@ set -e
 CE="olm.operatorframework.io_clusterextensions.yaml"
 CC="olm.operatorframework.io_clustercatalogs.yaml"
 CR="olm.operatorframework.io_clusterobjectsets.yaml"
+EP="olm.operatorframework.io_extensionpolicies.yaml"
 
 # order for modules and crds must match
 # each item in crds must be unique, and should be associated with a module
-modules=("operator-controller" "catalogd" "operator-controller")
-crds=("${CE}" "${CC}" "${CR}")
+modules=("operator-controller" "catalogd" "operator-controller" "operator-controller")
+crds=("${CE}" "${CC}" "${CR}" "${EP}")
 
 # Channels must much those in the generator
 channels=("standard" "experimental")
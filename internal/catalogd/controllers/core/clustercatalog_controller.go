@@ -20,14 +20,16 @@ import (
 	"context" // #nosec
 	"errors"
 	"fmt"
+	"io/fs"
 	"slices"
 	"sync"
 	"time"
 
-	"go.podman.io/image/v5/docker/reference"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/ptr"
@@ -40,7 +42,9 @@ import (
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	"github.com/operator-framework/operator-controller/internal/catalogd/storage"
+	"github.com/operator-framework/operator-controller/internal/catalogd/validation"
 	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
+	gitutil "github.com/operator-framework/operator-controller/internal/shared/util/git"
 	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
 	k8sutil "github.com/operator-framework/operator-controller/internal/shared/util/k8s"
 )
@@ -59,7 +63,24 @@ type ClusterCatalogReconciler struct {
 	ImageCache  imageutil.Cache
 	ImagePuller imageutil.Puller
 
+	// GitPuller pulls the content of catalogs sourced from git repositories.
+	// Git sources are rejected as an unknown source type when it is nil.
+	GitPuller gitutil.Puller
+	// GitAuthNamespace is the namespace the Secrets referenced by git sources
+	// are read from.
+	GitAuthNamespace string
+
 	Storage storage.Instance
+	// ReuseStoredContent is set when the content stored by Storage outlives
+	// catalogd, so that the content of the catalogs reported as Serving is not
+	// pulled again when catalogd restarts.
+	ReuseStoredContent bool
+	// ValidateContent is set when the content pulled from catalog sources is
+	// validated before it is stored.
+	ValidateContent bool
+	// ReportDegraded is set when the status of catalogs reports the sources
+	// whose content could not be served in place of the last known good content.
+	ReportDegraded bool
 
 	finalizers crfinalizer.Finalizers
 
@@ -72,7 +93,7 @@ type ClusterCatalogReconciler struct {
 }
 
 type storedCatalogData struct {
-	ref                reference.Canonical
+	resolvedSource     *ocv1.ResolvedCatalogSource
 	lastUnpack         time.Time
 	lastSuccessfulPoll time.Time
 	observedGeneration int64
@@ -101,8 +122,11 @@ func (r *ClusterCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// If we encounter an error, we should delete the stored catalog metadata
 	// which represents the state of a successfully unpacked catalog. Deleting
 	// this state ensures that we will continue retrying the unpacking process
-	// until it succeeds.
-	if reconcileErr != nil {
+	// until it succeeds. When degraded catalogs are reported, the metadata is
+	// kept while the content it describes is still served: it is the last known
+	// good state of the catalog, and the unpacking process is retried as the
+	// status no longer matches the expected status.
+	if reconcileErr != nil && !(r.ReportDegraded && r.Storage.ContentExists(reconciledCatsrc.Name)) {
 		r.deleteStoredCatalog(reconciledCatsrc.Name)
 	}
 
@@ -242,22 +266,18 @@ func (r *ClusterCatalogReconciler) reconcile(ctx context.Context, catalog *ocv1.
 		return nextPollResult(storedCatalog.lastSuccessfulPoll, catalog), nil
 	}
 
-	if catalog.Spec.Source.Type != ocv1.SourceTypeImage {
-		err := reconcile.TerminalError(fmt.Errorf("unknown source type %q", catalog.Spec.Source.Type))
-		updateStatusProgressing(&catalog.Status, catalog.GetGeneration(), err)
-		return ctrl.Result{}, err
-	}
-	if catalog.Spec.Source.Image == nil {
-		err := reconcile.TerminalError(fmt.Errorf("error parsing ClusterCatalog %q, image source is nil", catalog.Name))
+	fsys, resolvedSource, unpackTime, err := r.pullSource(ctx, catalog)
+	if err != nil {
 		updateStatusProgressing(&catalog.Status, catalog.GetGeneration(), err)
+		r.updateStatusDegraded(catalog, nil, err)
 		return ctrl.Result{}, err
 	}
 
-	fsys, canonicalRef, unpackTime, err := r.ImagePuller.Pull(ctx, catalog.Name, catalog.Spec.Source.Image.Ref, r.ImageCache)
-	if err != nil {
-		unpackErr := fmt.Errorf("source catalog content: %w", err)
-		updateStatusProgressing(&catalog.Status, catalog.GetGeneration(), unpackErr)
-		return ctrl.Result{}, unpackErr
+	if r.ValidateContent {
+		if err := r.validateContent(ctx, catalog, fsys); err != nil {
+			r.updateStatusDegraded(catalog, resolvedSource, err)
+			return ctrl.Result{}, err
+		}
 	}
 
 	// TODO: We should check to see if the unpacked result has the same content
@@ -266,17 +286,28 @@ func (r *ClusterCatalogReconciler) reconcile(ctx context.Context, catalog *ocv1.
 	if err := r.Storage.Store(ctx, catalog.Name, fsys); err != nil {
 		storageErr := fmt.Errorf("error storing fbc: %v", err)
 		updateStatusProgressing(&catalog.Status, catalog.GetGeneration(), storageErr)
+		r.updateStatusDegraded(catalog, resolvedSource, storageErr)
 		return ctrl.Result{}, storageErr
 	}
 	baseURL := r.Storage.BaseURL(catalog.Name)
 
 	updateStatusProgressing(&catalog.Status, catalog.GetGeneration(), nil)
-	updateStatusServing(&catalog.Status, canonicalRef, unpackTime, baseURL, catalog.GetGeneration())
+	updateStatusServing(&catalog.Status, resolvedSource, unpackTime, baseURL, catalog.GetGeneration())
+	if r.ValidateContent {
+		updateStatusValid(&catalog.Status, catalog.GetGeneration(), nil)
+	} else {
+		meta.RemoveStatusCondition(&catalog.Status.Conditions, ocv1.TypeValid)
+	}
+	if r.ReportDegraded {
+		updateStatusNotDegraded(&catalog.Status, catalog.GetGeneration())
+	} else {
+		meta.RemoveStatusCondition(&catalog.Status.Conditions, ocv1.TypeDegraded)
+	}
 
 	lastSuccessfulPoll := time.Now()
 	r.storedCatalogsMu.Lock()
 	r.storedCatalogs[catalog.Name] = storedCatalogData{
-		ref:                canonicalRef,
+		resolvedSource:     resolvedSource,
 		lastUnpack:         unpackTime,
 		lastSuccessfulPoll: lastSuccessfulPoll,
 		observedGeneration: catalog.GetGeneration(),
@@ -285,40 +316,186 @@ func (r *ClusterCatalogReconciler) reconcile(ctx context.Context, catalog *ocv1.
 	return nextPollResult(lastSuccessfulPoll, catalog), nil
 }
 
+// validateContent validates the content pulled from the source of a catalog.
+// When the content is invalid, the content served before is kept or deleted
+// according to the invalid content policy of the catalog.
+func (r *ClusterCatalogReconciler) validateContent(ctx context.Context, catalog *ocv1.ClusterCatalog, fsys fs.FS) error {
+	err := validation.ValidateFS(ctx, fsys)
+	if err == nil {
+		return nil
+	}
+	var validationErr *validation.Error
+	if !errors.As(err, &validationErr) {
+		validateErr := fmt.Errorf("error validating fbc: %v", err)
+		updateStatusProgressing(&catalog.Status, catalog.GetGeneration(), validateErr)
+		return validateErr
+	}
+
+	log.FromContext(ctx).Info("catalog content is invalid", "policy", catalog.Spec.InvalidContentPolicy, "error", err)
+	updateStatusValid(&catalog.Status, catalog.GetGeneration(), err)
+	if catalog.Spec.InvalidContentPolicy == ocv1.InvalidContentPolicyStopServing {
+		if err := r.Storage.Delete(catalog.Name); err != nil {
+			deleteErr := fmt.Errorf("error deleting invalid catalog content: %v", err)
+			updateStatusProgressing(&catalog.Status, catalog.GetGeneration(), deleteErr)
+			return deleteErr
+		}
+		updateStatusNotServing(&catalog.Status, catalog.GetGeneration())
+	}
+	invalidErr := errors.New("catalog content is invalid, see the Valid condition for details")
+	updateStatusProgressing(&catalog.Status, catalog.GetGeneration(), invalidErr)
+	return invalidErr
+}
+
+// pullSource pulls the catalog content from its source, returning the content
+// along with the resolved source it was pulled from.
+func (r *ClusterCatalogReconciler) pullSource(ctx context.Context, catalog *ocv1.ClusterCatalog) (fs.FS, *ocv1.ResolvedCatalogSource, time.Time, error) {
+	switch catalog.Spec.Source.Type {
+	case ocv1.SourceTypeImage:
+		if catalog.Spec.Source.Image == nil {
+			return nil, nil, time.Time{}, reconcile.TerminalError(fmt.Errorf("error parsing ClusterCatalog %q, image source is nil", catalog.Name))
+		}
+		fsys, canonicalRef, unpackTime, err := r.ImagePuller.Pull(ctx, catalog.Name, catalog.Spec.Source.Image.Ref, r.ImageCache)
+		if err != nil {
+			return nil, nil, time.Time{}, fmt.Errorf("source catalog content: %w", err)
+		}
+		return fsys, &ocv1.ResolvedCatalogSource{
+			Type:  ocv1.SourceTypeImage,
+			Image: &ocv1.ResolvedImageSource{Ref: canonicalRef.String()},
+		}, unpackTime, nil
+	case ocv1.SourceTypeGit:
+		if r.GitPuller != nil {
+			return r.pullGitSource(ctx, catalog)
+		}
+	}
+	return nil, nil, time.Time{}, reconcile.TerminalError(fmt.Errorf("unknown source type %q", catalog.Spec.Source.Type))
+}
+
+func (r *ClusterCatalogReconciler) pullGitSource(ctx context.Context, catalog *ocv1.ClusterCatalog) (fs.FS, *ocv1.ResolvedCatalogSource, time.Time, error) {
+	gitSource := catalog.Spec.Source.Git
+	if gitSource == nil {
+		return nil, nil, time.Time{}, reconcile.TerminalError(fmt.Errorf("error parsing ClusterCatalog %q, git source is nil", catalog.Name))
+	}
+
+	src := gitutil.Source{
+		URL:       gitSource.URL,
+		Ref:       gitSource.Ref,
+		Directory: gitSource.Directory,
+	}
+	if gitSource.AuthSecret != nil {
+		// The Secret is not watched: a missing or invalid Secret is retried until it is fixed.
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: r.GitAuthNamespace, Name: gitSource.AuthSecret.Name}, secret); err != nil {
+			return nil, nil, time.Time{}, fmt.Errorf("error getting git auth secret %q: %w", gitSource.AuthSecret.Name, err)
+		}
+		auth, err := gitutil.AuthFromSecret(secret)
+		if err != nil {
+			return nil, nil, time.Time{}, err
+		}
+		src.Auth = auth
+	}
+
+	fsys, commit, unpackTime, err := r.GitPuller.Pull(ctx, catalog.Name, src)
+	if err != nil {
+		return nil, nil, time.Time{}, fmt.Errorf("source catalog content: %w", err)
+	}
+	return fsys, &ocv1.ResolvedCatalogSource{
+		Type: ocv1.SourceTypeGit,
+		Git:  &ocv1.ResolvedGitSource{Commit: commit},
+	}, unpackTime, nil
+}
+
 func (r *ClusterCatalogReconciler) getCurrentState(catalog *ocv1.ClusterCatalog) (*ocv1.ClusterCatalogStatus, storedCatalogData, bool) {
 	r.storedCatalogsMu.RLock()
 	storedCatalog, hasStoredCatalog := r.storedCatalogs[catalog.Name]
 	r.storedCatalogsMu.RUnlock()
+	if !hasStoredCatalog && r.ReuseStoredContent {
+		storedCatalog, hasStoredCatalog = r.adoptStoredContent(catalog)
+	}
 
 	expectedStatus := catalog.Status.DeepCopy()
 
 	// Set expected status based on what we see in the stored catalog
 	clearUnknownConditions(expectedStatus)
 	if hasStoredCatalog && r.Storage.ContentExists(catalog.Name) {
-		updateStatusServing(expectedStatus, storedCatalog.ref, storedCatalog.lastUnpack, r.Storage.BaseURL(catalog.Name), storedCatalog.observedGeneration)
+		updateStatusServing(expectedStatus, storedCatalog.resolvedSource, storedCatalog.lastUnpack, r.Storage.BaseURL(catalog.Name), storedCatalog.observedGeneration)
 		updateStatusProgressing(expectedStatus, storedCatalog.observedGeneration, nil)
+		if r.ValidateContent {
+			updateStatusValid(expectedStatus, storedCatalog.observedGeneration, nil)
+		}
+		if r.ReportDegraded {
+			updateStatusNotDegraded(expectedStatus, storedCatalog.observedGeneration)
+		}
+	}
+	if !r.ValidateContent {
+		meta.RemoveStatusCondition(&expectedStatus.Conditions, ocv1.TypeValid)
+	}
+	if !r.ReportDegraded {
+		meta.RemoveStatusCondition(&expectedStatus.Conditions, ocv1.TypeDegraded)
+		expectedStatus.FailedSource = nil
 	}
 
 	return expectedStatus, storedCatalog, hasStoredCatalog
 }
 
+// adoptStoredContent records the content stored for a catalog before catalogd
+// restarted as the content it stores, as described by the status of the catalog.
+// It returns false when the status does not describe the content served for the
+// current generation of the catalog, or that content is no longer stored.
+func (r *ClusterCatalogReconciler) adoptStoredContent(catalog *ocv1.ClusterCatalog) (storedCatalogData, bool) {
+	serving := meta.FindStatusCondition(catalog.Status.Conditions, ocv1.TypeServing)
+	if serving == nil || serving.Status != metav1.ConditionTrue || serving.ObservedGeneration != catalog.Generation ||
+		catalog.Status.ResolvedSource == nil || catalog.Status.LastUnpacked == nil ||
+		!r.Storage.ContentExists(catalog.Name) {
+		return storedCatalogData{}, false
+	}
+
+	storedCatalog := storedCatalogData{
+		resolvedSource:     catalog.Status.ResolvedSource.DeepCopy(),
+		lastUnpack:         catalog.Status.LastUnpacked.Time,
+		lastSuccessfulPoll: catalog.Status.LastUnpacked.Time,
+		observedGeneration: catalog.Generation,
+	}
+	r.storedCatalogsMu.Lock()
+	r.storedCatalogs[catalog.Name] = storedCatalog
+	r.storedCatalogsMu.Unlock()
+	return storedCatalog, true
+}
+
 func nextPollResult(lastSuccessfulPoll time.Time, catalog *ocv1.ClusterCatalog) ctrl.Result {
 	var requeueAfter time.Duration
+	if pollDuration, ok := pollInterval(catalog); ok {
+		jitteredDuration := wait.Jitter(pollDuration, requeueJitterMaxFactor)
+		requeueAfter = time.Until(lastSuccessfulPoll.Add(jitteredDuration))
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}
+}
+
+// pollInterval returns the interval at which the catalog source is polled,
+// and false when polling is disabled.
+func pollInterval(catalog *ocv1.ClusterCatalog) (time.Duration, bool) {
+	var minutes *int
 	switch catalog.Spec.Source.Type {
 	case ocv1.SourceTypeImage:
-		if catalog.Spec.Source.Image != nil && catalog.Spec.Source.Image.PollIntervalMinutes != nil {
-			pollDuration := time.Duration(*catalog.Spec.Source.Image.PollIntervalMinutes) * time.Minute
-			jitteredDuration := wait.Jitter(pollDuration, requeueJitterMaxFactor)
-			requeueAfter = time.Until(lastSuccessfulPoll.Add(jitteredDuration))
+		if catalog.Spec.Source.Image != nil {
+			minutes = catalog.Spec.Source.Image.PollIntervalMinutes
+		}
+	case ocv1.SourceTypeGit:
+		if catalog.Spec.Source.Git != nil {
+			minutes = catalog.Spec.Source.Git.PollIntervalMinutes
 		}
 	}
-	return ctrl.Result{RequeueAfter: requeueAfter}
+	if minutes == nil {
+		return 0, false
+	}
+	return time.Duration(*minutes) * time.Minute, true
 }
 
 func clearUnknownConditions(status *ocv1.ClusterCatalogStatus) {
 	knownTypes := sets.New[string](
 		ocv1.TypeServing,
 		ocv1.TypeProgressing,
+		ocv1.TypeValid,
+		ocv1.TypeDegraded,
 	)
 	status.Conditions = slices.DeleteFunc(status.Conditions, func(cond metav1.Condition) bool {
 		return !knownTypes.Has(cond.Type)
@@ -348,13 +525,8 @@ func updateStatusProgressing(status *ocv1.ClusterCatalogStatus, generation int64
 	meta.SetStatusCondition(&status.Conditions, progressingCond)
 }
 
-func updateStatusServing(status *ocv1.ClusterCatalogStatus, ref reference.Canonical, modTime time.Time, baseURL string, generation int64) {
-	status.ResolvedSource = &ocv1.ResolvedCatalogSource{
-		Type: ocv1.SourceTypeImage,
-		Image: &ocv1.ResolvedImageSource{
-			Ref: ref.String(),
-		},
-	}
+func updateStatusServing(status *ocv1.ClusterCatalogStatus, resolvedSource *ocv1.ResolvedCatalogSource, modTime time.Time, baseURL string, generation int64) {
+	status.ResolvedSource = resolvedSource.DeepCopy()
 	status.URLs = &ocv1.ClusterCatalogURLs{
 		Base: baseURL,
 	}
@@ -368,6 +540,54 @@ func updateStatusServing(status *ocv1.ClusterCatalogStatus, ref reference.Canoni
 	})
 }
 
+func updateStatusValid(status *ocv1.ClusterCatalogStatus, generation int64, err error) {
+	validCond := metav1.Condition{
+		Type:               ocv1.TypeValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             ocv1.ReasonSucceeded,
+		Message:            "Content of the resolved source passed validation",
+		ObservedGeneration: generation,
+	}
+	if err != nil {
+		validCond.Status = metav1.ConditionFalse
+		validCond.Reason = ocv1.ReasonInvalidContent
+		validCond.Message = err.Error()
+	}
+	meta.SetStatusCondition(&status.Conditions, validCond)
+}
+
+// updateStatusDegraded records that the content of the candidate source, when it
+// was resolved, could not be served. The catalog is reported as Degraded while the
+// last known good content is still served in its place.
+func (r *ClusterCatalogReconciler) updateStatusDegraded(catalog *ocv1.ClusterCatalog, candidate *ocv1.ResolvedCatalogSource, err error) {
+	if !r.ReportDegraded {
+		return
+	}
+	catalog.Status.FailedSource = candidate.DeepCopy()
+	if !meta.IsStatusConditionTrue(catalog.Status.Conditions, ocv1.TypeServing) || !r.Storage.ContentExists(catalog.Name) {
+		meta.RemoveStatusCondition(&catalog.Status.Conditions, ocv1.TypeDegraded)
+		return
+	}
+	meta.SetStatusCondition(&catalog.Status.Conditions, metav1.Condition{
+		Type:               ocv1.TypeDegraded,
+		Status:             metav1.ConditionTrue,
+		Reason:             ocv1.ReasonServingLastKnownGood,
+		Message:            fmt.Sprintf("Serving last known good content, the content of the latest source could not be served: %s", errorutil.SanitizeNetworkError(err)),
+		ObservedGeneration: catalog.GetGeneration(),
+	})
+}
+
+func updateStatusNotDegraded(status *ocv1.ClusterCatalogStatus, generation int64) {
+	status.FailedSource = nil
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               ocv1.TypeDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             ocv1.ReasonSucceeded,
+		Message:            "Serving content from the latest resolved source",
+		ObservedGeneration: generation,
+	})
+}
+
 func updateStatusProgressingUserSpecifiedUnavailable(status *ocv1.ClusterCatalogStatus, generation int64) {
 	// Set Progressing condition to True with reason Succeeded
 	// since we have successfully progressed to the unavailable
@@ -394,6 +614,10 @@ func updateStatusProgressingUserSpecifiedUnavailable(status *ocv1.ClusterCatalog
 
 	meta.SetStatusCondition(&status.Conditions, progressingCond)
 	meta.SetStatusCondition(&status.Conditions, servingCond)
+
+	// Nothing is served, so there is no content to fall back to.
+	status.FailedSource = nil
+	meta.RemoveStatusCondition(&status.Conditions, ocv1.TypeDegraded)
 }
 
 func updateStatusNotServing(status *ocv1.ClusterCatalogStatus, generation int64) {
@@ -410,12 +634,13 @@ func updateStatusNotServing(status *ocv1.ClusterCatalogStatus, generation int64)
 
 func (r *ClusterCatalogReconciler) needsPoll(lastSuccessfulPoll time.Time, catalog *ocv1.ClusterCatalog) bool {
 	// If polling is disabled, we don't need to poll.
-	if catalog.Spec.Source.Image.PollIntervalMinutes == nil {
+	pollDuration, ok := pollInterval(catalog)
+	if !ok {
 		return false
 	}
 
 	// Only poll if the next poll time is in the past.
-	nextPoll := lastSuccessfulPoll.Add(time.Duration(*catalog.Spec.Source.Image.PollIntervalMinutes) * time.Minute)
+	nextPoll := lastSuccessfulPoll.Add(pollDuration)
 	return nextPoll.Before(time.Now())
 }
 
@@ -458,6 +683,12 @@ func (r *ClusterCatalogReconciler) deleteCatalogCache(ctx context.Context, catal
 		updateStatusProgressing(&catalog.Status, catalog.GetGeneration(), err)
 		return err
 	}
+	if r.GitPuller != nil {
+		if err := r.GitPuller.Delete(ctx, catalog.Name); err != nil {
+			updateStatusProgressing(&catalog.Status, catalog.GetGeneration(), err)
+			return err
+		}
+	}
 	r.deleteStoredCatalog(catalog.Name)
 	return nil
 }
@@ -16,12 +16,16 @@ import (
 	"go.uber.org/mock/gomock"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	"github.com/operator-framework/operator-controller/internal/catalogd/storage"
+	gitutil "github.com/operator-framework/operator-controller/internal/shared/util/git"
 	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
 	mockstorage "github.com/operator-framework/operator-controller/internal/testutil/mock/storage"
 )
@@ -48,6 +52,7 @@ func TestCatalogdControllerReconcile(t *testing.T) {
 		expectedError   error
 		expectedCatalog *ocv1.ClusterCatalog
 		puller          imageutil.Puller
+		gitPuller       gitutil.Puller
 		cache           imageutil.Cache
 		store           storage.Instance
 	}{
@@ -236,11 +241,146 @@ func TestCatalogdControllerReconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:          "git source type without a git puller, returns error",
+			expectedError: reconcile.TerminalError(errors.New(`unknown source type "Git"`)),
+			puller:        &imageutil.FakePuller{},
+			store:         newMockStore(mockCtrl, false),
+			catalog: &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "catalog",
+					Finalizers: []string{fbcDeletionFinalizer},
+				},
+				Spec: ocv1.ClusterCatalogSpec{
+					Source: ocv1.CatalogSource{
+						Type: ocv1.SourceTypeGit,
+						Git:  &ocv1.GitSource{URL: "https://example.com/catalog.git"},
+					},
+				},
+			},
+			expectedCatalog: &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "catalog",
+					Finalizers: []string{fbcDeletionFinalizer},
+				},
+				Spec: ocv1.ClusterCatalogSpec{
+					Source: ocv1.CatalogSource{
+						Type: ocv1.SourceTypeGit,
+						Git:  &ocv1.GitSource{URL: "https://example.com/catalog.git"},
+					},
+				},
+				Status: ocv1.ClusterCatalogStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   ocv1.TypeProgressing,
+							Status: metav1.ConditionFalse,
+							Reason: ocv1.ReasonBlocked,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:          "git source type, pull returns error, status updated to reflect error state and error is returned",
+			expectedError: fmt.Errorf("source catalog content: %w", fmt.Errorf("mockgitpuller error")),
+			puller:        &imageutil.FakePuller{},
+			gitPuller:     &gitutil.FakePuller{Error: errors.New("mockgitpuller error")},
+			store:         newMockStore(mockCtrl, false),
+			catalog: &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "catalog",
+					Finalizers: []string{fbcDeletionFinalizer},
+				},
+				Spec: ocv1.ClusterCatalogSpec{
+					Source: ocv1.CatalogSource{
+						Type: ocv1.SourceTypeGit,
+						Git:  &ocv1.GitSource{URL: "https://example.com/catalog.git"},
+					},
+				},
+			},
+			expectedCatalog: &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "catalog",
+					Finalizers: []string{fbcDeletionFinalizer},
+				},
+				Spec: ocv1.ClusterCatalogSpec{
+					Source: ocv1.CatalogSource{
+						Type: ocv1.SourceTypeGit,
+						Git:  &ocv1.GitSource{URL: "https://example.com/catalog.git"},
+					},
+				},
+				Status: ocv1.ClusterCatalogStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   ocv1.TypeProgressing,
+							Status: metav1.ConditionTrue,
+							Reason: ocv1.ReasonRetrying,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "git source type, pull succeeds, should reflect the resolved commit in status, and is serving",
+			puller: &imageutil.FakePuller{},
+			gitPuller: &gitutil.FakePuller{
+				FS:     &fstest.MapFS{},
+				Commit: "0123456789abcdef0123456789abcdef01234567",
+			},
+			store: newMockStore(mockCtrl, false),
+			catalog: &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "catalog",
+					Finalizers: []string{fbcDeletionFinalizer},
+				},
+				Spec: ocv1.ClusterCatalogSpec{
+					Source: ocv1.CatalogSource{
+						Type: ocv1.SourceTypeGit,
+						Git:  &ocv1.GitSource{URL: "https://example.com/catalog.git", Ref: "main"},
+					},
+				},
+			},
+			expectedCatalog: &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "catalog",
+					Finalizers: []string{fbcDeletionFinalizer},
+				},
+				Spec: ocv1.ClusterCatalogSpec{
+					Source: ocv1.CatalogSource{
+						Type: ocv1.SourceTypeGit,
+						Git:  &ocv1.GitSource{URL: "https://example.com/catalog.git", Ref: "main"},
+					},
+				},
+				Status: ocv1.ClusterCatalogStatus{
+					URLs: &ocv1.ClusterCatalogURLs{Base: "URL"},
+					Conditions: []metav1.Condition{
+						{
+							Type:   ocv1.TypeServing,
+							Status: metav1.ConditionTrue,
+							Reason: ocv1.ReasonAvailable,
+						},
+						{
+							Type:   ocv1.TypeProgressing,
+							Status: metav1.ConditionTrue,
+							Reason: ocv1.ReasonSucceeded,
+						},
+					},
+					ResolvedSource: &ocv1.ResolvedCatalogSource{
+						Type: ocv1.SourceTypeGit,
+						Git: &ocv1.ResolvedGitSource{
+							Commit: "0123456789abcdef0123456789abcdef01234567",
+						},
+					},
+					LastUnpacked: &metav1.Time{},
+				},
+			},
+		},
 		{
 			name:          "valid source type, unpack state == Unpacked, storage fails, failure reflected in status and error returned",
 			expectedError: fmt.Errorf("error storing fbc: mockstore store error"),
 			puller: &imageutil.FakePuller{
 				ImageFS: &fstest.MapFS{},
+				Ref:     mustRef(t, "my.org/someimage@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
 			},
 			store: newMockStore(mockCtrl, true),
 			catalog: &ocv1.ClusterCatalog{
@@ -285,6 +425,7 @@ func TestCatalogdControllerReconcile(t *testing.T) {
 			name: "storage finalizer not set, storage finalizer gets set",
 			puller: &imageutil.FakePuller{
 				ImageFS: &fstest.MapFS{},
+				Ref:     mustRef(t, "my.org/someimage@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
 			},
 			store: newMockStore(mockCtrl, false),
 			catalog: &ocv1.ClusterCatalog{
@@ -319,6 +460,7 @@ func TestCatalogdControllerReconcile(t *testing.T) {
 			name: "storage finalizer set, catalog deletion timestamp is not zero (or nil), finalizer removed",
 			puller: &imageutil.FakePuller{
 				ImageFS: &fstest.MapFS{},
+				Ref:     mustRef(t, "my.org/someimage@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
 			},
 			store: newMockStore(mockCtrl, false),
 			catalog: &ocv1.ClusterCatalog{
@@ -392,6 +534,7 @@ func TestCatalogdControllerReconcile(t *testing.T) {
 			expectedError: fmt.Errorf("finalizer %q failed: %w", fbcDeletionFinalizer, fmt.Errorf("mockstore delete error")),
 			puller: &imageutil.FakePuller{
 				ImageFS: &fstest.MapFS{},
+				Ref:     mustRef(t, "my.org/someimage@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
 			},
 			store: newMockStore(mockCtrl, true),
 			catalog: &ocv1.ClusterCatalog{
@@ -524,6 +667,7 @@ func TestCatalogdControllerReconcile(t *testing.T) {
 			name: "catalog availability set to disabled, status.urls should get unset",
 			puller: &imageutil.FakePuller{
 				ImageFS: &fstest.MapFS{},
+				Ref:     mustRef(t, "my.org/someimage@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
 			},
 			store: newMockStore(mockCtrl, false),
 			catalog: &ocv1.ClusterCatalog{
@@ -595,6 +739,7 @@ func TestCatalogdControllerReconcile(t *testing.T) {
 			name: "catalog availability set to disabled, finalizer should get removed",
 			puller: &imageutil.FakePuller{
 				ImageFS: &fstest.MapFS{},
+				Ref:     mustRef(t, "my.org/someimage@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
 			},
 			store: newMockStore(mockCtrl, false),
 			catalog: &ocv1.ClusterCatalog{
@@ -668,6 +813,7 @@ func TestCatalogdControllerReconcile(t *testing.T) {
 			name: "after catalog availability set to enable, finalizer should be added",
 			puller: &imageutil.FakePuller{
 				ImageFS: &fstest.MapFS{},
+				Ref:     mustRef(t, "my.org/someimage@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
 			},
 			store: newMockStore(mockCtrl, false),
 			catalog: &ocv1.ClusterCatalog{
@@ -782,6 +928,7 @@ func TestCatalogdControllerReconcile(t *testing.T) {
 			reconciler := &ClusterCatalogReconciler{
 				Client:         nil,
 				ImagePuller:    tt.puller,
+				GitPuller:      tt.gitPuller,
 				ImageCache:     tt.cache,
 				Storage:        tt.store,
 				storedCatalogs: map[string]storedCatalogData{},
@@ -896,7 +1043,7 @@ func TestPollingRequeue(t *testing.T) {
 				Storage: newMockStore(mockCtrl, false),
 				storedCatalogs: map[string]storedCatalogData{
 					tc.catalog.Name: {
-						ref:                ref,
+						resolvedSource:     tc.catalog.Status.ResolvedSource,
 						lastSuccessfulPoll: tc.lastPollTime,
 						lastUnpack:         tc.catalog.Status.LastUnpacked.Time,
 					},
@@ -952,7 +1099,7 @@ func TestPollingReconcilerUnpack(t *testing.T) {
 		return map[string]storedCatalogData{
 			"test-catalog": {
 				observedGeneration: successfulObservedGeneration,
-				ref:                successfulRef,
+				resolvedSource:     successfulUnpackStatus().ResolvedSource,
 				lastUnpack:         successfulUnpackTime,
 				lastSuccessfulPoll: lastPoll,
 			},
@@ -1044,6 +1191,48 @@ func TestPollingReconcilerUnpack(t *testing.T) {
 			storedCatalogData: successfulStoredCatalogData(time.Now().Add(-5 * time.Minute)),
 			expectedUnpackRun: true,
 		},
+		"ClusterCatalog with git source not being resolved the first time, pollInterval mentioned, \"now\" is before next expected poll time, unpack should not run": {
+			catalog: &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-catalog",
+					Finalizers: []string{fbcDeletionFinalizer},
+					Generation: 2,
+				},
+				Spec: ocv1.ClusterCatalogSpec{
+					Source: ocv1.CatalogSource{
+						Type: ocv1.SourceTypeGit,
+						Git: &ocv1.GitSource{
+							URL:                 "https://example.com/catalog.git",
+							PollIntervalMinutes: ptr.To(7),
+						},
+					},
+				},
+				Status: successfulUnpackStatus(),
+			},
+			storedCatalogData: successfulStoredCatalogData(time.Now()),
+			expectedUnpackRun: false,
+		},
+		"ClusterCatalog with git source not being resolved the first time, pollInterval mentioned, \"now\" is after next expected poll time, unpack should run": {
+			catalog: &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-catalog",
+					Finalizers: []string{fbcDeletionFinalizer},
+					Generation: 2,
+				},
+				Spec: ocv1.ClusterCatalogSpec{
+					Source: ocv1.CatalogSource{
+						Type: ocv1.SourceTypeGit,
+						Git: &ocv1.GitSource{
+							URL:                 "https://example.com/catalog.git",
+							PollIntervalMinutes: ptr.To(3),
+						},
+					},
+				},
+				Status: successfulUnpackStatus(),
+			},
+			storedCatalogData: successfulStoredCatalogData(time.Now().Add(-5 * time.Minute)),
+			expectedUnpackRun: true,
+		},
 		"ClusterCatalog not being resolved the first time, pollInterval mentioned, \"now\" is before next expected poll time, generation changed, unpack should run": {
 			catalog: &ocv1.ClusterCatalog{
 				ObjectMeta: metav1.ObjectMeta{
@@ -1118,6 +1307,7 @@ func TestPollingReconcilerUnpack(t *testing.T) {
 			reconciler := &ClusterCatalogReconciler{
 				Client:         nil,
 				ImagePuller:    &imageutil.FakePuller{Error: errors.New("mockpuller error")},
+				GitPuller:      &gitutil.FakePuller{Error: errors.New("mockgitpuller error")},
 				Storage:        newMockStore(mockCtrl, false),
 				storedCatalogs: scd,
 			}
@@ -1132,6 +1322,365 @@ func TestPollingReconcilerUnpack(t *testing.T) {
 	}
 }
 
+func TestReconcilerReuseStoredContent(t *testing.T) {
+	ref := mustRef(t, "my.org/someimage@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+	newCatalog := func(generation int64) *ocv1.ClusterCatalog {
+		return &ocv1.ClusterCatalog{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test-catalog",
+				Finalizers: []string{fbcDeletionFinalizer},
+				Generation: generation,
+			},
+			Spec: ocv1.ClusterCatalogSpec{
+				Source: ocv1.CatalogSource{
+					Type:  ocv1.SourceTypeImage,
+					Image: &ocv1.ImageSource{Ref: "my.org/someimage:latest"},
+				},
+			},
+			Status: ocv1.ClusterCatalogStatus{
+				URLs: &ocv1.ClusterCatalogURLs{Base: "URL"},
+				Conditions: []metav1.Condition{
+					{Type: ocv1.TypeProgressing, Status: metav1.ConditionTrue, Reason: ocv1.ReasonSucceeded, Message: "Successfully unpacked and stored content from resolved source", ObservedGeneration: 1},
+					{Type: ocv1.TypeServing, Status: metav1.ConditionTrue, Reason: ocv1.ReasonAvailable, Message: "Serving desired content from resolved source", ObservedGeneration: 1},
+				},
+				ResolvedSource: &ocv1.ResolvedCatalogSource{
+					Type:  ocv1.SourceTypeImage,
+					Image: &ocv1.ResolvedImageSource{Ref: ref.String()},
+				},
+				LastUnpacked: ptr.To(metav1.NewTime(time.Now().Truncate(time.Second))),
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		catalog            *ocv1.ClusterCatalog
+		reuseStoredContent bool
+		contentExists      bool
+		expectedUnpackRun  bool
+	}{
+		"content of a Serving catalog is reused": {
+			catalog:            newCatalog(1),
+			reuseStoredContent: true,
+			contentExists:      true,
+		},
+		"content is not reused unless enabled": {
+			catalog:           newCatalog(1),
+			contentExists:     true,
+			expectedUnpackRun: true,
+		},
+		"content that is no longer stored is not reused": {
+			catalog:            newCatalog(1),
+			reuseStoredContent: true,
+			expectedUnpackRun:  true,
+		},
+		"content stored for a previous generation is not reused": {
+			catalog:            newCatalog(2),
+			reuseStoredContent: true,
+			contentExists:      true,
+			expectedUnpackRun:  true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := mockstorage.NewMockInstance(gomock.NewController(t))
+			store.EXPECT().BaseURL(gomock.Any()).Return("URL").AnyTimes()
+			store.EXPECT().ContentExists(gomock.Any()).Return(tc.contentExists).AnyTimes()
+			reconciler := &ClusterCatalogReconciler{
+				ImagePuller:        &imageutil.FakePuller{Error: errors.New("mockpuller error")},
+				Storage:            store,
+				ReuseStoredContent: tc.reuseStoredContent,
+				storedCatalogs:     map[string]storedCatalogData{},
+			}
+			require.NoError(t, reconciler.setupFinalizers())
+			_, err := reconciler.reconcile(context.Background(), tc.catalog)
+			if tc.expectedUnpackRun {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, reconciler.storedCatalogs, "test-catalog")
+		})
+	}
+}
+
+func TestReconcilerValidateContent(t *testing.T) {
+	ref := mustRef(t, "my.org/someimage@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+	validFS := fstest.MapFS{"catalog.yaml": &fstest.MapFile{Data: []byte(`---
+schema: olm.package
+name: foo
+defaultChannel: stable
+---
+schema: olm.channel
+name: stable
+package: foo
+entries:
+- name: foo.v0.1.0
+---
+schema: olm.bundle
+name: foo.v0.1.0
+package: foo
+image: quay.io/example/foo-bundle:v0.1.0
+properties:
+- type: olm.package
+  value:
+    packageName: foo
+    version: 0.1.0
+`)}}
+	invalidFS := fstest.MapFS{"catalog.yaml": &fstest.MapFile{Data: []byte(`---
+schema: olm.package
+name: foo
+defaultChannel: stable
+`)}}
+	newCatalog := func(policy string) *ocv1.ClusterCatalog {
+		return &ocv1.ClusterCatalog{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test-catalog",
+				Finalizers: []string{fbcDeletionFinalizer},
+				Generation: 1,
+			},
+			Spec: ocv1.ClusterCatalogSpec{
+				Source: ocv1.CatalogSource{
+					Type:  ocv1.SourceTypeImage,
+					Image: &ocv1.ImageSource{Ref: "my.org/someimage:latest"},
+				},
+				InvalidContentPolicy: policy,
+			},
+			Status: ocv1.ClusterCatalogStatus{
+				URLs: &ocv1.ClusterCatalogURLs{Base: "URL"},
+				Conditions: []metav1.Condition{
+					{Type: ocv1.TypeServing, Status: metav1.ConditionTrue, Reason: ocv1.ReasonAvailable, ObservedGeneration: 1},
+				},
+				ResolvedSource: &ocv1.ResolvedCatalogSource{
+					Type:  ocv1.SourceTypeImage,
+					Image: &ocv1.ResolvedImageSource{Ref: ref.String()},
+				},
+				LastUnpacked: ptr.To(metav1.NewTime(time.Now().Truncate(time.Second))),
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		catalog         *ocv1.ClusterCatalog
+		validateContent bool
+		fsys            fstest.MapFS
+		expectStore     bool
+		expectDelete    bool
+		expectedValid   *metav1.Condition
+		expectedServing metav1.ConditionStatus
+	}{
+		"valid content is stored": {
+			catalog:         newCatalog(""),
+			validateContent: true,
+			fsys:            validFS,
+			expectStore:     true,
+			expectedValid:   &metav1.Condition{Status: metav1.ConditionTrue, Reason: ocv1.ReasonSucceeded},
+			expectedServing: metav1.ConditionTrue,
+		},
+		"invalid content is stored when validation is disabled": {
+			catalog:         newCatalog(""),
+			fsys:            invalidFS,
+			expectStore:     true,
+			expectedServing: metav1.ConditionTrue,
+		},
+		"invalid content is not stored and the previous content is kept by default": {
+			catalog:         newCatalog(""),
+			validateContent: true,
+			fsys:            invalidFS,
+			expectedValid:   &metav1.Condition{Status: metav1.ConditionFalse, Reason: ocv1.ReasonInvalidContent},
+			expectedServing: metav1.ConditionTrue,
+		},
+		"invalid content is not stored and the previous content is deleted with StopServing": {
+			catalog:         newCatalog(ocv1.InvalidContentPolicyStopServing),
+			validateContent: true,
+			fsys:            invalidFS,
+			expectDelete:    true,
+			expectedValid:   &metav1.Condition{Status: metav1.ConditionFalse, Reason: ocv1.ReasonInvalidContent},
+			expectedServing: metav1.ConditionFalse,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := mockstorage.NewMockInstance(gomock.NewController(t))
+			store.EXPECT().BaseURL(gomock.Any()).Return("URL").AnyTimes()
+			store.EXPECT().ContentExists(gomock.Any()).Return(true).AnyTimes()
+			if tc.expectStore {
+				store.EXPECT().Store(gomock.Any(), "test-catalog", gomock.Any()).Return(nil)
+			}
+			if tc.expectDelete {
+				store.EXPECT().Delete("test-catalog").Return(nil)
+			}
+			reconciler := &ClusterCatalogReconciler{
+				ImagePuller:     &imageutil.FakePuller{ImageFS: tc.fsys, Ref: ref},
+				Storage:         store,
+				ValidateContent: tc.validateContent,
+				storedCatalogs:  map[string]storedCatalogData{},
+			}
+			require.NoError(t, reconciler.setupFinalizers())
+			_, err := reconciler.reconcile(context.Background(), tc.catalog)
+			if tc.expectStore {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, "catalog content is invalid")
+			}
+
+			valid := meta.FindStatusCondition(tc.catalog.Status.Conditions, ocv1.TypeValid)
+			if tc.expectedValid == nil {
+				require.Nil(t, valid)
+			} else {
+				require.NotNil(t, valid)
+				require.Equal(t, tc.expectedValid.Status, valid.Status)
+				require.Equal(t, tc.expectedValid.Reason, valid.Reason)
+			}
+			require.True(t, meta.IsStatusConditionPresentAndEqual(tc.catalog.Status.Conditions, ocv1.TypeServing, tc.expectedServing))
+		})
+	}
+}
+
+func TestReconcilerReportDegraded(t *testing.T) {
+	servedRef := mustRef(t, "my.org/someimage@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+	candidateRef := mustRef(t, "my.org/someimage@sha256:a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90")
+	servedSource := &ocv1.ResolvedCatalogSource{Type: ocv1.SourceTypeImage, Image: &ocv1.ResolvedImageSource{Ref: servedRef.String()}}
+	candidateSource := &ocv1.ResolvedCatalogSource{Type: ocv1.SourceTypeImage, Image: &ocv1.ResolvedImageSource{Ref: candidateRef.String()}}
+	newCatalog := func() *ocv1.ClusterCatalog {
+		return &ocv1.ClusterCatalog{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test-catalog",
+				Finalizers: []string{fbcDeletionFinalizer},
+				Generation: 1,
+			},
+			Spec: ocv1.ClusterCatalogSpec{
+				Source: ocv1.CatalogSource{
+					Type:  ocv1.SourceTypeImage,
+					Image: &ocv1.ImageSource{Ref: "my.org/someimage:latest", PollIntervalMinutes: ptr.To(1)},
+				},
+			},
+			Status: ocv1.ClusterCatalogStatus{
+				URLs: &ocv1.ClusterCatalogURLs{Base: "URL"},
+				Conditions: []metav1.Condition{
+					{Type: ocv1.TypeProgressing, Status: metav1.ConditionTrue, Reason: ocv1.ReasonSucceeded, ObservedGeneration: 1},
+					{Type: ocv1.TypeServing, Status: metav1.ConditionTrue, Reason: ocv1.ReasonAvailable, ObservedGeneration: 1},
+				},
+				ResolvedSource: servedSource.DeepCopy(),
+				LastUnpacked:   ptr.To(metav1.NewTime(time.Now().Truncate(time.Second))),
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		reportDegraded       bool
+		pullErr              error
+		contentExists        bool
+		expectedDegraded     bool
+		expectedFailedSource *ocv1.ResolvedCatalogSource
+	}{
+		"failed store of a resolved source is reported while the previous content is served": {
+			reportDegraded:       true,
+			contentExists:        true,
+			expectedDegraded:     true,
+			expectedFailedSource: candidateSource,
+		},
+		"failed pull is reported while the previous content is served": {
+			reportDegraded:   true,
+			pullErr:          errors.New("mockpuller error"),
+			contentExists:    true,
+			expectedDegraded: true,
+		},
+		"catalog is not degraded when the previous content is not served": {
+			reportDegraded:       true,
+			expectedFailedSource: candidateSource,
+		},
+		"failures are not reported unless enabled": {
+			contentExists: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := mockstorage.NewMockInstance(gomock.NewController(t))
+			store.EXPECT().BaseURL(gomock.Any()).Return("URL").AnyTimes()
+			store.EXPECT().ContentExists(gomock.Any()).Return(tc.contentExists).AnyTimes()
+			store.EXPECT().Store(gomock.Any(), "test-catalog", gomock.Any()).Return(errors.New("mockstore store error")).AnyTimes()
+			reconciler := &ClusterCatalogReconciler{
+				ImagePuller:    &imageutil.FakePuller{Ref: candidateRef, Error: tc.pullErr},
+				Storage:        store,
+				ReportDegraded: tc.reportDegraded,
+				storedCatalogs: map[string]storedCatalogData{},
+			}
+			require.NoError(t, reconciler.setupFinalizers())
+			catalog := newCatalog()
+			_, err := reconciler.reconcile(context.Background(), catalog)
+			require.Error(t, err)
+
+			require.Equal(t, servedSource, catalog.Status.ResolvedSource)
+			require.Equal(t, tc.expectedFailedSource, catalog.Status.FailedSource)
+			degraded := meta.FindStatusCondition(catalog.Status.Conditions, ocv1.TypeDegraded)
+			if !tc.expectedDegraded {
+				require.Nil(t, degraded)
+				return
+			}
+			require.NotNil(t, degraded)
+			require.Equal(t, metav1.ConditionTrue, degraded.Status)
+			require.Equal(t, ocv1.ReasonServingLastKnownGood, degraded.Reason)
+			require.True(t, meta.IsStatusConditionTrue(catalog.Status.Conditions, ocv1.TypeServing))
+		})
+	}
+
+	t.Run("catalog is no longer degraded once the content of a source is served", func(t *testing.T) {
+		store := mockstorage.NewMockInstance(gomock.NewController(t))
+		store.EXPECT().BaseURL(gomock.Any()).Return("URL").AnyTimes()
+		store.EXPECT().ContentExists(gomock.Any()).Return(true).AnyTimes()
+		store.EXPECT().Store(gomock.Any(), "test-catalog", gomock.Any()).Return(nil)
+		reconciler := &ClusterCatalogReconciler{
+			ImagePuller:    &imageutil.FakePuller{Ref: candidateRef},
+			Storage:        store,
+			ReportDegraded: true,
+			storedCatalogs: map[string]storedCatalogData{},
+		}
+		require.NoError(t, reconciler.setupFinalizers())
+		catalog := newCatalog()
+		catalog.Status.FailedSource = candidateSource.DeepCopy()
+		meta.SetStatusCondition(&catalog.Status.Conditions, metav1.Condition{Type: ocv1.TypeDegraded, Status: metav1.ConditionTrue, Reason: ocv1.ReasonServingLastKnownGood})
+		_, err := reconciler.reconcile(context.Background(), catalog)
+		require.NoError(t, err)
+
+		require.Equal(t, candidateSource, catalog.Status.ResolvedSource)
+		require.Nil(t, catalog.Status.FailedSource)
+		require.True(t, meta.IsStatusConditionFalse(catalog.Status.Conditions, ocv1.TypeDegraded))
+	})
+
+	for name, tc := range map[string]struct {
+		reportDegraded bool
+		expectedStored bool
+	}{
+		"last known good state is kept when a failure is reported": {
+			reportDegraded: true,
+			expectedStored: true,
+		},
+		"stored state is deleted on failures unless reported": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, ocv1.AddToScheme(scheme))
+			store := mockstorage.NewMockInstance(gomock.NewController(t))
+			store.EXPECT().BaseURL(gomock.Any()).Return("URL").AnyTimes()
+			store.EXPECT().ContentExists(gomock.Any()).Return(true).AnyTimes()
+			store.EXPECT().Store(gomock.Any(), "test-catalog", gomock.Any()).Return(errors.New("mockstore store error")).AnyTimes()
+			catalog := newCatalog()
+			reconciler := &ClusterCatalogReconciler{
+				Client:         fake.NewClientBuilder().WithScheme(scheme).WithObjects(catalog).WithStatusSubresource(catalog).Build(),
+				ImagePuller:    &imageutil.FakePuller{Ref: candidateRef},
+				Storage:        store,
+				ReportDegraded: tc.reportDegraded,
+				storedCatalogs: map[string]storedCatalogData{
+					"test-catalog": {resolvedSource: servedSource, lastUnpack: catalog.Status.LastUnpacked.Time, lastSuccessfulPoll: time.Now().Add(-time.Hour), observedGeneration: 1},
+				},
+			}
+			require.NoError(t, reconciler.setupFinalizers())
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-catalog"}})
+			require.Error(t, err)
+
+			_, stored := reconciler.storedCatalogs["test-catalog"]
+			require.Equal(t, tc.expectedStored, stored)
+		})
+	}
+}
+
 func mustRef(t *testing.T, ref string) reference.Canonical {
 	t.Helper()
 	p, err := reference.Parse(ref)
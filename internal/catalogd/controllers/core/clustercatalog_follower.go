@@ -0,0 +1,137 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+// clusterCatalogFollower stores the content of the ClusterCatalogs served by the leader
+// in the local storage of the other replicas, so that every replica serves the same
+// content. It pulls the resolved source reported in the status of a ClusterCatalog,
+// rather than its source, so that replicas do not serve content the leader has not
+// resolved yet. It never updates ClusterCatalogs.
+//
+// Once its replica is elected leader, the ClusterCatalogReconciler takes over and the
+// follower does nothing.
+type clusterCatalogFollower struct {
+	reconciler *ClusterCatalogReconciler
+	elected    <-chan struct{}
+
+	storedSourcesMu sync.Mutex
+	storedSources   map[string]ocv1.ResolvedCatalogSource
+}
+
+// SetupFollowerWithManager sets up a controller storing the content served by the
+// leader on replicas that are not the leader. Unlike the controller set up by
+// SetupWithManager, it runs on every replica.
+func (r *ClusterCatalogReconciler) SetupFollowerWithManager(mgr ctrl.Manager) error {
+	f := &clusterCatalogFollower{
+		reconciler:    r,
+		elected:       mgr.Elected(),
+		storedSources: make(map[string]ocv1.ResolvedCatalogSource),
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ocv1.ClusterCatalog{}).
+		Named("catalogd-clustercatalog-follower").
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(f)
+}
+
+func (f *clusterCatalogFollower) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	select {
+	case <-f.elected:
+		return ctrl.Result{}, nil
+	default:
+	}
+
+	l := log.FromContext(ctx).WithName("catalogd-follower")
+	ctx = log.IntoContext(ctx, l)
+
+	catalog := &ocv1.ClusterCatalog{}
+	if err := f.reconciler.Get(ctx, req.NamespacedName, catalog); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		catalog = &ocv1.ClusterCatalog{ObjectMeta: metav1.ObjectMeta{Name: req.Name}}
+	}
+
+	resolvedSource := servedSource(catalog)
+	if resolvedSource == nil {
+		return ctrl.Result{}, f.delete(ctx, catalog)
+	}
+	if f.isStored(catalog.Name, *resolvedSource) && f.reconciler.Storage.ContentExists(catalog.Name) {
+		return ctrl.Result{}, nil
+	}
+
+	l.Info("storing content served by the leader", "resolvedSource", resolvedSource)
+	pinned, err := pinToResolvedSource(catalog, resolvedSource)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	fsys, _, _, err := f.reconciler.pullSource(ctx, pinned)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := f.reconciler.Storage.Store(ctx, catalog.Name, fsys); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error storing fbc: %v", err)
+	}
+
+	f.storedSourcesMu.Lock()
+	f.storedSources[catalog.Name] = *resolvedSource
+	f.storedSourcesMu.Unlock()
+	return ctrl.Result{}, nil
+}
+
+// delete removes the locally stored content of a catalog that is no longer served
+func (f *clusterCatalogFollower) delete(ctx context.Context, catalog *ocv1.ClusterCatalog) error {
+	f.storedSourcesMu.Lock()
+	delete(f.storedSources, catalog.Name)
+	f.storedSourcesMu.Unlock()
+
+	// The status changes made by deleteCatalogCache are discarded: only the leader updates ClusterCatalogs.
+	return f.reconciler.deleteCatalogCache(ctx, catalog.DeepCopy())
+}
+
+func (f *clusterCatalogFollower) isStored(catalogName string, resolvedSource ocv1.ResolvedCatalogSource) bool {
+	f.storedSourcesMu.Lock()
+	defer f.storedSourcesMu.Unlock()
+	stored, ok := f.storedSources[catalogName]
+	return ok && equality.Semantic.DeepEqual(stored, resolvedSource)
+}
+
+// servedSource returns the resolved source of the content the leader serves for a
+// catalog, or nil if the catalog is not served.
+func servedSource(catalog *ocv1.ClusterCatalog) *ocv1.ResolvedCatalogSource {
+	if !meta.IsStatusConditionTrue(catalog.Status.Conditions, ocv1.TypeServing) {
+		return nil
+	}
+	return catalog.Status.ResolvedSource
+}
+
+// pinToResolvedSource returns a copy of the catalog whose source is the given resolved source
+func pinToResolvedSource(catalog *ocv1.ClusterCatalog, resolvedSource *ocv1.ResolvedCatalogSource) (*ocv1.ClusterCatalog, error) {
+	pinned := catalog.DeepCopy()
+	switch {
+	case resolvedSource.Type == ocv1.SourceTypeImage && resolvedSource.Image != nil && pinned.Spec.Source.Image != nil:
+		pinned.Spec.Source.Type = ocv1.SourceTypeImage
+		pinned.Spec.Source.Image.Ref = resolvedSource.Image.Ref
+	case resolvedSource.Type == ocv1.SourceTypeGit && resolvedSource.Git != nil && pinned.Spec.Source.Git != nil:
+		pinned.Spec.Source.Type = ocv1.SourceTypeGit
+		pinned.Spec.Source.Git.Ref = resolvedSource.Git.Commit
+	default:
+		return nil, fmt.Errorf("resolved source of type %q does not match the source of ClusterCatalog %q", resolvedSource.Type, catalog.Name)
+	}
+	return pinned, nil
+}
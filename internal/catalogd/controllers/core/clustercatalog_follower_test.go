@@ -0,0 +1,159 @@
+package core
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.podman.io/image/v5/docker/reference"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
+	mockstorage "github.com/operator-framework/operator-controller/internal/testutil/mock/storage"
+)
+
+// recordingPuller records the image references it pulls
+type recordingPuller struct {
+	imageutil.FakePuller
+	pulled []string
+}
+
+func (p *recordingPuller) Pull(ctx context.Context, ownerID, ref string, cache imageutil.Cache) (fs.FS, reference.Canonical, time.Time, error) {
+	p.pulled = append(p.pulled, ref)
+	return p.FakePuller.Pull(ctx, ownerID, ref, cache)
+}
+
+func TestClusterCatalogFollowerReconcile(t *testing.T) {
+	resolvedRef := "my.org/someimage@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	newCatalog := func(serving metav1.ConditionStatus) *ocv1.ClusterCatalog {
+		return &ocv1.ClusterCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-catalog"},
+			Spec: ocv1.ClusterCatalogSpec{
+				Source: ocv1.CatalogSource{
+					Type:  ocv1.SourceTypeImage,
+					Image: &ocv1.ImageSource{Ref: "my.org/someimage:latest"},
+				},
+			},
+			Status: ocv1.ClusterCatalogStatus{
+				Conditions: []metav1.Condition{
+					{Type: ocv1.TypeServing, Status: serving, Reason: ocv1.ReasonAvailable, LastTransitionTime: metav1.Now()},
+				},
+				ResolvedSource: &ocv1.ResolvedCatalogSource{
+					Type:  ocv1.SourceTypeImage,
+					Image: &ocv1.ResolvedImageSource{Ref: resolvedRef},
+				},
+			},
+		}
+	}
+
+	for _, tt := range []struct {
+		name           string
+		catalog        *ocv1.ClusterCatalog
+		elected        bool
+		expectStore    func(m *mockstorage.MockInstance)
+		expectedPulled []string
+	}{
+		{
+			name:    "Serving catalog is pulled from its resolved source and stored",
+			catalog: newCatalog(metav1.ConditionTrue),
+			expectStore: func(m *mockstorage.MockInstance) {
+				m.EXPECT().Store(gomock.Any(), "test-catalog", gomock.Any()).Return(nil).Times(1)
+				m.EXPECT().ContentExists("test-catalog").Return(true).AnyTimes()
+			},
+			expectedPulled: []string{resolvedRef},
+		},
+		{
+			name:    "catalog that is not Serving is deleted",
+			catalog: newCatalog(metav1.ConditionFalse),
+			expectStore: func(m *mockstorage.MockInstance) {
+				m.EXPECT().Delete("test-catalog").Return(nil).Times(2)
+			},
+		},
+		{
+			name: "deleted catalog is deleted",
+			expectStore: func(m *mockstorage.MockInstance) {
+				m.EXPECT().Delete("test-catalog").Return(nil).Times(2)
+			},
+		},
+		{
+			name:        "nothing is done once elected leader",
+			catalog:     newCatalog(metav1.ConditionTrue),
+			elected:     true,
+			expectStore: func(m *mockstorage.MockInstance) {},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, ocv1.AddToScheme(scheme))
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.catalog != nil {
+				builder = builder.WithObjects(tt.catalog)
+			}
+			store := mockstorage.NewMockInstance(gomock.NewController(t))
+			tt.expectStore(store)
+			puller := &recordingPuller{FakePuller: imageutil.FakePuller{
+				ImageFS: fstest.MapFS{},
+				Ref:     mustRef(t, resolvedRef),
+			}}
+
+			elected := make(chan struct{})
+			if tt.elected {
+				close(elected)
+			}
+			f := &clusterCatalogFollower{
+				reconciler: &ClusterCatalogReconciler{
+					Client:      builder.Build(),
+					ImagePuller: puller,
+					ImageCache:  &imageutil.FakeCache{},
+					Storage:     store,
+				},
+				elected:       elected,
+				storedSources: make(map[string]ocv1.ResolvedCatalogSource),
+			}
+
+			// Reconciling twice checks content is only pulled again when the resolved source changes
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-catalog"}}
+			for range 2 {
+				_, err := f.Reconcile(context.Background(), req)
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedPulled, puller.pulled)
+		})
+	}
+}
+
+func TestPinToResolvedSource(t *testing.T) {
+	catalog := &ocv1.ClusterCatalog{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-catalog"},
+		Spec: ocv1.ClusterCatalogSpec{
+			Source: ocv1.CatalogSource{
+				Type: ocv1.SourceTypeGit,
+				Git:  &ocv1.GitSource{URL: "https://example.com/catalog.git", Ref: "main"},
+			},
+		},
+	}
+	commit := "0123456789abcdef0123456789abcdef01234567"
+
+	pinned, err := pinToResolvedSource(catalog, &ocv1.ResolvedCatalogSource{
+		Type: ocv1.SourceTypeGit,
+		Git:  &ocv1.ResolvedGitSource{Commit: commit},
+	})
+	require.NoError(t, err)
+	require.Equal(t, commit, pinned.Spec.Source.Git.Ref)
+	require.Equal(t, "main", catalog.Spec.Source.Git.Ref)
+
+	_, err = pinToResolvedSource(catalog, &ocv1.ResolvedCatalogSource{
+		Type:  ocv1.SourceTypeImage,
+		Image: &ocv1.ResolvedImageSource{Ref: "my.org/someimage@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+	})
+	require.Error(t, err)
+}
@@ -11,11 +11,25 @@ import (
 const (
 	APIV1MetasHandler     = featuregate.Feature("APIV1MetasHandler")
 	GraphQLCatalogQueries = featuregate.Feature("GraphQLCatalogQueries")
+	GitCatalogSource      = featuregate.Feature("GitCatalogSource")
+	APIV1DiffHandler      = featuregate.Feature("APIV1DiffHandler")
+	APIV1QueryHandler     = featuregate.Feature("APIV1QueryHandler")
+	S3CatalogStorage      = featuregate.Feature("S3CatalogStorage")
+	CatalogValidation     = featuregate.Feature("CatalogValidation")
+	DegradedCatalogStatus = featuregate.Feature("DegradedCatalogStatus")
+	BundleFirstSeen       = featuregate.Feature("BundleFirstSeen")
 )
 
 var catalogdFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	APIV1MetasHandler:     {Default: false, PreRelease: featuregate.Alpha, LockToDefault: false},
 	GraphQLCatalogQueries: {Default: false, PreRelease: featuregate.Alpha, LockToDefault: false},
+	GitCatalogSource:      {Default: false, PreRelease: featuregate.Alpha, LockToDefault: false},
+	APIV1DiffHandler:      {Default: false, PreRelease: featuregate.Alpha, LockToDefault: false},
+	APIV1QueryHandler:     {Default: false, PreRelease: featuregate.Alpha, LockToDefault: false},
+	S3CatalogStorage:      {Default: false, PreRelease: featuregate.Alpha, LockToDefault: false},
+	CatalogValidation:     {Default: false, PreRelease: featuregate.Alpha, LockToDefault: false},
+	DegradedCatalogStatus: {Default: false, PreRelease: featuregate.Alpha, LockToDefault: false},
+	BundleFirstSeen:       {Default: false, PreRelease: featuregate.Alpha, LockToDefault: false},
 }
 
 var CatalogdFeatureGate featuregate.MutableFeatureGate = featuregate.NewFeatureGate()
@@ -5,13 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/fs"
+	"maps"
 	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/graphql-go/graphql"
 
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	"github.com/operator-framework/operator-controller/internal/catalogd/index"
 )
 
 // Pre-compiled regex patterns to avoid repeated compilation in hot paths
@@ -389,7 +393,7 @@ func createNestedObjectType(typeName string, nestedFields map[string]*FieldInfo)
 		fields[fieldName] = &graphql.Field{
 			Type: fieldInfo.GraphQLType,
 			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-				if source, ok := p.Source.(map[string]interface{}); ok {
+				if source, ok := sourceObject(p.Source); ok {
 					// Try direct field name first
 					if value, ok := source[fieldName]; ok {
 						return marshalComplexValue(value), nil
@@ -415,7 +419,7 @@ func createNestedObjectType(typeName string, nestedFields map[string]*FieldInfo)
 // createFieldResolver creates a resolver function for a field name
 func createFieldResolver(fieldName string) graphql.FieldResolveFn {
 	return func(p graphql.ResolveParams) (interface{}, error) {
-		source, ok := p.Source.(map[string]interface{})
+		source, ok := sourceObject(p.Source)
 		if !ok {
 			return nil, nil
 		}
@@ -433,6 +437,7 @@ func createFieldResolver(fieldName string) graphql.FieldResolveFn {
 
 // createNestedFieldResolver creates a resolver that returns the raw value
 // so GraphQL can iterate over array-of-object fields (e.g. properties, entries).
+// Objects of the array are returned along with the object they belong to.
 func createNestedFieldResolver(fieldName string) graphql.FieldResolveFn {
 	return func(p graphql.ResolveParams) (interface{}, error) {
 		source, ok := p.Source.(map[string]interface{})
@@ -441,9 +446,22 @@ func createNestedFieldResolver(fieldName string) graphql.FieldResolveFn {
 		}
 
 		for origKey, value := range source {
-			if remapFieldName(origKey) == fieldName {
+			if remapFieldName(origKey) != fieldName {
+				continue
+			}
+			list, ok := value.([]interface{})
+			if !ok {
 				return value, nil
 			}
+			nested := make([]interface{}, 0, len(list))
+			for _, item := range list {
+				if obj, ok := item.(map[string]interface{}); ok {
+					nested = append(nested, nestedObject{value: obj, parent: source})
+				} else {
+					nested = append(nested, item)
+				}
+			}
+			return nested, nil
 		}
 		return nil, nil
 	}
@@ -513,9 +531,16 @@ func sanitizeTypeName(propType string) string {
 func BuildDynamicGraphQLSchema(catalogSchema *CatalogSchema, metasBySchema map[string][]*declcfg.Meta) (*DynamicSchema, error) {
 	// Pre-parse all meta blobs to avoid unmarshaling on every query
 	// This has minimal memory overhead (parsed objects ≈ raw blob size)
-	// but eliminates expensive json.Unmarshal operations from the query path
+	// but eliminates expensive json.Unmarshal operations from the query path.
+	// The parsed objects are indexed by schema, package and name, and laid out in
+	// schema name order, so that cursors are the same for the same catalog content.
 	parsedObjects := make(map[string][]map[string]interface{})
-	for schemaName, metas := range metasBySchema {
+	objects := &catalogObjects{
+		index:   &index.Index{},
+		objects: make(map[index.Section]map[string]interface{}),
+	}
+	for _, schemaName := range slices.Sorted(maps.Keys(metasBySchema)) {
+		metas := metasBySchema[schemaName]
 		parsedObjects[schemaName] = make([]map[string]interface{}, 0, len(metas))
 		for _, meta := range metas {
 			var obj map[string]interface{}
@@ -523,6 +548,7 @@ func BuildDynamicGraphQLSchema(catalogSchema *CatalogSchema, metasBySchema map[s
 				continue // Skip malformed objects (same as runtime behavior)
 			}
 			parsedObjects[schemaName] = append(parsedObjects[schemaName], obj)
+			objects.add(meta, obj)
 		}
 	}
 
@@ -532,6 +558,9 @@ func BuildDynamicGraphQLSchema(catalogSchema *CatalogSchema, metasBySchema map[s
 	for schemaName, schemaInfo := range catalogSchema.Schemas {
 		objectTypes[schemaName] = buildGraphQLObjectType(schemaName, schemaInfo)
 	}
+	addRelationships(objectTypes, objects)
+	propertyFilterType := newPropertyFilterType()
+	pageInfoType := newPageInfoType()
 
 	// Pre-build field name to schema name lookup map for O(1) access in resolvers
 	fieldNameToSchema := make(map[string]string)
@@ -555,20 +584,20 @@ func BuildDynamicGraphQLSchema(catalogSchema *CatalogSchema, metasBySchema map[s
 		sanitized := alphanumericOnlyRE.ReplaceAllString(schemaName, "")
 		fieldName := strings.ToLower(sanitized) + "s"
 
+		args := filterArgs(schemaName, propertyFilterType)
+		args["limit"] = &graphql.ArgumentConfig{
+			Type:         graphql.Int,
+			DefaultValue: maxPageSize,
+			Description:  "Maximum number of items to return",
+		}
+		args["offset"] = &graphql.ArgumentConfig{
+			Type:         graphql.Int,
+			DefaultValue: 0,
+			Description:  "Number of items to skip",
+		}
 		queryFields[fieldName] = &graphql.Field{
 			Type: graphql.NewList(objectType),
-			Args: graphql.FieldConfigArgument{
-				"limit": &graphql.ArgumentConfig{
-					Type:         graphql.Int,
-					DefaultValue: 100,
-					Description:  "Maximum number of items to return",
-				},
-				"offset": &graphql.ArgumentConfig{
-					Type:         graphql.Int,
-					DefaultValue: 0,
-					Description:  "Number of items to skip",
-				},
-			},
+			Args: args,
 			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 				// O(1) lookup of schema name from pre-built map
 				currentSchemaName, ok := fieldNameToSchema[p.Info.FieldName]
@@ -576,16 +605,18 @@ func BuildDynamicGraphQLSchema(catalogSchema *CatalogSchema, metasBySchema map[s
 					return nil, fmt.Errorf("unknown schema for field %s", p.Info.FieldName)
 				}
 
-				// Get pre-parsed objects for this schema (no unmarshaling needed!)
-				objects, ok := parsedObjects[currentSchemaName]
-				if !ok {
-					return []interface{}{}, nil
+				// Look up the pre-parsed objects matching the filters in the index
+				// (no unmarshaling needed!)
+				filter, err := newObjectFilter(currentSchemaName, p.Args)
+				if err != nil {
+					return nil, err
 				}
+				sections := filter.sections(objects)
 
 				// Parse arguments
 				limit, _ := p.Args["limit"].(int)
-				if limit <= 0 || limit > 100 {
-					limit = 100 // Clamp to default/max to prevent DoS
+				if limit <= 0 || limit > maxPageSize {
+					limit = maxPageSize // Clamp to default/max to prevent DoS
 				}
 				offset, _ := p.Args["offset"].(int)
 				if offset < 0 {
@@ -594,19 +625,22 @@ func BuildDynamicGraphQLSchema(catalogSchema *CatalogSchema, metasBySchema map[s
 
 				// Apply pagination to pre-parsed objects
 				var results []interface{}
-				for i, obj := range objects {
+				for i, s := range sections {
 					if i < offset {
 						continue
 					}
 					if len(results) >= limit {
 						break
 					}
-					results = append(results, obj)
+					results = append(results, objects.objects[s])
 				}
 
 				return results, nil
 			},
 		}
+
+		// Cursor-based pagination of the same objects, e.g. "olmbundlesConnection"
+		queryFields[fieldName+"Connection"] = buildConnectionField(schemaName, objectType, objects, filterArgs(schemaName, propertyFilterType), pageInfoType)
 	}
 
 	// Add summary field
@@ -0,0 +1,356 @@
+package graphql
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	mmsemver "github.com/Masterminds/semver/v3"
+	"github.com/graphql-go/graphql"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	"github.com/operator-framework/operator-controller/internal/catalogd/index"
+)
+
+// maxPageSize is the maximum number of objects returned by a single list or connection field
+const maxPageSize = 100
+
+// catalogObjects holds the pre-parsed objects of a catalog, indexed by their schema,
+// package and name so that queries and relationships look objects up instead of
+// scanning all the objects of a schema.
+type catalogObjects struct {
+	index   *index.Index
+	objects map[index.Section]map[string]interface{}
+}
+
+// add indexes a catalog object, parsed from the blob of its meta
+func (c *catalogObjects) add(meta *declcfg.Meta, obj map[string]interface{}) {
+	c.objects[c.index.Add(meta)] = obj
+}
+
+// lookup returns the sections of the objects matching the given schema, package and
+// name, in catalog order. Empty values match any object.
+func (c *catalogObjects) lookup(schema, pkg, name string) []index.Section {
+	return c.index.Lookup(schema, pkg, name)
+}
+
+// find returns the objects matching the given schema, package and name, in catalog order
+func (c *catalogObjects) find(schema, pkg, name string) []interface{} {
+	sections := c.lookup(schema, pkg, name)
+	results := make([]interface{}, 0, len(sections))
+	for _, s := range sections {
+		results = append(results, c.objects[s])
+	}
+	return results
+}
+
+// newPropertyFilterType creates the input type of the properties argument
+func newPropertyFilterType() *graphql.InputObject {
+	return graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "PropertyFilter",
+		Description: "Selects objects with a property of the given type, and a value containing the given JSON value",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"type": &graphql.InputObjectFieldConfig{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "Type of the property",
+			},
+			"value": &graphql.InputObjectFieldConfig{
+				Type:        graphql.String,
+				Description: "JSON value the property value must contain. Objects match objects with at least the given fields.",
+			},
+		},
+	})
+}
+
+// filterArgs returns the arguments selecting the objects of a schema
+func filterArgs(schemaName string, propertyFilterType *graphql.InputObject) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{
+		"package": &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "Only return objects of this package",
+		},
+		"name": &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "Only return objects with this name",
+		},
+		"properties": &graphql.ArgumentConfig{
+			Type:        graphql.NewList(graphql.NewNonNull(propertyFilterType)),
+			Description: "Only return objects with properties matching all of these filters",
+		},
+	}
+	if schemaName == declcfg.SchemaBundle {
+		args["channel"] = &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "Only return bundles that are entries of a channel with this name",
+		}
+		args["versionRange"] = &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "Only return bundles with a version in this semver range, such as \">1.2.0 <2.0.0\"",
+		}
+	}
+	return args
+}
+
+// objectFilter selects the objects of a schema
+type objectFilter struct {
+	schema       string
+	pkg          string
+	name         string
+	properties   []propertyFilter
+	channel      string
+	versionRange *mmsemver.Constraints
+}
+
+// propertyFilter selects objects by the type and value of their properties
+type propertyFilter struct {
+	typ   string
+	value interface{}
+}
+
+// newObjectFilter returns the filter of the objects of a schema selected by field arguments
+func newObjectFilter(schemaName string, args map[string]interface{}) (*objectFilter, error) {
+	f := &objectFilter{schema: schemaName}
+	f.pkg, _ = args["package"].(string)
+	f.name, _ = args["name"].(string)
+	f.channel, _ = args["channel"].(string)
+
+	props, _ := args["properties"].([]interface{})
+	for _, p := range props {
+		prop, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pf := propertyFilter{}
+		pf.typ, _ = prop["type"].(string)
+		if v, ok := prop["value"].(string); ok {
+			if err := json.Unmarshal([]byte(v), &pf.value); err != nil {
+				return nil, fmt.Errorf("invalid value of property filter %q: %w", pf.typ, err)
+			}
+		}
+		f.properties = append(f.properties, pf)
+	}
+
+	if versionRange, ok := args["versionRange"].(string); ok && versionRange != "" {
+		constraints, err := mmsemver.NewConstraint(versionRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid versionRange %q: %w", versionRange, err)
+		}
+		f.versionRange = constraints
+	}
+	return f, nil
+}
+
+// sections returns the sections of the objects selected by the filter, in catalog order
+func (f *objectFilter) sections(c *catalogObjects) []index.Section {
+	sections := c.lookup(f.schema, f.pkg, f.name)
+	if len(f.properties) == 0 && f.channel == "" && f.versionRange == nil {
+		return sections
+	}
+
+	var channelEntries map[string]struct{}
+	if f.channel != "" {
+		channelEntries = make(map[string]struct{})
+		for _, ch := range c.find(declcfg.SchemaChannel, f.pkg, f.channel) {
+			for _, entry := range objectList(ch.(map[string]interface{}), "entries") {
+				if name, ok := entry["name"].(string); ok {
+					channelEntries[name] = struct{}{}
+				}
+			}
+		}
+	}
+
+	selected := make([]index.Section, 0, len(sections))
+	for _, s := range sections {
+		obj := c.objects[s]
+		if channelEntries != nil {
+			if _, ok := channelEntries[stringField(obj, "name")]; !ok {
+				continue
+			}
+		}
+		if !f.matchesProperties(obj) || !f.matchesVersion(obj) {
+			continue
+		}
+		selected = append(selected, s)
+	}
+	return selected
+}
+
+// matchesProperties returns whether the object has properties matching all the property filters
+func (f *objectFilter) matchesProperties(obj map[string]interface{}) bool {
+	props := objectList(obj, "properties")
+	for _, pf := range f.properties {
+		matched := false
+		for _, prop := range props {
+			if prop["type"] == pf.typ && (pf.value == nil || containsJSON(prop["value"], pf.value)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesVersion returns whether the version of the olm.package property of the object
+// is in the version range of the filter
+func (f *objectFilter) matchesVersion(obj map[string]interface{}) bool {
+	if f.versionRange == nil {
+		return true
+	}
+	for _, prop := range objectList(obj, "properties") {
+		if prop["type"] != property.TypePackage {
+			continue
+		}
+		value, _ := prop["value"].(map[string]interface{})
+		version, err := mmsemver.NewVersion(stringField(value, "version"))
+		return err == nil && f.versionRange.Check(version)
+	}
+	return false
+}
+
+// containsJSON returns whether the JSON value got contains the JSON value want: objects
+// contain objects with a subset of their fields, and other values must be equal
+func containsJSON(got, want interface{}) bool {
+	wantObj, ok := want.(map[string]interface{})
+	if !ok {
+		return reflect.DeepEqual(got, want)
+	}
+	gotObj, ok := got.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for k, v := range wantObj {
+		if !containsJSON(gotObj[k], v) {
+			return false
+		}
+	}
+	return true
+}
+
+// objectList returns the objects of a list field of a JSON object
+func objectList(obj map[string]interface{}, field string) []map[string]interface{} {
+	list, _ := obj[field].([]interface{})
+	objects := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		if o, ok := item.(map[string]interface{}); ok {
+			objects = append(objects, o)
+		}
+	}
+	return objects
+}
+
+// stringField returns the value of a string field of a JSON object
+func stringField(obj map[string]interface{}, field string) string {
+	s, _ := obj[field].(string)
+	return s
+}
+
+// encodeCursor returns the opaque cursor of the object at the given section
+func encodeCursor(s index.Section) string {
+	return base64.StdEncoding.EncodeToString([]byte(strconv.FormatInt(s.Offset(), 10)))
+}
+
+// sectionsAfter returns the sections after the object of the given cursor
+func sectionsAfter(sections []index.Section, cursor string) ([]index.Section, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	offset, err := strconv.ParseInt(string(decoded), 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	i := sort.Search(len(sections), func(i int) bool {
+		return sections[i].Offset() > offset
+	})
+	return sections[i:], nil
+}
+
+// newPageInfoType creates the type describing a page of a connection
+func newPageInfoType() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"endCursor":   &graphql.Field{Type: graphql.String},
+		},
+	})
+}
+
+// buildConnectionField creates a field returning a page of the objects of a schema,
+// starting after a cursor, along with the cursors of the returned objects
+func buildConnectionField(schemaName string, objectType *graphql.Object, objects *catalogObjects, args graphql.FieldConfigArgument, pageInfoType *graphql.Object) *graphql.Field {
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: objectType.Name() + "Edge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"node":   &graphql.Field{Type: objectType},
+		},
+	})
+	connectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: objectType.Name() + "Connection",
+		Fields: graphql.Fields{
+			"edges":      &graphql.Field{Type: graphql.NewList(edgeType)},
+			"pageInfo":   &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+			"totalCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Number of objects matching the filters"},
+		},
+	})
+
+	args["first"] = &graphql.ArgumentConfig{
+		Type:         graphql.Int,
+		DefaultValue: maxPageSize,
+		Description:  "Maximum number of items to return",
+	}
+	args["after"] = &graphql.ArgumentConfig{
+		Type:        graphql.String,
+		Description: "Only return items after the item of this cursor",
+	}
+
+	return &graphql.Field{
+		Type: connectionType,
+		Args: args,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			filter, err := newObjectFilter(schemaName, p.Args)
+			if err != nil {
+				return nil, err
+			}
+			sections := filter.sections(objects)
+			totalCount := len(sections)
+			if after, ok := p.Args["after"].(string); ok && after != "" {
+				if sections, err = sectionsAfter(sections, after); err != nil {
+					return nil, err
+				}
+			}
+
+			first, _ := p.Args["first"].(int)
+			if first <= 0 || first > maxPageSize {
+				first = maxPageSize // Clamp to default/max to prevent DoS
+			}
+			hasNextPage := len(sections) > first
+			if hasNextPage {
+				sections = sections[:first]
+			}
+
+			edges := make([]interface{}, 0, len(sections))
+			pageInfo := map[string]interface{}{"hasNextPage": hasNextPage}
+			for _, s := range sections {
+				cursor := encodeCursor(s)
+				edges = append(edges, map[string]interface{}{"cursor": cursor, "node": objects.objects[s]})
+				pageInfo["endCursor"] = cursor
+			}
+			return map[string]interface{}{
+				"edges":      edges,
+				"pageInfo":   pageInfo,
+				"totalCount": totalCount,
+			}, nil
+		},
+	}
+}
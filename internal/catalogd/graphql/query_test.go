@@ -0,0 +1,288 @@
+package graphql
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+)
+
+const testCatalog = `{"schema": "olm.package", "name": "foo", "defaultChannel": "stable"}
+{"schema": "olm.channel", "name": "stable", "package": "foo", "entries": [{"name": "foo.v1.0.0"}, {"name": "foo.v1.1.0", "replaces": "foo.v1.0.0"}, {"name": "foo.v2.0.0", "replaces": "foo.v1.1.0"}]}
+{"schema": "olm.channel", "name": "candidate", "package": "foo", "entries": [{"name": "foo.v2.1.0"}]}
+{"schema": "olm.bundle", "name": "foo.v1.0.0", "package": "foo", "image": "foo:v1.0.0", "properties": [{"type": "olm.package", "value": {"packageName": "foo", "version": "1.0.0"}}]}
+{"schema": "olm.bundle", "name": "foo.v1.1.0", "package": "foo", "image": "foo:v1.1.0", "properties": [{"type": "olm.package", "value": {"packageName": "foo", "version": "1.1.0"}}, {"type": "olm.gvk", "value": {"group": "foo.io", "kind": "Foo", "version": "v1"}}]}
+{"schema": "olm.bundle", "name": "foo.v2.0.0", "package": "foo", "image": "foo:v2.0.0", "properties": [{"type": "olm.package", "value": {"packageName": "foo", "version": "2.0.0"}}, {"type": "olm.gvk", "value": {"group": "foo.io", "kind": "Foo", "version": "v2"}}]}
+{"schema": "olm.bundle", "name": "foo.v2.1.0", "package": "foo", "image": "foo:v2.1.0", "properties": [{"type": "olm.package", "value": {"packageName": "foo", "version": "2.1.0"}}]}
+{"schema": "olm.deprecations", "package": "foo", "entries": [{"reference": {"schema": "olm.bundle", "name": "foo.v1.0.0"}, "message": "foo.v1.0.0 is deprecated"}, {"reference": {"schema": "olm.channel", "name": "candidate"}, "message": "candidate is deprecated"}]}
+{"schema": "olm.package", "name": "bar", "defaultChannel": "stable"}
+{"schema": "olm.channel", "name": "stable", "package": "bar", "entries": [{"name": "bar.v1.0.0"}]}
+{"schema": "olm.bundle", "name": "bar.v1.0.0", "package": "bar", "image": "bar:v1.0.0", "properties": [{"type": "olm.package", "value": {"packageName": "bar", "version": "1.0.0"}}]}
+`
+
+// buildTestSchema builds the dynamic GraphQL schema of the test catalog
+func buildTestSchema(t *testing.T) *DynamicSchema {
+	t.Helper()
+	return buildSchema(t, testCatalog)
+}
+
+// buildSchema builds the dynamic GraphQL schema of a catalog
+func buildSchema(t *testing.T, catalog string) *DynamicSchema {
+	t.Helper()
+	var metas []*declcfg.Meta
+	dec := json.NewDecoder(strings.NewReader(catalog))
+	for dec.More() {
+		var meta declcfg.Meta
+		if err := dec.Decode(&meta); err != nil {
+			t.Fatalf("Failed to decode test catalog: %v", err)
+		}
+		metas = append(metas, &meta)
+	}
+	catalogSchema, err := DiscoverSchemaFromMetas(metas)
+	if err != nil {
+		t.Fatalf("Failed to discover schema: %v", err)
+	}
+	metasBySchema := make(map[string][]*declcfg.Meta)
+	for _, meta := range metas {
+		metasBySchema[meta.Schema] = append(metasBySchema[meta.Schema], meta)
+	}
+	dynamicSchema, err := BuildDynamicGraphQLSchema(catalogSchema, metasBySchema)
+	if err != nil {
+		t.Fatalf("Failed to build schema: %v", err)
+	}
+	return dynamicSchema
+}
+
+// runQuery executes a query against the dynamic schema and returns its data as JSON
+func runQuery(t *testing.T, dynamicSchema *DynamicSchema, query string, variables map[string]interface{}) string {
+	t.Helper()
+	result := graphql.Do(graphql.Params{
+		Schema:         dynamicSchema.Schema,
+		RequestString:  query,
+		VariableValues: variables,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("Query %q failed: %v", query, result.Errors)
+	}
+	data, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatalf("Failed to marshal result: %v", err)
+	}
+	return string(data)
+}
+
+// assertJSONEqual fails the test if the JSON documents are not equal
+func assertJSONEqual(t *testing.T, expected, actual string) {
+	t.Helper()
+	var e, a interface{}
+	if err := json.Unmarshal([]byte(expected), &e); err != nil {
+		t.Fatalf("Invalid expected JSON: %v", err)
+	}
+	if err := json.Unmarshal([]byte(actual), &a); err != nil {
+		t.Fatalf("Invalid actual JSON: %v", err)
+	}
+	if !reflect.DeepEqual(e, a) {
+		t.Errorf("Expected %s, got %s", expected, actual)
+	}
+}
+
+func TestQueryFilters(t *testing.T) {
+	dynamicSchema := buildTestSchema(t)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name:     "by package",
+			query:    `{ olmbundles(package: "bar") { name } }`,
+			expected: `{"olmbundles": [{"name": "bar.v1.0.0"}]}`,
+		},
+		{
+			name:     "by name",
+			query:    `{ olmchannels(package: "foo", name: "stable") { name package } }`,
+			expected: `{"olmchannels": [{"name": "stable", "package": "foo"}]}`,
+		},
+		{
+			name:     "by package, channel and version range",
+			query:    `{ olmbundles(package: "foo", channel: "stable", versionRange: ">1.0.0") { name } }`,
+			expected: `{"olmbundles": [{"name": "foo.v1.1.0"}, {"name": "foo.v2.0.0"}]}`,
+		},
+		{
+			name:     "by property value",
+			query:    `{ olmbundles(properties: [{type: "olm.gvk", value: "{\"kind\": \"Foo\", \"version\": \"v2\"}"}]) { name } }`,
+			expected: `{"olmbundles": [{"name": "foo.v2.0.0"}]}`,
+		},
+		{
+			name:     "by property type",
+			query:    `{ olmbundles(properties: [{type: "olm.gvk"}]) { name } }`,
+			expected: `{"olmbundles": [{"name": "foo.v1.1.0"}, {"name": "foo.v2.0.0"}]}`,
+		},
+		{
+			name:     "no match",
+			query:    `{ olmbundles(package: "baz") { name } }`,
+			expected: `{"olmbundles": []}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertJSONEqual(t, tt.expected, runQuery(t, dynamicSchema, tt.query, nil))
+		})
+	}
+}
+
+func TestQueryFilters_InvalidArguments(t *testing.T) {
+	dynamicSchema := buildTestSchema(t)
+
+	for _, query := range []string{
+		`{ olmbundles(versionRange: "not a range") { name } }`,
+		`{ olmbundles(properties: [{type: "olm.gvk", value: "{not json"}]) { name } }`,
+		`{ olmbundlesConnection(after: "not a cursor") { totalCount } }`,
+	} {
+		result := graphql.Do(graphql.Params{Schema: dynamicSchema.Schema, RequestString: query})
+		if len(result.Errors) == 0 {
+			t.Errorf("Expected query %q to fail", query)
+		}
+	}
+}
+
+func TestQueryConnection(t *testing.T) {
+	dynamicSchema := buildTestSchema(t)
+	query := `query Bundles($after: String) {
+		olmbundlesConnection(package: "foo", first: 3, after: $after) {
+			totalCount
+			edges { cursor node { name } }
+			pageInfo { hasNextPage endCursor }
+		}
+	}`
+
+	type page struct {
+		OlmbundlesConnection struct {
+			TotalCount int `json:"totalCount"`
+			Edges      []struct {
+				Cursor string `json:"cursor"`
+				Node   struct {
+					Name string `json:"name"`
+				} `json:"node"`
+			} `json:"edges"`
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+		} `json:"olmbundlesConnection"`
+	}
+
+	var names []string
+	variables := map[string]interface{}{}
+	for pages := 0; ; pages++ {
+		if pages > 2 {
+			t.Fatal("Expected the connection to be paginated in 2 pages")
+		}
+		var p page
+		if err := json.Unmarshal([]byte(runQuery(t, dynamicSchema, query, variables)), &p); err != nil {
+			t.Fatalf("Failed to decode page: %v", err)
+		}
+		conn := p.OlmbundlesConnection
+		if conn.TotalCount != 4 {
+			t.Errorf("Expected totalCount 4, got %d", conn.TotalCount)
+		}
+		for _, edge := range conn.Edges {
+			names = append(names, edge.Node.Name)
+		}
+		if !conn.PageInfo.HasNextPage {
+			break
+		}
+		if conn.PageInfo.EndCursor != conn.Edges[len(conn.Edges)-1].Cursor {
+			t.Errorf("Expected endCursor to be the cursor of the last edge")
+		}
+		variables["after"] = conn.PageInfo.EndCursor
+	}
+
+	expected := []string{"foo.v1.0.0", "foo.v1.1.0", "foo.v2.0.0", "foo.v2.1.0"}
+	if !reflect.DeepEqual(expected, names) {
+		t.Errorf("Expected bundles %v, got %v", expected, names)
+	}
+}
+
+func TestQueryRelationships(t *testing.T) {
+	dynamicSchema := buildTestSchema(t)
+
+	data := runQuery(t, dynamicSchema, `{
+		olmpackages(name: "foo") {
+			name
+			channels { name entries { name bundle { image } } }
+		}
+	}`, nil)
+	assertJSONEqual(t, `{"olmpackages": [{
+		"name": "foo",
+		"channels": [
+			{"name": "stable", "entries": [
+				{"name": "foo.v1.0.0", "bundle": {"image": "foo:v1.0.0"}},
+				{"name": "foo.v1.1.0", "bundle": {"image": "foo:v1.1.0"}},
+				{"name": "foo.v2.0.0", "bundle": {"image": "foo:v2.0.0"}}
+			]},
+			{"name": "candidate", "entries": [
+				{"name": "foo.v2.1.0", "bundle": {"image": "foo:v2.1.0"}}
+			]}
+		]
+	}]}`, data)
+
+	data = runQuery(t, dynamicSchema, `{
+		olmbundles(package: "foo", versionRange: "<2.0.0") { name deprecations { schema name message } }
+	}`, nil)
+	assertJSONEqual(t, `{"olmbundles": [
+		{"name": "foo.v1.0.0", "deprecations": [{"schema": "olm.bundle", "name": "foo.v1.0.0", "message": "foo.v1.0.0 is deprecated"}]},
+		{"name": "foo.v1.1.0", "deprecations": []}
+	]}`, data)
+
+	data = runQuery(t, dynamicSchema, `{ olmpackages(name: "bar") { bundles { name } } }`, nil)
+	assertJSONEqual(t, `{"olmpackages": [{"bundles": [{"name": "bar.v1.0.0"}]}]}`, data)
+}
+
+func TestBundleFirstSeen(t *testing.T) {
+	dynamicSchema := buildSchema(t, testCatalog+`{"schema": "olm.catalogd.firstSeen", "package": "foo", "name": "foo.v1.0.0", "firstSeen": "2026-01-02T03:04:05Z"}
+{"schema": "olm.catalogd.firstSeen", "package": "bar", "name": "foo.v1.1.0", "firstSeen": "2026-01-03T03:04:05Z"}
+`)
+
+	data := runQuery(t, dynamicSchema, `{ olmbundles(package: "foo", versionRange: "<2.0.0") { name firstSeen } }`, nil)
+	assertJSONEqual(t, `{"olmbundles": [
+		{"name": "foo.v1.0.0", "firstSeen": "2026-01-02T03:04:05Z"},
+		{"name": "foo.v1.1.0", "firstSeen": null}
+	]}`, data)
+}
+
+func TestContainsJSON(t *testing.T) {
+	got := map[string]interface{}{
+		"group":   "foo.io",
+		"kind":    "Foo",
+		"version": "v1",
+		"nested":  map[string]interface{}{"a": float64(1), "b": []interface{}{"x"}},
+	}
+
+	tests := []struct {
+		name     string
+		want     interface{}
+		expected bool
+	}{
+		{name: "subset of fields", want: map[string]interface{}{"kind": "Foo"}, expected: true},
+		{name: "nested subset", want: map[string]interface{}{"nested": map[string]interface{}{"a": float64(1)}}, expected: true},
+		{name: "different value", want: map[string]interface{}{"kind": "Bar"}, expected: false},
+		{name: "missing field", want: map[string]interface{}{"plural": "foos"}, expected: false},
+		{name: "lists must be equal", want: map[string]interface{}{"nested": map[string]interface{}{"b": []interface{}{"x"}}}, expected: true},
+		{name: "scalar against object", want: "Foo", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := containsJSON(got, tt.want); actual != tt.expected {
+				t.Errorf("containsJSON(%v) = %v, expected %v", tt.want, actual, tt.expected)
+			}
+		})
+	}
+}
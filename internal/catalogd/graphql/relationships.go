@@ -0,0 +1,154 @@
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	"github.com/operator-framework/operator-controller/internal/shared/firstseen"
+)
+
+// nestedObject is an object of an array-of-objects field, along with the catalog object
+// it belongs to, so that relationships of nested objects can be resolved
+type nestedObject struct {
+	value  map[string]interface{}
+	parent map[string]interface{}
+}
+
+// sourceObject returns the JSON object the fields of a GraphQL object are resolved from
+func sourceObject(source interface{}) (map[string]interface{}, bool) {
+	switch s := source.(type) {
+	case map[string]interface{}:
+		return s, true
+	case nestedObject:
+		return s.value, true
+	}
+	return nil, false
+}
+
+// newDeprecationEntryType creates the type of the deprecation entries that apply to a bundle
+func newDeprecationEntryType() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name:        "DeprecationEntry",
+		Description: "A deprecation of a package, channel or bundle",
+		Fields: graphql.Fields{
+			"schema":  &graphql.Field{Type: graphql.String, Description: "Schema of the deprecated object"},
+			"name":    &graphql.Field{Type: graphql.String, Description: "Name of the deprecated object, empty for packages"},
+			"message": &graphql.Field{Type: graphql.String, Description: "Deprecation message"},
+		},
+	})
+}
+
+// addRelationships adds fields resolving the relationships between the objects of the
+// olm.package, olm.channel, olm.bundle and olm.deprecations schemas:
+//
+//   - package.channels and package.bundles, the channels and bundles of a package
+//   - the bundle field of the entries of a channel, the bundle of the entry
+//   - bundle.deprecations, the deprecation entries of the bundle and of its package
+//   - bundle.firstSeen, when catalogd first served the bundle, from the olm.catalogd.firstSeen schema
+//
+// Fields are only added for the schemas the catalog contains, and when the objects of
+// the schema do not already have a field with the same name.
+func addRelationships(objectTypes map[string]*graphql.Object, objects *catalogObjects) {
+	addField := func(objectType *graphql.Object, name string, field *graphql.Field) {
+		if _, ok := objectType.Fields()[name]; !ok {
+			objectType.AddFieldConfig(name, field)
+		}
+	}
+
+	packageType, hasPackages := objectTypes[declcfg.SchemaPackage]
+	channelType, hasChannels := objectTypes[declcfg.SchemaChannel]
+	bundleType, hasBundles := objectTypes[declcfg.SchemaBundle]
+	_, hasDeprecations := objectTypes[declcfg.SchemaDeprecation]
+
+	if hasPackages && hasChannels {
+		addField(packageType, "channels", &graphql.Field{
+			Type:        graphql.NewList(channelType),
+			Description: "Channels of the package",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				pkg, _ := sourceObject(p.Source)
+				return objects.find(declcfg.SchemaChannel, stringField(pkg, "name"), ""), nil
+			},
+		})
+	}
+	if hasPackages && hasBundles {
+		addField(packageType, "bundles", &graphql.Field{
+			Type:        graphql.NewList(bundleType),
+			Description: "Bundles of the package",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				pkg, _ := sourceObject(p.Source)
+				return objects.find(declcfg.SchemaBundle, stringField(pkg, "name"), ""), nil
+			},
+		})
+	}
+
+	if hasChannels && hasBundles {
+		if entriesField, ok := channelType.Fields()["entries"]; ok {
+			if list, ok := entriesField.Type.(*graphql.List); ok {
+				if entryType, ok := list.OfType.(*graphql.Object); ok {
+					addField(entryType, "bundle", &graphql.Field{
+						Type:        bundleType,
+						Description: "Bundle of the channel entry",
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							entry, ok := p.Source.(nestedObject)
+							if !ok {
+								return nil, nil
+							}
+							bundles := objects.find(declcfg.SchemaBundle, stringField(entry.parent, "package"), stringField(entry.value, "name"))
+							if len(bundles) == 0 {
+								return nil, nil
+							}
+							return bundles[0], nil
+						},
+					})
+				}
+			}
+		}
+	}
+
+	if _, hasFirstSeen := objectTypes[firstseen.Schema]; hasBundles && hasFirstSeen {
+		addField(bundleType, "firstSeen", &graphql.Field{
+			Type:        graphql.String,
+			Description: "Time catalogd first served the bundle, since when it has been continuously served",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				bundle, _ := sourceObject(p.Source)
+				records := objects.find(firstseen.Schema, stringField(bundle, "package"), stringField(bundle, "name"))
+				if len(records) == 0 {
+					return nil, nil
+				}
+				return records[0].(map[string]interface{})["firstSeen"], nil
+			},
+		})
+	}
+
+	if hasBundles && hasDeprecations {
+		addField(bundleType, "deprecations", &graphql.Field{
+			Type:        graphql.NewList(newDeprecationEntryType()),
+			Description: "Deprecation entries of the bundle and of its package",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				bundle, _ := sourceObject(p.Source)
+				return bundleDeprecations(objects, bundle), nil
+			},
+		})
+	}
+}
+
+// bundleDeprecations returns the deprecation entries of the package of a bundle that
+// deprecate the bundle or its package
+func bundleDeprecations(objects *catalogObjects, bundle map[string]interface{}) []interface{} {
+	var entries []interface{}
+	for _, deprecations := range objects.find(declcfg.SchemaDeprecation, stringField(bundle, "package"), "") {
+		for _, entry := range objectList(deprecations.(map[string]interface{}), "entries") {
+			reference, _ := entry["reference"].(map[string]interface{})
+			schema, name := stringField(reference, "schema"), stringField(reference, "name")
+			if schema == declcfg.SchemaPackage || (schema == declcfg.SchemaBundle && name == stringField(bundle, "name")) {
+				entries = append(entries, map[string]interface{}{
+					"schema":  schema,
+					"name":    name,
+					"message": entry["message"],
+				})
+			}
+		}
+	}
+	return entries
+}
@@ -0,0 +1,147 @@
+// Package index implements an index of the FBC blobs of a catalog, as laid out one
+// after the other in a file, by their schema, package and name fields.
+package index
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+)
+
+// Index is an index of sections of an FBC file used to lookup FBC blobs that
+// match any combination of their schema, package, and name fields.
+//
+// This index strikes a balance between space and performance. It indexes each field
+// separately, and performs logical set intersections at lookup time in order to implement
+// a multi-parameter query.
+//
+// Note: it is permissible to change the indexing algorithm later if it is necessary to
+// tune the space / performance tradeoff. However care should be taken to ensure
+// that the actual content returned by the index remains identical, as users of the index
+// may be sensitive to differences introduced by index algorithm changes (e.g. if the
+// order of the returned sections changes).
+type Index struct {
+	BySchema  map[string][]Section `json:"by_schema"`
+	ByPackage map[string][]Section `json:"by_package"`
+	ByName    map[string][]Section `json:"by_name"`
+
+	// size is the size of the file laid out by the metas indexed with Add
+	size int64
+}
+
+// A Section is the byte offset and length of an FBC blob within the file.
+type Section struct {
+	offset int64
+	length int64
+}
+
+// Offset returns the byte offset of the section within the file.
+func (s Section) Offset() int64 {
+	return s.offset
+}
+
+func (s *Section) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`[%d,%d]`, s.offset, s.length)), nil
+}
+
+func (s *Section) UnmarshalJSON(b []byte) error {
+	vals := [2]int64{}
+	if err := json.Unmarshal(b, &vals); err != nil {
+		return err
+	}
+	s.offset = vals[0]
+	s.length = vals[1]
+	return nil
+}
+
+// Get returns a reader of the FBC blobs of the file read from r that match the given
+// schema, package and name. Empty values match any blob.
+func (i Index) Get(r io.ReaderAt, schema, packageName, name string) io.Reader {
+	sections := i.Lookup(schema, packageName, name)
+	srs := make([]io.Reader, 0, len(sections))
+	for _, s := range sections {
+		sr := io.NewSectionReader(r, s.offset, s.length)
+		srs = append(srs, sr)
+	}
+	return io.MultiReader(srs...)
+}
+
+// Lookup returns the sections of the FBC blobs that match the given schema, package
+// and name, in the order they are laid out in the file. Empty values match any blob.
+func (i Index) Lookup(schema, packageName, name string) []Section {
+	sections := i.getSectionSet(schema, packageName, name).UnsortedList()
+	slices.SortFunc(sections, func(a, b Section) int {
+		return cmp.Compare(a.offset, b.offset)
+	})
+	return sections
+}
+
+func (i *Index) getSectionSet(schema, packageName, name string) sets.Set[Section] {
+	// Initialize with all sections if no schema specified, otherwise use schema sections
+	sectionSet := sets.New[Section]()
+	if schema == "" {
+		for _, s := range i.BySchema {
+			sectionSet.Insert(s...)
+		}
+	} else {
+		sectionSet = sets.New[Section](i.BySchema[schema]...)
+	}
+
+	// Filter by package name if specified
+	if packageName != "" {
+		packageSections := sets.New[Section](i.ByPackage[packageName]...)
+		sectionSet = sectionSet.Intersection(packageSections)
+	}
+
+	// Filter by name if specified
+	if name != "" {
+		nameSections := sets.New[Section](i.ByName[name]...)
+		sectionSet = sectionSet.Intersection(nameSections)
+	}
+
+	return sectionSet
+}
+
+// New returns an index of the metas received from metasChan, laid out one after
+// the other in a file in the order they are received.
+func New(metasChan <-chan *declcfg.Meta) *Index {
+	idx := &Index{
+		BySchema:  make(map[string][]Section),
+		ByPackage: make(map[string][]Section),
+		ByName:    make(map[string][]Section),
+	}
+	for meta := range metasChan {
+		idx.Add(meta)
+	}
+	return idx
+}
+
+// Add indexes a meta laid out right after the metas already added to the index,
+// and returns its section. The zero value of Index is an empty index metas can be
+// added to.
+func (i *Index) Add(meta *declcfg.Meta) Section {
+	if i.BySchema == nil {
+		i.BySchema = make(map[string][]Section)
+		i.ByPackage = make(map[string][]Section)
+		i.ByName = make(map[string][]Section)
+	}
+	s := Section{offset: i.size, length: int64(len(meta.Blob))}
+	i.size += s.length
+
+	if meta.Schema != "" {
+		i.BySchema[meta.Schema] = append(i.BySchema[meta.Schema], s)
+	}
+	if meta.Package != "" {
+		i.ByPackage[meta.Package] = append(i.ByPackage[meta.Package], s)
+	}
+	if meta.Name != "" {
+		i.ByName[meta.Name] = append(i.ByName[meta.Name], s)
+	}
+	return s
+}
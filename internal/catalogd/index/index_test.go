@@ -1,4 +1,4 @@
-package storage
+package index
 
 import (
 	"bytes"
@@ -36,7 +36,7 @@ func TestIndexCreation(t *testing.T) {
 	close(metasChan)
 
 	// Create index
-	idx := newIndex(metasChan)
+	idx := New(metasChan)
 
 	// Verify schema index
 	require.Len(t, idx.BySchema, 2, "Expected 2 schema entries, got %d", len(idx.BySchema))
@@ -165,7 +165,7 @@ func TestIndexGet(t *testing.T) {
 	}
 	close(metasChan)
 
-	idx := newIndex(metasChan)
+	idx := New(metasChan)
 
 	// Create a reader from the metas
 	var combinedBlob bytes.Buffer
@@ -283,3 +283,19 @@ func createBlob(t *testing.T, data map[string]interface{}) []byte {
 	}
 	return append(blob, '\n')
 }
+
+func TestIndexAddAndLookup(t *testing.T) {
+	var idx Index
+	pkg := idx.Add(&declcfg.Meta{Schema: "olm.package", Name: "test", Blob: []byte(`{"a":1}`)})
+	bundle2 := idx.Add(&declcfg.Meta{Schema: "olm.bundle", Package: "test", Name: "test.v2", Blob: []byte(`{"b":22}`)})
+	bundle1 := idx.Add(&declcfg.Meta{Schema: "olm.bundle", Package: "test", Name: "test.v1", Blob: []byte(`{"c":333}`)})
+
+	require.Equal(t, int64(0), pkg.Offset())
+	require.Equal(t, int64(7), bundle2.Offset())
+	require.Equal(t, int64(15), bundle1.Offset())
+
+	require.Equal(t, []Section{bundle2, bundle1}, idx.Lookup("olm.bundle", "test", ""))
+	require.Equal(t, []Section{bundle1}, idx.Lookup("olm.bundle", "", "test.v1"))
+	require.Equal(t, []Section{pkg}, idx.Lookup("", "", "test"))
+	require.Empty(t, idx.Lookup("olm.channel", "test", ""))
+}
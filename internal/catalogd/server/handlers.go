@@ -1,6 +1,9 @@
 package server
 
 import (
+	"cmp"
+	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -39,6 +43,22 @@ const (
 	GraphQLQueriesEnabled  GraphQLQueriesMode = true
 )
 
+// DiffHandlerMode controls whether the diff API endpoint is enabled
+type DiffHandlerMode bool
+
+const (
+	DiffHandlerDisabled DiffHandlerMode = false
+	DiffHandlerEnabled  DiffHandlerMode = true
+)
+
+// QueryHandlerMode controls whether the cross-catalog query API endpoint is enabled
+type QueryHandlerMode bool
+
+const (
+	QueryHandlerDisabled QueryHandlerMode = false
+	QueryHandlerEnabled  QueryHandlerMode = true
+)
+
 // routeConfig defines allowed HTTP methods for a specific route
 type routeConfig struct {
 	path           string
@@ -53,6 +73,9 @@ type CatalogHandlers struct {
 	rootURL       *url.URL
 	enableMetas   MetasHandlerMode
 	enableGraphQL GraphQLQueriesMode
+	enableDiff    DiffHandlerMode
+	enableQuery   QueryHandlerMode
+	catalogs      CatalogLister
 }
 
 // Index provides methods for looking up catalog content by schema/package/name
@@ -60,29 +83,59 @@ type Index interface {
 	Get(catalogFile io.ReaderAt, schema, pkg, name string) io.Reader
 }
 
+// CatalogFile is the content of a file stored for a catalog, such as an *os.File
+type CatalogFile interface {
+	io.ReadSeekCloser
+	io.ReaderAt
+}
+
 // CatalogStore defines the storage interface needed by handlers
 type CatalogStore interface {
 	// GetCatalogData returns the catalog file and its metadata
-	GetCatalogData(catalog string) (*os.File, os.FileInfo, error)
+	GetCatalogData(catalog string) (CatalogFile, os.FileInfo, error)
 
 	// GetCatalogFS returns a filesystem interface for the catalog
 	GetCatalogFS(catalog string) (fs.FS, error)
 
 	// GetIndex returns the index for a catalog (if metas handler is enabled)
 	GetIndex(catalog string) (Index, error)
+
+	// GetCatalogDiff returns the diff between the previous and current content
+	// of a catalog and its metadata (if diff handler is enabled)
+	GetCatalogDiff(catalog string) (CatalogFile, os.FileInfo, error)
+}
+
+// ServedCatalog is a catalog whose content is served, along with its priority
+type ServedCatalog struct {
+	Name     string
+	Priority int32
+}
+
+// CatalogLister lists the catalogs whose content is queried by the cross-catalog query handler
+type CatalogLister interface {
+	// ListServedCatalogs returns the catalogs that are available to clients
+	ListServedCatalogs(ctx context.Context) ([]ServedCatalog, error)
 }
 
 // NewCatalogHandlers creates a new HTTP handlers instance
-func NewCatalogHandlers(store CatalogStore, graphqlSvc service.GraphQLService, rootURL *url.URL, enableMetas MetasHandlerMode, enableGraphQL GraphQLQueriesMode) *CatalogHandlers {
+func NewCatalogHandlers(store CatalogStore, graphqlSvc service.GraphQLService, rootURL *url.URL, enableMetas MetasHandlerMode, enableGraphQL GraphQLQueriesMode, enableDiff DiffHandlerMode) *CatalogHandlers {
 	return &CatalogHandlers{
 		store:         store,
 		graphqlSvc:    graphqlSvc,
 		rootURL:       rootURL,
 		enableMetas:   enableMetas,
 		enableGraphQL: enableGraphQL,
+		enableDiff:    enableDiff,
 	}
 }
 
+// WithQueryHandler enables the cross-catalog query handler, querying the catalogs listed by catalogs
+func (h *CatalogHandlers) WithQueryHandler(enableQuery QueryHandlerMode, catalogs CatalogLister) *CatalogHandlers {
+	h.enableQuery = enableQuery
+	h.catalogs = catalogs
+	return h
+}
+
 // Handler returns an HTTP handler with all routes configured
 func (h *CatalogHandlers) Handler() http.Handler {
 	// Build route configurations - each service contributes its routes and allowed methods
@@ -106,7 +159,23 @@ func (h *CatalogHandlers) Handler() http.Handler {
 		routes = append(routes, routeConfig{
 			path:           h.rootURL.JoinPath("{catalog}", "api", "v1", "graphql").Path,
 			handler:        h.handleV1GraphQL,
-			allowedMethods: []string{http.MethodPost},
+			allowedMethods: []string{http.MethodGet, http.MethodPost},
+		})
+	}
+
+	if h.enableDiff {
+		routes = append(routes, routeConfig{
+			path:           h.rootURL.JoinPath("{catalog}", "api", "v1", "diff").Path,
+			handler:        h.handleV1Diff,
+			allowedMethods: []string{http.MethodGet, http.MethodHead},
+		})
+	}
+
+	if h.enableQuery {
+		routes = append(routes, routeConfig{
+			path:           h.rootURL.JoinPath("api", "v1", "metas").Path,
+			handler:        h.handleV1Query,
+			allowedMethods: []string{http.MethodGet, http.MethodHead},
 		})
 	}
 
@@ -159,70 +228,207 @@ func (h *CatalogHandlers) handleV1Metas(w http.ResponseWriter, r *http.Request)
 	}
 	defer catalogFile.Close()
 
+	schema := r.URL.Query().Get("schema")
+	pkg := r.URL.Query().Get("package")
+	name := r.URL.Query().Get("name")
+
+	// If no parameters are provided, return the entire catalog
+	var content io.Reader = catalogFile
+	if schema != "" || pkg != "" || name != "" {
+		idx, err := h.store.GetIndex(catalog)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		// The ETag is computed from the matching content, so that clients can revalidate
+		// it even when other content of the catalog changed.
+		etag, err := contentETag(idx.Get(catalogFile, schema, pkg, name))
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		w.Header().Set("Etag", etag)
+		content = idx.Get(catalogFile, schema, pkg, name)
+	}
+
 	w.Header().Set("Last-Modified", catalogStat.ModTime().UTC().Format(timeFormat))
 	done := checkPreconditions(w, r, catalogStat.ModTime())
 	if done {
 		return
 	}
+	serveJSONLines(w, r, content)
+}
 
-	schema := r.URL.Query().Get("schema")
-	pkg := r.URL.Query().Get("package")
-	name := r.URL.Query().Get("name")
+// contentETag returns a weak ETag identifying the content read from r. The ETag is weak
+// because the response may be compressed.
+func contentETag(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`W/"%x"`, h.Sum(nil)), nil
+}
+
+// queryResult is a line of the response of the cross-catalog query handler
+type queryResult struct {
+	Catalog  string          `json:"catalog"`
+	Priority int32           `json:"priority"`
+	Meta     json.RawMessage `json:"meta"`
+}
 
+// handleV1Query serves the catalog content matching the query parameters across all the
+// served catalogs, ordered by descending catalog priority and then by catalog name. Each
+// line of the response is a queryResult.
+func (h *CatalogHandlers) handleV1Query(w http.ResponseWriter, r *http.Request) {
+	if h.catalogs == nil {
+		http.Error(w, "Cross-catalog queries are not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	query := r.URL.Query()
+	for param := range query {
+		if !slices.Contains([]string{"schema", "package", "name"}, param) {
+			httpError(w, errInvalidParams)
+			return
+		}
+	}
+	schema, pkg, name := query.Get("schema"), query.Get("package"), query.Get("name")
+	// Unlike the metas handler, returning the entire content of every catalog is not supported
 	if schema == "" && pkg == "" && name == "" {
-		// If no parameters are provided, return the entire catalog
-		serveJSONLines(w, r, catalogFile)
+		httpError(w, errInvalidParams)
 		return
 	}
 
-	idx, err := h.store.GetIndex(catalog)
+	catalogs, err := h.catalogs.ListServedCatalogs(r.Context())
 	if err != nil {
 		httpError(w, err)
 		return
 	}
-	indexReader := idx.Get(catalogFile, schema, pkg, name)
-	serveJSONLines(w, r, indexReader)
-}
+	slices.SortFunc(catalogs, func(a, b ServedCatalog) int {
+		return cmp.Or(cmp.Compare(b.Priority, a.Priority), strings.Compare(a.Name, b.Name))
+	})
+
+	// Open the content of every catalog before writing the response, so that errors are
+	// reported with their status. The matching content is then streamed to the response.
+	var results []catalogQuery
+	defer func() {
+		for _, q := range results {
+			q.file.Close()
+		}
+	}()
+	for _, catalog := range catalogs {
+		q, err := h.openCatalogQuery(catalog, schema, pkg, name)
+		// Catalogs that are not unpacked yet, or were deleted since they were listed, have no content
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		results = append(results, q)
+	}
 
-// handleV1GraphQL handles GraphQL queries
-func (h *CatalogHandlers) handleV1GraphQL(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Only POST is allowed", http.StatusMethodNotAllowed)
+	w.Header().Add("Content-Type", "application/jsonl")
+	if r.Method == http.MethodHead {
 		return
 	}
-	if h.graphqlSvc == nil {
-		http.Error(w, "GraphQL queries are not enabled", http.StatusServiceUnavailable)
-		return
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, q := range results {
+		dec := json.NewDecoder(q.content)
+		for {
+			var meta json.RawMessage
+			err := dec.Decode(&meta)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err == nil {
+				err = enc.Encode(queryResult{Catalog: q.catalog.Name, Priority: q.catalog.Priority, Meta: meta})
+			}
+			if err != nil {
+				// The status was already sent, the response is aborted so that it is not mistaken for a complete one
+				klog.ErrorS(err, "error streaming query results", "catalog", q.catalog.Name)
+				panic(http.ErrAbortHandler)
+			}
+		}
 	}
+}
 
+// catalogQuery is the content of a catalog matching a query
+type catalogQuery struct {
+	catalog ServedCatalog
+	file    io.Closer
+	content io.Reader
+}
+
+// openCatalogQuery opens the content of a catalog matching the given schema, package and name.
+// The returned file must be closed once the content was read.
+func (h *CatalogHandlers) openCatalogQuery(catalog ServedCatalog, schema, pkg, name string) (catalogQuery, error) {
+	catalogFile, _, err := h.store.GetCatalogData(catalog.Name)
+	if err != nil {
+		return catalogQuery{}, err
+	}
+	idx, err := h.store.GetIndex(catalog.Name)
+	if err != nil {
+		catalogFile.Close()
+		return catalogQuery{}, err
+	}
+	return catalogQuery{catalog: catalog, file: catalogFile, content: idx.Get(catalogFile, schema, pkg, name)}, nil
+}
+
+// handleV1Diff serves the diff between the previous and current catalog content
+func (h *CatalogHandlers) handleV1Diff(w http.ResponseWriter, r *http.Request) {
 	catalog := r.PathValue("catalog")
 	if err := isValidCatalogName(catalog); err != nil {
 		httpError(w, err)
 		return
 	}
+	diffFile, diffStat, err := h.store.GetCatalogDiff(catalog)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	defer diffFile.Close()
 
-	// Limit request body size to prevent memory exhaustion attacks (1MB limit)
-	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	w.Header().Add("Content-Type", "application/json")
+	http.ServeContent(w, r, "", diffStat.ModTime(), diffFile)
+}
 
-	// Parse GraphQL query from request body
-	var params struct {
-		Query string `json:"query"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+// handleV1GraphQL handles GraphQL requests. Requests are sent either as POST requests with
+// a JSON body, or as GET requests with query parameters so that their responses can be cached.
+func (h *CatalogHandlers) handleV1GraphQL(w http.ResponseWriter, r *http.Request) {
+	if h.graphqlSvc == nil {
+		http.Error(w, "GraphQL queries are not enabled", http.StatusServiceUnavailable)
 		return
 	}
 
-	// Validate query
-	if params.Query == "" {
-		http.Error(w, "Query cannot be empty", http.StatusBadRequest)
+	catalog := r.PathValue("catalog")
+	if err := isValidCatalogName(catalog); err != nil {
+		httpError(w, err)
 		return
 	}
-	if len(params.Query) > 100000 { // 100KB limit
-		http.Error(w, "Query too large", http.StatusBadRequest)
+
+	params, ok := parseGraphQLRequest(w, r)
+	if !ok {
 		return
 	}
 
+	if r.Method == http.MethodGet {
+		// The result of a GET request only changes with the catalog content
+		catalogFile, catalogStat, err := h.store.GetCatalogData(catalog)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		catalogFile.Close()
+
+		w.Header().Set("Last-Modified", catalogStat.ModTime().UTC().Format(timeFormat))
+		if done := checkPreconditions(w, r, catalogStat.ModTime()); done {
+			return
+		}
+	}
+
 	// Get catalog filesystem
 	catalogFS, err := h.store.GetCatalogFS(catalog)
 	if err != nil {
@@ -231,7 +437,11 @@ func (h *CatalogHandlers) handleV1GraphQL(w http.ResponseWriter, r *http.Request
 	}
 
 	// Execute GraphQL query through the service
-	result, err := h.graphqlSvc.ExecuteQuery(catalog, catalogFS, params.Query)
+	result, err := h.graphqlSvc.ExecuteQuery(catalog, catalogFS, params)
+	if errors.Is(err, service.ErrPersistedQueryNotFound) {
+		http.Error(w, "Persisted query not found", http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		httpError(w, err)
 		return
@@ -244,6 +454,53 @@ func (h *CatalogHandlers) handleV1GraphQL(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// parseGraphQLRequest reads a GraphQL request from the query parameters of a GET request,
+// or from the JSON body of a POST request. Invalid requests are answered with a 400 status.
+func parseGraphQLRequest(w http.ResponseWriter, r *http.Request) (service.QueryRequest, bool) {
+	var params service.QueryRequest
+	if r.Method == http.MethodGet {
+		query := r.URL.Query()
+		for param := range query {
+			if !slices.Contains([]string{"query", "operationName", "variables", "documentId"}, param) {
+				http.Error(w, fmt.Sprintf("Unexpected query parameter %q", param), http.StatusBadRequest)
+				return params, false
+			}
+		}
+		params.Query = query.Get("query")
+		params.OperationName = query.Get("operationName")
+		params.DocumentID = query.Get("documentId")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &params.Variables); err != nil {
+				http.Error(w, "Variables must be a JSON object", http.StatusBadRequest)
+				return params, false
+			}
+		}
+	} else {
+		// Limit request body size to prevent memory exhaustion attacks (1MB limit)
+		r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return params, false
+		}
+	}
+
+	// Validate query
+	var msg string
+	switch {
+	case params.DocumentID != "" && params.Query != "":
+		msg = "Query and documentId cannot both be set"
+	case params.DocumentID == "" && params.Query == "":
+		msg = "Query cannot be empty"
+	case len(params.Query) > 100000: // 100KB limit
+		msg = "Query too large"
+	}
+	if msg != "" {
+		http.Error(w, msg, http.StatusBadRequest)
+		return params, false
+	}
+	return params, true
+}
+
 // httpError writes an HTTP error response based on the error type
 func httpError(w http.ResponseWriter, err error) {
 	var code int
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io/fs"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -16,6 +18,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/operator-framework/operator-controller/internal/catalogd/server"
+	"github.com/operator-framework/operator-controller/internal/catalogd/service"
 	mockcatalogdserver "github.com/operator-framework/operator-controller/internal/testutil/mock/catalogdserver"
 	mockcatalogdservice "github.com/operator-framework/operator-controller/internal/testutil/mock/catalogdservice"
 )
@@ -26,10 +29,10 @@ func TestHandleV1GraphQL_MethodNotAllowed(t *testing.T) {
 	store := mockcatalogdserver.NewMockCatalogStore(ctrl)
 	graphqlSvc := mockcatalogdservice.NewMockGraphQLService(ctrl)
 
-	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled)
+	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled, server.DiffHandlerDisabled)
 	handler := handlers.Handler()
 
-	req := httptest.NewRequest(http.MethodGet, "/test-catalog/api/v1/graphql", nil)
+	req := httptest.NewRequest(http.MethodPut, "/test-catalog/api/v1/graphql", nil)
 	w := httptest.NewRecorder()
 
 	handler.ServeHTTP(w, req)
@@ -45,7 +48,7 @@ func TestHandleV1GraphQL_InvalidCatalogName(t *testing.T) {
 	store := mockcatalogdserver.NewMockCatalogStore(ctrl)
 	graphqlSvc := mockcatalogdservice.NewMockGraphQLService(ctrl)
 
-	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled)
+	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled, server.DiffHandlerDisabled)
 	handler := handlers.Handler()
 
 	req := httptest.NewRequest(http.MethodPost, "/INVALID-CATALOG-NAME/api/v1/graphql", strings.NewReader(`{"query": "{ summary { totalSchemas } }"}`))
@@ -64,7 +67,7 @@ func TestHandleV1GraphQL_InvalidJSON(t *testing.T) {
 	store := mockcatalogdserver.NewMockCatalogStore(ctrl)
 	graphqlSvc := mockcatalogdservice.NewMockGraphQLService(ctrl)
 
-	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled)
+	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled, server.DiffHandlerDisabled)
 	handler := handlers.Handler()
 
 	req := httptest.NewRequest(http.MethodPost, "/test-catalog/api/v1/graphql", strings.NewReader(`{invalid json`))
@@ -83,7 +86,7 @@ func TestHandleV1GraphQL_EmptyQuery(t *testing.T) {
 	store := mockcatalogdserver.NewMockCatalogStore(ctrl)
 	graphqlSvc := mockcatalogdservice.NewMockGraphQLService(ctrl)
 
-	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled)
+	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled, server.DiffHandlerDisabled)
 	handler := handlers.Handler()
 
 	req := httptest.NewRequest(http.MethodPost, "/test-catalog/api/v1/graphql", strings.NewReader(`{"query": ""}`))
@@ -105,7 +108,7 @@ func TestHandleV1GraphQL_QueryTooLarge(t *testing.T) {
 	store := mockcatalogdserver.NewMockCatalogStore(ctrl)
 	graphqlSvc := mockcatalogdservice.NewMockGraphQLService(ctrl)
 
-	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled)
+	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled, server.DiffHandlerDisabled)
 	handler := handlers.Handler()
 
 	// Create a query larger than 100KB
@@ -126,7 +129,7 @@ func TestHandleV1GraphQL_BodyTooLarge(t *testing.T) {
 	store := mockcatalogdserver.NewMockCatalogStore(ctrl)
 	graphqlSvc := mockcatalogdservice.NewMockGraphQLService(ctrl)
 
-	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled)
+	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled, server.DiffHandlerDisabled)
 	handler := handlers.Handler()
 
 	// Create a body larger than 1MB
@@ -162,9 +165,9 @@ func TestHandleV1GraphQL_Success(t *testing.T) {
 	}
 
 	graphqlSvc := mockcatalogdservice.NewMockGraphQLService(ctrl)
-	graphqlSvc.EXPECT().ExecuteQuery("test-catalog", catalogFS, "{ summary { totalSchemas } }").Return(expectedResult, nil)
+	graphqlSvc.EXPECT().ExecuteQuery("test-catalog", catalogFS, service.QueryRequest{Query: "{ summary { totalSchemas } }"}).Return(expectedResult, nil)
 
-	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled)
+	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled, server.DiffHandlerDisabled)
 	handler := handlers.Handler()
 
 	query := `{"query": "{ summary { totalSchemas } }"}`
@@ -210,7 +213,7 @@ func TestHandleV1GraphQL_GetCatalogFSError(t *testing.T) {
 
 	graphqlSvc := mockcatalogdservice.NewMockGraphQLService(ctrl)
 
-	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled)
+	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled, server.DiffHandlerDisabled)
 	handler := handlers.Handler()
 
 	query := `{"query": "{ summary { totalSchemas } }"}`
@@ -235,9 +238,9 @@ func TestHandleV1GraphQL_ExecuteQueryError(t *testing.T) {
 	store.EXPECT().GetCatalogFS("test-catalog").Return(catalogFS, nil)
 
 	graphqlSvc := mockcatalogdservice.NewMockGraphQLService(ctrl)
-	graphqlSvc.EXPECT().ExecuteQuery("test-catalog", catalogFS, "{ summary { totalSchemas } }").Return(nil, context.DeadlineExceeded)
+	graphqlSvc.EXPECT().ExecuteQuery("test-catalog", catalogFS, service.QueryRequest{Query: "{ summary { totalSchemas } }"}).Return(nil, context.DeadlineExceeded)
 
-	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled)
+	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled, server.DiffHandlerDisabled)
 	handler := handlers.Handler()
 
 	query := `{"query": "{ summary { totalSchemas } }"}`
@@ -251,16 +254,189 @@ func TestHandleV1GraphQL_ExecuteQueryError(t *testing.T) {
 	}
 }
 
-func TestAllowedMethodsHandler_POSTOnlyForGraphQL(t *testing.T) {
+func TestHandleV1GraphQL_VariablesAndOperationName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rootURL, _ := url.Parse("http://localhost/")
+
+	catalogFS := os.DirFS(t.TempDir())
+	store := mockcatalogdserver.NewMockCatalogStore(ctrl)
+	store.EXPECT().GetCatalogFS("test-catalog").Return(catalogFS, nil)
+
+	graphqlSvc := mockcatalogdservice.NewMockGraphQLService(ctrl)
+	graphqlSvc.EXPECT().ExecuteQuery("test-catalog", catalogFS, service.QueryRequest{
+		Query:         "query Bundles($limit: Int) { olmbundles(limit: $limit) { name } }",
+		OperationName: "Bundles",
+		Variables:     map[string]interface{}{"limit": float64(5)},
+	}).Return(&graphql.Result{}, nil)
+
+	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled, server.DiffHandlerDisabled)
+	handler := handlers.Handler()
+
+	body := `{"query": "query Bundles($limit: Int) { olmbundles(limit: $limit) { name } }", "operationName": "Bundles", "variables": {"limit": 5}}`
+	req := httptest.NewRequest(http.MethodPost, "/test-catalog/api/v1/graphql", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestHandleV1GraphQL_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rootURL, _ := url.Parse("http://localhost/")
+
+	tmpDir := t.TempDir()
+	catalogFS := os.DirFS(tmpDir)
+	catalogFile, err := os.Create(filepath.Join(tmpDir, "catalog.jsonl"))
+	if err != nil {
+		t.Fatalf("Failed to create catalog file: %v", err)
+	}
+	catalogStat, err := catalogFile.Stat()
+	if err != nil {
+		t.Fatalf("Failed to stat catalog file: %v", err)
+	}
+
+	store := mockcatalogdserver.NewMockCatalogStore(ctrl)
+	store.EXPECT().GetCatalogData("test-catalog").Return(catalogFile, catalogStat, nil)
+	store.EXPECT().GetCatalogFS("test-catalog").Return(catalogFS, nil)
+
+	graphqlSvc := mockcatalogdservice.NewMockGraphQLService(ctrl)
+	graphqlSvc.EXPECT().ExecuteQuery("test-catalog", catalogFS, service.QueryRequest{
+		Query:     "query Bundles($limit: Int) { olmbundles(limit: $limit) { name } }",
+		Variables: map[string]interface{}{"limit": float64(5)},
+	}).Return(&graphql.Result{}, nil)
+
+	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled, server.DiffHandlerDisabled)
+	handler := handlers.Handler()
+
+	params := url.Values{
+		"query":     {"query Bundles($limit: Int) { olmbundles(limit: $limit) { name } }"},
+		"variables": {`{"limit": 5}`},
+	}
+	req := httptest.NewRequest(http.MethodGet, "/test-catalog/api/v1/graphql?"+params.Encode(), nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	lastModified := w.Header().Get("Last-Modified")
+	if lastModified == "" {
+		t.Fatal("Expected Last-Modified header to be set")
+	}
+
+	// A conditional request is answered without executing the query
+	store.EXPECT().GetCatalogData("test-catalog").Return(catalogFile, catalogStat, nil)
+	req = httptest.NewRequest(http.MethodGet, "/test-catalog/api/v1/graphql?"+params.Encode(), nil)
+	req.Header.Set("If-Modified-Since", lastModified)
+	w = httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected status %d, got %d", http.StatusNotModified, w.Code)
+	}
+}
+
+func TestHandleV1GraphQL_InvalidRequests(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		method          string
+		target          string
+		body            string
+		expectedMessage string
+	}{
+		{
+			name:            "GET with invalid variables",
+			method:          http.MethodGet,
+			target:          "/test-catalog/api/v1/graphql?query=%7B+summary+%7B+totalSchemas+%7D+%7D&variables=%5B1%5D",
+			expectedMessage: "Variables must be a JSON object",
+		},
+		{
+			name:            "GET with unexpected parameter",
+			method:          http.MethodGet,
+			target:          "/test-catalog/api/v1/graphql?query=%7B+summary+%7B+totalSchemas+%7D+%7D&extensions=%7B%7D",
+			expectedMessage: `Unexpected query parameter "extensions"`,
+		},
+		{
+			name:            "GET without query",
+			method:          http.MethodGet,
+			target:          "/test-catalog/api/v1/graphql",
+			expectedMessage: "Query cannot be empty",
+		},
+		{
+			name:            "query and documentId",
+			method:          http.MethodPost,
+			target:          "/test-catalog/api/v1/graphql",
+			body:            `{"query": "{ summary { totalSchemas } }", "documentId": "summary"}`,
+			expectedMessage: "Query and documentId cannot both be set",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			rootURL, _ := url.Parse("http://localhost/")
+			store := mockcatalogdserver.NewMockCatalogStore(ctrl)
+			graphqlSvc := mockcatalogdservice.NewMockGraphQLService(ctrl)
+
+			handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled, server.DiffHandlerDisabled)
+			handler := handlers.Handler()
+
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tc.expectedMessage) {
+				t.Errorf("Expected error message %q, got: %s", tc.expectedMessage, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleV1GraphQL_PersistedQueryNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rootURL, _ := url.Parse("http://localhost/")
+
+	catalogFS := os.DirFS(t.TempDir())
+	store := mockcatalogdserver.NewMockCatalogStore(ctrl)
+	store.EXPECT().GetCatalogFS("test-catalog").Return(catalogFS, nil)
+
+	graphqlSvc := mockcatalogdservice.NewMockGraphQLService(ctrl)
+	graphqlSvc.EXPECT().ExecuteQuery("test-catalog", catalogFS, service.QueryRequest{DocumentID: "missing"}).
+		Return(nil, fmt.Errorf("%w: %q", service.ErrPersistedQueryNotFound, "missing"))
+
+	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled, server.DiffHandlerDisabled)
+	handler := handlers.Handler()
+
+	req := httptest.NewRequest(http.MethodPost, "/test-catalog/api/v1/graphql", strings.NewReader(`{"documentId": "missing"}`))
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "Persisted query not found") {
+		t.Errorf("Expected error message about persisted query, got: %s", w.Body.String())
+	}
+}
+
+func TestAllowedMethodsHandler_POSTForGraphQL(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	rootURL, _ := url.Parse("http://localhost/")
 	store := mockcatalogdserver.NewMockCatalogStore(ctrl)
 	store.EXPECT().GetCatalogFS("test-catalog").Return(nil, nil)
 
 	graphqlSvc := mockcatalogdservice.NewMockGraphQLService(ctrl)
-	graphqlSvc.EXPECT().ExecuteQuery("test-catalog", nil, "{ summary { totalSchemas } }").Return(nil, nil)
+	graphqlSvc.EXPECT().ExecuteQuery("test-catalog", nil, service.QueryRequest{Query: "{ summary { totalSchemas } }"}).Return(nil, nil)
 
-	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled)
+	handlers := server.NewCatalogHandlers(store, graphqlSvc, rootURL, server.MetasHandlerDisabled, server.GraphQLQueriesEnabled, server.DiffHandlerDisabled)
 	handler := handlers.Handler()
 
 	// Test POST to GraphQL endpoint - should be allowed
@@ -269,7 +445,6 @@ func TestAllowedMethodsHandler_POSTOnlyForGraphQL(t *testing.T) {
 	handler.ServeHTTP(w, graphqlReq)
 
 	// Should not return 405 Method Not Allowed at the router level
-	// (handler itself returns 405 for GET, but router allows POST through)
 	if w.Code == http.StatusMethodNotAllowed && strings.Contains(w.Body.String(), "Method Not Allowed") {
 		t.Error("POST should be allowed for GraphQL endpoint at router level")
 	}
@@ -26,7 +26,7 @@ const (
 // resulted in sending StatusNotModified or StatusPreconditionFailed.
 func checkPreconditions(w http.ResponseWriter, r *http.Request, modtime time.Time) bool {
 	// This function carefully follows RFC 7232 section 6.
-	ch := checkIfMatch(r)
+	ch := checkIfMatch(w, r)
 	if ch == condNone {
 		ch = checkIfUnmodifiedSince(r, modtime)
 	}
@@ -34,7 +34,7 @@ func checkPreconditions(w http.ResponseWriter, r *http.Request, modtime time.Tim
 		w.WriteHeader(http.StatusPreconditionFailed)
 		return true
 	}
-	switch checkIfNoneMatch(r) {
+	switch checkIfNoneMatch(w, r) {
 	case condFalse:
 		if r.Method == "GET" || r.Method == "HEAD" {
 			writeNotModified(w)
@@ -128,7 +128,7 @@ func writeNotModified(w http.ResponseWriter) {
 	w.WriteHeader(http.StatusNotModified)
 }
 
-func checkIfNoneMatch(r *http.Request) condResult {
+func checkIfNoneMatch(w http.ResponseWriter, r *http.Request) condResult {
 	inm := r.Header.Get("If-None-Match")
 	if inm == "" {
 		return condNone
@@ -150,6 +150,9 @@ func checkIfNoneMatch(r *http.Request) condResult {
 		if etag == "" {
 			break
 		}
+		if etagWeakMatch(etag, w.Header().Get("Etag")) {
+			return condFalse
+		}
 		buf = remain
 	}
 	return condTrue
@@ -169,7 +172,7 @@ func parseTime(text string) (t time.Time, err error) {
 	return
 }
 
-func checkIfMatch(r *http.Request) condResult {
+func checkIfMatch(w http.ResponseWriter, r *http.Request) condResult {
 	im := r.Header.Get("If-Match")
 	if im == "" {
 		return condNone
@@ -190,6 +193,9 @@ func checkIfMatch(r *http.Request) condResult {
 		if etag == "" {
 			break
 		}
+		if etagStrongMatch(etag, w.Header().Get("Etag")) {
+			return condTrue
+		}
 		im = remain
 	}
 
@@ -224,3 +230,15 @@ func scanETag(s string) (etag string, remain string) {
 	}
 	return "", ""
 }
+
+// etagStrongMatch reports whether a and b match using strong ETag comparison.
+// Assumes a and b are valid ETags.
+func etagStrongMatch(a, b string) bool {
+	return a == b && a != "" && a[0] == '"'
+}
+
+// etagWeakMatch reports whether a and b match using weak ETag comparison.
+// Assumes a and b are valid ETags.
+func etagWeakMatch(a, b string) bool {
+	return strings.TrimPrefix(a, "W/") == strings.TrimPrefix(b, "W/")
+}
@@ -8,14 +8,18 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/gorilla/handlers"
 	"github.com/klauspost/compress/gzhttp"
+	"k8s.io/apimachinery/pkg/api/meta"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	catalogdmetrics "github.com/operator-framework/operator-controller/internal/catalogd/metrics"
 	"github.com/operator-framework/operator-controller/internal/catalogd/storage"
 )
@@ -26,6 +30,9 @@ type CatalogServerConfig struct {
 	CertFile     string
 	KeyFile      string
 	LocalStorage storage.Instance
+	// Catalogs reads the ClusterCatalogs whose content must be stored locally
+	// before the server first becomes ready. When nil, the server is ready once started.
+	Catalogs client.Reader
 	// TLSOpts are optional functions applied to the TLS configuration when serving over HTTPS.
 	// Use these to configure cipher suites, minimum TLS version, curve preferences, and
 	// certificate retrieval (e.g. via a certwatcher).
@@ -33,10 +40,11 @@ type CatalogServerConfig struct {
 }
 
 // AddCatalogServerToManager adds the catalog HTTP server to the manager and registers
-// a readiness check that passes once the server has started serving.  Because
-// NeedLeaderElection returns false, Start() is called on every pod immediately, so all
-// replicas bind the catalog port and become ready.  Non-leader pods serve requests but
-// return 404 (empty local cache); callers are expected to retry.
+// a readiness check that passes once the server has started serving, and the content
+// of every Serving ClusterCatalog was stored locally at least once.  Because NeedLeaderElection returns
+// false, Start() is called on every pod immediately, so all replicas bind the catalog
+// port.  Non-leader pods store the content served by the leader themselves, and become
+// ready once they can serve the same content.
 func AddCatalogServerToManager(mgr ctrl.Manager, cfg CatalogServerConfig) error {
 	shutdownTimeout := 30 * time.Second
 	r := &catalogServerRunnable{
@@ -56,9 +64,8 @@ func AddCatalogServerToManager(mgr ctrl.Manager, cfg CatalogServerConfig) error
 	}
 
 	// Register a readiness check that passes once Start() has been called and the
-	// server is actively serving.  All pods reach Start() (NeedLeaderElection=false),
-	// so all replicas become ready and receive traffic; non-leaders return 404 until
-	// they win the leader lease and populate their local cache.
+	// content of the Serving catalogs was stored locally, so that starting replicas do
+	// not receive traffic while they would return 404 for every Serving catalog.
 	if err := mgr.AddReadyzCheck("catalog-server", r.readyzCheck()); err != nil {
 		return fmt.Errorf("error adding catalog server readiness check: %w", err)
 	}
@@ -67,14 +74,15 @@ func AddCatalogServerToManager(mgr ctrl.Manager, cfg CatalogServerConfig) error
 }
 
 // catalogServerRunnable is a Runnable that binds the catalog HTTP port on every pod.
-// Because NeedLeaderElection returns false, Start() is called on all replicas immediately;
-// non-leader pods serve the catalog port but return 404 (empty local cache).
+// Because NeedLeaderElection returns false, Start() is called on all replicas immediately.
 type catalogServerRunnable struct {
 	cfg             CatalogServerConfig
 	server          *http.Server
 	shutdownTimeout time.Duration
 	// ready is closed by Start() once the server is about to begin serving.
 	ready chan struct{}
+	// synced is set once the content of every Serving catalog was stored locally.
+	synced atomic.Bool
 }
 
 // NeedLeaderElection returns false so the catalog server starts on every pod
@@ -83,9 +91,8 @@ type catalogServerRunnable struct {
 // (held by the still-running old pod) and therefore could never pass the
 // catalog-server readiness check, deadlocking the rollout.
 //
-// Non-leader pods serve the catalog HTTP port but have an empty local cache
-// (only the leader's reconciler downloads catalog content), so requests to a
-// non-leader return 404.  Callers are expected to retry.
+// Non-leader pods serve the content stored by the ClusterCatalog follower
+// controller, which also runs on every pod.
 func (r *catalogServerRunnable) NeedLeaderElection() bool { return false }
 
 func (r *catalogServerRunnable) Start(ctx context.Context) error {
@@ -131,15 +138,36 @@ func (r *catalogServerRunnable) Start(ctx context.Context) error {
 	return nil
 }
 
-// readyzCheck returns a healthz.Checker that passes once Start() has been called.
+// readyzCheck returns a healthz.Checker that passes once Start() has been called and
+// the content of every Serving ClusterCatalog was stored locally. Only the initial sync
+// is awaited: a catalog that starts serving, or whose content is being replaced, later
+// on must not make every replica unready at once while they store its content.
 func (r *catalogServerRunnable) readyzCheck() healthz.Checker {
-	return func(_ *http.Request) error {
+	return func(req *http.Request) error {
 		select {
 		case <-r.ready:
-			return nil
 		default:
 			return fmt.Errorf("catalog server not yet started")
 		}
+		if r.cfg.Catalogs == nil || r.synced.Load() {
+			return nil
+		}
+
+		var catalogs ocv1.ClusterCatalogList
+		if err := r.cfg.Catalogs.List(req.Context(), &catalogs); err != nil {
+			return fmt.Errorf("error listing ClusterCatalogs: %w", err)
+		}
+		var missing []string
+		for _, catalog := range catalogs.Items {
+			if meta.IsStatusConditionTrue(catalog.Status.Conditions, ocv1.TypeServing) && !r.cfg.LocalStorage.ContentExists(catalog.Name) {
+				missing = append(missing, catalog.Name)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("content of Serving ClusterCatalogs %v not yet stored", missing)
+		}
+		r.synced.Store(true)
+		return nil
 	}
 }
 
@@ -23,7 +23,11 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	mockstorage "github.com/operator-framework/operator-controller/internal/testutil/mock/storage"
 )
 
@@ -260,3 +264,40 @@ func TestCatalogServerTLSOptsCertSourceRequired(t *testing.T) {
 	err := r.Start(ctx)
 	require.ErrorContains(t, err, "TLSOpts must configure a certificate source")
 }
+
+func TestCatalogServerReadyzCheck(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, ocv1.AddToScheme(scheme))
+	newCatalog := func(name string, serving metav1.ConditionStatus) *ocv1.ClusterCatalog {
+		return &ocv1.ClusterCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: ocv1.ClusterCatalogStatus{Conditions: []metav1.Condition{
+				{Type: ocv1.TypeServing, Status: serving, Reason: ocv1.ReasonAvailable, LastTransitionTime: metav1.Now()},
+			}},
+		}
+	}
+	catalogs := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newCatalog("serving", metav1.ConditionTrue),
+		newCatalog("not-serving", metav1.ConditionFalse),
+	).Build()
+
+	stored := false
+	mockStorage := mockstorage.NewMockInstance(gomock.NewController(t))
+	mockStorage.EXPECT().ContentExists("serving").DoAndReturn(func(string) bool { return stored }).AnyTimes()
+	r := &catalogServerRunnable{
+		cfg:   CatalogServerConfig{LocalStorage: mockStorage, Catalogs: catalogs},
+		ready: make(chan struct{}),
+	}
+	check := r.readyzCheck()
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	require.ErrorContains(t, check(req), "not yet started")
+	close(r.ready)
+	require.ErrorContains(t, check(req), "[serving] not yet stored")
+	stored = true
+	require.NoError(t, check(req))
+
+	t.Log("By checking the content of a catalog missing after the initial sync does not make the replica unready")
+	stored = false
+	require.NoError(t, check(req))
+}
@@ -2,11 +2,16 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/fs"
 	"sync"
 
 	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
 	"golang.org/x/sync/singleflight"
 
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
@@ -14,29 +19,70 @@ import (
 	gql "github.com/operator-framework/operator-controller/internal/catalogd/graphql"
 )
 
+// ErrPersistedQueryNotFound is returned when a request references a persisted query
+// that has not been registered
+var ErrPersistedQueryNotFound = errors.New("persisted query not found")
+
+// QueryRequest is a GraphQL request, as sent by GraphQL over HTTP clients
+type QueryRequest struct {
+	// Query is the GraphQL document to execute
+	Query string `json:"query,omitempty"`
+
+	// OperationName selects the operation to execute when the document contains several
+	OperationName string `json:"operationName,omitempty"`
+
+	// Variables are the values of the variables of the operation
+	Variables map[string]interface{} `json:"variables,omitempty"`
+
+	// DocumentID is the name of a persisted query to execute instead of Query
+	DocumentID string `json:"documentId,omitempty"`
+}
+
 // GraphQLService handles GraphQL schema generation and query execution for catalogs
 type GraphQLService interface {
 	// GetSchema returns the GraphQL schema for a catalog, using cache if available
 	GetSchema(catalog string, catalogFS fs.FS) (*gql.DynamicSchema, error)
 
-	// ExecuteQuery executes a GraphQL query against a catalog
-	ExecuteQuery(catalog string, catalogFS fs.FS, query string) (*graphql.Result, error)
+	// ExecuteQuery executes a GraphQL request against a catalog
+	ExecuteQuery(catalog string, catalogFS fs.FS, req QueryRequest) (*graphql.Result, error)
+
+	// RegisterPersistedQuery registers a named query that requests can reference by its name
+	RegisterPersistedQuery(name, query string) error
 
 	// InvalidateCache removes the cached schema for a catalog
 	InvalidateCache(catalog string)
 }
 
+// persistedQueryKey identifies the validation result of a persisted query against the schema of a catalog
+type persistedQueryKey struct {
+	catalog string
+	name    string
+}
+
+// persistedQueryValidation is the validation result of a persisted query against a catalog schema
+type persistedQueryValidation struct {
+	schema *gql.DynamicSchema
+	errors []gqlerrors.FormattedError
+}
+
 // CachedGraphQLService implements GraphQLService with an in-memory schema cache
 type CachedGraphQLService struct {
 	schemaMux   sync.RWMutex
 	schemaCache map[string]*gql.DynamicSchema
 	buildGroup  singleflight.Group // Prevents duplicate concurrent schema builds
+
+	// Persisted queries are parsed once when registered, and validated once per catalog schema
+	persistedMux      sync.RWMutex
+	persistedQueries  map[string]*ast.Document
+	persistedValidity map[persistedQueryKey]persistedQueryValidation
 }
 
 // NewCachedGraphQLService creates a new GraphQL service with caching
 func NewCachedGraphQLService() *CachedGraphQLService {
 	return &CachedGraphQLService{
-		schemaCache: make(map[string]*gql.DynamicSchema),
+		schemaCache:       make(map[string]*gql.DynamicSchema),
+		persistedQueries:  make(map[string]*ast.Document),
+		persistedValidity: make(map[persistedQueryKey]persistedQueryValidation),
 	}
 }
 
@@ -81,21 +127,84 @@ func (s *CachedGraphQLService) GetSchema(catalog string, catalogFS fs.FS) (*gql.
 	return result.(*gql.DynamicSchema), nil
 }
 
-// ExecuteQuery executes a GraphQL query against a catalog
-func (s *CachedGraphQLService) ExecuteQuery(catalog string, catalogFS fs.FS, query string) (*graphql.Result, error) {
+// ExecuteQuery executes a GraphQL request against a catalog. Requests with a DocumentID
+// execute the persisted query of that name, which is not parsed or validated again.
+func (s *CachedGraphQLService) ExecuteQuery(catalog string, catalogFS fs.FS, req QueryRequest) (*graphql.Result, error) {
+	var doc *ast.Document
+	if req.DocumentID != "" {
+		s.persistedMux.RLock()
+		doc = s.persistedQueries[req.DocumentID]
+		s.persistedMux.RUnlock()
+		if doc == nil {
+			return nil, fmt.Errorf("%w: %q", ErrPersistedQueryNotFound, req.DocumentID)
+		}
+	}
+
 	// Get or build the schema (uses cache and singleflight)
 	dynamicSchema, err := s.GetSchema(catalog, catalogFS)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get GraphQL schema: %w", err)
 	}
 
-	// Execute the query
-	result := graphql.Do(graphql.Params{
+	if doc == nil {
+		// Parse, validate and execute the query
+		return graphql.Do(graphql.Params{
+			Schema:         dynamicSchema.Schema,
+			RequestString:  req.Query,
+			VariableValues: req.Variables,
+			OperationName:  req.OperationName,
+		}), nil
+	}
+
+	if errs := s.validatePersistedQuery(catalog, req.DocumentID, dynamicSchema, doc); len(errs) > 0 {
+		return &graphql.Result{Errors: errs}, nil
+	}
+	return graphql.Execute(graphql.ExecuteParams{
 		Schema:        dynamicSchema.Schema,
-		RequestString: query,
+		AST:           doc,
+		Args:          req.Variables,
+		OperationName: req.OperationName,
+	}), nil
+}
+
+// validatePersistedQuery validates a persisted query against the schema of a catalog,
+// reusing the result of a previous validation against the same schema
+func (s *CachedGraphQLService) validatePersistedQuery(catalog, name string, dynamicSchema *gql.DynamicSchema, doc *ast.Document) []gqlerrors.FormattedError {
+	key := persistedQueryKey{catalog: catalog, name: name}
+	s.persistedMux.RLock()
+	validation, ok := s.persistedValidity[key]
+	s.persistedMux.RUnlock()
+	if ok && validation.schema == dynamicSchema {
+		return validation.errors
+	}
+
+	result := graphql.ValidateDocument(&dynamicSchema.Schema, doc, nil)
+	s.persistedMux.Lock()
+	s.persistedValidity[key] = persistedQueryValidation{schema: dynamicSchema, errors: result.Errors}
+	s.persistedMux.Unlock()
+	return result.Errors
+}
+
+// RegisterPersistedQuery parses a query and registers it under the given name. The query
+// is validated against the schema of each catalog the first time it is executed against it.
+func (s *CachedGraphQLService) RegisterPersistedQuery(name, query string) error {
+	if name == "" {
+		return errors.New("persisted query name cannot be empty")
+	}
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(query), Name: name}),
 	})
+	if err != nil {
+		return fmt.Errorf("error parsing persisted query %q: %w", name, err)
+	}
 
-	return result, nil
+	s.persistedMux.Lock()
+	defer s.persistedMux.Unlock()
+	if _, ok := s.persistedQueries[name]; ok {
+		return fmt.Errorf("persisted query %q is already registered", name)
+	}
+	s.persistedQueries[name] = doc
+	return nil
 }
 
 // InvalidateCache removes the cached schema for a catalog
@@ -103,6 +212,14 @@ func (s *CachedGraphQLService) InvalidateCache(catalog string) {
 	s.schemaMux.Lock()
 	delete(s.schemaCache, catalog)
 	s.schemaMux.Unlock()
+
+	s.persistedMux.Lock()
+	for key := range s.persistedValidity {
+		if key.catalog == catalog {
+			delete(s.persistedValidity, key)
+		}
+	}
+	s.persistedMux.Unlock()
 }
 
 // buildSchemaFromFS builds a GraphQL schema from a catalog filesystem
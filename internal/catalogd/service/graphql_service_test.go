@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"io/fs"
 	"sync"
 	"testing"
@@ -310,7 +311,7 @@ func TestCachedGraphQLService_ExecuteQuery(t *testing.T) {
 
 	// Execute a simple introspection query
 	query := `{ __schema { queryType { name } } }`
-	result, err := svc.ExecuteQuery("test-catalog", testFS, query)
+	result, err := svc.ExecuteQuery("test-catalog", testFS, QueryRequest{Query: query})
 	if err != nil {
 		t.Fatalf("ExecuteQuery failed: %v", err)
 	}
@@ -329,3 +330,114 @@ func TestCachedGraphQLService_ExecuteQuery(t *testing.T) {
 		t.Error("Expected result to have data")
 	}
 }
+
+func TestCachedGraphQLService_ExecuteQueryWithVariables(t *testing.T) {
+	svc := NewCachedGraphQLService()
+
+	testFS := fstest.MapFS{
+		"catalog.json": &fstest.MapFile{
+			Data: []byte(`{"schema": "olm.package", "name": "package-a", "defaultChannel": "stable"}
+{"schema": "olm.package", "name": "package-b", "defaultChannel": "stable"}`),
+		},
+	}
+
+	result, err := svc.ExecuteQuery("test-catalog", testFS, QueryRequest{
+		Query: `query Packages($limit: Int) { olmpackages(limit: $limit) { name } }
+query Summary { summary { totalSchemas } }`,
+		OperationName: "Packages",
+		Variables:     map[string]interface{}{"limit": 1},
+	})
+	if err != nil {
+		t.Fatalf("ExecuteQuery failed: %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("Expected no GraphQL errors, got: %v", result.Errors)
+	}
+	data := result.Data.(map[string]interface{})
+	if _, ok := data["summary"]; ok {
+		t.Error("Expected only the selected operation to be executed")
+	}
+	packages, ok := data["olmpackages"].([]interface{})
+	if !ok || len(packages) != 1 {
+		t.Errorf("Expected the limit variable to return 1 package, got: %v", data["olmpackages"])
+	}
+}
+
+func TestCachedGraphQLService_PersistedQueries(t *testing.T) {
+	svc := NewCachedGraphQLService()
+
+	testFS := fstest.MapFS{
+		"catalog.json": &fstest.MapFile{
+			Data: []byte(`{"schema": "olm.package", "name": "package-a", "defaultChannel": "stable"}
+{"schema": "olm.package", "name": "package-b", "defaultChannel": "stable"}`),
+		},
+	}
+
+	if err := svc.RegisterPersistedQuery("packages", `query Packages($limit: Int) { olmpackages(limit: $limit) { name } }`); err != nil {
+		t.Fatalf("RegisterPersistedQuery failed: %v", err)
+	}
+	if err := svc.RegisterPersistedQuery("packages", `{ summary { totalSchemas } }`); err == nil {
+		t.Error("Expected an error registering a persisted query twice")
+	}
+	if err := svc.RegisterPersistedQuery("invalid", `{ summary {`); err == nil {
+		t.Error("Expected an error registering a persisted query that does not parse")
+	}
+	if err := svc.RegisterPersistedQuery("", `{ summary { totalSchemas } }`); err == nil {
+		t.Error("Expected an error registering a persisted query without a name")
+	}
+
+	result, err := svc.ExecuteQuery("test-catalog", testFS, QueryRequest{DocumentID: "packages", Variables: map[string]interface{}{"limit": 1}})
+	if err != nil {
+		t.Fatalf("ExecuteQuery failed: %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Fatalf("Expected no GraphQL errors, got: %v", result.Errors)
+	}
+	packages, ok := result.Data.(map[string]interface{})["olmpackages"].([]interface{})
+	if !ok || len(packages) != 1 {
+		t.Errorf("Expected the limit variable to return 1 package, got: %v", result.Data)
+	}
+
+	_, err = svc.ExecuteQuery("test-catalog", testFS, QueryRequest{DocumentID: "missing"})
+	if !errors.Is(err, ErrPersistedQueryNotFound) {
+		t.Errorf("Expected ErrPersistedQueryNotFound, got: %v", err)
+	}
+}
+
+func TestCachedGraphQLService_PersistedQueryValidation(t *testing.T) {
+	svc := NewCachedGraphQLService()
+
+	packagesFS := fstest.MapFS{
+		"catalog.json": &fstest.MapFile{
+			Data: []byte(`{"schema": "olm.package", "name": "test-package", "defaultChannel": "stable"}`),
+		},
+	}
+	channelsFS := fstest.MapFS{
+		"catalog.json": &fstest.MapFile{
+			Data: []byte(`{"schema": "olm.channel", "name": "stable", "package": "test-package"}`),
+		},
+	}
+
+	if err := svc.RegisterPersistedQuery("packages", `{ olmpackages { name } }`); err != nil {
+		t.Fatalf("RegisterPersistedQuery failed: %v", err)
+	}
+
+	// The query is not valid against the schema of a catalog without packages
+	result, err := svc.ExecuteQuery("test-catalog", channelsFS, QueryRequest{DocumentID: "packages"})
+	if err != nil {
+		t.Fatalf("ExecuteQuery failed: %v", err)
+	}
+	if len(result.Errors) == 0 {
+		t.Error("Expected validation errors")
+	}
+
+	// Once the catalog content changes, the query is validated against the new schema
+	svc.InvalidateCache("test-catalog")
+	result, err = svc.ExecuteQuery("test-catalog", packagesFS, QueryRequest{DocumentID: "packages"})
+	if err != nil {
+		t.Fatalf("ExecuteQuery failed: %v", err)
+	}
+	if len(result.Errors) > 0 {
+		t.Errorf("Expected no GraphQL errors, got: %v", result.Errors)
+	}
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/catalogd/server"
+)
+
+// ClusterCatalogLister lists the ClusterCatalogs available to clients, along
+// with their priority. It implements the server.CatalogLister interface.
+type ClusterCatalogLister struct {
+	Reader client.Reader
+}
+
+var _ server.CatalogLister = (*ClusterCatalogLister)(nil)
+
+// ListServedCatalogs returns the ClusterCatalogs whose availability mode is not Unavailable.
+// The content of unavailable catalogs is removed by the ClusterCatalog controller, but it may
+// not be removed yet when the availability mode has just changed.
+func (l *ClusterCatalogLister) ListServedCatalogs(ctx context.Context) ([]server.ServedCatalog, error) {
+	var catalogs ocv1.ClusterCatalogList
+	if err := l.Reader.List(ctx, &catalogs); err != nil {
+		return nil, fmt.Errorf("error listing ClusterCatalogs: %w", err)
+	}
+	served := make([]server.ServedCatalog, 0, len(catalogs.Items))
+	for _, catalog := range catalogs.Items {
+		if catalog.Spec.AvailabilityMode == ocv1.AvailabilityModeUnavailable {
+			continue
+		}
+		served = append(served, server.ServedCatalog{Name: catalog.Name, Priority: catalog.Spec.Priority})
+	}
+	return served, nil
+}
@@ -0,0 +1,323 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+)
+
+// CatalogDiff describes the changes between the previously served content of a
+// catalog and its current content.
+type CatalogDiff struct {
+	// PreviousLastModified is the time the previous content was stored.
+	PreviousLastModified time.Time `json:"previousLastModified"`
+	// CurrentLastModified is the time the current content was stored.
+	CurrentLastModified time.Time `json:"currentLastModified"`
+	// Packages lists the packages that were added, removed or changed.
+	Packages []PackageDiff `json:"packages"`
+}
+
+// PackageDiffType is the kind of change made to a package.
+type PackageDiffType string
+
+const (
+	PackageAdded    PackageDiffType = "Added"
+	PackageRemoved  PackageDiffType = "Removed"
+	PackageModified PackageDiffType = "Modified"
+)
+
+// PackageDiff describes the changes made to the bundles, channels and
+// deprecations of a package.
+type PackageDiff struct {
+	Name string          `json:"name"`
+	Type PackageDiffType `json:"type"`
+
+	AddedBundles   []string `json:"addedBundles,omitempty"`
+	RemovedBundles []string `json:"removedBundles,omitempty"`
+
+	AddedChannels    []string      `json:"addedChannels,omitempty"`
+	RemovedChannels  []string      `json:"removedChannels,omitempty"`
+	ModifiedChannels []ChannelDiff `json:"modifiedChannels,omitempty"`
+
+	AddedDeprecations   []declcfg.DeprecationEntry `json:"addedDeprecations,omitempty"`
+	RemovedDeprecations []declcfg.DeprecationEntry `json:"removedDeprecations,omitempty"`
+}
+
+// ChannelDiff describes the changes made to the entries of a channel, and
+// so to its upgrade edges.
+type ChannelDiff struct {
+	Name            string                 `json:"name"`
+	AddedEntries    []declcfg.ChannelEntry `json:"addedEntries,omitempty"`
+	RemovedEntries  []declcfg.ChannelEntry `json:"removedEntries,omitempty"`
+	ModifiedEntries []ChannelEntryDiff     `json:"modifiedEntries,omitempty"`
+}
+
+// ChannelEntryDiff holds the previous and current upgrade edges of a channel entry.
+type ChannelEntryDiff struct {
+	Previous declcfg.ChannelEntry `json:"previous"`
+	Current  declcfg.ChannelEntry `json:"current"`
+}
+
+// packageContent is the content of a package relevant to a diff.
+type packageContent struct {
+	exists       bool
+	bundles      sets.Set[string]
+	channels     map[string]map[string]declcfg.ChannelEntry
+	deprecations []declcfg.DeprecationEntry
+}
+
+// catalogContent reads the content of each package of a stored catalog, using its
+// index to only read the blobs of the schemas relevant to a diff.
+func catalogContent(catalogDir string) (map[string]*packageContent, error) {
+	idx, err := loadIndex(catalogIndexFilePath(catalogDir))
+	if err != nil {
+		return nil, err
+	}
+	catalogFile, err := os.Open(catalogFilePath(catalogDir))
+	if err != nil {
+		return nil, err
+	}
+	defer catalogFile.Close()
+
+	pkgs := map[string]*packageContent{}
+	pkg := func(name string) *packageContent {
+		if _, ok := pkgs[name]; !ok {
+			pkgs[name] = &packageContent{bundles: sets.New[string](), channels: map[string]map[string]declcfg.ChannelEntry{}}
+		}
+		return pkgs[name]
+	}
+	walk := func(schema string, fn func(*declcfg.Meta) error) error {
+		return declcfg.WalkMetasReader(idx.Get(catalogFile, schema, "", ""), func(meta *declcfg.Meta, err error) error {
+			if err != nil {
+				return err
+			}
+			return fn(meta)
+		})
+	}
+
+	if err := walk(declcfg.SchemaPackage, func(meta *declcfg.Meta) error {
+		pkg(meta.Name).exists = true
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := walk(declcfg.SchemaBundle, func(meta *declcfg.Meta) error {
+		pkg(meta.Package).bundles.Insert(meta.Name)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := walk(declcfg.SchemaChannel, func(meta *declcfg.Meta) error {
+		var ch declcfg.Channel
+		if err := json.Unmarshal(meta.Blob, &ch); err != nil {
+			return fmt.Errorf("error parsing channel %q of package %q: %w", meta.Name, meta.Package, err)
+		}
+		entries := make(map[string]declcfg.ChannelEntry, len(ch.Entries))
+		for _, e := range ch.Entries {
+			entries[e.Name] = e
+		}
+		pkg(ch.Package).channels[ch.Name] = entries
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := walk(declcfg.SchemaDeprecation, func(meta *declcfg.Meta) error {
+		var d declcfg.Deprecation
+		if err := json.Unmarshal(meta.Blob, &d); err != nil {
+			return fmt.Errorf("error parsing deprecations of package %q: %w", meta.Package, err)
+		}
+		p := pkg(d.Package)
+		p.deprecations = append(p.deprecations, d.Entries...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return pkgs, nil
+}
+
+// diffCatalogs computes the diff between the catalogs stored in previousDir and currentDir.
+func diffCatalogs(previousDir, currentDir string) (*CatalogDiff, error) {
+	previous, err := catalogContent(previousDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading previous catalog content: %w", err)
+	}
+	current, err := catalogContent(currentDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading current catalog content: %w", err)
+	}
+	previousStat, err := os.Stat(catalogFilePath(previousDir))
+	if err != nil {
+		return nil, err
+	}
+	currentStat, err := os.Stat(catalogFilePath(currentDir))
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &CatalogDiff{
+		PreviousLastModified: previousStat.ModTime().UTC(),
+		CurrentLastModified:  currentStat.ModTime().UTC(),
+		Packages:             []PackageDiff{},
+	}
+	empty := &packageContent{bundles: sets.New[string](), channels: map[string]map[string]declcfg.ChannelEntry{}}
+	names := sets.KeySet(previous).Union(sets.KeySet(current))
+	for _, name := range sets.List(names) {
+		prev, cur := previous[name], current[name]
+		if prev == nil {
+			prev = empty
+		}
+		if cur == nil {
+			cur = empty
+		}
+		if d := diffPackage(name, prev, cur); d != nil {
+			diff.Packages = append(diff.Packages, *d)
+		}
+	}
+	return diff, nil
+}
+
+// diffPackage returns the diff of a package, or nil if it did not change.
+func diffPackage(name string, prev, cur *packageContent) *PackageDiff {
+	d := &PackageDiff{
+		Name:           name,
+		Type:           PackageModified,
+		AddedBundles:   sets.List(cur.bundles.Difference(prev.bundles)),
+		RemovedBundles: sets.List(prev.bundles.Difference(cur.bundles)),
+	}
+	switch {
+	case !prev.exists && cur.exists:
+		d.Type = PackageAdded
+	case prev.exists && !cur.exists:
+		d.Type = PackageRemoved
+	}
+
+	for _, ch := range slices.Sorted(maps.Keys(cur.channels)) {
+		prevEntries, ok := prev.channels[ch]
+		if !ok {
+			d.AddedChannels = append(d.AddedChannels, ch)
+			continue
+		}
+		if cd := diffChannel(ch, prevEntries, cur.channels[ch]); cd != nil {
+			d.ModifiedChannels = append(d.ModifiedChannels, *cd)
+		}
+	}
+	for _, ch := range slices.Sorted(maps.Keys(prev.channels)) {
+		if _, ok := cur.channels[ch]; !ok {
+			d.RemovedChannels = append(d.RemovedChannels, ch)
+		}
+	}
+
+	d.AddedDeprecations = deprecationsNotIn(cur.deprecations, prev.deprecations)
+	d.RemovedDeprecations = deprecationsNotIn(prev.deprecations, cur.deprecations)
+
+	if d.Type == PackageModified &&
+		len(d.AddedBundles) == 0 && len(d.RemovedBundles) == 0 &&
+		len(d.AddedChannels) == 0 && len(d.RemovedChannels) == 0 && len(d.ModifiedChannels) == 0 &&
+		len(d.AddedDeprecations) == 0 && len(d.RemovedDeprecations) == 0 {
+		return nil
+	}
+	return d
+}
+
+// diffChannel returns the diff of the entries of a channel, or nil if they did not change.
+func diffChannel(name string, prev, cur map[string]declcfg.ChannelEntry) *ChannelDiff {
+	d := &ChannelDiff{Name: name}
+	for _, entryName := range slices.Sorted(maps.Keys(cur)) {
+		prevEntry, ok := prev[entryName]
+		switch {
+		case !ok:
+			d.AddedEntries = append(d.AddedEntries, cur[entryName])
+		case !channelEntryEqual(prevEntry, cur[entryName]):
+			d.ModifiedEntries = append(d.ModifiedEntries, ChannelEntryDiff{Previous: prevEntry, Current: cur[entryName]})
+		}
+	}
+	for _, entryName := range slices.Sorted(maps.Keys(prev)) {
+		if _, ok := cur[entryName]; !ok {
+			d.RemovedEntries = append(d.RemovedEntries, prev[entryName])
+		}
+	}
+	if len(d.AddedEntries) == 0 && len(d.RemovedEntries) == 0 && len(d.ModifiedEntries) == 0 {
+		return nil
+	}
+	return d
+}
+
+func channelEntryEqual(a, b declcfg.ChannelEntry) bool {
+	return a.Name == b.Name && a.Replaces == b.Replaces && a.SkipRange == b.SkipRange && slices.Equal(a.Skips, b.Skips)
+}
+
+// deprecationsNotIn returns the entries of a that are not in b.
+func deprecationsNotIn(a, b []declcfg.DeprecationEntry) []declcfg.DeprecationEntry {
+	var out []declcfg.DeprecationEntry
+	for _, e := range a {
+		if !slices.Contains(b, e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// storeDiffData computes the diff between the previous content of a catalog and the
+// content staged in catalogDir, and writes it to catalogDir. Nothing is written if
+// there is no previous content, or if it was stored without an index.
+func storeDiffData(previousDir, catalogDir string) error {
+	if _, err := os.Stat(catalogIndexFilePath(previousDir)); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	diff, err := diffCatalogs(previousDir, catalogDir)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(catalogDiffFilePath(catalogDir))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(diff)
+}
+
+// sameCatalogData reports whether two stored catalogs have the same content.
+func sameCatalogData(aDir, bDir string) (bool, error) {
+	a, err := os.Open(catalogFilePath(aDir))
+	if err != nil {
+		return false, err
+	}
+	defer a.Close()
+	b, err := os.Open(catalogFilePath(bDir))
+	if err != nil {
+		return false, err
+	}
+	defer b.Close()
+
+	aBuf, bBuf := make([]byte, 32*1024), make([]byte, 32*1024)
+	for {
+		an, aErr := io.ReadFull(a, aBuf)
+		bn, bErr := io.ReadFull(b, bBuf)
+		if an != bn || string(aBuf[:an]) != string(bBuf[:bn]) {
+			return false, nil
+		}
+		aDone := errors.Is(aErr, io.EOF) || errors.Is(aErr, io.ErrUnexpectedEOF)
+		bDone := errors.Is(bErr, io.EOF) || errors.Is(bErr, io.ErrUnexpectedEOF)
+		switch {
+		case aErr != nil && !aDone:
+			return false, aErr
+		case bErr != nil && !bDone:
+			return false, bErr
+		case aDone || bDone:
+			return aDone == bDone, nil
+		}
+	}
+}
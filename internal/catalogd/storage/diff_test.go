@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+)
+
+func TestDiffPackage(t *testing.T) {
+	previous := &packageContent{
+		exists:  true,
+		bundles: sets.New("foo.v1.0.0", "foo.v1.1.0"),
+		channels: map[string]map[string]declcfg.ChannelEntry{
+			"stable": {
+				"foo.v1.0.0": {Name: "foo.v1.0.0"},
+				"foo.v1.1.0": {Name: "foo.v1.1.0", Replaces: "foo.v1.0.0"},
+			},
+			"beta": {"foo.v1.0.0": {Name: "foo.v1.0.0"}},
+		},
+		deprecations: []declcfg.DeprecationEntry{
+			{Reference: declcfg.PackageScopedReference{Schema: declcfg.SchemaChannel, Name: "beta"}, Message: "beta is deprecated"},
+		},
+	}
+
+	require.Nil(t, diffPackage("foo", previous, previous))
+
+	current := &packageContent{
+		exists:  true,
+		bundles: sets.New("foo.v1.1.0", "foo.v1.2.0"),
+		channels: map[string]map[string]declcfg.ChannelEntry{
+			"stable": {
+				"foo.v1.1.0": {Name: "foo.v1.1.0", SkipRange: "<1.1.0"},
+				"foo.v1.2.0": {Name: "foo.v1.2.0", Replaces: "foo.v1.1.0"},
+			},
+			"fast": {"foo.v1.2.0": {Name: "foo.v1.2.0"}},
+		},
+		deprecations: []declcfg.DeprecationEntry{
+			{Reference: declcfg.PackageScopedReference{Schema: declcfg.SchemaBundle, Name: "foo.v1.1.0"}, Message: "foo.v1.1.0 is deprecated"},
+		},
+	}
+	require.Equal(t, &PackageDiff{
+		Name:            "foo",
+		Type:            PackageModified,
+		AddedBundles:    []string{"foo.v1.2.0"},
+		RemovedBundles:  []string{"foo.v1.0.0"},
+		AddedChannels:   []string{"fast"},
+		RemovedChannels: []string{"beta"},
+		ModifiedChannels: []ChannelDiff{{
+			Name:           "stable",
+			AddedEntries:   []declcfg.ChannelEntry{{Name: "foo.v1.2.0", Replaces: "foo.v1.1.0"}},
+			RemovedEntries: []declcfg.ChannelEntry{{Name: "foo.v1.0.0"}},
+			ModifiedEntries: []ChannelEntryDiff{{
+				Previous: declcfg.ChannelEntry{Name: "foo.v1.1.0", Replaces: "foo.v1.0.0"},
+				Current:  declcfg.ChannelEntry{Name: "foo.v1.1.0", SkipRange: "<1.1.0"},
+			}},
+		}},
+		AddedDeprecations:   current.deprecations,
+		RemovedDeprecations: previous.deprecations,
+	}, diffPackage("foo", previous, current))
+
+	empty := &packageContent{bundles: sets.New[string](), channels: map[string]map[string]declcfg.ChannelEntry{}}
+	removed := diffPackage("foo", previous, empty)
+	require.Equal(t, PackageRemoved, removed.Type)
+	require.Equal(t, []string{"foo.v1.0.0", "foo.v1.1.0"}, removed.RemovedBundles)
+	require.Equal(t, []string{"beta", "stable"}, removed.RemovedChannels)
+}
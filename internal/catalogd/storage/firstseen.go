@@ -0,0 +1,208 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+// bundleKey identifies a bundle of a catalog
+type bundleKey struct {
+	pkg  string
+	name string
+}
+
+// firstSeenDataKey is the key of the data of the ConfigMaps recording when the bundles
+// of a catalog were first seen. It holds a JSON object mapping the name of each package
+// to the time each of its bundles was first seen, keyed by bundle name.
+const firstSeenDataKey = "firstSeen.json"
+
+// firstSeenShards is the number of ConfigMaps the records of a catalog are split across,
+// so that the records of large catalogs fit in ConfigMaps. All the bundles of a package
+// are recorded in the same ConfigMap.
+const firstSeenShards = 16
+
+// maxFirstSeenDataSize bounds the size of the records held by a ConfigMap, leaving room
+// for its metadata within the 1MiB size limit of objects.
+const maxFirstSeenDataSize = 1000 * 1024
+
+// FirstSeenRecorder records when the bundles of catalogs were first seen in ConfigMaps
+// of Namespace, so that the records outlive the content stored by catalogd pods and are
+// the same for all the replicas. The records of a catalog are split across ConfigMaps by
+// package, each of them owned by the ClusterCatalog, so that they are removed along with it.
+//
+// Client must not read ConfigMaps from a cache, as the records are updated by all the
+// replicas.
+type FirstSeenRecorder struct {
+	Client    client.Client
+	Namespace string
+
+	clock       clock.PassiveClock
+	maxDataSize int
+}
+
+// NewFirstSeenRecorder returns a FirstSeenRecorder recording in ConfigMaps of namespace
+func NewFirstSeenRecorder(c client.Client, namespace string) *FirstSeenRecorder {
+	return &FirstSeenRecorder{Client: c, Namespace: namespace, clock: clock.RealClock{}, maxDataSize: maxFirstSeenDataSize}
+}
+
+// configMapName returns the name of the ConfigMap recording when the bundles of the
+// packages of a catalog in the given shard were first seen
+func (r *FirstSeenRecorder) configMapName(catalog string, shard int) string {
+	return fmt.Sprintf("catalogd-first-seen-%s-%d", catalog, shard)
+}
+
+// firstSeenShard returns the shard the bundles of a package are recorded in
+func firstSeenShard(pkg string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(pkg))
+	return int(h.Sum32() % firstSeenShards)
+}
+
+// Record returns when each of the given bundles of a catalog was first seen, recording
+// the current time for the bundles that were not seen before. The bundles that are not
+// given are no longer recorded, so that a bundle removed from the catalog is seen again
+// when it comes back.
+func (r *FirstSeenRecorder) Record(ctx context.Context, catalog string, bundles []bundleKey) (map[bundleKey]time.Time, error) {
+	shards := make([][]bundleKey, firstSeenShards)
+	for _, b := range bundles {
+		shard := firstSeenShard(b.pkg)
+		shards[shard] = append(shards[shard], b)
+	}
+	rec := &shardRecorder{FirstSeenRecorder: r, catalog: catalog, now: r.clock.Now().UTC().Truncate(time.Second)}
+	firstSeen := make(map[bundleKey]time.Time, len(bundles))
+	for shard, shardBundles := range shards {
+		recorded, err := rec.record(ctx, shard, shardBundles)
+		if err != nil {
+			return nil, fmt.Errorf("error recording when the bundles of catalog %q were first seen: %w", catalog, err)
+		}
+		maps.Copy(firstSeen, recorded)
+	}
+	return firstSeen, nil
+}
+
+// shardRecorder records when the bundles of a catalog were first seen, one shard at a time
+type shardRecorder struct {
+	*FirstSeenRecorder
+	catalog string
+	now     time.Time
+	owner   *metav1.OwnerReference
+}
+
+// record records when the given bundles of a shard were first seen in its ConfigMap
+func (r *shardRecorder) record(ctx context.Context, shard int, bundles []bundleKey) (map[bundleKey]time.Time, error) {
+	name := r.configMapName(r.catalog, shard)
+	var firstSeen map[bundleKey]time.Time
+	// Replicas storing the same content record it concurrently: the first one wins.
+	err := retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}, func() error {
+		cm := &corev1.ConfigMap{}
+		err := r.Client.Get(ctx, client.ObjectKey{Namespace: r.Namespace, Name: name}, cm)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		exists := err == nil
+		recorded, err := decodeFirstSeen(cm)
+		if err != nil {
+			return fmt.Errorf("error reading ConfigMap %q: %w", cm.Name, err)
+		}
+
+		firstSeen = make(map[bundleKey]time.Time, len(bundles))
+		for _, b := range bundles {
+			seen, ok := recorded[b]
+			if !ok {
+				seen = r.now
+			}
+			firstSeen[b] = seen
+		}
+		if maps.EqualFunc(recorded, firstSeen, time.Time.Equal) {
+			return nil
+		}
+
+		data, err := encodeFirstSeen(firstSeen)
+		if err != nil {
+			return err
+		}
+		if len(data) > r.maxDataSize {
+			return fmt.Errorf("the records of the %d bundles of ConfigMap %q take %d bytes, more than the %d bytes it can hold", len(firstSeen), name, len(data), r.maxDataSize)
+		}
+		cm.Data = map[string]string{firstSeenDataKey: data}
+		if exists {
+			return r.Client.Update(ctx, cm)
+		}
+		owner, err := r.ownerReference(ctx)
+		if err != nil {
+			return err
+		}
+		cm.ObjectMeta = metav1.ObjectMeta{
+			Namespace:       r.Namespace,
+			Name:            name,
+			OwnerReferences: []metav1.OwnerReference{owner},
+		}
+		return r.Client.Create(ctx, cm)
+	})
+	return firstSeen, err
+}
+
+// ownerReference returns a reference to the ClusterCatalog, which owns the ConfigMaps
+func (r *shardRecorder) ownerReference(ctx context.Context) (metav1.OwnerReference, error) {
+	if r.owner == nil {
+		clusterCatalog := &ocv1.ClusterCatalog{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: r.catalog}, clusterCatalog); err != nil {
+			return metav1.OwnerReference{}, fmt.Errorf("error getting ClusterCatalog %q: %w", r.catalog, err)
+		}
+		r.owner = &metav1.OwnerReference{
+			APIVersion: ocv1.GroupVersion.String(),
+			Kind:       "ClusterCatalog",
+			Name:       clusterCatalog.Name,
+			UID:        clusterCatalog.UID,
+		}
+	}
+	return *r.owner, nil
+}
+
+func decodeFirstSeen(cm *corev1.ConfigMap) (map[bundleKey]time.Time, error) {
+	firstSeen := map[bundleKey]time.Time{}
+	data, ok := cm.Data[firstSeenDataKey]
+	if !ok {
+		return firstSeen, nil
+	}
+	var packages map[string]map[string]time.Time
+	if err := json.Unmarshal([]byte(data), &packages); err != nil {
+		return nil, err
+	}
+	for pkg, bundles := range packages {
+		for name, seen := range bundles {
+			firstSeen[bundleKey{pkg, name}] = seen
+		}
+	}
+	return firstSeen, nil
+}
+
+func encodeFirstSeen(firstSeen map[bundleKey]time.Time) (string, error) {
+	packages := map[string]map[string]time.Time{}
+	for b, seen := range firstSeen {
+		if packages[b.pkg] == nil {
+			packages[b.pkg] = map[string]time.Time{}
+		}
+		packages[b.pkg][b.name] = seen
+	}
+	data, err := json.Marshal(packages)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
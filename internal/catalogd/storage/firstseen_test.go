@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+// newTestFirstSeenRecorder returns a FirstSeenRecorder of a cluster with the named
+// ClusterCatalogs, using a fake clock.
+func newTestFirstSeenRecorder(t *testing.T, funcs interceptor.Funcs, catalogs ...string) (*FirstSeenRecorder, *clocktesting.FakeClock) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, ocv1.AddToScheme(scheme))
+	builder := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(funcs)
+	for _, catalog := range catalogs {
+		builder = builder.WithObjects(&ocv1.ClusterCatalog{ObjectMeta: metav1.ObjectMeta{Name: catalog, UID: types.UID("uid-" + catalog)}})
+	}
+	r := NewFirstSeenRecorder(builder.Build(), "olmv1-system")
+	clk := clocktesting.NewFakeClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))
+	r.clock = clk
+	return r, clk
+}
+
+func TestFirstSeenRecorder(t *testing.T) {
+	r, clk := newTestFirstSeenRecorder(t, interceptor.Funcs{}, "test-catalog")
+	ctx := context.Background()
+	v1 := bundleKey{"pkg", "pkg.v1.0.0"}
+	v2 := bundleKey{"pkg", "pkg.v2.0.0"}
+	start := clk.Now()
+
+	t.Log("By checking bundles are recorded in a ConfigMap owned by the ClusterCatalog")
+	firstSeen, err := r.Record(ctx, "test-catalog", []bundleKey{v1})
+	require.NoError(t, err)
+	require.Equal(t, map[bundleKey]time.Time{v1: start}, firstSeen)
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, r.Client.Get(ctx, client.ObjectKey{Namespace: "olmv1-system", Name: fmt.Sprintf("catalogd-first-seen-test-catalog-%d", firstSeenShard("pkg"))}, cm))
+	require.Len(t, cm.OwnerReferences, 1)
+	require.Equal(t, "ClusterCatalog", cm.OwnerReferences[0].Kind)
+	require.Equal(t, "test-catalog", cm.OwnerReferences[0].Name)
+
+	t.Log("By checking another replica reads the recorded times, and records new bundles")
+	clk.Step(time.Hour)
+	replica := NewFirstSeenRecorder(r.Client, r.Namespace)
+	replica.clock = clk
+	firstSeen, err = replica.Record(ctx, "test-catalog", []bundleKey{v1, v2})
+	require.NoError(t, err)
+	require.Equal(t, map[bundleKey]time.Time{v1: start, v2: start.Add(time.Hour)}, firstSeen)
+
+	t.Log("By checking a bundle removed from the catalog is seen again when it comes back")
+	clk.Step(time.Hour)
+	_, err = r.Record(ctx, "test-catalog", []bundleKey{v1})
+	require.NoError(t, err)
+	firstSeen, err = r.Record(ctx, "test-catalog", []bundleKey{v1, v2})
+	require.NoError(t, err)
+	require.Equal(t, map[bundleKey]time.Time{v1: start, v2: start.Add(2 * time.Hour)}, firstSeen)
+
+	t.Log("By checking nothing is recorded for a catalog that does not exist")
+	_, err = r.Record(ctx, "missing-catalog", []bundleKey{v1})
+	require.ErrorContains(t, err, `error getting ClusterCatalog "missing-catalog"`)
+}
+
+func TestFirstSeenRecorderConflict(t *testing.T) {
+	// Another replica records the bundles first, at another time.
+	var replica *FirstSeenRecorder
+	conflicted := false
+	r, _ := newTestFirstSeenRecorder(t, interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if !conflicted {
+				conflicted = true
+				replicaClock := clocktesting.NewFakeClock(time.Date(2026, 10, 18, 11, 0, 0, 0, time.UTC))
+				replica.clock = replicaClock
+				if _, err := replica.Record(ctx, "test-catalog", []bundleKey{{"pkg", "pkg.v1.0.0"}}); err != nil {
+					return err
+				}
+				return apierrors.NewAlreadyExists(schema.GroupResource{Resource: "configmaps"}, obj.GetName())
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}, "test-catalog")
+	replica = NewFirstSeenRecorder(r.Client, r.Namespace)
+
+	firstSeen, err := r.Record(context.Background(), "test-catalog", []bundleKey{{"pkg", "pkg.v1.0.0"}})
+	require.NoError(t, err)
+	require.Equal(t, map[bundleKey]time.Time{{"pkg", "pkg.v1.0.0"}: time.Date(2026, 10, 18, 11, 0, 0, 0, time.UTC)}, firstSeen)
+}
+
+func TestFirstSeenRecorderLargeCatalog(t *testing.T) {
+	r, clk := newTestFirstSeenRecorder(t, interceptor.Funcs{}, "test-catalog")
+	ctx := context.Background()
+	var bundles []bundleKey
+	for p := range 2000 {
+		pkg := fmt.Sprintf("a-package-with-a-rather-long-name-%04d", p)
+		for v := range 10 {
+			bundles = append(bundles, bundleKey{pkg, fmt.Sprintf("%s.v1.%d.0", pkg, v)})
+		}
+	}
+	start := clk.Now()
+
+	t.Log("By checking the records are split across ConfigMaps fitting in the size limit of objects")
+	firstSeen, err := r.Record(ctx, "test-catalog", bundles)
+	require.NoError(t, err)
+	require.Len(t, firstSeen, len(bundles))
+	cms := &corev1.ConfigMapList{}
+	require.NoError(t, r.Client.List(ctx, cms, client.InNamespace("olmv1-system")))
+	require.Len(t, cms.Items, firstSeenShards)
+	size := 0
+	for _, cm := range cms.Items {
+		require.LessOrEqual(t, len(cm.Data[firstSeenDataKey]), maxFirstSeenDataSize)
+		size += len(cm.Data[firstSeenDataKey])
+	}
+	require.Greater(t, size, 1024*1024, "the records do not fit in a single object")
+
+	t.Log("By checking the records are read back from all the ConfigMaps")
+	clk.Step(time.Hour)
+	firstSeen, err = r.Record(ctx, "test-catalog", bundles)
+	require.NoError(t, err)
+	for _, b := range bundles {
+		require.Equal(t, start, firstSeen[b])
+	}
+
+	t.Log("By checking records that do not fit in a ConfigMap are not recorded")
+	r.maxDataSize = 1024
+	var pkgBundles []bundleKey
+	for v := range 100 {
+		pkgBundles = append(pkgBundles, bundleKey{"pkg", fmt.Sprintf("pkg.v1.%d.0", v)})
+	}
+	_, err = r.Record(ctx, "test-catalog", pkgBundles)
+	require.ErrorContains(t, err, fmt.Sprintf(`the records of the 100 bundles of ConfigMap "catalogd-first-seen-test-catalog-%d" take`, firstSeenShard("pkg")))
+	require.ErrorContains(t, err, "more than the 1024 bytes it can hold")
+}
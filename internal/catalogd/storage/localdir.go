@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/singleflight"
@@ -19,14 +20,18 @@ import (
 
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
 
+	"github.com/operator-framework/operator-controller/internal/catalogd/index"
 	"github.com/operator-framework/operator-controller/internal/catalogd/server"
 	"github.com/operator-framework/operator-controller/internal/catalogd/service"
+	"github.com/operator-framework/operator-controller/internal/shared/firstseen"
 )
 
 // Re-export enum types and constants from server package for convenience
 type (
 	MetasHandlerMode   = server.MetasHandlerMode
 	GraphQLQueriesMode = server.GraphQLQueriesMode
+	DiffHandlerMode    = server.DiffHandlerMode
+	QueryHandlerMode   = server.QueryHandlerMode
 )
 
 const (
@@ -34,6 +39,10 @@ const (
 	MetasHandlerEnabled    = server.MetasHandlerEnabled
 	GraphQLQueriesDisabled = server.GraphQLQueriesDisabled
 	GraphQLQueriesEnabled  = server.GraphQLQueriesEnabled
+	DiffHandlerDisabled    = server.DiffHandlerDisabled
+	DiffHandlerEnabled     = server.DiffHandlerEnabled
+	QueryHandlerDisabled   = server.QueryHandlerDisabled
+	QueryHandlerEnabled    = server.QueryHandlerEnabled
 )
 
 // LocalDirV1 is a storage Instance. When Storing a new FBC contained in
@@ -41,11 +50,26 @@ const (
 // it is copied to its final destination in RootDir/<catalogName>.jsonl. This is
 // done so that clients accessing the content stored in RootDir/<catalogName>.json1
 // have an atomic view of the content for a catalog.
+//
+// When the diff handler is enabled, the previously served content of a catalog
+// is kept in RootDir/.previous/<catalogName> when its content changes, along with
+// the diff between the previous and the current content.
+//
+// When the query handler is enabled, the content of the catalogs listed by
+// Catalogs can be queried at once.
+//
+// When FirstSeen is set, the content of a catalog is stored along with a blob
+// recording when each of its bundles was first seen, that is, since when the
+// bundle has been continuously part of the stored content, as recorded by FirstSeen.
 type LocalDirV1 struct {
 	RootDir              string
 	RootURL              *url.URL
 	EnableMetasHandler   MetasHandlerMode
 	EnableGraphQLQueries GraphQLQueriesMode
+	EnableDiffHandler    DiffHandlerMode
+	EnableQueryHandler   QueryHandlerMode
+	Catalogs             server.CatalogLister
+	FirstSeen            *FirstSeenRecorder
 
 	m sync.RWMutex
 	// this singleflight Group is used in `GetIndex()` to handle concurrent HTTP requests
@@ -66,12 +90,14 @@ var (
 )
 
 // NewLocalDirV1 creates a new LocalDirV1 storage instance
-func NewLocalDirV1(rootDir string, rootURL *url.URL, enableMetasHandler MetasHandlerMode, enableGraphQLQueries GraphQLQueriesMode) *LocalDirV1 {
+func NewLocalDirV1(rootDir string, rootURL *url.URL, enableMetasHandler MetasHandlerMode, enableGraphQLQueries GraphQLQueriesMode, enableDiffHandler DiffHandlerMode, enableQueryHandler QueryHandlerMode) *LocalDirV1 {
 	s := &LocalDirV1{
 		RootDir:              rootDir,
 		RootURL:              rootURL,
 		EnableMetasHandler:   enableMetasHandler,
 		EnableGraphQLQueries: enableGraphQLQueries,
+		EnableDiffHandler:    enableDiffHandler,
+		EnableQueryHandler:   enableQueryHandler,
 	}
 	if enableGraphQLQueries == GraphQLQueriesEnabled {
 		s.graphqlSvc = service.NewCachedGraphQLService()
@@ -79,7 +105,40 @@ func NewLocalDirV1(rootDir string, rootURL *url.URL, enableMetasHandler MetasHan
 	return s
 }
 
+// RegisterPersistedQueries registers each .graphql file at the root of fsys as a persisted
+// GraphQL query, named after the file name without its extension.
+func (s *LocalDirV1) RegisterPersistedQueries(fsys fs.FS) error {
+	return registerPersistedQueries(s.graphqlSvc, fsys)
+}
+
+func registerPersistedQueries(graphqlSvc service.GraphQLService, fsys fs.FS) error {
+	if graphqlSvc == nil {
+		return errors.New("GraphQL queries are not enabled")
+	}
+	files, err := fs.Glob(fsys, "*.graphql")
+	if err != nil {
+		return fmt.Errorf("error listing persisted queries: %w", err)
+	}
+	for _, file := range files {
+		query, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return fmt.Errorf("error reading persisted query %q: %w", file, err)
+		}
+		if err := graphqlSvc.RegisterPersistedQuery(strings.TrimSuffix(file, ".graphql"), string(query)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *LocalDirV1) Store(ctx context.Context, catalog string, fsys fs.FS) error {
+	// Recording when bundles were first seen calls the API server, which must not
+	// block serving catalogs.
+	firstSeen, err := recordFirstSeen(ctx, s.FirstSeen, catalog, fsys)
+	if err != nil {
+		return err
+	}
+
 	s.m.Lock()
 	defer s.m.Unlock()
 
@@ -99,54 +158,44 @@ func (s *LocalDirV1) Store(ctx context.Context, catalog string, fsys fs.FS) erro
 	}
 	defer os.RemoveAll(tmpCatalogDir)
 
-	storeMetaFuncs := []storeMetasFunc{storeCatalogData}
-	if s.EnableMetasHandler {
-		storeMetaFuncs = append(storeMetaFuncs, storeIndexData)
-	}
+	catalogDir := s.catalogDir(catalog)
 
-	eg, egCtx := errgroup.WithContext(ctx)
-	// Pre-allocate metaChans with correct capacity to avoid reallocation
-	metaChans := make([]chan *declcfg.Meta, 0, len(storeMetaFuncs))
+	// The diff is computed from the indexes of the previous and current content.
+	if err := stageCatalog(ctx, fsys, tmpCatalogDir, s.needsIndex(), firstSeen); err != nil {
+		return err
+	}
 
-	for range storeMetaFuncs {
-		metaChans = append(metaChans, make(chan *declcfg.Meta, 1))
+	rotate := false
+	if s.EnableDiffHandler {
+		if rotate, err = s.stageDiff(catalog, tmpCatalogDir); err != nil {
+			return fmt.Errorf("error computing catalog diff: %w", err)
+		}
 	}
-	for i, f := range storeMetaFuncs {
-		eg.Go(func() error {
-			return f(tmpCatalogDir, metaChans[i])
-		})
+
+	// The served content is moved aside rather than removed, so that it keeps
+	// being served if the staged content cannot be put in its place.
+	lastKnownGoodDir := tmpCatalogDir + "-served"
+	defer os.RemoveAll(lastKnownGoodDir)
+	hasServedContent, err := dirExists(catalogDir)
+	if err != nil {
+		return err
 	}
-	err = declcfg.WalkMetasFS(egCtx, fsys, func(path string, meta *declcfg.Meta, err error) error {
-		if err != nil {
+	if hasServedContent {
+		if err := os.Rename(catalogDir, lastKnownGoodDir); err != nil {
 			return err
 		}
-		for _, ch := range metaChans {
-			select {
-			case ch <- meta:
-			case <-egCtx.Done():
-				return egCtx.Err()
-			}
-		}
-		return nil
-	}, declcfg.WithConcurrency(1))
-	for _, ch := range metaChans {
-		close(ch)
 	}
-	if err != nil {
-		return fmt.Errorf("error walking FBC root: %w", err)
-	}
-
-	if err := eg.Wait(); err != nil {
-		return err
+	restore := func(storeErr error) error {
+		if !hasServedContent {
+			return storeErr
+		}
+		if err := errors.Join(os.RemoveAll(catalogDir), os.Rename(lastKnownGoodDir, catalogDir)); err != nil {
+			return fmt.Errorf("%w (restoring the previously served content also failed: %v)", storeErr, err)
+		}
+		return storeErr
 	}
-
-	catalogDir := s.catalogDir(catalog)
-	err = errors.Join(
-		os.RemoveAll(catalogDir),
-		os.Rename(tmpCatalogDir, catalogDir),
-	)
-	if err != nil {
-		return err
+	if err := os.Rename(tmpCatalogDir, catalogDir); err != nil {
+		return restore(err)
 	}
 
 	// Invalidate and pre-warm GraphQL schema cache if GraphQL service is enabled
@@ -157,18 +206,63 @@ func (s *LocalDirV1) Store(ctx context.Context, catalog string, fsys fs.FS) erro
 		// Use the actual catalog directory filesystem, not the input fsys
 		catalogFS := os.DirFS(catalogDir)
 		if _, err := s.graphqlSvc.GetSchema(catalog, catalogFS); err != nil {
-			// Schema build failed - rollback by removing the catalog directory
+			// Schema build failed - roll back to the previously served content
 			// to maintain consistency (don't persist catalog without valid schema)
-			if removeErr := os.RemoveAll(catalogDir); removeErr != nil {
-				return fmt.Errorf("failed to pre-build GraphQL schema for catalog %q: %w (rollback also failed: %v)", catalog, err, removeErr)
-			}
-			return fmt.Errorf("failed to pre-build GraphQL schema for catalog %q: %w", catalog, err)
+			s.graphqlSvc.InvalidateCache(catalog)
+			return restore(fmt.Errorf("failed to pre-build GraphQL schema for catalog %q: %w", catalog, err))
 		}
 	}
 
+	if rotate {
+		previousDir := s.previousCatalogDir(catalog)
+		if err := os.MkdirAll(filepath.Dir(previousDir), 0700); err != nil {
+			return err
+		}
+		return errors.Join(
+			os.RemoveAll(previousDir),
+			os.Rename(lastKnownGoodDir, previousDir),
+		)
+	}
 	return nil
 }
 
+// stageDiff stores the diff between the previous content of a catalog and the content
+// staged in tmpCatalogDir in tmpCatalogDir. The previous content is the currently served
+// content if the staged content differs from it, in which case stageDiff returns true to
+// signal that the served content must be kept as the previous content once the staged
+// content is in place.
+// This method must be called while the write lock is held.
+func (s *LocalDirV1) stageDiff(catalog, tmpCatalogDir string) (bool, error) {
+	catalogDir := s.catalogDir(catalog)
+
+	_, err := os.Stat(catalogFilePath(catalogDir))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return false, err
+	default:
+		same, err := sameCatalogData(catalogDir, tmpCatalogDir)
+		if err != nil {
+			return false, err
+		}
+		if !same {
+			return true, storeDiffData(catalogDir, tmpCatalogDir)
+		}
+	}
+	return false, storeDiffData(s.previousCatalogDir(catalog), tmpCatalogDir)
+}
+
+func dirExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
+
 // removeOrphanedTempDirs removes temporary staging directories that were created by a
 // previous Store call for the given catalog but were not cleaned up because the process
 // was interrupted (e.g. killed by the OOM killer) before the deferred RemoveAll could run.
@@ -202,7 +296,10 @@ func (s *LocalDirV1) Delete(catalog string) error {
 		s.graphqlSvc.InvalidateCache(catalog)
 	}
 
-	return os.RemoveAll(s.catalogDir(catalog))
+	return errors.Join(
+		os.RemoveAll(s.catalogDir(catalog)),
+		os.RemoveAll(s.previousCatalogDir(catalog)),
+	)
 }
 
 func (s *LocalDirV1) ContentExists(catalog string) bool {
@@ -218,7 +315,7 @@ func (s *LocalDirV1) ContentExists(catalog string) bool {
 		return false
 	}
 
-	if s.EnableMetasHandler {
+	if s.needsIndex() {
 		indexFileStat, err := os.Stat(catalogIndexFilePath(s.catalogDir(catalog)))
 		if err != nil {
 			return false
@@ -230,16 +327,138 @@ func (s *LocalDirV1) ContentExists(catalog string) bool {
 	return true
 }
 
+// needsIndex returns whether the enabled handlers look content up in the index of catalogs
+func (s *LocalDirV1) needsIndex() bool {
+	return bool(s.EnableMetasHandler) || bool(s.EnableDiffHandler) || bool(s.EnableQueryHandler)
+}
+
 func (s *LocalDirV1) catalogDir(catalog string) string {
 	return filepath.Join(s.RootDir, catalog)
 }
 
+// previousCatalogDir is where the previously served content of a catalog is kept.
+// Catalog names are DNS1123 subdomains, so they cannot collide with the ".previous" directory.
+func (s *LocalDirV1) previousCatalogDir(catalog string) string {
+	return filepath.Join(s.RootDir, ".previous", catalog)
+}
+
+// Names of the files stored for a catalog
+const (
+	catalogFileName      = "catalog.jsonl"
+	catalogIndexFileName = "index.json"
+	catalogDiffFileName  = "diff.json"
+)
+
 func catalogFilePath(catalogDir string) string {
-	return filepath.Join(catalogDir, "catalog.jsonl")
+	return filepath.Join(catalogDir, catalogFileName)
 }
 
 func catalogIndexFilePath(catalogDir string) string {
-	return filepath.Join(catalogDir, "index.json")
+	return filepath.Join(catalogDir, catalogIndexFileName)
+}
+
+func catalogDiffFilePath(catalogDir string) string {
+	return filepath.Join(catalogDir, catalogDiffFileName)
+}
+
+// recordFirstSeen returns when each bundle of the FBC contained in fsys was first seen,
+// as recorded by firstSeen for catalog, or nil when firstSeen is nil.
+func recordFirstSeen(ctx context.Context, firstSeen *FirstSeenRecorder, catalog string, fsys fs.FS) (map[bundleKey]time.Time, error) {
+	if firstSeen == nil {
+		return nil, nil
+	}
+	var bundles []bundleKey
+	err := declcfg.WalkMetasFS(ctx, fsys, func(path string, meta *declcfg.Meta, err error) error {
+		if err != nil {
+			return err
+		}
+		if meta.Schema == declcfg.SchemaBundle {
+			bundles = append(bundles, bundleKey{meta.Package, meta.Name})
+		}
+		return nil
+	}, declcfg.WithConcurrency(1))
+	if err != nil {
+		return nil, fmt.Errorf("error walking FBC root: %w", err)
+	}
+	return firstSeen.Record(ctx, catalog, bundles)
+}
+
+// stageCatalog writes the FBC contained in fsys to catalogDir, along with its index
+// when withIndex is set. Blobs recording when bundles were first seen are reserved to
+// catalogd, so they are left out. When firstSeen is not nil, a blob recording when
+// each bundle was first seen is written instead, as returned by recordFirstSeen.
+func stageCatalog(ctx context.Context, fsys fs.FS, catalogDir string, withIndex bool, firstSeen map[bundleKey]time.Time) error {
+	storeMetaFuncs := []storeMetasFunc{storeCatalogData}
+	if withIndex {
+		storeMetaFuncs = append(storeMetaFuncs, storeIndexData)
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	// Pre-allocate metaChans with correct capacity to avoid reallocation
+	metaChans := make([]chan *declcfg.Meta, 0, len(storeMetaFuncs))
+
+	for range storeMetaFuncs {
+		metaChans = append(metaChans, make(chan *declcfg.Meta, 1))
+	}
+	for i, f := range storeMetaFuncs {
+		eg.Go(func() error {
+			return f(catalogDir, metaChans[i])
+		})
+	}
+	send := func(meta *declcfg.Meta) error {
+		for _, ch := range metaChans {
+			select {
+			case ch <- meta:
+			case <-egCtx.Done():
+				return egCtx.Err()
+			}
+		}
+		return nil
+	}
+	var bundles []bundleKey
+	err := declcfg.WalkMetasFS(egCtx, fsys, func(path string, meta *declcfg.Meta, err error) error {
+		if err != nil {
+			return err
+		}
+		switch meta.Schema {
+		case firstseen.Schema:
+			return nil
+		case declcfg.SchemaBundle:
+			bundles = append(bundles, bundleKey{meta.Package, meta.Name})
+		}
+		return send(meta)
+	}, declcfg.WithConcurrency(1))
+	if err != nil {
+		err = fmt.Errorf("error walking FBC root: %w", err)
+	} else if firstSeen != nil {
+		err = sendFirstSeen(firstSeen, bundles, send)
+	}
+	for _, ch := range metaChans {
+		close(ch)
+	}
+	if err != nil {
+		return err
+	}
+
+	return eg.Wait()
+}
+
+// sendFirstSeen sends a blob recording when each of the bundles of a catalog was first seen
+func sendFirstSeen(firstSeen map[bundleKey]time.Time, bundles []bundleKey, send func(*declcfg.Meta) error) error {
+	for _, b := range bundles {
+		seen, ok := firstSeen[b]
+		if !ok {
+			return fmt.Errorf("no record of when bundle %q of package %q was first seen", b.name, b.pkg)
+		}
+		meta, err := firstseen.NewMeta(b.pkg, b.name, seen)
+		if err != nil {
+			return err
+		}
+		if err := send(meta); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 type storeMetasFunc func(catalogDir string, metaChan <-chan *declcfg.Meta) error
@@ -260,7 +479,7 @@ func storeCatalogData(catalogDir string, metas <-chan *declcfg.Meta) error {
 }
 
 func storeIndexData(catalogDir string, metas <-chan *declcfg.Meta) error {
-	idx := newIndex(metas)
+	idx := index.New(metas)
 
 	f, err := os.Create(catalogIndexFilePath(catalogDir))
 	if err != nil {
@@ -280,28 +499,44 @@ func (s *LocalDirV1) BaseURL(catalog string) string {
 // StorageServerHandler returns an HTTP handler for serving catalog content
 // This implements the Instance interface for backward compatibility
 func (s *LocalDirV1) StorageServerHandler() http.Handler {
-	handlers := server.NewCatalogHandlers(s, s.graphqlSvc, s.RootURL, s.EnableMetasHandler, s.EnableGraphQLQueries)
+	handlers := server.NewCatalogHandlers(s, s.graphqlSvc, s.RootURL, s.EnableMetasHandler, s.EnableGraphQLQueries, s.EnableDiffHandler).
+		WithQueryHandler(s.EnableQueryHandler, s.Catalogs)
 	return handlers.Handler()
 }
 
 // GetCatalogData returns the catalog file and its metadata
 // Implements server.CatalogStore interface
-func (s *LocalDirV1) GetCatalogData(catalog string) (*os.File, os.FileInfo, error) {
+func (s *LocalDirV1) GetCatalogData(catalog string) (server.CatalogFile, os.FileInfo, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	return openWithStat(catalogFilePath(s.catalogDir(catalog)))
+}
+
+// GetCatalogDiff returns the diff between the previous and current content of
+// the catalog, and its metadata. It returns fs.ErrNotExist if the content of the
+// catalog has not changed since the diff handler was enabled.
+// Implements server.CatalogStore interface
+func (s *LocalDirV1) GetCatalogDiff(catalog string) (server.CatalogFile, os.FileInfo, error) {
 	s.m.RLock()
 	defer s.m.RUnlock()
 
-	catalogFile, err := os.Open(catalogFilePath(s.catalogDir(catalog)))
+	return openWithStat(catalogDiffFilePath(s.catalogDir(catalog)))
+}
+
+func openWithStat(path string) (server.CatalogFile, os.FileInfo, error) {
+	f, err := os.Open(path)
 	if err != nil {
 		return nil, nil, err
 	}
-	catalogFileStat, err := catalogFile.Stat()
+	stat, err := f.Stat()
 	if err != nil {
-		if closeErr := catalogFile.Close(); closeErr != nil {
-			klog.ErrorS(closeErr, "failed to close catalog file after stat error")
+		if closeErr := f.Close(); closeErr != nil {
+			klog.ErrorS(closeErr, "failed to close file after stat error", "path", path)
 		}
 		return nil, nil, err
 	}
-	return catalogFile, catalogFileStat, nil
+	return f, stat, nil
 }
 
 // GetCatalogFS returns a filesystem interface for the catalog
@@ -331,19 +566,23 @@ func (s *LocalDirV1) GetIndex(catalog string) (server.Index, error) {
 	defer s.m.RUnlock()
 
 	idx, err, _ := s.sf.Do(catalog, func() (interface{}, error) {
-		indexFile, err := os.Open(catalogIndexFilePath(s.catalogDir(catalog)))
-		if err != nil {
-			return nil, err
-		}
-		defer indexFile.Close()
-		var idx index
-		if err := json.NewDecoder(indexFile).Decode(&idx); err != nil {
-			return nil, err
-		}
-		return &idx, nil
+		return loadIndex(catalogIndexFilePath(s.catalogDir(catalog)))
 	})
 	if err != nil {
 		return nil, err
 	}
-	return idx.(*index), nil
+	return idx.(*index.Index), nil
+}
+
+func loadIndex(path string) (*index.Index, error) {
+	indexFile, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer indexFile.Close()
+	var idx index.Index
+	if err := json.NewDecoder(indexFile).Decode(&idx); err != nil {
+		return nil, err
+	}
+	return &idx, nil
 }
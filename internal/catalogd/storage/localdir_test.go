@@ -3,6 +3,7 @@ package storage
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -16,11 +17,22 @@ import (
 	"sync"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	gql "github.com/operator-framework/operator-controller/internal/catalogd/graphql"
+	"github.com/operator-framework/operator-controller/internal/catalogd/service"
+	"github.com/operator-framework/operator-controller/internal/shared/firstseen"
 )
 
 const urlPrefix = "/catalogs/"
@@ -40,6 +52,8 @@ func TestLocalDirStorage(t *testing.T) {
 					&url.URL{Scheme: "http", Host: "test-addr", Path: urlPrefix},
 					MetasHandlerDisabled,
 					GraphQLQueriesDisabled,
+					DiffHandlerDisabled,
+					QueryHandlerDisabled,
 				)
 				return s, createTestFS(t)
 			},
@@ -80,6 +94,8 @@ func TestLocalDirStorage(t *testing.T) {
 					nil,
 					MetasHandlerEnabled,
 					GraphQLQueriesDisabled,
+					DiffHandlerDisabled,
+					QueryHandlerDisabled,
 				)
 				return s, createTestFS(t)
 			},
@@ -104,7 +120,7 @@ func TestLocalDirStorage(t *testing.T) {
 			name: "concurrent reads during write should not cause data race",
 			setup: func(t *testing.T) (*LocalDirV1, fs.FS) {
 				dir := t.TempDir()
-				s := NewLocalDirV1(dir, nil, MetasHandlerDisabled, GraphQLQueriesDisabled)
+				s := NewLocalDirV1(dir, nil, MetasHandlerDisabled, GraphQLQueriesDisabled, DiffHandlerDisabled, QueryHandlerDisabled)
 				return s, createTestFS(t)
 			},
 			test: func(t *testing.T, s *LocalDirV1, fsys fs.FS) {
@@ -134,7 +150,7 @@ func TestLocalDirStorage(t *testing.T) {
 		{
 			name: "delete nonexistent catalog",
 			setup: func(t *testing.T) (*LocalDirV1, fs.FS) {
-				return NewLocalDirV1(t.TempDir(), nil, MetasHandlerDisabled, GraphQLQueriesDisabled), nil
+				return NewLocalDirV1(t.TempDir(), nil, MetasHandlerDisabled, GraphQLQueriesDisabled, DiffHandlerDisabled, QueryHandlerDisabled), nil
 			},
 			test: func(t *testing.T, s *LocalDirV1, _ fs.FS) {
 				err := s.Delete("nonexistent")
@@ -208,6 +224,46 @@ func TestLocalDirStorage(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "failed store keeps serving the previous content",
+			setup: func(t *testing.T) (*LocalDirV1, fs.FS) {
+				s := NewLocalDirV1(t.TempDir(), nil, MetasHandlerEnabled, GraphQLQueriesEnabled, DiffHandlerEnabled, QueryHandlerDisabled)
+				s.graphqlSvc = &failingGraphQLService{GraphQLService: s.graphqlSvc}
+				return s, createTestFS(t)
+			},
+			test: func(t *testing.T, s *LocalDirV1, fsys fs.FS) {
+				const catalog = "test-catalog"
+				require.NoError(t, s.Store(context.Background(), catalog, fsys))
+				served, err := os.ReadFile(catalogFilePath(s.catalogDir(catalog)))
+				require.NoError(t, err)
+
+				updated := createTestFS(t).(*fstest.MapFS)
+				(*updated)["update.yaml"] = &fstest.MapFile{Data: []byte(`{"schema":"olm.package","name":"other_operator"}`)}
+				s.graphqlSvc.(*failingGraphQLService).fail = true
+				require.ErrorContains(t, s.Store(context.Background(), catalog, updated), "failed to pre-build GraphQL schema")
+
+				require.True(t, s.ContentExists(catalog))
+				content, err := os.ReadFile(catalogFilePath(s.catalogDir(catalog)))
+				require.NoError(t, err)
+				require.Equal(t, string(served), string(content))
+				_, err = os.Stat(s.previousCatalogDir(catalog))
+				require.ErrorIs(t, err, fs.ErrNotExist, "the served content must not be kept as the previous content")
+				entries, err := os.ReadDir(s.RootDir)
+				require.NoError(t, err)
+				for _, entry := range entries {
+					require.False(t, strings.HasPrefix(entry.Name(), "."+catalog+"-"), "unexpected staging directory %q", entry.Name())
+				}
+
+				s.graphqlSvc.(*failingGraphQLService).fail = false
+				require.NoError(t, s.Store(context.Background(), catalog, updated))
+				content, err = os.ReadFile(catalogFilePath(s.catalogDir(catalog)))
+				require.NoError(t, err)
+				require.Contains(t, string(content), "other_operator")
+				previous, err := os.ReadFile(catalogFilePath(s.previousCatalogDir(catalog)))
+				require.NoError(t, err)
+				require.Equal(t, string(served), string(previous))
+			},
+		},
 		{
 			name: "store with invalid permissions",
 			setup: func(t *testing.T) (*LocalDirV1, fs.FS) {
@@ -216,7 +272,7 @@ func TestLocalDirStorage(t *testing.T) {
 				if err := os.Chmod(dir, 0000); err != nil {
 					t.Fatal(err)
 				}
-				return NewLocalDirV1(dir, nil, MetasHandlerDisabled, GraphQLQueriesDisabled), createTestFS(t)
+				return NewLocalDirV1(dir, nil, MetasHandlerDisabled, GraphQLQueriesDisabled, DiffHandlerDisabled, QueryHandlerDisabled), createTestFS(t)
 			},
 			test: func(t *testing.T, s *LocalDirV1, fsys fs.FS) {
 				err := s.Store(context.Background(), "test-catalog", fsys)
@@ -243,7 +299,7 @@ func TestLocalDirStorage(t *testing.T) {
 }
 
 func TestLocalDirServerHandler(t *testing.T) {
-	store := NewLocalDirV1(t.TempDir(), &url.URL{Path: urlPrefix}, MetasHandlerDisabled, GraphQLQueriesDisabled)
+	store := NewLocalDirV1(t.TempDir(), &url.URL{Path: urlPrefix}, MetasHandlerDisabled, GraphQLQueriesDisabled, DiffHandlerDisabled, QueryHandlerDisabled)
 	if store.Store(context.Background(), "test-catalog", createTestFS(t)) != nil {
 		t.Fatal("failed to store test catalog and start server")
 	}
@@ -349,6 +405,8 @@ func TestMetasEndpoint(t *testing.T) {
 		&url.URL{Path: urlPrefix},
 		MetasHandlerEnabled,
 		GraphQLQueriesDisabled,
+		DiffHandlerDisabled,
+		QueryHandlerDisabled,
 	)
 	if store.Store(context.Background(), "test-catalog", createTestFS(t)) != nil {
 		t.Fatal("failed to store test catalog")
@@ -426,6 +484,31 @@ func TestMetasEndpoint(t *testing.T) {
 			expectedStatusCode: http.StatusNotModified,
 			expectedContent:    "",
 		},
+		{
+			name:        "cached response with If-None-Match",
+			queryParams: "?package=webhook_operator_test",
+			initRequest: func(req *http.Request) error {
+				resp, err := http.DefaultClient.Do(req)
+				if err != nil {
+					return err
+				}
+				resp.Body.Close()
+				req.Header.Set("If-None-Match", resp.Header.Get("Etag"))
+				return nil
+			},
+			expectedStatusCode: http.StatusNotModified,
+			expectedContent:    "",
+		},
+		{
+			name:        "request with a stale If-None-Match",
+			queryParams: "?schema=olm.package",
+			initRequest: func(req *http.Request) error {
+				req.Header.Set("If-None-Match", `W/"stale"`)
+				return nil
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedContent:    `{"defaultChannel":"preview_test","name":"webhook_operator_test","schema":"olm.package"}`,
+		},
 		{
 			name:               "request with unknown parameters",
 			queryParams:        "?non-existent=foo",
@@ -488,12 +571,323 @@ func TestMetasEndpoint(t *testing.T) {
 	}
 }
 
+func TestDiffEndpoint(t *testing.T) {
+	store := NewLocalDirV1(
+		t.TempDir(),
+		&url.URL{Path: urlPrefix},
+		MetasHandlerDisabled,
+		GraphQLQueriesDisabled,
+		DiffHandlerEnabled,
+		QueryHandlerDisabled,
+	)
+	testServer := httptest.NewServer(store.StorageServerHandler())
+	defer testServer.Close()
+	diffURL := fmt.Sprintf("%s/catalogs/test-catalog/api/v1/diff", testServer.URL)
+	getDiff := func(t *testing.T) (int, string) {
+		resp, err := http.Get(diffURL) //nolint:gosec
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	t.Log("By checking there is no diff before the content changes")
+	require.NoError(t, store.Store(context.Background(), "test-catalog", createTestFS(t)))
+	code, _ := getDiff(t)
+	require.Equal(t, http.StatusNotFound, code)
+	require.NoError(t, store.Store(context.Background(), "test-catalog", createTestFS(t)))
+	code, _ = getDiff(t)
+	require.Equal(t, http.StatusNotFound, code)
+
+	t.Log("By checking the diff reports the changes to the content")
+	updated := createTestFS(t).(*fstest.MapFS)
+	(*updated)["update.yaml"] = &fstest.MapFile{Data: []byte(
+		generateJSONLinesOrFail(t, []byte(`---
+schema: olm.bundle
+name: bundle.v0.0.2
+package: webhook_operator_test
+image: quaydock.io/namespace/bundle:0.0.4
+`)) + generateJSONLinesOrFail(t, []byte(`---
+schema: olm.channel
+package: webhook_operator_test
+name: stable
+entries:
+  - name: bundle.v0.0.2
+`)) + generateJSONLinesOrFail(t, []byte(`---
+schema: olm.deprecations
+package: webhook_operator_test
+entries:
+  - reference:
+      schema: olm.bundle
+      name: bundle.v0.0.1
+    message: bundle.v0.0.1 is deprecated
+`)) + generateJSONLinesOrFail(t, []byte(`---
+schema: olm.package
+name: other_operator
+`))),
+		Mode: os.ModePerm,
+	}
+	require.NoError(t, store.Store(context.Background(), "test-catalog", updated))
+	code, body := getDiff(t)
+	require.Equal(t, http.StatusOK, code)
+	var diff CatalogDiff
+	require.NoError(t, json.Unmarshal([]byte(body), &diff))
+	require.Equal(t, []PackageDiff{
+		{Name: "other_operator", Type: PackageAdded},
+		{
+			Name:          "webhook_operator_test",
+			Type:          PackageModified,
+			AddedBundles:  []string{"bundle.v0.0.2"},
+			AddedChannels: []string{"stable"},
+			AddedDeprecations: []declcfg.DeprecationEntry{{
+				Reference: declcfg.PackageScopedReference{Schema: declcfg.SchemaBundle, Name: "bundle.v0.0.1"},
+				Message:   "bundle.v0.0.1 is deprecated",
+			}},
+		},
+	}, diff.Packages)
+
+	t.Log("By checking the previous content is not replaced when the content does not change")
+	require.NoError(t, store.Store(context.Background(), "test-catalog", updated))
+	code, body = getDiff(t)
+	require.Equal(t, http.StatusOK, code)
+	var unchanged CatalogDiff
+	require.NoError(t, json.Unmarshal([]byte(body), &unchanged))
+	require.Equal(t, diff.Packages, unchanged.Packages)
+
+	t.Log("By checking the diff is removed with the catalog")
+	require.NoError(t, store.Delete("test-catalog"))
+	_, err := os.Stat(store.previousCatalogDir("test-catalog"))
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestFirstSeen(t *testing.T) {
+	store := NewLocalDirV1(
+		t.TempDir(),
+		&url.URL{Path: urlPrefix},
+		MetasHandlerEnabled,
+		GraphQLQueriesDisabled,
+		DiffHandlerDisabled,
+		QueryHandlerDisabled,
+	)
+	recorder, clk := newTestFirstSeenRecorder(t, interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if !store.m.TryRLock() {
+				t.Error("catalogs cannot be served while the records of when bundles were first seen are read")
+			} else {
+				store.m.RUnlock()
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}, "test-catalog")
+	store.FirstSeen = recorder
+	testServer := httptest.NewServer(store.StorageServerHandler())
+	defer testServer.Close()
+	getFirstSeen := func(t *testing.T) map[string]time.Time {
+		resp, err := http.Get(fmt.Sprintf("%s/catalogs/test-catalog/api/v1/metas?schema=%s", testServer.URL, firstseen.Schema)) //nolint:gosec
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var metas []declcfg.Meta
+		require.NoError(t, declcfg.WalkMetasReader(resp.Body, func(meta *declcfg.Meta, err error) error {
+			if err != nil {
+				return err
+			}
+			metas = append(metas, *meta)
+			return nil
+		}))
+		times, err := firstseen.FromMetas(metas)
+		require.NoError(t, err)
+		return times
+	}
+	withFiles := func(files map[string]string) fs.FS {
+		fsys := createTestFS(t).(*fstest.MapFS)
+		for name, data := range files {
+			(*fsys)[name] = &fstest.MapFile{Data: []byte(generateJSONLinesOrFail(t, []byte(data))), Mode: os.ModePerm}
+		}
+		return fsys
+	}
+	newBundle := `---
+schema: olm.bundle
+name: bundle.v0.0.2
+package: webhook_operator_test
+image: quaydock.io/namespace/bundle:0.0.4
+`
+
+	t.Log("By checking the bundles are recorded as first seen when they are stored")
+	require.NoError(t, store.Store(context.Background(), "test-catalog", createTestFS(t)))
+	firstSeen := getFirstSeen(t)
+	require.Equal(t, map[string]time.Time{"bundle.v0.0.1": clk.Now()}, firstSeen)
+
+	t.Log("By checking the time bundles were first seen is kept when new content is stored")
+	clk.Step(time.Hour)
+	require.NoError(t, store.Store(context.Background(), "test-catalog", withFiles(map[string]string{"new.yaml": newBundle})))
+	updated := getFirstSeen(t)
+	require.Equal(t, map[string]time.Time{"bundle.v0.0.1": firstSeen["bundle.v0.0.1"], "bundle.v0.0.2": clk.Now()}, updated)
+
+	t.Log("By checking the records outlive the stored content")
+	clk.Step(time.Hour)
+	restarted := NewLocalDirV1(t.TempDir(), &url.URL{Path: urlPrefix}, MetasHandlerEnabled, GraphQLQueriesDisabled, DiffHandlerDisabled, QueryHandlerDisabled)
+	restarted.FirstSeen = recorder
+	require.NoError(t, restarted.Store(context.Background(), "test-catalog", withFiles(map[string]string{"new.yaml": newBundle})))
+	catalogFile, _, err := restarted.GetCatalogData("test-catalog")
+	require.NoError(t, err)
+	defer catalogFile.Close()
+	require.Equal(t, updated["bundle.v0.0.1"], readFirstSeen(t, catalogFile)[bundleKey{"webhook_operator_test", "bundle.v0.0.1"}])
+
+	t.Log("By checking the times recorded by the catalog source are ignored")
+	require.NoError(t, store.Store(context.Background(), "test-catalog", withFiles(map[string]string{
+		"new.yaml": newBundle,
+		"spoofed.yaml": fmt.Sprintf(`---
+schema: %s
+package: webhook_operator_test
+name: bundle.v0.0.2
+firstSeen: "2000-01-01T00:00:00Z"
+`, firstseen.Schema),
+	})))
+	require.Equal(t, updated, getFirstSeen(t))
+
+	t.Log("By checking a bundle removed from the catalog is seen again when it comes back")
+	require.NoError(t, store.Store(context.Background(), "test-catalog", createTestFS(t)))
+	require.Len(t, getFirstSeen(t), 1)
+	require.NoError(t, store.Store(context.Background(), "test-catalog", withFiles(map[string]string{"new.yaml": newBundle})))
+	require.Equal(t, clk.Now(), getFirstSeen(t)["bundle.v0.0.2"])
+}
+
+// readFirstSeen returns when each bundle was first seen, as recorded in a catalog file
+func readFirstSeen(t *testing.T, catalogFile io.ReadSeeker) map[bundleKey]time.Time {
+	t.Helper()
+	_, err := catalogFile.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	firstSeen := map[bundleKey]time.Time{}
+	require.NoError(t, declcfg.WalkMetasReader(catalogFile, func(meta *declcfg.Meta, err error) error {
+		if err != nil || meta.Schema != firstseen.Schema {
+			return err
+		}
+		var r firstseen.Record
+		if err := json.Unmarshal(meta.Blob, &r); err != nil {
+			return err
+		}
+		firstSeen[bundleKey{r.Package, r.Name}] = r.FirstSeen
+		return nil
+	}))
+	return firstSeen
+}
+
+func TestQueryEndpoint(t *testing.T) {
+	store := NewLocalDirV1(
+		t.TempDir(),
+		&url.URL{Path: urlPrefix},
+		MetasHandlerDisabled,
+		GraphQLQueriesDisabled,
+		DiffHandlerDisabled,
+		QueryHandlerEnabled,
+	)
+	scheme := runtime.NewScheme()
+	require.NoError(t, ocv1.AddToScheme(scheme))
+	newCatalog := func(name string, priority int32, availabilityMode ocv1.AvailabilityMode) *ocv1.ClusterCatalog {
+		return &ocv1.ClusterCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       ocv1.ClusterCatalogSpec{Priority: priority, AvailabilityMode: availabilityMode},
+		}
+	}
+	store.Catalogs = &ClusterCatalogLister{Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newCatalog("catalog-a", 0, ocv1.AvailabilityModeAvailable),
+		newCatalog("catalog-b", 0, ocv1.AvailabilityModeAvailable),
+		newCatalog("catalog-c", 100, ocv1.AvailabilityModeAvailable),
+		newCatalog("unavailable", 200, ocv1.AvailabilityModeUnavailable),
+		newCatalog("not-unpacked", 0, ocv1.AvailabilityModeAvailable),
+	).Build()}
+	for _, catalog := range []string{"catalog-a", "catalog-b", "catalog-c", "unavailable", "not-listed"} {
+		require.NoError(t, store.Store(context.Background(), catalog, createTestFS(t)))
+	}
+	testServer := httptest.NewServer(store.StorageServerHandler())
+	defer testServer.Close()
+
+	t.Log("By checking results are annotated with their catalog, ordered by priority")
+	resp, err := http.Get(fmt.Sprintf("%s/catalogs/api/v1/metas?schema=olm.package", testServer.URL)) //nolint:gosec
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/jsonl", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	pkg := `{"defaultChannel":"preview_test","name":"webhook_operator_test","schema":"olm.package"}`
+	require.Equal(t, strings.Join([]string{
+		`{"catalog":"catalog-c","priority":100,"meta":` + pkg + `}`,
+		`{"catalog":"catalog-a","priority":0,"meta":` + pkg + `}`,
+		`{"catalog":"catalog-b","priority":0,"meta":` + pkg + `}`,
+	}, "\n")+"\n", string(body))
+
+	t.Log("By checking HEAD requests have no body")
+	resp, err = http.Head(fmt.Sprintf("%s/catalogs/api/v1/metas?schema=olm.package", testServer.URL)) //nolint:gosec
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/jsonl", resp.Header.Get("Content-Type"))
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Empty(t, body)
+
+	t.Log("By checking invalid queries are rejected")
+	for _, query := range []string{"", "?schema=olm.package&catalog=catalog-a"} {
+		resp, err := http.Get(fmt.Sprintf("%s/catalogs/api/v1/metas%s", testServer.URL, query)) //nolint:gosec
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	t.Log("By checking the endpoint is not served when the query handler is disabled")
+	disabled := NewLocalDirV1(store.RootDir, &url.URL{Path: urlPrefix}, MetasHandlerDisabled, GraphQLQueriesDisabled, DiffHandlerDisabled, QueryHandlerDisabled)
+	disabledServer := httptest.NewServer(disabled.StorageServerHandler())
+	defer disabledServer.Close()
+	resp, err = http.Get(fmt.Sprintf("%s/catalogs/api/v1/metas?schema=olm.package", disabledServer.URL)) //nolint:gosec
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestGraphQLPersistedQueries(t *testing.T) {
+	store := NewLocalDirV1(
+		t.TempDir(),
+		&url.URL{Path: urlPrefix},
+		MetasHandlerDisabled,
+		GraphQLQueriesEnabled,
+		DiffHandlerDisabled,
+		QueryHandlerDisabled,
+	)
+	require.NoError(t, store.RegisterPersistedQueries(fstest.MapFS{
+		"packages.graphql": &fstest.MapFile{Data: []byte(`query Packages($limit: Int) { olmpackages(limit: $limit) { name } }`)},
+		"README.md":        &fstest.MapFile{Data: []byte(`not a query`)},
+	}))
+	require.NoError(t, store.Store(context.Background(), "test-catalog", createTestFS(t)))
+
+	testServer := httptest.NewServer(store.StorageServerHandler())
+	defer testServer.Close()
+
+	params := url.Values{"documentId": {"packages"}, "variables": {`{"limit": 1}`}}
+	resp, err := http.Get(fmt.Sprintf("%s/catalogs/test-catalog/api/v1/graphql?%s", testServer.URL, params.Encode())) //nolint:gosec
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotEmpty(t, resp.Header.Get("Last-Modified"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data": {"olmpackages": [{"name": "webhook_operator_test"}]}}`, string(body))
+
+	t.Log("By checking persisted queries cannot be registered without GraphQL queries enabled")
+	disabled := NewLocalDirV1(t.TempDir(), &url.URL{Path: urlPrefix}, MetasHandlerDisabled, GraphQLQueriesDisabled, DiffHandlerDisabled, QueryHandlerDisabled)
+	require.Error(t, disabled.RegisterPersistedQueries(fstest.MapFS{}))
+}
+
 func TestServerLoadHandling(t *testing.T) {
 	store := NewLocalDirV1(
 		t.TempDir(),
 		&url.URL{Path: urlPrefix},
 		MetasHandlerEnabled,
 		GraphQLQueriesDisabled,
+		DiffHandlerDisabled,
+		QueryHandlerDisabled,
 	)
 
 	// Create large test data
@@ -626,6 +1020,19 @@ func TestServerLoadHandling(t *testing.T) {
 	}
 }
 
+// failingGraphQLService fails to build the schema of catalogs when fail is set.
+type failingGraphQLService struct {
+	service.GraphQLService
+	fail bool
+}
+
+func (f *failingGraphQLService) GetSchema(catalog string, catalogFS fs.FS) (*gql.DynamicSchema, error) {
+	if f.fail {
+		return nil, errors.New("schema build error")
+	}
+	return f.GraphQLService.GetSchema(catalog, catalogFS)
+}
+
 func createTestFS(t *testing.T) fs.FS {
 	t.Helper()
 	testBundleTemplate := `---
@@ -0,0 +1,632 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/operator-framework/operator-controller/internal/catalogd/index"
+	"github.com/operator-framework/operator-controller/internal/catalogd/server"
+	"github.com/operator-framework/operator-controller/internal/catalogd/service"
+)
+
+// currentObjectName is the name of the object holding the digest of the content
+// currently served for a catalog.
+const currentObjectName = "current"
+
+// DefaultS3RequestTimeout is the default RequestTimeout of S3V1
+const DefaultS3RequestTimeout = 30 * time.Second
+
+// S3V1 is a storage Instance storing the content of catalogs in a bucket of an
+// S3-compatible object storage, so that the content outlives catalogd pods and is
+// shared by all the replicas. The content is served by catalogd from the bucket.
+//
+// Each version of the content of a catalog is stored under
+// Prefix/<catalogName>/<digest>/, where digest is the sha256 digest of its
+// catalog.jsonl, and Prefix/<catalogName>/current holds the digest of the version
+// being served. The content is staged in StagingDir before it is uploaded, and the
+// current object is only updated once all the objects of a version are uploaded, so
+// that clients have an atomic view of the content for a catalog. The previously
+// served version is kept until the next one is stored, so that in-flight requests
+// can complete.
+//
+// When FirstSeen is set, the content of a catalog is stored along with a blob
+// recording when each of its bundles was first seen, as in LocalDirV1.
+//
+// The requests made to the bucket on behalf of the methods that are not given a
+// context are bounded by RequestTimeout. The objects served to clients are read for as
+// long as they are served, and the requests reading them are cancelled once closed.
+type S3V1 struct {
+	Client               *minio.Client
+	Bucket               string
+	Prefix               string
+	StagingDir           string
+	RootURL              *url.URL
+	EnableMetasHandler   MetasHandlerMode
+	EnableGraphQLQueries GraphQLQueriesMode
+	EnableDiffHandler    DiffHandlerMode
+	EnableQueryHandler   QueryHandlerMode
+	Catalogs             server.CatalogLister
+	RequestTimeout       time.Duration
+	FirstSeen            *FirstSeenRecorder
+
+	// m serializes the changes made to the bucket by this instance. Objects are
+	// never modified once uploaded, so reads do not need to hold it.
+	m sync.Mutex
+	// sf loads the index of a version of a catalog once per concurrent group of
+	// requests, as in LocalDirV1.
+	sf        singleflight.Group
+	indexesMu sync.Mutex
+	indexes   map[string]loadedIndex
+
+	// GraphQL service for handling schema generation and caching
+	graphqlSvc service.GraphQLService
+	// schemaDigests holds the version of each catalog its cached GraphQL schema is
+	// built from, as replicas other than the one storing a new version must
+	// invalidate the schema they built from the previous version.
+	schemaDigestsMu sync.Mutex
+	schemaDigests   map[string]string
+}
+
+// loadedIndex is the index of a version of the content of a catalog
+type loadedIndex struct {
+	digest string
+	idx    *index.Index
+}
+
+var (
+	_ Instance            = (*S3V1)(nil)
+	_ server.CatalogStore = (*S3V1)(nil)
+)
+
+// NewS3V1 creates a new S3V1 storage instance
+func NewS3V1(client *minio.Client, bucket, prefix, stagingDir string, rootURL *url.URL, enableMetasHandler MetasHandlerMode, enableGraphQLQueries GraphQLQueriesMode, enableDiffHandler DiffHandlerMode, enableQueryHandler QueryHandlerMode) *S3V1 {
+	s := &S3V1{
+		Client:               client,
+		Bucket:               bucket,
+		Prefix:               prefix,
+		StagingDir:           stagingDir,
+		RootURL:              rootURL,
+		EnableMetasHandler:   enableMetasHandler,
+		EnableGraphQLQueries: enableGraphQLQueries,
+		EnableDiffHandler:    enableDiffHandler,
+		EnableQueryHandler:   enableQueryHandler,
+		RequestTimeout:       DefaultS3RequestTimeout,
+		indexes:              make(map[string]loadedIndex),
+		schemaDigests:        make(map[string]string),
+	}
+	if enableGraphQLQueries == GraphQLQueriesEnabled {
+		s.graphqlSvc = service.NewCachedGraphQLService()
+	}
+	return s
+}
+
+// RegisterPersistedQueries registers each .graphql file at the root of fsys as a persisted
+// GraphQL query, named after the file name without its extension.
+func (s *S3V1) RegisterPersistedQueries(fsys fs.FS) error {
+	return registerPersistedQueries(s.graphqlSvc, fsys)
+}
+
+func (s *S3V1) Store(ctx context.Context, catalog string, fsys fs.FS) error {
+	// Recording when bundles were first seen calls the API server, which must not
+	// block serving catalogs.
+	firstSeen, err := recordFirstSeen(ctx, s.FirstSeen, catalog, fsys)
+	if err != nil {
+		return err
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	tmpCatalogDir, err := os.MkdirTemp(s.StagingDir, fmt.Sprintf(".%s-*", catalog))
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpCatalogDir)
+
+	if err := stageCatalog(ctx, fsys, tmpCatalogDir, s.needsIndex(), firstSeen); err != nil {
+		return err
+	}
+	digest, err := fileDigest(catalogFilePath(tmpCatalogDir))
+	if err != nil {
+		return err
+	}
+
+	// Build the GraphQL schema before uploading, so that content without a valid
+	// schema is never served.
+	if s.graphqlSvc != nil {
+		s.schemaDigestsMu.Lock()
+		s.graphqlSvc.InvalidateCache(catalog)
+		_, err := s.graphqlSvc.GetSchema(catalog, os.DirFS(tmpCatalogDir))
+		if err != nil {
+			s.graphqlSvc.InvalidateCache(catalog)
+			delete(s.schemaDigests, catalog)
+		} else {
+			s.schemaDigests[catalog] = digest
+		}
+		s.schemaDigestsMu.Unlock()
+		if err != nil {
+			return fmt.Errorf("failed to pre-build GraphQL schema for catalog %q: %w", catalog, err)
+		}
+	}
+
+	current, err := s.currentDigest(ctx, catalog)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if current == digest && s.versionExists(ctx, catalog, digest) {
+		return nil
+	}
+
+	if s.EnableDiffHandler && current != "" && current != digest {
+		if err := s.storeDiff(ctx, catalog, current, tmpCatalogDir); err != nil {
+			return fmt.Errorf("error computing catalog diff: %w", err)
+		}
+	}
+	if err := s.upload(ctx, catalog, digest, tmpCatalogDir); err != nil {
+		return err
+	}
+	if err := s.putObject(ctx, s.objectKey(catalog, currentObjectName), strings.NewReader(digest), int64(len(digest))); err != nil {
+		return err
+	}
+
+	// Keep the previous version, which may still be read by in-flight requests.
+	return s.removeObjects(ctx, catalog, func(key string) bool {
+		version := s.versionOf(catalog, key)
+		return version != "" && version != currentObjectName && version != digest && version != current
+	})
+}
+
+// storeDiff stores the diff between the current version of a catalog and the content
+// staged in tmpCatalogDir in tmpCatalogDir.
+func (s *S3V1) storeDiff(ctx context.Context, catalog, current, tmpCatalogDir string) error {
+	previousDir, err := os.MkdirTemp(s.StagingDir, fmt.Sprintf(".%s-*", catalog))
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(previousDir)
+
+	for _, name := range []string{catalogFileName, catalogIndexFileName} {
+		err := s.Client.FGetObject(ctx, s.Bucket, s.objectKey(catalog, current, name), filepath.Join(previousDir, name), minio.GetObjectOptions{})
+		if isNoSuchKey(err) {
+			// The previous version was stored without an index
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return storeDiffData(previousDir, tmpCatalogDir)
+}
+
+// upload uploads the files of the content staged in tmpCatalogDir as the given version of a catalog
+func (s *S3V1) upload(ctx context.Context, catalog, digest, tmpCatalogDir string) error {
+	entries, err := os.ReadDir(tmpCatalogDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		_, err := s.Client.FPutObject(ctx, s.Bucket, s.objectKey(catalog, digest, entry.Name()), filepath.Join(tmpCatalogDir, entry.Name()), minio.PutObjectOptions{
+			ContentType:      contentTypeOf(entry.Name()),
+			DisableMultipart: true,
+		})
+		if err != nil {
+			return fmt.Errorf("error uploading %q of catalog %q: %w", entry.Name(), catalog, err)
+		}
+	}
+	return nil
+}
+
+func (s *S3V1) Delete(catalog string) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	// Invalidate GraphQL cache if service is enabled
+	if s.graphqlSvc != nil {
+		s.schemaDigestsMu.Lock()
+		s.graphqlSvc.InvalidateCache(catalog)
+		delete(s.schemaDigests, catalog)
+		s.schemaDigestsMu.Unlock()
+	}
+
+	s.indexesMu.Lock()
+	delete(s.indexes, catalog)
+	s.indexesMu.Unlock()
+
+	ctx, cancel := s.requestContext()
+	defer cancel()
+	return s.removeObjects(ctx, catalog, func(string) bool { return true })
+}
+
+// requestContext returns the context of the requests made to the bucket on behalf of a
+// method that is not given a context, bounded by RequestTimeout.
+func (s *S3V1) requestContext() (context.Context, context.CancelFunc) {
+	if s.RequestTimeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), s.RequestTimeout)
+}
+
+// removeObjects removes the objects of a catalog whose key matches
+func (s *S3V1) removeObjects(ctx context.Context, catalog string, matches func(key string) bool) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var errs []error
+	for object := range s.Client.ListObjects(ctx, s.Bucket, minio.ListObjectsOptions{Prefix: s.catalogPrefix(catalog), Recursive: true}) {
+		if object.Err != nil {
+			return object.Err
+		}
+		if !matches(object.Key) {
+			continue
+		}
+		if err := s.Client.RemoveObject(ctx, s.Bucket, object.Key, minio.RemoveObjectOptions{}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *S3V1) ContentExists(catalog string) bool {
+	ctx, cancel := s.requestContext()
+	defer cancel()
+	digest, err := s.currentDigest(ctx, catalog)
+	if err != nil {
+		return false
+	}
+	return s.versionExists(ctx, catalog, digest)
+}
+
+// versionExists returns whether all the objects needed to serve a version of a catalog exist
+func (s *S3V1) versionExists(ctx context.Context, catalog, digest string) bool {
+	names := []string{catalogFileName}
+	if s.needsIndex() {
+		names = append(names, catalogIndexFileName)
+	}
+	for _, name := range names {
+		if _, err := s.Client.StatObject(ctx, s.Bucket, s.objectKey(catalog, digest, name), minio.StatObjectOptions{}); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// needsIndex returns whether the enabled handlers look content up in the index of catalogs
+func (s *S3V1) needsIndex() bool {
+	return bool(s.EnableMetasHandler) || bool(s.EnableDiffHandler) || bool(s.EnableQueryHandler)
+}
+
+func (s *S3V1) catalogPrefix(catalog string) string {
+	return path.Join(s.Prefix, catalog) + "/"
+}
+
+func (s *S3V1) objectKey(catalog string, elem ...string) string {
+	return path.Join(append([]string{s.Prefix, catalog}, elem...)...)
+}
+
+// versionOf returns the version of the content of a catalog the object with the given key
+// belongs to, or currentObjectName for the current object.
+func (s *S3V1) versionOf(catalog, key string) string {
+	rest := strings.TrimPrefix(key, s.catalogPrefix(catalog))
+	version, _, _ := strings.Cut(rest, "/")
+	return version
+}
+
+// currentDigest returns the digest of the version of a catalog being served. It returns
+// fs.ErrNotExist if no content is stored for the catalog.
+func (s *S3V1) currentDigest(ctx context.Context, catalog string) (string, error) {
+	obj, err := s.Client.GetObject(ctx, s.Bucket, s.objectKey(catalog, currentObjectName), minio.GetObjectOptions{})
+	if err != nil {
+		return "", err
+	}
+	defer obj.Close()
+	digest, err := io.ReadAll(obj)
+	if isNoSuchKey(err) {
+		return "", fs.ErrNotExist
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(digest), nil
+}
+
+func (s *S3V1) putObject(ctx context.Context, key string, r io.Reader, size int64) error {
+	_, err := s.Client.PutObject(ctx, s.Bucket, key, r, size, minio.PutObjectOptions{DisableMultipart: true})
+	return err
+}
+
+func (s *S3V1) BaseURL(catalog string) string {
+	return s.RootURL.JoinPath(catalog).String()
+}
+
+// StorageServerHandler returns an HTTP handler for serving catalog content
+func (s *S3V1) StorageServerHandler() http.Handler {
+	handlers := server.NewCatalogHandlers(s, s.graphqlSvc, s.RootURL, s.EnableMetasHandler, s.EnableGraphQLQueries, s.EnableDiffHandler).
+		WithQueryHandler(s.EnableQueryHandler, s.Catalogs)
+	return handlers.Handler()
+}
+
+// GetCatalogData returns the catalog file of the current version of a catalog and its metadata
+// Implements server.CatalogStore interface
+func (s *S3V1) GetCatalogData(catalog string) (server.CatalogFile, os.FileInfo, error) {
+	return s.openCurrent(catalog, catalogFileName)
+}
+
+// GetCatalogDiff returns the diff between the previous and current version of a catalog,
+// and its metadata. It returns fs.ErrNotExist if the current version was stored while the
+// diff handler was disabled, or is the first version of the catalog.
+// Implements server.CatalogStore interface
+func (s *S3V1) GetCatalogDiff(catalog string) (server.CatalogFile, os.FileInfo, error) {
+	return s.openCurrent(catalog, catalogDiffFileName)
+}
+
+func (s *S3V1) openCurrent(catalog, name string) (server.CatalogFile, os.FileInfo, error) {
+	ctx, cancel := s.requestContext()
+	defer cancel()
+	digest, err := s.currentDigest(ctx, catalog)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.openVersion(catalog, digest, name)
+}
+
+// openVersion opens an object of a version of a catalog. Only the request looking the
+// object up is bounded by RequestTimeout, as the object is read after it is returned.
+func (s *S3V1) openVersion(catalog, digest, name string) (server.CatalogFile, os.FileInfo, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopTimeout := func() {}
+	if s.RequestTimeout > 0 {
+		timeout := time.AfterFunc(s.RequestTimeout, cancel)
+		stopTimeout = func() { timeout.Stop() }
+	}
+	obj, err := s.Client.GetObject(ctx, s.Bucket, s.objectKey(catalog, digest, name), minio.GetObjectOptions{})
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	info, err := obj.Stat()
+	stopTimeout()
+	if err != nil {
+		_ = obj.Close()
+		cancel()
+		if isNoSuchKey(err) {
+			return nil, nil, fs.ErrNotExist
+		}
+		return nil, nil, err
+	}
+	return &object{Object: obj, cancel: cancel}, objectFileInfo{info}, nil
+}
+
+// object is an object of a catalog being read, whose requests are cancelled once it is
+// closed.
+type object struct {
+	*minio.Object
+	cancel context.CancelFunc
+}
+
+func (o *object) Close() error {
+	defer o.cancel()
+	return o.Object.Close()
+}
+
+// GetCatalogFS returns a filesystem holding the catalog file of the current version of a
+// catalog. The cached GraphQL schema of the catalog is invalidated when it was built from
+// another version.
+// Implements server.CatalogStore interface
+func (s *S3V1) GetCatalogFS(catalog string) (fs.FS, error) {
+	ctx, cancel := s.requestContext()
+	defer cancel()
+	digest, err := s.currentDigest(ctx, catalog)
+	if err != nil {
+		return nil, err
+	}
+	if s.graphqlSvc != nil {
+		s.schemaDigestsMu.Lock()
+		if s.schemaDigests[catalog] != digest {
+			s.graphqlSvc.InvalidateCache(catalog)
+			s.schemaDigests[catalog] = digest
+		}
+		s.schemaDigestsMu.Unlock()
+	}
+	return catalogObjectFS{open: func() (server.CatalogFile, os.FileInfo, error) {
+		return s.openVersion(catalog, digest, catalogFileName)
+	}}, nil
+}
+
+// GetIndex returns the index for a catalog. The catalog file handed to the returned
+// index may belong to a version of the catalog stored after the index was returned, in
+// which case the content is looked up in the index of that version.
+// Implements server.CatalogStore interface
+func (s *S3V1) GetIndex(catalog string) (server.Index, error) {
+	ctx, cancel := s.requestContext()
+	defer cancel()
+	digest, err := s.currentDigest(ctx, catalog)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.loadIndex(ctx, catalog, digest); err != nil {
+		return nil, err
+	}
+	return &versionedIndex{store: s, catalog: catalog, digest: digest}, nil
+}
+
+// loadIndex returns the index of a version of a catalog, caching the index of the most
+// recently loaded version of each catalog.
+func (s *S3V1) loadIndex(ctx context.Context, catalog, digest string) (*index.Index, error) {
+	s.indexesMu.Lock()
+	loaded, ok := s.indexes[catalog]
+	s.indexesMu.Unlock()
+	if ok && loaded.digest == digest {
+		return loaded.idx, nil
+	}
+
+	key := s.objectKey(catalog, digest, catalogIndexFileName)
+	idx, err, _ := s.sf.Do(key, func() (interface{}, error) {
+		obj, err := s.Client.GetObject(ctx, s.Bucket, key, minio.GetObjectOptions{})
+		if err != nil {
+			return nil, err
+		}
+		defer obj.Close()
+		var idx index.Index
+		if err := json.NewDecoder(obj).Decode(&idx); err != nil {
+			if isNoSuchKey(err) {
+				return nil, fs.ErrNotExist
+			}
+			return nil, err
+		}
+		return &idx, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.indexesMu.Lock()
+	s.indexes[catalog] = loadedIndex{digest: digest, idx: idx.(*index.Index)}
+	s.indexesMu.Unlock()
+	return idx.(*index.Index), nil
+}
+
+// versionedIndex is the index of a catalog stored in a bucket. It looks content up in
+// the index of the version of the catalog file it is given.
+type versionedIndex struct {
+	store   *S3V1
+	catalog string
+	digest  string
+}
+
+func (i *versionedIndex) Get(catalogFile io.ReaderAt, schema, packageName, name string) io.Reader {
+	digest := i.digest
+	if obj, ok := catalogFile.(*object); ok {
+		if info, err := obj.Stat(); err == nil {
+			digest = i.store.versionOf(i.catalog, info.Key)
+		}
+	}
+	ctx, cancel := i.store.requestContext()
+	defer cancel()
+	idx, err := i.store.loadIndex(ctx, i.catalog, digest)
+	if err != nil {
+		return errReader{err}
+	}
+	return idx.Get(catalogFile, schema, packageName, name)
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+// objectFileInfo is the fs.FileInfo of an object
+type objectFileInfo struct {
+	info minio.ObjectInfo
+}
+
+func (i objectFileInfo) Name() string       { return path.Base(i.info.Key) }
+func (i objectFileInfo) Size() int64        { return i.info.Size }
+func (i objectFileInfo) Mode() fs.FileMode  { return 0444 }
+func (i objectFileInfo) ModTime() time.Time { return i.info.LastModified }
+func (i objectFileInfo) IsDir() bool        { return false }
+func (i objectFileInfo) Sys() any           { return nil }
+
+// catalogObjectFS is a filesystem holding the catalog.jsonl file of a catalog, read from its object
+type catalogObjectFS struct {
+	open func() (server.CatalogFile, os.FileInfo, error)
+}
+
+func (f catalogObjectFS) Open(name string) (fs.File, error) {
+	switch {
+	case !fs.ValidPath(name):
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	case name == ".":
+		return &catalogObjectDir{fsys: f}, nil
+	case name != catalogFileName:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	file, info, err := f.open()
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &catalogObjectFile{CatalogFile: file, info: info}, nil
+}
+
+type catalogObjectFile struct {
+	server.CatalogFile
+	info os.FileInfo
+}
+
+func (f *catalogObjectFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+// catalogObjectDir is the root directory of a catalogObjectFS
+type catalogObjectDir struct {
+	fsys catalogObjectFS
+	read bool
+}
+
+func (d *catalogObjectDir) Stat() (fs.FileInfo, error) { return catalogObjectDirInfo{}, nil }
+func (d *catalogObjectDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: ".", Err: errors.New("is a directory")}
+}
+func (d *catalogObjectDir) Close() error { return nil }
+
+func (d *catalogObjectDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if d.read {
+		if n > 0 {
+			return nil, io.EOF
+		}
+		return nil, nil
+	}
+	d.read = true
+	file, info, err := d.fsys.open()
+	if err != nil {
+		return nil, err
+	}
+	_ = file.Close()
+	return []fs.DirEntry{fs.FileInfoToDirEntry(info)}, nil
+}
+
+type catalogObjectDirInfo struct{}
+
+func (catalogObjectDirInfo) Name() string       { return "." }
+func (catalogObjectDirInfo) Size() int64        { return 0 }
+func (catalogObjectDirInfo) Mode() fs.FileMode  { return fs.ModeDir | 0555 }
+func (catalogObjectDirInfo) ModTime() time.Time { return time.Time{} }
+func (catalogObjectDirInfo) IsDir() bool        { return true }
+func (catalogObjectDirInfo) Sys() any           { return nil }
+
+func isNoSuchKey(err error) bool {
+	return err != nil && minio.ToErrorResponse(err).Code == minio.NoSuchKey
+}
+
+func fileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func contentTypeOf(name string) string {
+	if name == catalogFileName {
+		return "application/jsonl"
+	}
+	return "application/json"
+}
@@ -0,0 +1,333 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestS3Storage(t *testing.T) {
+	bucket := newFakeS3(t, "catalogs")
+	newStore := func(t *testing.T) *S3V1 {
+		return NewS3V1(bucket.client(t), "catalogs", "catalogd", t.TempDir(), &url.URL{Path: urlPrefix},
+			MetasHandlerEnabled, GraphQLQueriesEnabled, DiffHandlerEnabled, QueryHandlerDisabled)
+	}
+	store := newStore(t)
+	testServer := httptest.NewServer(store.StorageServerHandler())
+	defer testServer.Close()
+	get := func(t *testing.T, path string) (int, string) {
+		resp, err := http.Get(testServer.URL + urlPrefix + path) //nolint:gosec
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+	queryPackages := func(t *testing.T, serverURL string) string {
+		resp, err := http.Post(serverURL+urlPrefix+"test-catalog/api/v1/graphql", "application/json", strings.NewReader(`{"query":"{ olmpackages { name } }"}`)) //nolint:gosec
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	t.Log("By checking nothing is served before content is stored")
+	require.False(t, store.ContentExists("test-catalog"))
+	code, _ := get(t, "test-catalog/api/v1/all")
+	require.Equal(t, http.StatusNotFound, code)
+
+	t.Log("By storing the content of a catalog")
+	fsys := createTestFS(t)
+	require.NoError(t, store.Store(context.Background(), "test-catalog", fsys))
+	require.True(t, store.ContentExists("test-catalog"))
+	expectedContent := string((*fsys.(*fstest.MapFS))["test-catalog.yaml"].Data)
+
+	t.Log("By checking the content is served from the bucket")
+	code, body := get(t, "test-catalog/api/v1/all")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, expectedContent, body)
+	code, body = get(t, "test-catalog/api/v1/metas?schema=olm.package")
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `{"defaultChannel":"preview_test","name":"webhook_operator_test","schema":"olm.package"}`, body)
+
+	require.Contains(t, queryPackages(t, testServer.URL), "webhook_operator_test")
+
+	t.Log("By checking another replica serves the same content without storing it")
+	replica := newStore(t)
+	replicaServer := httptest.NewServer(replica.StorageServerHandler())
+	defer replicaServer.Close()
+	require.Contains(t, queryPackages(t, replicaServer.URL), "webhook_operator_test")
+	require.True(t, replica.ContentExists("test-catalog"))
+	catalogFile, _, err := replica.GetCatalogData("test-catalog")
+	require.NoError(t, err)
+	replicaContent, err := io.ReadAll(catalogFile)
+	require.NoError(t, err)
+	require.NoError(t, catalogFile.Close())
+	require.Equal(t, expectedContent, string(replicaContent))
+
+	t.Log("By checking storing the same content again does not upload it")
+	uploads := bucket.uploads()
+	require.NoError(t, store.Store(context.Background(), "test-catalog", fsys))
+	require.Equal(t, uploads, bucket.uploads())
+	code, _ = get(t, "test-catalog/api/v1/diff")
+	require.Equal(t, http.StatusNotFound, code)
+
+	t.Log("By storing updated content")
+	updated := createTestFS(t).(*fstest.MapFS)
+	(*updated)["update.yaml"] = &fstest.MapFile{Data: []byte(generateJSONLinesOrFail(t, []byte(`---
+schema: olm.package
+name: other_operator
+`))), Mode: os.ModePerm}
+	require.NoError(t, store.Store(context.Background(), "test-catalog", updated))
+	code, body = get(t, "test-catalog/api/v1/metas?schema=olm.package&name=other_operator")
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `{"name":"other_operator","schema":"olm.package"}`, body)
+	code, body = get(t, "test-catalog/api/v1/diff")
+	require.Equal(t, http.StatusOK, code)
+	var diff CatalogDiff
+	require.NoError(t, json.Unmarshal([]byte(body), &diff))
+	require.Equal(t, []PackageDiff{{Name: "other_operator", Type: PackageAdded}}, diff.Packages)
+	require.Contains(t, queryPackages(t, replicaServer.URL), "other_operator")
+
+	t.Log("By checking only the current and previous versions are kept")
+	(*updated)["update.yaml"] = &fstest.MapFile{Data: []byte(generateJSONLinesOrFail(t, []byte(`---
+schema: olm.package
+name: another_operator
+`))), Mode: os.ModePerm}
+	require.NoError(t, store.Store(context.Background(), "test-catalog", updated))
+	versions := map[string]struct{}{}
+	for _, key := range bucket.keys() {
+		versions[store.versionOf("test-catalog", key)] = struct{}{}
+	}
+	require.Len(t, versions, 3, "expected the current object and two versions, got %v", bucket.keys())
+
+	t.Log("By checking the content of a version is looked up in the index of that version")
+	idx, err := replica.GetIndex("test-catalog")
+	require.NoError(t, err)
+	catalogFile, _, err = replica.GetCatalogData("test-catalog")
+	require.NoError(t, err)
+	defer catalogFile.Close()
+	blobs, err := io.ReadAll(idx.Get(catalogFile, "olm.package", "", "another_operator"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"another_operator","schema":"olm.package"}`, string(blobs))
+
+	t.Log("By deleting the catalog")
+	require.NoError(t, store.Delete("test-catalog"))
+	require.False(t, store.ContentExists("test-catalog"))
+	require.False(t, replica.ContentExists("test-catalog"))
+	require.Empty(t, bucket.keys())
+	code, _ = get(t, "test-catalog/api/v1/all")
+	require.Equal(t, http.StatusNotFound, code)
+}
+
+func TestS3StorageRequestTimeout(t *testing.T) {
+	bucket := newFakeS3(t, "catalogs")
+	store := NewS3V1(bucket.client(t), "catalogs", "catalogd", t.TempDir(), &url.URL{Path: urlPrefix},
+		MetasHandlerEnabled, GraphQLQueriesDisabled, DiffHandlerDisabled, QueryHandlerDisabled)
+	store.RequestTimeout = 100 * time.Millisecond
+	require.NoError(t, store.Store(context.Background(), "test-catalog", createTestFS(t)))
+
+	t.Log("By checking the content of a catalog is read past the timeout once looked up")
+	catalogFile, _, err := store.GetCatalogData("test-catalog")
+	require.NoError(t, err)
+	defer catalogFile.Close()
+	time.Sleep(2 * store.RequestTimeout)
+	_, err = io.ReadAll(catalogFile)
+	require.NoError(t, err)
+
+	t.Log("By checking requests to an unresponsive bucket time out")
+	bucket.hang.Store(true)
+	defer bucket.hang.Store(false)
+	require.False(t, store.ContentExists("test-catalog"))
+	_, _, err = store.GetCatalogData("test-catalog")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	_, err = store.GetIndex("test-catalog")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorIs(t, store.Delete("test-catalog"), context.DeadlineExceeded)
+}
+
+func TestS3StorageFirstSeen(t *testing.T) {
+	bucket := newFakeS3(t, "catalogs")
+	recorder, clk := newTestFirstSeenRecorder(t, interceptor.Funcs{}, "test-catalog")
+	store := NewS3V1(bucket.client(t), "catalogs", "catalogd", t.TempDir(), &url.URL{Path: urlPrefix},
+		MetasHandlerEnabled, GraphQLQueriesDisabled, DiffHandlerDisabled, QueryHandlerDisabled)
+	store.FirstSeen = recorder
+
+	t.Log("By checking when bundles were first seen is stored along with the content")
+	require.NoError(t, store.Store(context.Background(), "test-catalog", createTestFS(t)))
+	seen := clk.Now()
+	clk.Step(time.Hour)
+	require.NoError(t, store.Store(context.Background(), "test-catalog", createTestFS(t)))
+	catalogFile, _, err := store.GetCatalogData("test-catalog")
+	require.NoError(t, err)
+	defer catalogFile.Close()
+	require.Equal(t, map[bundleKey]time.Time{{"webhook_operator_test", "bundle.v0.0.1"}: seen}, readFirstSeen(t, catalogFile))
+}
+
+// fakeS3 is an in-memory stand-in for an S3-compatible object storage serving a single
+// bucket. It implements the subset of the S3 API used by S3V1.
+type fakeS3 struct {
+	server *httptest.Server
+	bucket string
+	// hang makes requests wait until they are cancelled
+	hang atomic.Bool
+
+	mu       sync.Mutex
+	objects  map[string]fakeS3Object
+	putCount int
+}
+
+type fakeS3Object struct {
+	data    []byte
+	etag    string
+	modTime time.Time
+}
+
+func newFakeS3(t *testing.T, bucket string) *fakeS3 {
+	f := &fakeS3{bucket: bucket, objects: map[string]fakeS3Object{}}
+	f.server = httptest.NewTLSServer(f)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// client returns a client of the bucket
+func (f *fakeS3) client(t *testing.T) *minio.Client {
+	client, err := minio.New(strings.TrimPrefix(f.server.URL, "https://"), &minio.Options{
+		Creds:     credentials.NewStaticV4("access-key", "secret-key", ""),
+		Secure:    true,
+		Region:    "us-east-1",
+		Transport: f.server.Client().Transport,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func (f *fakeS3) uploads() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.putCount
+}
+
+func (f *fakeS3) keys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keys := make([]string, 0, len(f.objects))
+	for key := range f.objects {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.hang.Load() {
+		<-r.Context().Done()
+		return
+	}
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != f.bucket {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case key == "" && r.Method == http.MethodGet:
+		f.list(w, r.URL.Query().Get("prefix"))
+	case key == "":
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeS3Error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		sum := md5.Sum(data) //nolint:gosec
+		obj := fakeS3Object{data: data, etag: `"` + hex.EncodeToString(sum[:]) + `"`, modTime: time.Now()}
+		f.objects[key] = obj
+		f.putCount++
+		w.Header().Set("ETag", obj.etag)
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		obj, ok := f.objects[key]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("ETag", obj.etag)
+		http.ServeContent(w, r, key, obj.modTime, bytes.NewReader(obj.data))
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeS3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+type fakeS3ListResult struct {
+	XMLName     xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+	Name        string
+	Prefix      string
+	KeyCount    int
+	MaxKeys     int
+	IsTruncated bool
+	Contents    []fakeS3ListEntry
+}
+
+type fakeS3ListEntry struct {
+	Key          string
+	LastModified string
+	ETag         string
+	Size         int64
+	StorageClass string
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, prefix string) {
+	result := fakeS3ListResult{Name: f.bucket, Prefix: prefix, MaxKeys: 1000}
+	for key, obj := range f.objects {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		result.Contents = append(result.Contents, fakeS3ListEntry{
+			Key:          key,
+			LastModified: obj.modTime.UTC().Format("2006-01-02T15:04:05.000Z"),
+			ETag:         obj.etag,
+			Size:         int64(len(obj.data)),
+			StorageClass: "STANDARD",
+		})
+	}
+	slices.SortFunc(result.Contents, func(a, b fakeS3ListEntry) int { return strings.Compare(a.Key, b.Key) })
+	result.KeyCount = len(result.Contents)
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>%s</Code><Message>%s</Message></Error>`, code, code)
+}
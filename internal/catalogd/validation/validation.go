@@ -0,0 +1,334 @@
+// Package validation validates the File-Based Catalog (FBC) content of catalogs
+// before it is stored, so that broken content is reported on the ClusterCatalog
+// rather than surfacing as resolution errors in the clients of the catalog.
+package validation
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	"github.com/operator-framework/operator-controller/internal/operator-controller/bundleutil"
+)
+
+// maxReportedErrors is the maximum number of problems listed in the message of an Error
+const maxReportedErrors = 20
+
+// Error lists the problems found in the content of a catalog
+type Error struct {
+	Errs []error
+}
+
+func (e *Error) Error() string {
+	reported := e.Errs[:min(len(e.Errs), maxReportedErrors)]
+	msgs := make([]string, 0, len(reported)+1)
+	for _, err := range reported {
+		msgs = append(msgs, err.Error())
+	}
+	if len(e.Errs) > len(reported) {
+		msgs = append(msgs, fmt.Sprintf("and %d more", len(e.Errs)-len(reported)))
+	}
+	return fmt.Sprintf("found %d problems in catalog content: %s", len(e.Errs), strings.Join(msgs, "; "))
+}
+
+func (e *Error) Unwrap() []error {
+	return e.Errs
+}
+
+// ValidateFS validates the FBC content of fsys. It checks that:
+//   - each olm.package, olm.channel, olm.bundle and olm.deprecations blob is well-formed,
+//     and sets the fields required by its schema;
+//   - packages, channels and bundles are unique, and the references between them, as well
+//     as the references of deprecations, resolve;
+//   - the version of each bundle is a valid semantic version;
+//   - the upgrade graph of each channel has a single head, no cycles and no stranded bundles.
+//
+// It returns an *Error listing every problem found, or another error when fsys cannot be read.
+func ValidateFS(ctx context.Context, fsys fs.FS) error {
+	v := &validator{
+		packages:     map[string]*declcfg.Package{},
+		channels:     map[string]map[string]*declcfg.Channel{},
+		bundles:      map[string]map[string]*declcfg.Bundle{},
+		deprecations: sets.New[string](),
+	}
+	if err := declcfg.WalkMetasFS(ctx, fsys, func(path string, meta *declcfg.Meta, err error) error {
+		if err != nil {
+			v.errorf("%s: %v", path, err)
+			return nil
+		}
+		v.addMeta(path, meta)
+		return nil
+	}, declcfg.WithConcurrency(1)); err != nil {
+		return fmt.Errorf("error reading catalog content: %w", err)
+	}
+
+	v.checkReferences()
+	// The upgrade graphs are only checked once the content is otherwise valid, as the
+	// conversion of the content to a model stops at the first problem it finds.
+	if len(v.errs) == 0 {
+		v.checkUpgradeGraphs()
+	}
+	if len(v.errs) > 0 {
+		return &Error{Errs: v.errs}
+	}
+	return nil
+}
+
+type validator struct {
+	cfg          declcfg.DeclarativeConfig
+	packages     map[string]*declcfg.Package
+	channels     map[string]map[string]*declcfg.Channel
+	bundles      map[string]map[string]*declcfg.Bundle
+	deprecations sets.Set[string]
+	errs         []error
+}
+
+func (v *validator) errorf(format string, args ...any) {
+	v.errs = append(v.errs, fmt.Errorf(format, args...))
+}
+
+// addMeta checks a blob is well-formed for its schema, and records it. Blobs of
+// other schemas than the ones OLM defines are allowed, and not checked.
+func (v *validator) addMeta(path string, meta *declcfg.Meta) {
+	switch meta.Schema {
+	case "":
+		v.errorf("%s: blob %q has no schema", path, meta.Name)
+	case declcfg.SchemaPackage:
+		var pkg declcfg.Package
+		if err := json.Unmarshal(meta.Blob, &pkg); err != nil {
+			v.errorf("%s: invalid %s blob %q: %v", path, meta.Schema, meta.Name, err)
+			return
+		}
+		switch {
+		case pkg.Name == "":
+			v.errorf("%s: %s blob has no name", path, meta.Schema)
+		case v.packages[pkg.Name] != nil:
+			v.errorf("duplicate package %q", pkg.Name)
+		default:
+			v.packages[pkg.Name] = &pkg
+			v.cfg.Packages = append(v.cfg.Packages, pkg)
+		}
+	case declcfg.SchemaChannel:
+		var ch declcfg.Channel
+		if err := json.Unmarshal(meta.Blob, &ch); err != nil {
+			v.errorf("%s: invalid %s blob %q: %v", path, meta.Schema, meta.Name, err)
+			return
+		}
+		switch {
+		case ch.Name == "":
+			v.errorf("%s: %s blob of package %q has no name", path, meta.Schema, ch.Package)
+		case ch.Package == "":
+			v.errorf("%s: channel %q has no package", path, ch.Name)
+		case v.channels[ch.Package][ch.Name] != nil:
+			v.errorf("duplicate channel %q in package %q", ch.Name, ch.Package)
+		default:
+			addTo(v.channels, ch.Package, ch.Name, &ch)
+			v.cfg.Channels = append(v.cfg.Channels, ch)
+		}
+	case declcfg.SchemaBundle:
+		var b declcfg.Bundle
+		if err := json.Unmarshal(meta.Blob, &b); err != nil {
+			v.errorf("%s: invalid %s blob %q: %v", path, meta.Schema, meta.Name, err)
+			return
+		}
+		switch {
+		case b.Name == "":
+			v.errorf("%s: %s blob of package %q has no name", path, meta.Schema, b.Package)
+		case b.Package == "":
+			v.errorf("%s: bundle %q has no package", path, b.Name)
+		case v.bundles[b.Package][b.Name] != nil:
+			v.errorf("duplicate bundle %q in package %q", b.Name, b.Package)
+		default:
+			v.checkBundleVersion(&b)
+			addTo(v.bundles, b.Package, b.Name, &b)
+			v.cfg.Bundles = append(v.cfg.Bundles, b)
+		}
+	case declcfg.SchemaDeprecation:
+		var d declcfg.Deprecation
+		if err := json.Unmarshal(meta.Blob, &d); err != nil {
+			v.errorf("%s: invalid %s blob: %v", path, meta.Schema, err)
+			return
+		}
+		switch {
+		case d.Package == "":
+			v.errorf("%s: %s blob has no package", path, meta.Schema)
+		case v.deprecations.Has(d.Package):
+			v.errorf("duplicate deprecations for package %q", d.Package)
+		default:
+			v.deprecations.Insert(d.Package)
+			v.cfg.Deprecations = append(v.cfg.Deprecations, d)
+		}
+	}
+}
+
+// checkBundleVersion checks a bundle has a single olm.package property naming its package,
+// and that its version, along with its release when set, can be parsed.
+func (v *validator) checkBundleVersion(b *declcfg.Bundle) {
+	var pkgs []property.Package
+	for _, p := range b.Properties {
+		if p.Type != property.TypePackage {
+			continue
+		}
+		var pkg property.Package
+		if err := json.Unmarshal(p.Value, &pkg); err != nil {
+			v.errorf("bundle %q of package %q has an invalid %s property: %v", b.Name, b.Package, property.TypePackage, err)
+			return
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	if len(pkgs) != 1 {
+		v.errorf("bundle %q of package %q must have exactly one %s property, found %d", b.Name, b.Package, property.TypePackage, len(pkgs))
+		return
+	}
+	if pkgs[0].PackageName != b.Package {
+		v.errorf("bundle %q of package %q has a %s property for package %q", b.Name, b.Package, property.TypePackage, pkgs[0].PackageName)
+	}
+
+	var err error
+	if pkgs[0].Release != "" {
+		_, err = bundleutil.ParseExplicitRelease(pkgs[0].Version, pkgs[0].Release)
+	} else {
+		_, err = bundleutil.ParseLegacyVersionRelease(pkgs[0].Version)
+	}
+	if err != nil {
+		v.errorf("bundle %q of package %q has an invalid version %q: %v", b.Name, b.Package, pkgs[0].Version, err)
+	}
+}
+
+// checkReferences checks the references between the blobs of the catalog resolve
+func (v *validator) checkReferences() {
+	for _, pkgName := range sortedKeys(v.packages) {
+		pkg := v.packages[pkgName]
+		if pkg.DefaultChannel != "" && v.channels[pkgName][pkg.DefaultChannel] == nil {
+			v.errorf("default channel %q of package %q does not exist", pkg.DefaultChannel, pkgName)
+		}
+	}
+
+	for _, pkgName := range sortedKeys(v.channels) {
+		if v.packages[pkgName] == nil {
+			v.errorf("channels %v reference unknown package %q", sortedKeys(v.channels[pkgName]), pkgName)
+			continue
+		}
+		for _, chName := range sortedKeys(v.channels[pkgName]) {
+			v.checkChannelEntries(v.channels[pkgName][chName])
+		}
+	}
+
+	for _, pkgName := range sortedKeys(v.bundles) {
+		if v.packages[pkgName] == nil {
+			v.errorf("bundles %v reference unknown package %q", sortedKeys(v.bundles[pkgName]), pkgName)
+			continue
+		}
+		inChannels := sets.New[string]()
+		for _, ch := range v.channels[pkgName] {
+			for _, entry := range ch.Entries {
+				inChannels.Insert(entry.Name)
+			}
+		}
+		for _, bundleName := range sortedKeys(v.bundles[pkgName]) {
+			if !inChannels.Has(bundleName) {
+				v.errorf("bundle %q of package %q is not an entry of any channel", bundleName, pkgName)
+			}
+		}
+	}
+
+	for _, d := range v.cfg.Deprecations {
+		if v.packages[d.Package] == nil {
+			v.errorf("deprecations reference unknown package %q", d.Package)
+			continue
+		}
+		for _, entry := range d.Entries {
+			ref := entry.Reference
+			switch ref.Schema {
+			case declcfg.SchemaPackage:
+				if ref.Name != "" && ref.Name != d.Package {
+					v.errorf("deprecations of package %q reference another package %q", d.Package, ref.Name)
+				}
+			case declcfg.SchemaChannel:
+				if v.channels[d.Package][ref.Name] == nil {
+					v.errorf("deprecations of package %q reference unknown channel %q", d.Package, ref.Name)
+				}
+			case declcfg.SchemaBundle:
+				if v.bundles[d.Package][ref.Name] == nil {
+					v.errorf("deprecations of package %q reference unknown bundle %q", d.Package, ref.Name)
+				}
+			default:
+				v.errorf("deprecations of package %q reference %q with unknown schema %q", d.Package, ref.Name, ref.Schema)
+			}
+		}
+	}
+}
+
+// checkChannelEntries checks the entries of a channel are unique bundles of its package,
+// and that they do not replace bundles that are not in the channel. The last entry of the
+// upgrade graph is allowed to replace a bundle that is not in the channel anymore.
+func (v *validator) checkChannelEntries(ch *declcfg.Channel) {
+	if len(ch.Entries) == 0 {
+		v.errorf("channel %q of package %q has no entries", ch.Name, ch.Package)
+		return
+	}
+
+	entries := sets.New[string]()
+	for _, entry := range ch.Entries {
+		switch {
+		case entry.Name == "":
+			v.errorf("channel %q of package %q has an entry with no name", ch.Name, ch.Package)
+		case entries.Has(entry.Name):
+			v.errorf("channel %q of package %q has duplicate entry %q", ch.Name, ch.Package, entry.Name)
+		case v.bundles[ch.Package][entry.Name] == nil:
+			v.errorf("channel %q of package %q references unknown bundle %q", ch.Name, ch.Package, entry.Name)
+		}
+		entries.Insert(entry.Name)
+	}
+
+	var dangling []declcfg.ChannelEntry
+	for _, entry := range ch.Entries {
+		if entry.Replaces != "" && !entries.Has(entry.Replaces) {
+			dangling = append(dangling, entry)
+		}
+	}
+	if len(dangling) > 1 {
+		slices.SortFunc(dangling, func(a, b declcfg.ChannelEntry) int { return cmp.Compare(a.Name, b.Name) })
+		for _, entry := range dangling {
+			v.errorf("entry %q of channel %q of package %q replaces %q, which is not an entry of the channel", entry.Name, ch.Name, ch.Package, entry.Replaces)
+		}
+	}
+}
+
+// checkUpgradeGraphs checks the upgrade graph of each channel, reusing the validation
+// of the model of the catalog built by operator-registry.
+func (v *validator) checkUpgradeGraphs() {
+	m, err := declcfg.ConvertToModel(v.cfg)
+	if err != nil {
+		v.errs = append(v.errs, err)
+		return
+	}
+	if err := m.Validate(); err != nil {
+		v.errs = append(v.errs, err)
+	}
+}
+
+func addTo[T any](m map[string]map[string]*T, pkgName, name string, value *T) {
+	if m[pkgName] == nil {
+		m[pkgName] = map[string]*T{}
+	}
+	m[pkgName][name] = value
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
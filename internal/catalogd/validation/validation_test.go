@@ -0,0 +1,294 @@
+package validation_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-controller/internal/catalogd/validation"
+)
+
+const validCatalog = `---
+schema: olm.package
+name: foo
+defaultChannel: stable
+---
+schema: olm.channel
+name: stable
+package: foo
+entries:
+- name: foo.v0.1.0
+- name: foo.v0.2.0
+  replaces: foo.v0.1.0
+---
+schema: olm.bundle
+name: foo.v0.1.0
+package: foo
+image: quay.io/example/foo-bundle:v0.1.0
+properties:
+- type: olm.package
+  value:
+    packageName: foo
+    version: 0.1.0
+---
+schema: olm.bundle
+name: foo.v0.2.0
+package: foo
+image: quay.io/example/foo-bundle:v0.2.0
+properties:
+- type: olm.package
+  value:
+    packageName: foo
+    version: 0.2.0
+---
+schema: olm.deprecations
+package: foo
+entries:
+- reference:
+    schema: olm.bundle
+    name: foo.v0.1.0
+  message: foo.v0.1.0 is deprecated
+---
+schema: example.custom
+name: anything
+`
+
+func TestValidateFS(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		content        string
+		expectedErrors []string
+	}{
+		{
+			name:    "valid catalog",
+			content: validCatalog,
+		},
+		{
+			name: "blob without schema",
+			content: validCatalog + `---
+name: foo
+`,
+			expectedErrors: []string{`catalog.yaml: blob "foo" has no schema`},
+		},
+		{
+			name: "duplicate package, channel and bundle",
+			content: validCatalog + `---
+schema: olm.package
+name: foo
+---
+schema: olm.channel
+name: stable
+package: foo
+entries:
+- name: foo.v0.1.0
+---
+schema: olm.bundle
+name: foo.v0.1.0
+package: foo
+image: quay.io/example/foo-bundle:v0.1.0
+properties:
+- type: olm.package
+  value:
+    packageName: foo
+    version: 0.1.0
+`,
+			expectedErrors: []string{
+				`duplicate package "foo"`,
+				`duplicate channel "stable" in package "foo"`,
+				`duplicate bundle "foo.v0.1.0" in package "foo"`,
+			},
+		},
+		{
+			name: "dangling references",
+			content: `---
+schema: olm.package
+name: foo
+defaultChannel: beta
+---
+schema: olm.channel
+name: stable
+package: foo
+entries:
+- name: foo.v0.1.0
+  replaces: foo.v0.0.1
+- name: foo.v0.2.0
+  replaces: foo.v0.0.2
+- name: foo.v0.3.0
+  replaces: foo.v0.2.0
+---
+schema: olm.bundle
+name: foo.v0.1.0
+package: foo
+image: quay.io/example/foo-bundle:v0.1.0
+properties:
+- type: olm.package
+  value:
+    packageName: foo
+    version: 0.1.0
+---
+schema: olm.bundle
+name: foo.v0.2.0
+package: foo
+image: quay.io/example/foo-bundle:v0.2.0
+properties:
+- type: olm.package
+  value:
+    packageName: foo
+    version: 0.2.0
+---
+schema: olm.bundle
+name: foo.v0.4.0
+package: foo
+image: quay.io/example/foo-bundle:v0.4.0
+properties:
+- type: olm.package
+  value:
+    packageName: foo
+    version: 0.4.0
+---
+schema: olm.bundle
+name: bar.v0.1.0
+package: bar
+image: quay.io/example/bar-bundle:v0.1.0
+properties:
+- type: olm.package
+  value:
+    packageName: bar
+    version: 0.1.0
+---
+schema: olm.deprecations
+package: foo
+entries:
+- reference:
+    schema: olm.channel
+    name: alpha
+  message: alpha is deprecated
+`,
+			expectedErrors: []string{
+				`default channel "beta" of package "foo" does not exist`,
+				`channel "stable" of package "foo" references unknown bundle "foo.v0.3.0"`,
+				`entry "foo.v0.1.0" of channel "stable" of package "foo" replaces "foo.v0.0.1", which is not an entry of the channel`,
+				`entry "foo.v0.2.0" of channel "stable" of package "foo" replaces "foo.v0.0.2", which is not an entry of the channel`,
+				`bundles [bar.v0.1.0] reference unknown package "bar"`,
+				`bundle "foo.v0.4.0" of package "foo" is not an entry of any channel`,
+				`deprecations of package "foo" reference unknown channel "alpha"`,
+			},
+		},
+		{
+			name: "unparseable versions",
+			content: `---
+schema: olm.package
+name: foo
+---
+schema: olm.channel
+name: stable
+package: foo
+entries:
+- name: foo.v0.1.0
+- name: foo.v0.2.0
+- name: foo.v0.3.0
+---
+schema: olm.bundle
+name: foo.v0.1.0
+package: foo
+image: quay.io/example/foo-bundle:v0.1.0
+properties:
+- type: olm.package
+  value:
+    packageName: foo
+    version: v0.1
+---
+schema: olm.bundle
+name: foo.v0.2.0
+package: foo
+image: quay.io/example/foo-bundle:v0.2.0
+properties:
+- type: olm.package
+  value:
+    packageName: foo
+    version: 0.2.0
+    release: "a..b"
+---
+schema: olm.bundle
+name: foo.v0.3.0
+package: foo
+image: quay.io/example/foo-bundle:v0.3.0
+properties: []
+`,
+			expectedErrors: []string{
+				`bundle "foo.v0.1.0" of package "foo" has an invalid version "v0.1"`,
+				`bundle "foo.v0.2.0" of package "foo" has an invalid version "0.2.0"`,
+				`bundle "foo.v0.3.0" of package "foo" must have exactly one olm.package property, found 0`,
+			},
+		},
+		{
+			name: "broken channel graph",
+			content: `---
+schema: olm.package
+name: foo
+---
+schema: olm.channel
+name: stable
+package: foo
+entries:
+- name: foo.v0.1.0
+- name: foo.v0.2.0
+---
+schema: olm.bundle
+name: foo.v0.1.0
+package: foo
+image: quay.io/example/foo-bundle:v0.1.0
+properties:
+- type: olm.package
+  value:
+    packageName: foo
+    version: 0.1.0
+---
+schema: olm.bundle
+name: foo.v0.2.0
+package: foo
+image: quay.io/example/foo-bundle:v0.2.0
+properties:
+- type: olm.package
+  value:
+    packageName: foo
+    version: 0.2.0
+`,
+			expectedErrors: []string{`multiple channel heads found in graph: foo.v0.1.0, foo.v0.2.0`},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fsys := fstest.MapFS{"catalog.yaml": &fstest.MapFile{Data: []byte(tc.content)}}
+			err := validation.ValidateFS(context.Background(), fsys)
+			if len(tc.expectedErrors) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var validationErr *validation.Error
+			require.ErrorAs(t, err, &validationErr)
+			require.Len(t, validationErr.Errs, len(tc.expectedErrors), "unexpected errors: %v", validationErr)
+			for _, expected := range tc.expectedErrors {
+				require.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	content := `---
+schema: olm.package
+name: foo
+`
+	for range 25 {
+		content += `---
+schema: olm.channel
+package: foo
+`
+	}
+	err := validation.ValidateFS(context.Background(), fstest.MapFS{"catalog.yaml": &fstest.MapFile{Data: []byte(content)}})
+	require.ErrorContains(t, err, "found 25 problems in catalog content: ")
+	require.ErrorContains(t, err, "; and 5 more")
+}
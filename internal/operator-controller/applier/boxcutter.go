@@ -108,7 +108,7 @@ func (r *SimpleRevisionGenerator) GenerateRevisionFromHelmRelease(
 	if v, ok := helmRelease.Labels[labels.BundleReleaseKey]; ok {
 		revisionAnnotations[labels.BundleReleaseKey] = v
 	}
-	rev := r.buildClusterObjectSet(objs, ext, revisionAnnotations)
+	rev := buildClusterObjectSet(objs, ext, revisionAnnotations)
 	rev.WithName(fmt.Sprintf("%s-1", ext.Name))
 	rev.Spec.WithRevision(1)
 	rev.Spec.WithCollisionProtection(ocv1.CollisionProtectionNone) // allow to adopt objects from previous release
@@ -184,7 +184,7 @@ func (r *SimpleRevisionGenerator) GenerateRevision(
 		objs = append(objs, *ocv1ac.ClusterObjectSetObject().
 			WithObject(unstr))
 	}
-	rev := r.buildClusterObjectSet(objs, ext, revisionAnnotations)
+	rev := buildClusterObjectSet(objs, ext, revisionAnnotations)
 	rev.Spec.WithCollisionProtection(ocv1.CollisionProtectionPrevent)
 	return rev, nil
 }
@@ -231,7 +231,7 @@ func sanitizedUnstructured(ctx context.Context, unstr *unstructured.Unstructured
 	obj["metadata"] = metadataSanitized
 }
 
-func (r *SimpleRevisionGenerator) buildClusterObjectSet(
+func buildClusterObjectSet(
 	objects []ocv1ac.ClusterObjectSetObjectApplyConfiguration,
 	ext *ocv1.ClusterExtension,
 	annotations map[string]string,
@@ -251,6 +251,9 @@ func (r *SimpleRevisionGenerator) buildClusterObjectSet(
 	if p := ext.Spec.ProgressDeadlineMinutes; p > 0 {
 		spec.WithProgressDeadlineMinutes(p)
 	}
+	if p := ext.Spec.RollbackPolicy; p != "" {
+		spec.WithRollbackPolicy(p)
+	}
 
 	return ocv1ac.ClusterObjectSet("").
 		WithAnnotations(annotations).
@@ -461,6 +464,7 @@ type Boxcutter struct {
 	PreAuthorizer     authorization.PreAuthorizer
 	FieldOwner        string
 	SystemNamespace   string
+	PlanClientGetter  PlanClientGetter
 }
 
 func (bc *Boxcutter) Apply(ctx context.Context, contentFS fs.FS, ext *ocv1.ClusterExtension, objectLabels, revisionAnnotations map[string]string) (bool, string, error) {
@@ -476,7 +480,8 @@ func (bc *Boxcutter) Apply(ctx context.Context, contentFS fs.FS, ext *ocv1.Clust
 
 	// If contentFS is nil, we're maintaining the current state without catalog access.
 	// In this case, we should use the existing installed revision without generating a new one.
-	if contentFS == nil {
+	// A pinned revision has no content FS, its content is read from the pinned ClusterObjectSet.
+	if contentFS == nil && ext.Spec.PinnedRevision == 0 {
 		if len(existingRevisions) == 0 {
 			return false, "", fmt.Errorf("catalog content unavailable and no revision installed")
 		}
@@ -488,7 +493,7 @@ func (bc *Boxcutter) Apply(ctx context.Context, contentFS fs.FS, ext *ocv1.Clust
 	}
 
 	// Generate desired revision
-	desiredRevision, err := bc.RevisionGenerator.GenerateRevision(ctx, contentFS, ext, objectLabels, revisionAnnotations)
+	desiredRevision, err := bc.generateRevision(ctx, contentFS, ext, existingRevisions, objectLabels, revisionAnnotations)
 	if err != nil {
 		return false, "", err
 	}
@@ -512,6 +517,14 @@ func (bc *Boxcutter) Apply(ctx context.Context, contentFS fs.FS, ext *ocv1.Clust
 		desiredRevision.Spec.WithRevision(currentRevision.Spec.Revision)
 		desiredRevision.WithName(currentRevision.Name)
 
+		// A rolled back revision is compared as archived, so that its content is
+		// not rolled out again until a different bundle or configuration is resolved,
+		// or until it is pinned explicitly.
+		rolledBack := isRolledBack(currentRevision) && ext.Spec.PinnedRevision == 0
+		if rolledBack {
+			desiredRevision.Spec.WithLifecycleState(ocv1.ClusterObjectSetLifecycleStateArchived)
+		}
+
 		// Save inline objects before externalization (needed for preflights + createExternalizedRevision)
 		savedInline := saveInlineObjects(desiredRevision)
 
@@ -534,6 +547,9 @@ func (bc *Boxcutter) Apply(ctx context.Context, contentFS fs.FS, ext *ocv1.Clust
 
 		// Restore inline objects for preflights + createExternalizedRevision
 		restoreInlineObjects(desiredRevision, savedInline)
+		if rolledBack {
+			desiredRevision.Spec.WithLifecycleState(ocv1.ClusterObjectSetLifecycleStateActive)
+		}
 
 		switch {
 		case apierrors.IsInvalid(err):
@@ -547,26 +563,8 @@ func (bc *Boxcutter) Apply(ctx context.Context, contentFS fs.FS, ext *ocv1.Clust
 
 	// Preflights
 	plainObjs := getObjects(desiredRevision)
-	for _, preflight := range bc.Preflights {
-		if shouldSkipPreflight(ctx, preflight, ext, state) {
-			continue
-		}
-		switch state {
-		case StateNeedsInstall:
-			err := preflight.Install(ctx, plainObjs)
-			if err != nil {
-				return false, "", err
-			}
-		// TODO: jlanford's IDE says that "StateNeedsUpgrade" condition is always true, but
-		//   it isn't immediately obvious why that is. Perhaps len(existingRevisions) is
-		//   always greater than 0 (seems unlikely), or shouldSkipPreflight always returns
-		//   true (and we continue) when state == StateNeedsInstall?
-		case StateNeedsUpgrade:
-			err := preflight.Upgrade(ctx, plainObjs)
-			if err != nil {
-				return false, "", err
-			}
-		}
+	if err := runPreflights(ctx, bc.Preflights, ext, state, plainObjs); err != nil {
+		return false, "", err
 	}
 
 	if state != StateUnchanged {
@@ -584,6 +582,124 @@ func (bc *Boxcutter) Apply(ctx context.Context, contentFS fs.FS, ext *ocv1.Clust
 	return true, "", nil
 }
 
+// Plan generates the revision for the content in the provided fs.FS and runs the same checks as Apply,
+// then returns the changes rolling out the revision would make to the cluster, without creating it.
+func (bc *Boxcutter) Plan(ctx context.Context, contentFS fs.FS, ext *ocv1.ClusterExtension, objectLabels, revisionAnnotations map[string]string) ([]ocv1.PlannedObjectChange, error) {
+	if bc.PlanClientGetter == nil {
+		return nil, errors.New("PlanClientGetter is nil")
+	}
+	existingRevisions, err := bc.getExistingRevisions(ctx, ext.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	desiredRevision, err := bc.generateRevision(ctx, contentFS, ext, existingRevisions, objectLabels, revisionAnnotations)
+	if err != nil {
+		return nil, err
+	}
+	desiredRevision.WithName(fmt.Sprintf("%s-%d", ext.Name, latestRevisionNumber(existingRevisions)+1))
+
+	// The objects of the latest revision are compared with the desired objects to
+	// find the objects the new revision would delete. A rolled back revision was
+	// replaced by the revision before it, so it is skipped.
+	state := StateNeedsInstall
+	var currentObjs []client.Object
+	if len(existingRevisions) > 0 {
+		state = StateNeedsUpgrade
+		current := &existingRevisions[len(existingRevisions)-1]
+		if isRolledBack(current) && len(existingRevisions) > 1 {
+			current = &existingRevisions[len(existingRevisions)-2]
+		}
+		currentObjs, err = bc.revisionObjects(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := bc.runPreAuthorizationChecks(ctx, getUserInfo(ext), desiredRevision); err != nil {
+		return nil, err
+	}
+	plainObjs := getObjects(desiredRevision)
+	if err := runPreflights(ctx, bc.Preflights, ext, state, plainObjs); err != nil {
+		return nil, err
+	}
+
+	cl, err := bc.PlanClientGetter.PlanClientFor(ctx, ext)
+	if err != nil {
+		return nil, err
+	}
+	return planObjectChanges(ctx, cl, ext, plainObjs, currentObjs)
+}
+
+// generateRevision generates the desired revision from the bundle content, or from the
+// pinned revision when spec.pinnedRevision is set.
+func (bc *Boxcutter) generateRevision(
+	ctx context.Context, contentFS fs.FS, ext *ocv1.ClusterExtension, existingRevisions []ocv1.ClusterObjectSet,
+	objectLabels, revisionAnnotations map[string]string,
+) (*ocv1ac.ClusterObjectSetApplyConfiguration, error) {
+	if ext.Spec.PinnedRevision > 0 {
+		return bc.pinnedRevision(ctx, ext, existingRevisions)
+	}
+	return bc.RevisionGenerator.GenerateRevision(ctx, contentFS, ext, objectLabels, revisionAnnotations)
+}
+
+// pinnedRevision generates a revision with the objects and bundle annotations of the
+// revision pinned by spec.pinnedRevision. Archived revisions only keep references to
+// their externalized objects, which are resolved here.
+func (bc *Boxcutter) pinnedRevision(ctx context.Context, ext *ocv1.ClusterExtension, existingRevisions []ocv1.ClusterObjectSet) (*ocv1ac.ClusterObjectSetApplyConfiguration, error) {
+	idx := slices.IndexFunc(existingRevisions, func(rev ocv1.ClusterObjectSet) bool {
+		return rev.Spec.Revision == ext.Spec.PinnedRevision
+	})
+	if idx < 0 {
+		return nil, fmt.Errorf("pinned revision %d not found", ext.Spec.PinnedRevision)
+	}
+	pinned := &existingRevisions[idx]
+
+	var objs []ocv1ac.ClusterObjectSetObjectApplyConfiguration
+	for _, phase := range pinned.Spec.Phases {
+		for _, obj := range phase.Objects {
+			u := obj.Object.DeepCopy()
+			if obj.Ref.Name != "" {
+				resolved, err := ResolveObjectRef(ctx, bc.Client, obj.Ref)
+				if err != nil {
+					return nil, fmt.Errorf("resolving ref of revision %q in phase %q: %w", pinned.Name, phase.Name, err)
+				}
+				u = resolved
+			}
+			o := ocv1ac.ClusterObjectSetObject().WithObject(*u)
+			if cp := cmp.Or(obj.CollisionProtection, phase.CollisionProtection); cp != "" {
+				o.WithCollisionProtection(cp)
+			}
+			objs = append(objs, *o)
+		}
+	}
+
+	rev := buildClusterObjectSet(objs, ext, maps.Clone(pinned.Annotations))
+	rev.Spec.WithCollisionProtection(pinned.Spec.CollisionProtection)
+	return rev, nil
+}
+
+// revisionObjects returns the objects of a ClusterObjectSet, resolving the objects
+// externalized to Secrets.
+func (bc *Boxcutter) revisionObjects(ctx context.Context, cos *ocv1.ClusterObjectSet) ([]client.Object, error) {
+	var objs []client.Object
+	for _, phase := range cos.Spec.Phases {
+		for _, obj := range phase.Objects {
+			switch {
+			case obj.Object.Object != nil:
+				objs = append(objs, obj.Object.DeepCopy())
+			case obj.Ref.Name != "":
+				resolved, err := ResolveObjectRef(ctx, bc.Client, obj.Ref)
+				if err != nil {
+					return nil, fmt.Errorf("resolving ref of revision %q in phase %q: %w", cos.Name, phase.Name, err)
+				}
+				objs = append(objs, resolved)
+			}
+		}
+	}
+	return objs, nil
+}
+
 // createExternalizedRevision creates a new COS with all objects externalized to Secrets.
 // It follows a crash-safe three-step sequence: create Secrets, create COS, patch ownerRefs.
 func (bc *Boxcutter) createExternalizedRevision(ctx context.Context, ext *ocv1.ClusterExtension, desiredRevision *ocv1ac.ClusterObjectSetApplyConfiguration, existingRevisions []ocv1.ClusterObjectSet) error {
@@ -686,6 +802,13 @@ func (bc *Boxcutter) getExistingRevisions(ctx context.Context, extName string) (
 	return existingRevisionList.Items, nil
 }
 
+// isRolledBack returns true if the revision was archived because it failed to roll out
+// and its rollback policy is Automatic.
+func isRolledBack(rev *ocv1.ClusterObjectSet) bool {
+	return rev.Spec.LifecycleState == ocv1.ClusterObjectSetLifecycleStateArchived &&
+		meta.IsStatusConditionTrue(rev.Status.Conditions, ocv1.ClusterObjectSetTypeRolledBack)
+}
+
 func latestRevisionNumber(prevRevisions []ocv1.ClusterObjectSet) int64 {
 	if len(prevRevisions) == 0 {
 		return 0
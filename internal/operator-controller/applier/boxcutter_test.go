@@ -2,6 +2,7 @@ package applier_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -1077,6 +1078,76 @@ func TestBoxcutter_Apply(t *testing.T) {
 				assert.Equal(t, ext.Name, rev.Labels[labels.OwnerNameKey])
 			},
 		},
+		{
+			name: "rolled back revision is not rolled out again",
+			mockBuilder: func(t *testing.T) applier.ClusterObjectSetGenerator {
+				ctrl := gomock.NewController(t)
+				m := mockapplier.NewMockClusterObjectSetGenerator(ctrl)
+				m.EXPECT().GenerateRevision(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, bundleFS fs.FS, ext *ocv1.ClusterExtension, objectLabels, revisionAnnotations map[string]string) (*ocv1ac.ClusterObjectSetApplyConfiguration, error) {
+						return ocv1ac.ClusterObjectSet("").
+							WithAnnotations(revisionAnnotations).
+							WithLabels(map[string]string{
+								labels.OwnerNameKey: ext.Name,
+							}).
+							WithSpec(ocv1ac.ClusterObjectSetSpec().
+								WithLifecycleState(ocv1.ClusterObjectSetLifecycleStateActive).
+								WithPhases(
+									ocv1ac.ClusterObjectSetPhase().
+										WithName(string(applier.PhaseDeploy)).
+										WithObjects(
+											ocv1ac.ClusterObjectSetObject().
+												WithObject(unstructured.Unstructured{
+													Object: map[string]interface{}{
+														"apiVersion": "v1",
+														"kind":       "ConfigMap",
+														"metadata": map[string]interface{}{
+															"name": "test-cm",
+														},
+													},
+												}),
+										),
+								),
+							), nil
+					}).AnyTimes()
+				m.EXPECT().GenerateRevisionFromHelmRelease(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+				return m
+			},
+			existingObjs: []client.Object{
+				func() client.Object {
+					rev := defaultDesiredRevision.DeepCopy()
+					rev.Spec.LifecycleState = ocv1.ClusterObjectSetLifecycleStateArchived
+					rev.Status.Conditions = []metav1.Condition{{
+						Type:   ocv1.ClusterObjectSetTypeRolledBack,
+						Status: metav1.ConditionTrue,
+						Reason: ocv1.ReasonProgressDeadlineExceeded,
+					}}
+					return rev
+				}(),
+			},
+			// The API server rejects un-archiving a revision.
+			clientIterceptor: &interceptor.Funcs{
+				Apply: func(ctx context.Context, client client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+					cos, ok := obj.(*ocv1ac.ClusterObjectSetApplyConfiguration)
+					if !ok {
+						return fmt.Errorf("expected ClusterObjectSetApplyConfiguration, got %T", obj)
+					}
+					if ptr.Deref(cos.GetName(), "") == "test-ext-1" && ptr.Deref(cos.Spec.LifecycleState, "") == ocv1.ClusterObjectSetLifecycleStateActive {
+						gk := ocv1.SchemeGroupVersion.WithKind("ClusterObjectSet").GroupKind()
+						return apierrors.NewInvalid(gk, "test-ext-1", field.ErrorList{field.Invalid(field.NewPath("spec.lifecycleState"), "Active", "cannot un-archive")})
+					}
+					return client.Apply(ctx, obj, opts...)
+				},
+			},
+			validate: func(t *testing.T, c client.Client) {
+				revList := &ocv1.ClusterObjectSetList{}
+				err := c.List(context.Background(), revList, client.MatchingLabels{labels.OwnerNameKey: ext.Name})
+				require.NoError(t, err)
+				require.Len(t, revList.Items, 1)
+				assert.Equal(t, "test-ext-1", revList.Items[0].Name)
+				assert.Equal(t, ocv1.ClusterObjectSetLifecycleStateArchived, revList.Items[0].Spec.LifecycleState)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1134,6 +1205,136 @@ func TestBoxcutter_Apply(t *testing.T) {
 	}
 }
 
+func TestBoxcutter_Apply_PinnedRevision(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, ocv1.AddToScheme(testScheme))
+	require.NoError(t, corev1.AddToScheme(testScheme))
+
+	ext := &ocv1.ClusterExtension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-ext",
+			UID:  "test-uid",
+		},
+		Spec: ocv1.ClusterExtensionSpec{
+			Namespace:      "test-namespace",
+			ServiceAccount: ocv1.ServiceAccountReference{Name: "test-sa"},
+			PinnedRevision: 1,
+		},
+	}
+	configMap := func(name string) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": name},
+		}}
+	}
+	pinnedObj := configMap("test-cm-v1")
+	pinnedObjJSON, err := json.Marshal(pinnedObj.Object)
+	require.NoError(t, err)
+
+	// The archived revision references its object in a Secret.
+	archived := &ocv1.ClusterObjectSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-ext-1",
+			Labels:      map[string]string{labels.OwnerNameKey: ext.Name},
+			Annotations: map[string]string{labels.BundleVersionKey: "1.0.0", labels.PackageNameKey: "test-package"},
+		},
+		Spec: ocv1.ClusterObjectSetSpec{
+			Revision:            1,
+			LifecycleState:      ocv1.ClusterObjectSetLifecycleStateArchived,
+			CollisionProtection: ocv1.CollisionProtectionPrevent,
+			Phases: []ocv1.ClusterObjectSetPhase{{
+				Name: string(applier.PhaseDeploy),
+				Objects: []ocv1.ClusterObjectSetObject{{
+					Ref: ocv1.ObjectSourceRef{Name: "test-ext-1-objects", Namespace: "olmv1-system", Key: "cm"},
+				}},
+			}},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ext-1-objects", Namespace: "olmv1-system"},
+		Data:       map[string][]byte{"cm": pinnedObjJSON},
+	}
+	latest := &ocv1.ClusterObjectSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-ext-2",
+			Labels:      map[string]string{labels.OwnerNameKey: ext.Name},
+			Annotations: map[string]string{labels.BundleVersionKey: "1.1.0", labels.PackageNameKey: "test-package"},
+		},
+		Spec: ocv1.ClusterObjectSetSpec{
+			Revision:            2,
+			LifecycleState:      ocv1.ClusterObjectSetLifecycleStateActive,
+			CollisionProtection: ocv1.CollisionProtectionPrevent,
+			Phases: []ocv1.ClusterObjectSetPhase{{
+				Name:    string(applier.PhaseDeploy),
+				Objects: []ocv1.ClusterObjectSetObject{{Object: configMap("test-cm-v2")}},
+			}},
+		},
+	}
+
+	newBoxcutter := func(t *testing.T, objs ...client.Object) (*applier.Boxcutter, client.Client) {
+		mockCtrl := gomock.NewController(t)
+		// The bundle content is not used when a revision is pinned.
+		generator := mockapplier.NewMockClusterObjectSetGenerator(mockCtrl)
+		preflight := mockapplier.NewMockPreflight(mockCtrl)
+		preflight.EXPECT().Upgrade(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, objs []client.Object) error {
+			require.Len(t, objs, 1)
+			assert.Equal(t, "test-cm-v1", objs[0].GetName())
+			return nil
+		}).AnyTimes()
+		cl := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(objs...).
+			// The API server rejects changes to the phases of the latest revision.
+			WithInterceptorFuncs(interceptor.Funcs{
+				Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+					cos, ok := obj.(*ocv1ac.ClusterObjectSetApplyConfiguration)
+					if ok && ptr.Deref(cos.GetName(), "") == "test-ext-2" {
+						gk := ocv1.SchemeGroupVersion.WithKind("ClusterObjectSet").GroupKind()
+						return apierrors.NewInvalid(gk, "test-ext-2", field.ErrorList{field.Invalid(field.NewPath("spec.phases"), "", "phases are immutable")})
+					}
+					return c.Apply(ctx, obj, opts...)
+				},
+			}).
+			Build()
+		return &applier.Boxcutter{
+			Client:            cl,
+			Scheme:            testScheme,
+			RevisionGenerator: generator,
+			Preflights:        []applier.Preflight{preflight},
+			FieldOwner:        "test-owner",
+			SystemNamespace:   "olmv1-system",
+		}, cl
+	}
+
+	t.Run("creates a new revision with the content of the pinned revision", func(t *testing.T) {
+		bc, cl := newBoxcutter(t, archived.DeepCopy(), secret.DeepCopy(), latest.DeepCopy())
+
+		completed, _, err := bc.Apply(t.Context(), nil, ext, nil, nil)
+		require.NoError(t, err)
+		assert.True(t, completed)
+
+		rev := &ocv1.ClusterObjectSet{}
+		require.NoError(t, cl.Get(t.Context(), client.ObjectKey{Name: "test-ext-3"}, rev))
+		assert.Equal(t, int64(3), rev.Spec.Revision)
+		assert.Equal(t, ocv1.ClusterObjectSetLifecycleStateActive, rev.Spec.LifecycleState)
+		assert.Equal(t, ocv1.CollisionProtectionPrevent, rev.Spec.CollisionProtection)
+		assert.Equal(t, "1.0.0", rev.Annotations[labels.BundleVersionKey])
+		assert.Equal(t, "test-sa", rev.Annotations[labels.ServiceAccountNameKey])
+		require.Len(t, rev.Spec.Phases, 1)
+		require.Len(t, rev.Spec.Phases[0].Objects, 1)
+		obj, err := applier.ResolveObjectRef(t.Context(), cl, rev.Spec.Phases[0].Objects[0].Ref)
+		require.NoError(t, err)
+		assert.Equal(t, "test-cm-v1", obj.GetName())
+	})
+
+	t.Run("fails when the pinned revision does not exist", func(t *testing.T) {
+		bc, _ := newBoxcutter(t, latest.DeepCopy())
+
+		completed, _, err := bc.Apply(t.Context(), nil, ext, nil, nil)
+		require.EqualError(t, err, "pinned revision 1 not found")
+		assert.False(t, completed)
+	})
+}
+
 func Test_PreAuthorizer_Integration(t *testing.T) {
 	testScheme := runtime.NewScheme()
 	require.NoError(t, ocv1.AddToScheme(testScheme))
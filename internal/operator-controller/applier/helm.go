@@ -3,6 +3,7 @@ package applier
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -28,10 +29,13 @@ import (
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/authorization"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/config"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/contentmanager"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/contentmanager/cache"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/features"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/rukpak/bundle"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/rukpak/util"
+	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
 	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
 )
 
@@ -65,6 +69,7 @@ type Helm struct {
 	PreAuthorizer                 authorization.PreAuthorizer
 	HelmChartProvider             HelmChartProvider
 	HelmReleaseToObjectsConverter HelmReleaseToObjectsConverterInterface
+	PlanClientGetter              PlanClientGetter
 
 	Manager contentmanager.Manager
 	Watcher cache.Watcher
@@ -94,11 +99,10 @@ func (h *Helm) Apply(ctx context.Context, contentFS fs.FS, ext *ocv1.ClusterExte
 		return h.reconcileExistingRelease(ctx, ac, ext)
 	}
 
-	chrt, err := h.buildHelmChart(contentFS, ext)
+	chrt, values, err := h.buildHelmChart(contentFS, ext)
 	if err != nil {
 		return false, "", err
 	}
-	values := chartutil.Values{}
 
 	post := &postrenderer{
 		labels: objectLabels,
@@ -126,22 +130,8 @@ func (h *Helm) Apply(ctx context.Context, contentFS fs.FS, ext *ocv1.ClusterExte
 		return false, "", err
 	}
 
-	for _, preflight := range h.Preflights {
-		if shouldSkipPreflight(ctx, preflight, ext, state) {
-			continue
-		}
-		switch state {
-		case StateNeedsInstall:
-			err := preflight.Install(ctx, objs)
-			if err != nil {
-				return false, "", err
-			}
-		case StateNeedsUpgrade:
-			err := preflight.Upgrade(ctx, objs)
-			if err != nil {
-				return false, "", err
-			}
-		}
+	if err := runPreflights(ctx, h.Preflights, ext, state, objs); err != nil {
+		return false, "", err
 	}
 
 	switch state {
@@ -188,6 +178,59 @@ func (h *Helm) Apply(ctx context.Context, contentFS fs.FS, ext *ocv1.ClusterExte
 	return true, "", nil
 }
 
+// Plan renders the content in the provided fs.FS and runs the same checks as Apply, then returns the
+// changes installing or upgrading the Helm release would make to the cluster, without applying them.
+func (h *Helm) Plan(ctx context.Context, contentFS fs.FS, ext *ocv1.ClusterExtension, objectLabels map[string]string, _ map[string]string) ([]ocv1.PlannedObjectChange, error) {
+	if h.PlanClientGetter == nil {
+		return nil, errors.New("PlanClientGetter is nil")
+	}
+	chrt, values, err := h.buildHelmChart(contentFS, ext)
+	if err != nil {
+		return nil, err
+	}
+
+	post := &postrenderer{
+		labels: objectLabels,
+	}
+
+	if h.PreAuthorizer != nil {
+		if err := h.runPreAuthorizationChecks(ctx, ext, chrt, values, post); err != nil {
+			return nil, err
+		}
+	}
+
+	ac, err := h.ActionClientGetter.ActionClientFor(ctx, ext)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, desiredRel, state, err := h.getReleaseState(ac, ext, chrt, values, post)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release state using server-side dry-run: %w", err)
+	}
+	objs, err := h.HelmReleaseToObjectsConverter.GetObjectsFromRelease(desiredRel)
+	if err != nil {
+		return nil, err
+	}
+	if err := runPreflights(ctx, h.Preflights, ext, state, objs); err != nil {
+		return nil, err
+	}
+
+	var currentObjs []client.Object
+	if rel != nil {
+		currentObjs, err = h.HelmReleaseToObjectsConverter.GetObjectsFromRelease(rel)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cl, err := h.PlanClientGetter.PlanClientFor(ctx, ext)
+	if err != nil {
+		return nil, err
+	}
+	return planObjectChanges(ctx, cl, ext, objs, currentObjs)
+}
+
 // reconcileExistingRelease reconciles an existing Helm release without catalog access.
 // This is used when the catalog is unavailable but we need to maintain the current installation.
 // It reconciles the release to actively maintain resources, and sets up watchers for monitoring/observability.
@@ -244,21 +287,68 @@ func (h *Helm) reconcileExistingRelease(ctx context.Context, ac helmclient.Actio
 	return true, "", nil
 }
 
-func (h *Helm) buildHelmChart(bundleFS fs.FS, ext *ocv1.ClusterExtension) (*chart.Chart, error) {
+// buildHelmChart returns the chart to install for the bundle, along with the values to render it
+// with. Native Helm charts are rendered with the ClusterExtension configuration as values, while
+// the configuration of registry+v1 bundles is already applied by the HelmChartProvider.
+func (h *Helm) buildHelmChart(bundleFS fs.FS, ext *ocv1.ClusterExtension) (*chart.Chart, chartutil.Values, error) {
 	if h.HelmChartProvider == nil {
-		return nil, errors.New("HelmChartProvider is nil")
+		return nil, nil, errors.New("HelmChartProvider is nil")
 	}
 	if features.OperatorControllerFeatureGate.Enabled(features.HelmChartSupport) {
 		meta := new(chart.Metadata)
 		if ok, _ := imageutil.IsBundleSourceChart(bundleFS, meta); ok {
-			return imageutil.LoadChartFSWithOptions(
+			chrt, err := imageutil.LoadChartFSWithOptions(
 				bundleFS,
 				fmt.Sprintf("%s-%s.tgz", meta.Name, meta.Version),
 				imageutil.WithInstallNamespace(ext.Spec.Namespace),
 			)
+			if err != nil {
+				return nil, nil, err
+			}
+			values, err := chartValues(chrt, ext)
+			if err != nil {
+				return nil, nil, err
+			}
+			return chrt, values, nil
 		}
 	}
-	return h.HelmChartProvider.Get(bundleFS, ext)
+	chrt, err := h.HelmChartProvider.Get(bundleFS, ext)
+	if err != nil {
+		return nil, nil, err
+	}
+	return chrt, chartutil.Values{}, nil
+}
+
+// chartValues returns the ClusterExtension configuration as the values of a native Helm chart,
+// after validating them against the values.schema.json of the chart, if it has one.
+func chartValues(chrt *chart.Chart, ext *ocv1.ClusterExtension) (chartutil.Values, error) {
+	values, err := config.UnmarshalConfig(extensionConfigBytes(ext), nil, ext.Spec.Namespace)
+	if err != nil {
+		return nil, errorutil.NewTerminalError(ocv1.ReasonInvalidConfiguration, fmt.Errorf("invalid ClusterExtension configuration: %w", err))
+	}
+
+	schema, err := (&bundle.HelmChart{Chart: chrt}).GetConfigSchema()
+	if err != nil {
+		return nil, fmt.Errorf("error getting configuration schema: %w", err)
+	}
+	if schema == nil {
+		return chartutil.Values(*values), nil
+	}
+
+	// Like Helm, the schema is checked against the values merged with the chart defaults,
+	// so that required values the chart already provides do not have to be configured.
+	merged, err := chartutil.CoalesceValues(chrt, *values)
+	if err != nil {
+		return nil, errorutil.NewTerminalError(ocv1.ReasonInvalidConfiguration, fmt.Errorf("invalid ClusterExtension configuration: %w", err))
+	}
+	mergedBytes, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := config.UnmarshalConfig(mergedBytes, schema, ext.Spec.Namespace); err != nil {
+		return nil, errorutil.NewTerminalError(ocv1.ReasonInvalidConfiguration, fmt.Errorf("invalid ClusterExtension configuration: %w", err))
+	}
+	return chartutil.Values(*values), nil
 }
 
 func (h *Helm) renderClientOnlyRelease(ctx context.Context, ext *ocv1.ClusterExtension, chrt *chart.Chart, values chartutil.Values, post postrender.PostRenderer) (*release.Release, error) {
@@ -0,0 +1,100 @@
+package applier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
+)
+
+func TestChartValues(t *testing.T) {
+	newExt := func(inline string) *ocv1.ClusterExtension {
+		ext := &ocv1.ClusterExtension{Spec: ocv1.ClusterExtensionSpec{Namespace: "test-ns"}}
+		if inline != "" {
+			ext.Spec.Config = &ocv1.ClusterExtensionConfig{
+				ConfigType: ocv1.ClusterExtensionConfigTypeInline,
+				Inline:     &apiextensionsv1.JSON{Raw: []byte(inline)},
+			}
+		}
+		return ext
+	}
+	newChart := func(schema string) *chart.Chart {
+		return &chart.Chart{
+			Metadata: &chart.Metadata{Name: "test-chart", Version: "1.0.0"},
+			Values:   map[string]any{"replicas": 1, "image": map[string]any{"tag": "latest"}},
+			Schema:   []byte(schema),
+		}
+	}
+	const schema = `{
+		"type": "object",
+		"required": ["replicas", "image"],
+		"properties": {
+			"replicas": {"type": "integer"},
+			"image": {"type": "object", "properties": {"tag": {"type": "string"}}}
+		}
+	}`
+
+	for _, tc := range []struct {
+		name           string
+		chart          *chart.Chart
+		inline         string
+		expectedValues chartutil.Values
+		expectedErr    string
+	}{
+		{
+			name:           "no configuration",
+			chart:          newChart(""),
+			expectedValues: chartutil.Values{},
+		},
+		{
+			name:           "configuration without schema",
+			chart:          newChart(""),
+			inline:         `{"replicas": "two"}`,
+			expectedValues: chartutil.Values{"replicas": "two"},
+		},
+		{
+			name:           "configuration valid against schema",
+			chart:          newChart(schema),
+			inline:         `{"image": {"tag": "v1.2.3"}}`,
+			expectedValues: chartutil.Values{"image": map[string]any{"tag": "v1.2.3"}},
+		},
+		{
+			name:           "required values provided by the chart defaults",
+			chart:          newChart(schema),
+			expectedValues: chartutil.Values{},
+		},
+		{
+			name:        "configuration invalid against schema",
+			chart:       newChart(schema),
+			inline:      `{"replicas": "two"}`,
+			expectedErr: `invalid ClusterExtension configuration: invalid configuration: invalid type for field "replicas"`,
+		},
+		{
+			name:        "invalid schema",
+			chart:       newChart(`{"type": `),
+			expectedErr: `error parsing values.schema.json of chart "test-chart"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := chartValues(tc.chart, newExt(tc.inline))
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedValues, values)
+		})
+	}
+
+	t.Run("invalid configuration is a terminal error", func(t *testing.T) {
+		_, err := chartValues(newChart(schema), newExt(`{"replicas": "two"}`))
+		reason, ok := errorutil.ExtractTerminalReason(err)
+		require.True(t, ok)
+		require.Equal(t, ocv1.ReasonInvalidConfiguration, reason)
+	})
+}
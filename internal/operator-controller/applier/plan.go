@@ -0,0 +1,199 @@
+package applier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+// planFieldOwner is the field manager of the server-side dry-run applies used
+// to find out whether a planned object differs from the live object.
+const planFieldOwner = "olm.operatorframework.io/plan"
+
+// PlanClientGetter returns the client used to compare the objects of a plan with
+// the live objects of the cluster. The client should have the same permissions as
+// the client that applies the objects of the ClusterExtension.
+type PlanClientGetter interface {
+	PlanClientFor(context.Context, *ocv1.ClusterExtension) (client.Client, error)
+}
+
+// PlanClientGetterFunc is a function that implements PlanClientGetter.
+type PlanClientGetterFunc func(context.Context, *ocv1.ClusterExtension) (client.Client, error)
+
+func (f PlanClientGetterFunc) PlanClientFor(ctx context.Context, ext *ocv1.ClusterExtension) (client.Client, error) {
+	return f(ctx, ext)
+}
+
+// RestConfigPlanClientGetter is a PlanClientGetter whose clients are configured by a
+// rest config mapper, such as the one used by the applier. The client of a ClusterExtension
+// is created once and reused for as long as its install namespace and service account are
+// unchanged, so that planning a rollout does not set up a new client on every reconcile.
+type RestConfigPlanClientGetter struct {
+	baseConfig       *rest.Config
+	restConfigMapper func(context.Context, client.Object, *rest.Config) (*rest.Config, error)
+	options          client.Options
+
+	mu      sync.Mutex
+	clients map[string]planClient
+}
+
+// planClient is the client of a ClusterExtension and the service account it was created for.
+type planClient struct {
+	serviceAccount types.NamespacedName
+	client         client.Client
+}
+
+func NewRestConfigPlanClientGetter(baseConfig *rest.Config, restConfigMapper func(context.Context, client.Object, *rest.Config) (*rest.Config, error), options client.Options) *RestConfigPlanClientGetter {
+	return &RestConfigPlanClientGetter{
+		baseConfig:       baseConfig,
+		restConfigMapper: restConfigMapper,
+		options:          options,
+		clients:          map[string]planClient{},
+	}
+}
+
+func (g *RestConfigPlanClientGetter) PlanClientFor(ctx context.Context, ext *ocv1.ClusterExtension) (client.Client, error) {
+	sa := types.NamespacedName{Namespace: ext.Spec.Namespace, Name: ext.Spec.ServiceAccount.Name}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if c, ok := g.clients[ext.GetName()]; ok && c.serviceAccount == sa {
+		return c.client, nil
+	}
+
+	restConfig, err := g.restConfigMapper(ctx, ext, g.baseConfig)
+	if err != nil {
+		return nil, err
+	}
+	cl, err := client.New(restConfig, g.options)
+	if err != nil {
+		return nil, err
+	}
+	g.clients[ext.GetName()] = planClient{serviceAccount: sa, client: cl}
+	return cl, nil
+}
+
+// Forget drops the client of the ClusterExtension. It must be called once the
+// ClusterExtension is deleted.
+func (g *RestConfigPlanClientGetter) Forget(extName string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.clients, extName)
+}
+
+// planObjectChanges compares the desired objects of a ClusterExtension with the live
+// objects and with the objects of its current installation:
+//   - desired objects that do not exist are planned to be created.
+//   - desired objects whose server-side dry-run apply differs from the live object are
+//     planned to be updated.
+//   - objects of the current installation that are not desired anymore are planned to
+//     be deleted.
+func planObjectChanges(ctx context.Context, cl client.Client, ext *ocv1.ClusterExtension, desired, current []client.Object) ([]ocv1.PlannedObjectChange, error) {
+	var changes []ocv1.PlannedObjectChange
+	desiredKeys := make(map[planObjectKey]struct{}, len(desired))
+	for _, obj := range desired {
+		u, err := toPlanObject(cl, ext, obj)
+		if err != nil {
+			return nil, err
+		}
+		desiredKeys[planObjectKeyFor(u)] = struct{}{}
+
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(u.GroupVersionKind())
+		err = cl.Get(ctx, client.ObjectKeyFromObject(u), live)
+		if apierrors.IsNotFound(err) {
+			changes = append(changes, plannedObjectChange(ocv1.PlannedActionCreate, u))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("getting %s %q: %w", u.GetKind(), client.ObjectKeyFromObject(u), err)
+		}
+
+		applied := u.DeepCopy()
+		if err := cl.Patch(ctx, applied, client.Apply, client.DryRunAll, client.FieldOwner(planFieldOwner), client.ForceOwnership); err != nil {
+			return nil, fmt.Errorf("dry-run applying %s %q: %w", u.GetKind(), client.ObjectKeyFromObject(u), err)
+		}
+		if !equality.Semantic.DeepEqual(comparableObject(live), comparableObject(applied)) {
+			changes = append(changes, plannedObjectChange(ocv1.PlannedActionUpdate, u))
+		}
+	}
+
+	for _, obj := range current {
+		u, err := toPlanObject(cl, ext, obj)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := desiredKeys[planObjectKeyFor(u)]; !ok {
+			changes = append(changes, plannedObjectChange(ocv1.PlannedActionDelete, u))
+		}
+	}
+	return changes, nil
+}
+
+// planObjectKey identifies an object independently of its API version, so that
+// an object moving to a new API version is planned as an update.
+type planObjectKey struct {
+	schema.GroupKind
+	client.ObjectKey
+}
+
+func planObjectKeyFor(u *unstructured.Unstructured) planObjectKey {
+	return planObjectKey{GroupKind: u.GroupVersionKind().GroupKind(), ObjectKey: client.ObjectKeyFromObject(u)}
+}
+
+// toPlanObject converts obj to an unstructured object, defaulting the namespace of
+// namespace-scoped objects to the install namespace of the ClusterExtension.
+func toPlanObject(cl client.Client, ext *ocv1.ClusterExtension, obj client.Object) (*unstructured.Unstructured, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if ok {
+		u = u.DeepCopy()
+	} else {
+		data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, fmt.Errorf("converting %s %q: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+		u = &unstructured.Unstructured{Object: data}
+	}
+	if u.GetNamespace() == "" {
+		namespaced, err := cl.IsObjectNamespaced(u)
+		if err != nil {
+			return nil, fmt.Errorf("determining scope of %s %q: %w", u.GetKind(), u.GetName(), err)
+		}
+		if namespaced {
+			u.SetNamespace(ext.Spec.Namespace)
+		}
+	}
+	return u, nil
+}
+
+// comparableObject returns a copy of u without the fields that the API server updates
+// on every apply, and without its status, which an apply never changes.
+func comparableObject(u *unstructured.Unstructured) map[string]interface{} {
+	c := u.DeepCopy()
+	c.SetManagedFields(nil)
+	c.SetResourceVersion("")
+	c.SetGeneration(0)
+	unstructured.RemoveNestedField(c.Object, "status")
+	return c.Object
+}
+
+func plannedObjectChange(action string, u *unstructured.Unstructured) ocv1.PlannedObjectChange {
+	return ocv1.PlannedObjectChange{
+		Action:     action,
+		APIVersion: u.GetAPIVersion(),
+		Kind:       u.GetKind(),
+		Namespace:  u.GetNamespace(),
+		Name:       u.GetName(),
+	}
+}
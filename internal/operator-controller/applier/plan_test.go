@@ -0,0 +1,192 @@
+package applier_test
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	ocv1ac "github.com/operator-framework/operator-controller/applyconfigurations/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/applier"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/labels"
+	mockapplier "github.com/operator-framework/operator-controller/internal/testutil/mock/applier"
+)
+
+func planTestConfigMap(name string, data map[string]interface{}) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": name},
+		"data":       data,
+	}}
+}
+
+func TestBoxcutter_Plan(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	require.NoError(t, ocv1.AddToScheme(testScheme))
+	require.NoError(t, corev1.AddToScheme(testScheme))
+
+	ext := &ocv1.ClusterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ext", UID: "test-uid"},
+		Spec: ocv1.ClusterExtensionSpec{
+			Namespace:      "test-namespace",
+			ServiceAccount: ocv1.ServiceAccountReference{Name: "test-sa"},
+		},
+	}
+
+	newGenerator := func(t *testing.T, objs ...unstructured.Unstructured) applier.ClusterObjectSetGenerator {
+		m := mockapplier.NewMockClusterObjectSetGenerator(gomock.NewController(t))
+		m.EXPECT().GenerateRevision(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ fs.FS, _ *ocv1.ClusterExtension, _, _ map[string]string) (*ocv1ac.ClusterObjectSetApplyConfiguration, error) {
+				phase := ocv1ac.ClusterObjectSetPhase().WithName("deploy")
+				for _, obj := range objs {
+					phase.WithObjects(ocv1ac.ClusterObjectSetObject().WithObject(obj))
+				}
+				return ocv1ac.ClusterObjectSet("").WithSpec(ocv1ac.ClusterObjectSetSpec().WithPhases(phase)), nil
+			}).AnyTimes()
+		return m
+	}
+
+	existingRevision := &ocv1.ClusterObjectSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-ext-1",
+			Labels: map[string]string{labels.OwnerNameKey: ext.Name},
+		},
+		Spec: ocv1.ClusterObjectSetSpec{
+			Revision: 1,
+			Phases: []ocv1.ClusterObjectSetPhase{{
+				Name: "deploy",
+				Objects: []ocv1.ClusterObjectSetObject{
+					{Object: planTestConfigMap("unchanged", map[string]interface{}{"key": "value"})},
+					{Object: planTestConfigMap("changed", map[string]interface{}{"key": "value"})},
+					{Object: planTestConfigMap("removed", map[string]interface{}{"key": "value"})},
+				},
+			}},
+		},
+	}
+	liveObjects := []client.Object{
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unchanged", Namespace: "test-namespace"}, Data: map[string]string{"key": "value"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "changed", Namespace: "test-namespace"}, Data: map[string]string{"key": "value"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "removed", Namespace: "test-namespace"}, Data: map[string]string{"key": "value"}},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		existingObjs    []client.Object
+		desiredObjs     []unstructured.Unstructured
+		expectedChanges []ocv1.PlannedObjectChange
+	}{
+		{
+			name: "install plans all objects to be created",
+			desiredObjs: []unstructured.Unstructured{
+				planTestConfigMap("unchanged", map[string]interface{}{"key": "value"}),
+			},
+			expectedChanges: []ocv1.PlannedObjectChange{
+				{Action: ocv1.PlannedActionCreate, APIVersion: "v1", Kind: "ConfigMap", Namespace: "test-namespace", Name: "unchanged"},
+			},
+		},
+		{
+			name:         "upgrade plans created, updated and deleted objects",
+			existingObjs: append([]client.Object{existingRevision}, liveObjects...),
+			desiredObjs: []unstructured.Unstructured{
+				planTestConfigMap("unchanged", map[string]interface{}{"key": "value"}),
+				planTestConfigMap("changed", map[string]interface{}{"key": "new-value"}),
+				planTestConfigMap("added", map[string]interface{}{"key": "value"}),
+			},
+			expectedChanges: []ocv1.PlannedObjectChange{
+				{Action: ocv1.PlannedActionUpdate, APIVersion: "v1", Kind: "ConfigMap", Namespace: "test-namespace", Name: "changed"},
+				{Action: ocv1.PlannedActionCreate, APIVersion: "v1", Kind: "ConfigMap", Namespace: "test-namespace", Name: "added"},
+				{Action: ocv1.PlannedActionDelete, APIVersion: "v1", Kind: "ConfigMap", Namespace: "test-namespace", Name: "removed"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(testScheme)).
+				WithObjects(tc.existingObjs...).
+				Build()
+			bc := &applier.Boxcutter{
+				Client:            fakeClient,
+				Scheme:            testScheme,
+				RevisionGenerator: newGenerator(t, tc.desiredObjs...),
+				FieldOwner:        "test-owner",
+				PlanClientGetter: applier.PlanClientGetterFunc(func(context.Context, *ocv1.ClusterExtension) (client.Client, error) {
+					return fakeClient, nil
+				}),
+			}
+
+			changes, err := bc.Plan(context.Background(), fstest.MapFS{}, ext, nil, nil)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedChanges, changes)
+
+			t.Log("By checking nothing was applied")
+			revisions := &ocv1.ClusterObjectSetList{}
+			require.NoError(t, fakeClient.List(context.Background(), revisions))
+			cms := &corev1.ConfigMapList{}
+			require.NoError(t, fakeClient.List(context.Background(), cms))
+			require.Len(t, tc.existingObjs, len(revisions.Items)+len(cms.Items))
+			for _, cm := range cms.Items {
+				require.Equal(t, map[string]string{"key": "value"}, cm.Data)
+			}
+		})
+	}
+}
+
+func TestBoxcutter_Plan_RequiresPlanClientGetter(t *testing.T) {
+	bc := &applier.Boxcutter{}
+	_, err := bc.Plan(context.Background(), fstest.MapFS{}, &ocv1.ClusterExtension{}, nil, nil)
+	require.EqualError(t, err, "PlanClientGetter is nil")
+}
+
+func TestRestConfigPlanClientGetter(t *testing.T) {
+	var mapped []string
+	getter := applier.NewRestConfigPlanClientGetter(&rest.Config{Host: "https://127.0.0.1:6443"},
+		func(_ context.Context, o client.Object, c *rest.Config) (*rest.Config, error) {
+			ext := o.(*ocv1.ClusterExtension)
+			mapped = append(mapped, ext.Spec.ServiceAccount.Name)
+			return rest.CopyConfig(c), nil
+		},
+		client.Options{Mapper: testrestmapper.TestOnlyStaticRESTMapper(runtime.NewScheme())},
+	)
+	ext := &ocv1.ClusterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ext"},
+		Spec: ocv1.ClusterExtensionSpec{
+			Namespace:      "test-namespace",
+			ServiceAccount: ocv1.ServiceAccountReference{Name: "test-sa"},
+		},
+	}
+
+	t.Log("By checking the client of a ClusterExtension is reused")
+	first, err := getter.PlanClientFor(context.Background(), ext)
+	require.NoError(t, err)
+	second, err := getter.PlanClientFor(context.Background(), ext)
+	require.NoError(t, err)
+	require.Same(t, first, second)
+	require.Equal(t, []string{"test-sa"}, mapped)
+
+	t.Log("By checking a new client is created when the service account changes")
+	ext.Spec.ServiceAccount.Name = "other-sa"
+	third, err := getter.PlanClientFor(context.Background(), ext)
+	require.NoError(t, err)
+	require.NotSame(t, first, third)
+	require.Equal(t, []string{"test-sa", "other-sa"}, mapped)
+
+	t.Log("By checking a new client is created once the ClusterExtension is forgotten")
+	getter.Forget(ext.GetName())
+	_, err = getter.PlanClientFor(context.Background(), ext)
+	require.NoError(t, err)
+	require.Equal(t, []string{"test-sa", "other-sa", "other-sa"}, mapped)
+}
@@ -52,3 +52,24 @@ func shouldSkipPreflight(ctx context.Context, preflight Preflight, ext *ocv1.Clu
 	}
 	return false
 }
+
+// runPreflights runs the preflight checks matching the release state against objs,
+// and returns the error of the first failing check.
+func runPreflights(ctx context.Context, preflights []Preflight, ext *ocv1.ClusterExtension, state string, objs []client.Object) error {
+	for _, preflight := range preflights {
+		if shouldSkipPreflight(ctx, preflight, ext, state) {
+			continue
+		}
+		switch state {
+		case StateNeedsInstall:
+			if err := preflight.Install(ctx, objs); err != nil {
+				return err
+			}
+		case StateNeedsUpgrade:
+			if err := preflight.Upgrade(ctx, objs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
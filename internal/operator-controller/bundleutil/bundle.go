@@ -24,6 +24,55 @@ func GetVersionAndRelease(b declcfg.Bundle) (*declcfg.VersionRelease, error) {
 	return nil, fmt.Errorf("no package property found in bundle %q", b.Name)
 }
 
+// PropertyMaxKubeVersion is the type of the property declaring the highest minor
+// version of Kubernetes a bundle supports, such as "1.33".
+const PropertyMaxKubeVersion = "olm.maxKubeVersion"
+
+// GetMinKubeVersion returns the lowest version of Kubernetes the bundle supports, as
+// declared by the minKubeVersion of its olm.csv.metadata property. It returns nil when
+// the bundle does not declare one.
+func GetMinKubeVersion(b declcfg.Bundle) (*bsemver.Version, error) {
+	for _, p := range b.Properties {
+		if p.Type != property.TypeCSVMetadata {
+			continue
+		}
+		var csvMetadata property.CSVMetadata
+		if err := json.Unmarshal(p.Value, &csvMetadata); err != nil {
+			return nil, fmt.Errorf("error unmarshalling csv metadata property: %w", err)
+		}
+		if csvMetadata.MinKubeVersion == "" {
+			return nil, nil
+		}
+		v, err := bsemver.ParseTolerant(csvMetadata.MinKubeVersion)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing minKubeVersion %q: %w", csvMetadata.MinKubeVersion, err)
+		}
+		return &v, nil
+	}
+	return nil, nil
+}
+
+// GetMaxKubeVersion returns the highest minor version of Kubernetes the bundle supports,
+// as declared by its olm.maxKubeVersion property. Only the major and minor versions of
+// the returned version are meaningful. It returns nil when the bundle does not declare one.
+func GetMaxKubeVersion(b declcfg.Bundle) (*bsemver.Version, error) {
+	for _, p := range b.Properties {
+		if p.Type != PropertyMaxKubeVersion {
+			continue
+		}
+		var maxKubeVersion string
+		if err := json.Unmarshal(p.Value, &maxKubeVersion); err != nil {
+			return nil, fmt.Errorf("error unmarshalling %s property: %w", PropertyMaxKubeVersion, err)
+		}
+		v, err := bsemver.ParseTolerant(maxKubeVersion)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s %q: %w", PropertyMaxKubeVersion, maxKubeVersion, err)
+		}
+		return &v, nil
+	}
+	return nil, nil
+}
+
 // ParseLegacyVersionRelease parses a registry+v1 bundle version string and returns a
 // VersionRelease. Some registry+v1 bundles utilize the build metadata field of the semver version
 // as release information (a semver spec violation maintained for backward compatibility). When the
@@ -143,6 +192,13 @@ func MetadataFor(bundleName string, vr declcfg.VersionRelease) ocv1.BundleMetada
 	}
 }
 
+// VersionString returns the version and release as a single semver version, carrying the
+// release as build metadata like the versions of registry+v1 bundles, such as "1.0.0+2".
+// Pinning the version range of a ClusterExtension to it selects that exact release.
+func VersionString(vr declcfg.VersionRelease) string {
+	return asLegacyRegistryV1Version(vr).String()
+}
+
 // asLegacyRegistryV1Version converts a VersionRelease into a standard semver version.
 // If the VersionRelease's Release field is set, the returned semver version's build
 // metadata is set to the VersionRelease's Release. Otherwise, the build metadata is
@@ -338,3 +338,33 @@ func TestGetVersionAndRelease_Errors(t *testing.T) {
 		require.Contains(t, err.Error(), "error parsing version")
 	})
 }
+
+func TestGetKubeVersions(t *testing.T) {
+	bundle := declcfg.Bundle{
+		Name: "test-bundle",
+		Properties: []property.Property{
+			property.MustBuild(&property.CSVMetadata{MinKubeVersion: "1.30.0"}),
+			{Type: bundleutil.PropertyMaxKubeVersion, Value: json.RawMessage(`"1.33"`)},
+		},
+	}
+	minVersion, err := bundleutil.GetMinKubeVersion(bundle)
+	require.NoError(t, err)
+	require.Equal(t, bsemver.MustParse("1.30.0"), *minVersion)
+	maxVersion, err := bundleutil.GetMaxKubeVersion(bundle)
+	require.NoError(t, err)
+	require.Equal(t, bsemver.MustParse("1.33.0"), *maxVersion)
+
+	t.Log("By checking bundles may not declare the versions")
+	minVersion, err = bundleutil.GetMinKubeVersion(declcfg.Bundle{})
+	require.NoError(t, err)
+	require.Nil(t, minVersion)
+	maxVersion, err = bundleutil.GetMaxKubeVersion(declcfg.Bundle{})
+	require.NoError(t, err)
+	require.Nil(t, maxVersion)
+
+	t.Log("By checking invalid versions are errors")
+	_, err = bundleutil.GetMaxKubeVersion(declcfg.Bundle{Properties: []property.Property{
+		{Type: bundleutil.PropertyMaxKubeVersion, Value: json.RawMessage(`"latest"`)},
+	}})
+	require.ErrorContains(t, err, `error parsing olm.maxKubeVersion "latest"`)
+}
@@ -14,13 +14,22 @@ import (
 	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/client"
 )
 
-var _ client.Cache = &filesystemCache{}
+var (
+	_ client.Cache        = &filesystemCache{}
+	_ client.PackageCache = &filesystemCache{}
+)
+
+// packagesDirName is the directory of the cache holding the content of
+// packages fetched individually. Catalog names cannot start with a dot,
+// so it does not conflict with the directory of a catalog.
+const packagesDirName = ".packages"
 
 func NewFilesystemCache(cachePath string) *filesystemCache {
 	return &filesystemCache{
-		cachePath:              cachePath,
-		mutex:                  sync.RWMutex{},
-		cacheDataByCatalogName: map[string]cacheData{},
+		cachePath:                cachePath,
+		mutex:                    sync.RWMutex{},
+		cacheDataByCatalogName:   map[string]cacheData{},
+		packageDataByCatalogName: map[string]map[string]packageData{},
 	}
 }
 
@@ -33,13 +42,22 @@ type cacheData struct {
 	Error error
 }
 
+// packageData holds the version of the catalog
+// the content of a package was cached for, and
+// the ETag catalogd served the content with.
+type packageData struct {
+	Ref  string
+	ETag string
+}
+
 // FilesystemCache is a cache that
 // uses the local filesystem for caching
 // catalog contents.
 type filesystemCache struct {
-	mutex                  sync.RWMutex
-	cachePath              string
-	cacheDataByCatalogName map[string]cacheData
+	mutex                    sync.RWMutex
+	cachePath                string
+	cacheDataByCatalogName   map[string]cacheData
+	packageDataByCatalogName map[string]map[string]packageData
 }
 
 // Put writes content from source to the filesystem and stores errToCache
@@ -86,7 +104,23 @@ func (fsc *filesystemCache) writeFS(catalogName string, source io.Reader) (fs.FS
 	}
 	defer os.RemoveAll(tmpDir)
 
-	if err := declcfg.WalkMetasReader(source, func(meta *declcfg.Meta, err error) error {
+	if err := writeMetas(tmpDir, source); err != nil {
+		return nil, err
+	}
+
+	if err := os.RemoveAll(cacheDir); err != nil {
+		return nil, fmt.Errorf("error removing old cache directory: %v", err)
+	}
+	if err := os.Rename(tmpDir, cacheDir); err != nil {
+		return nil, fmt.Errorf("error moving temporary directory to cache directory: %v", err)
+	}
+
+	return os.DirFS(cacheDir), nil
+}
+
+// writeMetas writes each meta read from source to a file of dir, grouped by package and schema
+func writeMetas(dir string, source io.Reader) error {
+	return declcfg.WalkMetasReader(source, func(meta *declcfg.Meta, err error) error {
 		if err != nil {
 			return fmt.Errorf("error parsing catalog contents: %v", err)
 		}
@@ -98,7 +132,7 @@ func (fsc *filesystemCache) writeFS(catalogName string, source io.Reader) (fs.FS
 		if meta.Name == "" {
 			metaName = meta.Schema
 		}
-		metaPath := filepath.Join(tmpDir, pkgName, meta.Schema, metaName+".json")
+		metaPath := filepath.Join(dir, pkgName, meta.Schema, metaName+".json")
 		if err := os.MkdirAll(filepath.Dir(metaPath), os.ModePerm); err != nil {
 			return fmt.Errorf("error creating directory for catalog metadata: %v", err)
 		}
@@ -106,18 +140,7 @@ func (fsc *filesystemCache) writeFS(catalogName string, source io.Reader) (fs.FS
 			return fmt.Errorf("error writing catalog metadata to file: %v", err)
 		}
 		return nil
-	}); err != nil {
-		return nil, err
-	}
-
-	if err := os.RemoveAll(cacheDir); err != nil {
-		return nil, fmt.Errorf("error removing old cache directory: %v", err)
-	}
-	if err := os.Rename(tmpDir, cacheDir); err != nil {
-		return nil, fmt.Errorf("error moving temporary directory to cache directory: %v", err)
-	}
-
-	return os.DirFS(cacheDir), nil
+	})
 }
 
 // Get returns cache for a specified catalog name and version (resolvedRef).
@@ -148,13 +171,114 @@ func (fsc *filesystemCache) get(catalogName, resolvedRef string) (fs.FS, error)
 	return nil, nil
 }
 
-// Remove deletes cache directory for a given catalog from the filesystem
+// GetPackage returns the cached content of a package of a catalog
+// for a specified version of the catalog (resolvedRef), along with
+// the ETag catalogd served the content with.
+//
+// Method behaviour is as follows:
+//   - If the content of the package is cached for resolvedRef,
+//     it returns a non-nil fs.FS and its ETag
+//   - If the content of the package is cached for another version
+//     of the catalog, it returns a nil fs.FS and its ETag, so that
+//     the content can be revalidated
+//   - If the content of the package isn't cached, it returns
+//     a nil fs.FS and an empty ETag
+func (fsc *filesystemCache) GetPackage(catalogName, resolvedRef, pkgName string) (fs.FS, string, error) {
+	if err := validatePackageName(pkgName); err != nil {
+		return nil, "", err
+	}
+
+	fsc.mutex.RLock()
+	defer fsc.mutex.RUnlock()
+
+	data, ok := fsc.packageDataByCatalogName[catalogName][pkgName]
+	if !ok {
+		return nil, "", nil
+	}
+	if data.Ref != resolvedRef {
+		return nil, data.ETag, nil
+	}
+	return os.DirFS(fsc.packageDir(catalogName, pkgName)), data.ETag, nil
+}
+
+// PutPackage writes the content of a package of a catalog from source to the
+// filesystem for a specified version of the catalog (resolvedRef), along with
+// the ETag catalogd served the content with.
+//
+// A nil source records that the content cached for another version of the
+// catalog was revalidated, and is still the content of the package for resolvedRef.
+func (fsc *filesystemCache) PutPackage(catalogName, resolvedRef, pkgName, etag string, source io.Reader) (fs.FS, error) {
+	if err := validatePackageName(pkgName); err != nil {
+		return nil, err
+	}
+
+	fsc.mutex.Lock()
+	defer fsc.mutex.Unlock()
+
+	if source != nil {
+		if err := fsc.writePackage(catalogName, pkgName, source); err != nil {
+			return nil, err
+		}
+	} else if _, ok := fsc.packageDataByCatalogName[catalogName][pkgName]; !ok {
+		return nil, fmt.Errorf("error revalidating package %q of catalog %q: package is not cached", pkgName, catalogName)
+	}
+
+	if fsc.packageDataByCatalogName[catalogName] == nil {
+		fsc.packageDataByCatalogName[catalogName] = map[string]packageData{}
+	}
+	fsc.packageDataByCatalogName[catalogName][pkgName] = packageData{
+		Ref:  resolvedRef,
+		ETag: etag,
+	}
+	return os.DirFS(fsc.packageDir(catalogName, pkgName)), nil
+}
+
+func (fsc *filesystemCache) writePackage(catalogName, pkgName string, source io.Reader) error {
+	catalogPackagesDir := filepath.Dir(fsc.packageDir(catalogName, pkgName))
+	if err := os.MkdirAll(catalogPackagesDir, 0700); err != nil {
+		return fmt.Errorf("error creating package cache directory: %v", err)
+	}
+
+	tmpDir, err := os.MkdirTemp(catalogPackagesDir, ".tmp-")
+	if err != nil {
+		return fmt.Errorf("error creating temporary directory to unpack package metadata: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := writeMetas(tmpDir, source); err != nil {
+		return err
+	}
+	// The package directory is created even when the package is not in the catalog
+	tmpPkgDir := filepath.Join(tmpDir, pkgName)
+	if err := os.MkdirAll(tmpPkgDir, 0700); err != nil {
+		return fmt.Errorf("error creating directory for package metadata: %v", err)
+	}
+
+	pkgDir := fsc.packageDir(catalogName, pkgName)
+	if err := os.RemoveAll(pkgDir); err != nil {
+		return fmt.Errorf("error removing old package cache directory: %v", err)
+	}
+	if err := os.Rename(tmpPkgDir, pkgDir); err != nil {
+		return fmt.Errorf("error moving temporary directory to package cache directory: %v", err)
+	}
+	return nil
+}
+
+// Remove deletes cache directory for a given catalog from the filesystem,
+// along with the content of its packages
 func (fsc *filesystemCache) Remove(catalogName string) error {
 	cacheDir := fsc.cacheDir(catalogName)
 
 	fsc.mutex.Lock()
 	defer fsc.mutex.Unlock()
 
+	if _, exists := fsc.packageDataByCatalogName[catalogName]; exists {
+		if err := os.RemoveAll(filepath.Join(fsc.cachePath, packagesDirName, catalogName)); err != nil {
+			return fmt.Errorf("error removing package cache directory: %v", err)
+		}
+		delete(fsc.packageDataByCatalogName, catalogName)
+	}
+
 	if _, exists := fsc.cacheDataByCatalogName[catalogName]; !exists {
 		return nil
 	}
@@ -171,6 +295,19 @@ func (fsc *filesystemCache) cacheDir(catalogName string) string {
 	return filepath.Join(fsc.cachePath, catalogName)
 }
 
+// validatePackageName checks a package name can be used as the name of its cache directory.
+// Names starting with a dot are rejected, so that they do not conflict with temporary directories.
+func validatePackageName(pkgName string) error {
+	if pkgName == "" || strings.HasPrefix(pkgName, ".") || strings.ContainsAny(pkgName, `/\`) {
+		return fmt.Errorf("invalid package name %q", pkgName)
+	}
+	return nil
+}
+
+func (fsc *filesystemCache) packageDir(catalogName, pkgName string) string {
+	return filepath.Join(fsc.cachePath, packagesDirName, catalogName, pkgName)
+}
+
 // removeOrphanedTempDirs removes temporary staging directories left behind by a
 // previous writeFS call for the given catalog that was interrupted before the
 // rename (e.g. pod eviction or crash). Temp dirs use the prefix ".{catalogName}-"
@@ -132,6 +132,82 @@ func TestFilesystemCachePutAndGet(t *testing.T) {
 	assert.Nil(t, actualFSGet)
 }
 
+func TestFilesystemCachePutAndGetPackage(t *testing.T) {
+	const (
+		catalogName  = "test-catalog"
+		resolvedRef1 = "fake/catalog@sha256:fakesha1"
+		resolvedRef2 = "fake/catalog@sha256:fakesha2"
+		etag         = `W/"fake-etag"`
+	)
+	pkgFS := fstest.MapFS{
+		"olm.package/fake1.json":       &fstest.MapFile{Data: []byte(package1)},
+		"olm.bundle/fake1.v1.0.0.json": &fstest.MapFile{Data: []byte(bundle1)},
+		"olm.channel/stable.json":      &fstest.MapFile{Data: []byte(stableChannel)},
+	}
+
+	cacheDir := t.TempDir()
+	c := cache.NewFilesystemCache(cacheDir)
+
+	t.Log("Get package not in cache")
+	actualFSGet, actualETag, err := c.GetPackage(catalogName, resolvedRef1, "fake1")
+	require.NoError(t, err)
+	assert.Nil(t, actualFSGet)
+	assert.Empty(t, actualETag)
+
+	t.Log("Revalidate package not in cache")
+	_, err = c.PutPackage(catalogName, resolvedRef1, "fake1", etag, nil)
+	require.ErrorContains(t, err, "package is not cached")
+
+	t.Log("Put v1 package content into cache")
+	actualFSPut, err := c.PutPackage(catalogName, resolvedRef1, "fake1", etag, defaultContent())
+	require.NoError(t, err)
+	require.NoError(t, equalFilesystems(pkgFS, actualFSPut))
+
+	t.Log("Get v1 package content from cache")
+	actualFSGet, actualETag, err = c.GetPackage(catalogName, resolvedRef1, "fake1")
+	require.NoError(t, err)
+	require.NoError(t, equalFilesystems(pkgFS, actualFSGet))
+	assert.Equal(t, etag, actualETag)
+
+	t.Log("Get ETag of v1 package content for v2")
+	actualFSGet, actualETag, err = c.GetPackage(catalogName, resolvedRef2, "fake1")
+	require.NoError(t, err)
+	assert.Nil(t, actualFSGet)
+	assert.Equal(t, etag, actualETag)
+
+	t.Log("Revalidate v1 package content for v2")
+	actualFSPut, err = c.PutPackage(catalogName, resolvedRef2, "fake1", etag, nil)
+	require.NoError(t, err)
+	require.NoError(t, equalFilesystems(pkgFS, actualFSPut))
+	actualFSGet, _, err = c.GetPackage(catalogName, resolvedRef2, "fake1")
+	require.NoError(t, err)
+	require.NoError(t, equalFilesystems(pkgFS, actualFSGet))
+
+	t.Log("Put content of a package missing from the catalog")
+	actualFSPut, err = c.PutPackage(catalogName, resolvedRef2, "missing", etag, strings.NewReader(""))
+	require.NoError(t, err)
+	require.NoError(t, equalFilesystems(fstest.MapFS{}, actualFSPut))
+
+	t.Log("Put package with an invalid name")
+	_, err = c.PutPackage(catalogName, resolvedRef2, "../fake1", etag, defaultContent())
+	require.ErrorContains(t, err, "invalid package name")
+
+	t.Log("Package content does not conflict with catalog content")
+	_, err = c.Put(catalogName, resolvedRef2, defaultContent(), nil)
+	require.NoError(t, err)
+	actualFSGet, _, err = c.GetPackage(catalogName, resolvedRef2, "fake1")
+	require.NoError(t, err)
+	require.NoError(t, equalFilesystems(pkgFS, actualFSGet))
+
+	t.Log("Remove catalog along with its packages")
+	require.NoError(t, c.Remove(catalogName))
+	actualFSGet, actualETag, err = c.GetPackage(catalogName, resolvedRef2, "fake1")
+	require.NoError(t, err)
+	assert.Nil(t, actualFSGet)
+	assert.Empty(t, actualETag)
+	assert.NoDirExists(t, filepath.Join(cacheDir, ".packages", catalogName))
+}
+
 func TestFilesystemCacheRemove(t *testing.T) {
 	catalogName := "test-catalog"
 	resolvedRef := "fake/catalog@sha256:fakesha"
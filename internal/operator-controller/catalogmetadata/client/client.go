@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 
+	"golang.org/x/sync/singleflight"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -20,7 +21,8 @@ import (
 )
 
 const (
-	clusterCatalogV1ApiURL = "api/v1/all"
+	clusterCatalogV1ApiURL   = "api/v1/all"
+	clusterCatalogV1MetasURL = "api/v1/metas"
 )
 
 type Cache interface {
@@ -48,6 +50,30 @@ type Cache interface {
 	Put(catalogName, resolvedRef string, source io.Reader, errToCache error) (fs.FS, error)
 }
 
+type PackageCache interface {
+	// GetPackage returns the cached content of a package of a catalog
+	// for a specified catalog version (resolvedRef), along with the ETag
+	// catalogd served the content with.
+	//
+	// Method behaviour is as follows:
+	//   - If the content of the package is cached for resolvedRef,
+	//     it returns a non-nil fs.FS and its ETag
+	//   - If the content of the package is cached for another version
+	//     of the catalog, it returns a nil fs.FS and its ETag, so that
+	//     the content can be revalidated
+	//   - If the content of the package isn't cached, it returns
+	//     a nil fs.FS and an empty ETag
+	GetPackage(catalogName, resolvedRef, pkgName string) (fs.FS, string, error)
+
+	// PutPackage writes the content of a package of a catalog from source
+	// in the cache backend for a specified catalog version (resolvedRef),
+	// along with the ETag catalogd served the content with.
+	//
+	// A nil source records that the content cached for another version of
+	// the catalog was revalidated, and is still the content of the package.
+	PutPackage(catalogName, resolvedRef, pkgName, etag string, source io.Reader) (fs.FS, error)
+}
+
 func New(cache Cache, httpClient func() (*http.Client, error)) *Client {
 	return &Client{
 		cache:      cache,
@@ -55,10 +81,21 @@ func New(cache Cache, httpClient func() (*http.Client, error)) *Client {
 	}
 }
 
+// WithPackageCache makes the client fetch the content of each package it is asked for
+// from the metas endpoint of catalogd, and cache it in packageCache. The content of an
+// entire catalog is then only fetched when it is asked for, and PopulateCache does nothing.
+func (c *Client) WithPackageCache(packageCache PackageCache) *Client {
+	c.packageCache = packageCache
+	return c
+}
+
 // Client is reading catalog metadata
 type Client struct {
-	cache      Cache
-	httpClient func() (*http.Client, error)
+	cache        Cache
+	packageCache PackageCache
+	httpClient   func() (*http.Client, error)
+	// fetches deduplicates concurrent requests for the same content
+	fetches singleflight.Group
 }
 
 func (c *Client) GetPackage(ctx context.Context, catalog *ocv1.ClusterCatalog, pkgName string) (*declcfg.DeclarativeConfig, error) {
@@ -66,7 +103,16 @@ func (c *Client) GetPackage(ctx context.Context, catalog *ocv1.ClusterCatalog, p
 		return nil, err
 	}
 
-	catalogFsys, err := c.cache.Get(catalog.Name, catalog.Status.ResolvedSource.Image.Ref)
+	if c.packageCache != nil && pkgName != "" {
+		return c.getPackage(ctx, catalog, pkgName)
+	}
+
+	catalogFsys, err := c.cache.Get(catalog.Name, ResolvedRef(catalog))
+	if c.packageCache != nil && (err != nil || catalogFsys == nil) {
+		// The cache is not populated by the ClusterCatalog controller when packages are
+		// fetched individually, so the content of the catalog is fetched once needed.
+		catalogFsys, err = c.populateCacheOnce(ctx, catalog)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving cache for catalog %q: %v", catalog.Name, err)
 	}
@@ -74,6 +120,15 @@ func (c *Client) GetPackage(ctx context.Context, catalog *ocv1.ClusterCatalog, p
 		return nil, fmt.Errorf("cache for catalog %q not found", catalog.Name)
 	}
 
+	// An empty package name selects the content of the entire catalog
+	if pkgName == "" {
+		catalogFBC, err := declcfg.LoadFS(ctx, catalogFsys)
+		if err != nil {
+			return nil, fmt.Errorf("error loading catalog %q: %v", catalog.Name, err)
+		}
+		return catalogFBC, nil
+	}
+
 	pkgFsys, err := fs.Sub(catalogFsys, pkgName)
 	if err != nil {
 		if !errors.Is(err, fs.ErrNotExist) {
@@ -92,43 +147,118 @@ func (c *Client) GetPackage(ctx context.Context, catalog *ocv1.ClusterCatalog, p
 	return pkgFBC, nil
 }
 
+// getPackage returns the content of a package, fetched from the metas endpoint of catalogd
+// unless it is cached for the current version of the catalog.
+func (c *Client) getPackage(ctx context.Context, catalog *ocv1.ClusterCatalog, pkgName string) (*declcfg.DeclarativeConfig, error) {
+	resolvedRef := ResolvedRef(catalog)
+	pkgFsys, etag, err := c.packageCache.GetPackage(catalog.Name, resolvedRef, pkgName)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving cache for package %q of catalog %q: %v", pkgName, catalog.Name, err)
+	}
+	if pkgFsys == nil {
+		fetched, err, _ := c.fetches.Do(fmt.Sprintf("%s/%s@%s", catalog.Name, pkgName, resolvedRef), func() (interface{}, error) {
+			return c.fetchPackage(ctx, catalog, pkgName, etag)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error fetching package %q of catalog %q: %v", pkgName, catalog.Name, err)
+		}
+		pkgFsys = fetched.(fs.FS)
+	}
+
+	pkgFBC, err := declcfg.LoadFS(ctx, pkgFsys)
+	if err != nil {
+		return nil, fmt.Errorf("error loading package %q: %v", pkgName, err)
+	}
+	return pkgFBC, nil
+}
+
+// fetchPackage fetches the content of a package from the metas endpoint of catalogd. When
+// content of the package is cached for another version of the catalog, it is revalidated
+// with its etag, and only fetched again when it changed.
+func (c *Client) fetchPackage(ctx context.Context, catalog *ocv1.ClusterCatalog, pkgName, etag string) (fs.FS, error) {
+	resp, err := c.doRequest(ctx, catalog, clusterCatalogV1MetasURL, url.Values{"package": []string{pkgName}}, etag)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	resolvedRef := ResolvedRef(catalog)
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return c.packageCache.PutPackage(catalog.Name, resolvedRef, pkgName, resp.Header.Get("Etag"), resp.Body)
+	case http.StatusNotModified:
+		return c.packageCache.PutPackage(catalog.Name, resolvedRef, pkgName, etag, nil)
+	default:
+		// Like in PopulateCache, non-200 responses are not cached so that the next
+		// lookup of the package retries a fresh HTTP request.
+		return nil, fmt.Errorf("error: received unexpected response status code %d", resp.StatusCode)
+	}
+}
+
+// PopulateCache fetches the content of the entire catalog in the cache. It does nothing
+// when the content of packages is fetched individually.
 func (c *Client) PopulateCache(ctx context.Context, catalog *ocv1.ClusterCatalog) (fs.FS, error) {
 	if err := validateCatalog(catalog); err != nil {
 		return nil, err
 	}
+	if c.packageCache != nil {
+		return nil, nil
+	}
+	return c.populateCache(ctx, catalog)
+}
+
+// populateCacheOnce populates the cache, sharing the result with concurrent callers for the same catalog version
+func (c *Client) populateCacheOnce(ctx context.Context, catalog *ocv1.ClusterCatalog) (fs.FS, error) {
+	catalogFsys, err, _ := c.fetches.Do(fmt.Sprintf("%s@%s", catalog.Name, ResolvedRef(catalog)), func() (interface{}, error) {
+		return c.populateCache(ctx, catalog)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return catalogFsys.(fs.FS), nil
+}
 
-	resp, err := c.doRequest(ctx, catalog)
+func (c *Client) populateCache(ctx context.Context, catalog *ocv1.ClusterCatalog) (fs.FS, error) {
+	resp, err := c.doRequest(ctx, catalog, clusterCatalogV1ApiURL, nil, "")
 	if err != nil {
 		// Any errors from the http request we want to cache
 		// so later on cache get they can be bubbled up to the user.
-		return c.cache.Put(catalog.Name, catalog.Status.ResolvedSource.Image.Ref, nil, err)
+		return c.cache.Put(catalog.Name, ResolvedRef(catalog), nil, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		// Do not cache non-200 responses (e.g. 404 from a non-leader catalogd pod).
-		// Returning the error directly lets the next reconcile retry a fresh HTTP
-		// request and eventually hit the leader.
+		// Do not cache non-200 responses (e.g. 404 from a catalogd pod that has not
+		// stored the content of a newly served catalog yet). Returning the error
+		// directly lets the next reconcile retry a fresh HTTP request.
 		return nil, fmt.Errorf("error: received unexpected response status code %d", resp.StatusCode)
 	}
 
-	return c.cache.Put(catalog.Name, catalog.Status.ResolvedSource.Image.Ref, resp.Body, nil)
+	return c.cache.Put(catalog.Name, ResolvedRef(catalog), resp.Body, nil)
 }
 
-func (c *Client) doRequest(ctx context.Context, catalog *ocv1.ClusterCatalog) (*http.Response, error) {
+// doRequest sends a GET request to an endpoint of the catalogd API. A non-empty etag is sent in
+// the If-None-Match header, so that catalogd answers with a 304 status when the content matches it.
+func (c *Client) doRequest(ctx context.Context, catalog *ocv1.ClusterCatalog, endpoint string, query url.Values, etag string) (*http.Response, error) {
 	if catalog.Status.URLs == nil {
 		return nil, fmt.Errorf("error: catalog %q has a nil status.urls value", catalog.Name)
 	}
 
-	catalogdURL, err := url.JoinPath(catalog.Status.URLs.Base, clusterCatalogV1ApiURL)
+	catalogdURL, err := url.JoinPath(catalog.Status.URLs.Base, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("error forming catalogd API endpoint: %v", err)
 	}
+	if len(query) > 0 {
+		catalogdURL += "?" + query.Encode()
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, catalogdURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error forming request: %v", err)
 	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
 
 	client, err := c.httpClient()
 	if err != nil {
@@ -159,9 +289,25 @@ func validateCatalog(catalog *ocv1.ClusterCatalog) error {
 		return fmt.Errorf("error: catalog %q has a nil status.resolvedSource value", catalog.Name)
 	}
 
-	if catalog.Status.ResolvedSource.Image == nil {
-		return fmt.Errorf("error: catalog %q has a nil status.resolvedSource.image value", catalog.Name)
+	if ResolvedRef(catalog) == "" {
+		return fmt.Errorf("error: catalog %q has no resolved image reference nor git commit in status.resolvedSource", catalog.Name)
 	}
 
 	return nil
 }
+
+// ResolvedRef returns the reference identifying the version of the catalog contents currently
+// served: the resolved image reference for image sources, or the commit for git sources.
+// It returns an empty string while the source of the catalog is not resolved.
+func ResolvedRef(catalog *ocv1.ClusterCatalog) string {
+	rs := catalog.Status.ResolvedSource
+	switch {
+	case rs == nil:
+		return ""
+	case rs.Image != nil:
+		return rs.Image.Ref
+	case rs.Git != nil:
+		return rs.Git.Commit
+	}
+	return ""
+}
@@ -129,6 +129,26 @@ func TestClientGetPackage(t *testing.T) {
 				assert.Equal(t, &declcfg.DeclarativeConfig{Packages: []declcfg.Package{{Schema: declcfg.SchemaPackage, Name: "pkg-present"}}}, fbc)
 			},
 		},
+		{
+			name:    "served, empty package name returns entire catalog",
+			catalog: defaultCatalog,
+			pkgName: "",
+			setupCache: func(ctrl *gomock.Controller) catalogclient.Cache {
+				cache := mockcatalogclient.NewMockCache(ctrl)
+				cache.EXPECT().Get(gomock.Any(), gomock.Any()).Return(fstest.MapFS{
+					"pkg-a/olm.package/pkg-a.json": &fstest.MapFile{Data: []byte(`{"schema": "olm.package","name": "pkg-a"}`)},
+					"pkg-b/olm.package/pkg-b.json": &fstest.MapFile{Data: []byte(`{"schema": "olm.package","name": "pkg-b"}`)},
+				}, nil)
+				return cache
+			},
+			assert: func(t *testing.T, fbc *declcfg.DeclarativeConfig, err error) {
+				require.NoError(t, err)
+				assert.ElementsMatch(t, []declcfg.Package{
+					{Schema: declcfg.SchemaPackage, Name: "pkg-a"},
+					{Schema: declcfg.SchemaPackage, Name: "pkg-b"},
+				}, fbc.Packages)
+			},
+		},
 		{
 			name:    "cache unpopulated",
 			catalog: defaultCatalog,
@@ -307,3 +327,155 @@ func TestClientPopulateCache(t *testing.T) {
 		})
 	}
 }
+
+func TestClientGetPackageWithPackageCache(t *testing.T) {
+	pkgFS := fstest.MapFS{
+		"olm.package/pkg-present.json": &fstest.MapFile{Data: []byte(`{"schema": "olm.package","name": "pkg-present"}`)},
+	}
+	catalogFS := fstest.MapFS{
+		"pkg-present/olm.package/pkg-present.json": &fstest.MapFile{Data: []byte(`{"schema": "olm.package","name": "pkg-present"}`)},
+	}
+	const (
+		resolvedRef = "fake/catalog@sha256:fakesha"
+		etag        = `W/"fake-etag"`
+	)
+	metasURL := "https://fake-url.svc.local/catalogs/catalog-1/api/v1/metas?package=pkg-present"
+
+	type testCase struct {
+		name       string
+		pkgName    string
+		setupMocks func(t *testing.T, ctrl *gomock.Controller) (*mockcatalogclient.MockCache, *mockcatalogclient.MockPackageCache, *mockhttputil.MockRoundTripper)
+		assert     func(*testing.T, *declcfg.DeclarativeConfig, error)
+	}
+	for _, tc := range []testCase{
+		{
+			name:    "package cached for the catalog version",
+			pkgName: "pkg-present",
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (*mockcatalogclient.MockCache, *mockcatalogclient.MockPackageCache, *mockhttputil.MockRoundTripper) {
+				packageCache := mockcatalogclient.NewMockPackageCache(ctrl)
+				packageCache.EXPECT().GetPackage("catalog-1", resolvedRef, "pkg-present").Return(pkgFS, etag, nil)
+				return mockcatalogclient.NewMockCache(ctrl), packageCache, mockhttputil.NewMockRoundTripper(ctrl)
+			},
+			assert: func(t *testing.T, dc *declcfg.DeclarativeConfig, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, &declcfg.DeclarativeConfig{Packages: []declcfg.Package{{Schema: declcfg.SchemaPackage, Name: "pkg-present"}}}, dc)
+			},
+		},
+		{
+			name:    "package not cached is fetched from the metas endpoint",
+			pkgName: "pkg-present",
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (*mockcatalogclient.MockCache, *mockcatalogclient.MockPackageCache, *mockhttputil.MockRoundTripper) {
+				packageCache := mockcatalogclient.NewMockPackageCache(ctrl)
+				packageCache.EXPECT().GetPackage("catalog-1", resolvedRef, "pkg-present").Return(nil, "", nil)
+				packageCache.EXPECT().PutPackage("catalog-1", resolvedRef, "pkg-present", etag, gomock.Any()).DoAndReturn(
+					func(catalogName, resolvedRef, pkgName, etag string, source io.Reader) (fs.FS, error) {
+						require.NotNil(t, source)
+						body, err := io.ReadAll(source)
+						require.NoError(t, err)
+						assert.Equal(t, "fake-package-content", string(body))
+						return pkgFS, nil
+					},
+				)
+
+				tripper := mockhttputil.NewMockRoundTripper(ctrl)
+				tripper.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, metasURL, req.URL.String())
+					assert.Empty(t, req.Header.Get("If-None-Match"))
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Etag": []string{etag}},
+						Body:       io.NopCloser(strings.NewReader("fake-package-content")),
+					}, nil
+				})
+				return mockcatalogclient.NewMockCache(ctrl), packageCache, tripper
+			},
+			assert: func(t *testing.T, dc *declcfg.DeclarativeConfig, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, &declcfg.DeclarativeConfig{Packages: []declcfg.Package{{Schema: declcfg.SchemaPackage, Name: "pkg-present"}}}, dc)
+			},
+		},
+		{
+			name:    "package cached for another catalog version is revalidated",
+			pkgName: "pkg-present",
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (*mockcatalogclient.MockCache, *mockcatalogclient.MockPackageCache, *mockhttputil.MockRoundTripper) {
+				packageCache := mockcatalogclient.NewMockPackageCache(ctrl)
+				packageCache.EXPECT().GetPackage("catalog-1", resolvedRef, "pkg-present").Return(nil, etag, nil)
+				packageCache.EXPECT().PutPackage("catalog-1", resolvedRef, "pkg-present", etag, nil).Return(pkgFS, nil)
+
+				tripper := mockhttputil.NewMockRoundTripper(ctrl)
+				tripper.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, etag, req.Header.Get("If-None-Match"))
+					return &http.Response{
+						StatusCode: http.StatusNotModified,
+						Body:       io.NopCloser(strings.NewReader("")),
+					}, nil
+				})
+				return mockcatalogclient.NewMockCache(ctrl), packageCache, tripper
+			},
+			assert: func(t *testing.T, dc *declcfg.DeclarativeConfig, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, &declcfg.DeclarativeConfig{Packages: []declcfg.Package{{Schema: declcfg.SchemaPackage, Name: "pkg-present"}}}, dc)
+			},
+		},
+		{
+			name:    "unexpected http status is not cached",
+			pkgName: "pkg-present",
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (*mockcatalogclient.MockCache, *mockcatalogclient.MockPackageCache, *mockhttputil.MockRoundTripper) {
+				packageCache := mockcatalogclient.NewMockPackageCache(ctrl)
+				packageCache.EXPECT().GetPackage("catalog-1", resolvedRef, "pkg-present").Return(nil, "", nil)
+
+				tripper := mockhttputil.NewMockRoundTripper(ctrl)
+				tripper.EXPECT().RoundTrip(gomock.Any()).Return(&http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(strings.NewReader("404 Not Found")),
+				}, nil)
+				return mockcatalogclient.NewMockCache(ctrl), packageCache, tripper
+			},
+			assert: func(t *testing.T, dc *declcfg.DeclarativeConfig, err error) {
+				assert.ErrorContains(t, err, `error fetching package "pkg-present" of catalog "catalog-1"`)
+				assert.ErrorContains(t, err, "received unexpected response status code 404")
+			},
+		},
+		{
+			name: "entire catalog is fetched once needed",
+			setupMocks: func(t *testing.T, ctrl *gomock.Controller) (*mockcatalogclient.MockCache, *mockcatalogclient.MockPackageCache, *mockhttputil.MockRoundTripper) {
+				cache := mockcatalogclient.NewMockCache(ctrl)
+				cache.EXPECT().Get("catalog-1", resolvedRef).Return(nil, nil)
+				cache.EXPECT().Put("catalog-1", resolvedRef, gomock.Any(), nil).Return(catalogFS, nil)
+
+				tripper := mockhttputil.NewMockRoundTripper(ctrl)
+				tripper.EXPECT().RoundTrip(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "https://fake-url.svc.local/catalogs/catalog-1/api/v1/all", req.URL.String())
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader("fake-catalog-content")),
+					}, nil
+				})
+				return cache, mockcatalogclient.NewMockPackageCache(ctrl), tripper
+			},
+			assert: func(t *testing.T, dc *declcfg.DeclarativeConfig, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, &declcfg.DeclarativeConfig{Packages: []declcfg.Package{{Schema: declcfg.SchemaPackage, Name: "pkg-present"}}}, dc)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cache, packageCache, tripper := tc.setupMocks(t, ctrl)
+			c := catalogclient.New(cache, func() (*http.Client, error) {
+				return &http.Client{Transport: tripper}, nil
+			}).WithPackageCache(packageCache)
+
+			dc, err := c.GetPackage(context.Background(), defaultCatalog(), tc.pkgName)
+			tc.assert(t, dc, err)
+		})
+	}
+
+	t.Run("PopulateCache does nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		c := catalogclient.New(mockcatalogclient.NewMockCache(ctrl), nil).WithPackageCache(mockcatalogclient.NewMockPackageCache(ctrl))
+		fsys, err := c.PopulateCache(context.Background(), defaultCatalog())
+		require.NoError(t, err)
+		assert.Nil(t, fsys)
+	})
+}
@@ -1,6 +1,8 @@
 package filter
 
 import (
+	"time"
+
 	bsemver "github.com/blang/semver/v4"
 
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
@@ -47,3 +49,35 @@ func InAnyChannel(channels ...declcfg.Channel) filter.Predicate[declcfg.Bundle]
 		return false
 	}
 }
+
+// CompatibleWithKubernetes returns a predicate that matches bundles supporting the given
+// version of Kubernetes: it must be at least the minKubeVersion of the bundle, and its
+// minor version must not be higher than the olm.maxKubeVersion of the bundle. Bundles
+// declaring invalid versions never match.
+func CompatibleWithKubernetes(kubeVersion bsemver.Version) filter.Predicate[declcfg.Bundle] {
+	return func(b declcfg.Bundle) bool {
+		minVersion, err := bundleutil.GetMinKubeVersion(b)
+		if err != nil {
+			return false
+		}
+		if minVersion != nil && kubeVersion.LT(*minVersion) {
+			return false
+		}
+		maxVersion, err := bundleutil.GetMaxKubeVersion(b)
+		if err != nil {
+			return false
+		}
+		return maxVersion == nil || kubeVersion.Major < maxVersion.Major ||
+			(kubeVersion.Major == maxVersion.Major && kubeVersion.Minor <= maxVersion.Minor)
+	}
+}
+
+// FirstSeenBefore returns a predicate that matches bundles first seen before the given
+// time, according to firstSeen, which is keyed by bundle name. Bundles missing from
+// firstSeen never match.
+func FirstSeenBefore(firstSeen map[string]time.Time, before time.Time) filter.Predicate[declcfg.Bundle] {
+	return func(b declcfg.Bundle) bool {
+		seen, ok := firstSeen[b.Name]
+		return ok && seen.Before(before)
+	}
+}
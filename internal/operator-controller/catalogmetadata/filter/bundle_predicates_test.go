@@ -3,13 +3,16 @@ package filter_test
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
+	bsemver "github.com/blang/semver/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
 	"github.com/operator-framework/operator-registry/alpha/property"
 
+	"github.com/operator-framework/operator-controller/internal/operator-controller/bundleutil"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/compare"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/filter"
 )
@@ -68,3 +71,37 @@ func TestInAnyChannel(t *testing.T) {
 	assert.False(t, fStable(b2))
 	assert.False(t, fStable(b3))
 }
+
+func TestFirstSeenBefore(t *testing.T) {
+	now := time.Now()
+	f := filter.FirstSeenBefore(map[string]time.Time{
+		"b1": now.Add(-48 * time.Hour),
+		"b2": now.Add(-time.Hour),
+	}, now.Add(-24*time.Hour))
+
+	assert.True(t, f(declcfg.Bundle{Name: "b1"}))
+	assert.False(t, f(declcfg.Bundle{Name: "b2"}))
+	assert.False(t, f(declcfg.Bundle{Name: "b3"}))
+}
+
+func TestCompatibleWithKubernetes(t *testing.T) {
+	newBundle := func(minKubeVersion, maxKubeVersion string) declcfg.Bundle {
+		b := declcfg.Bundle{}
+		if minKubeVersion != "" {
+			b.Properties = append(b.Properties, property.MustBuild(&property.CSVMetadata{MinKubeVersion: minKubeVersion}))
+		}
+		if maxKubeVersion != "" {
+			b.Properties = append(b.Properties, property.Property{Type: bundleutil.PropertyMaxKubeVersion, Value: json.RawMessage(`"` + maxKubeVersion + `"`)})
+		}
+		return b
+	}
+
+	f := filter.CompatibleWithKubernetes(bsemver.MustParse("1.33.2"))
+	assert.True(t, f(newBundle("", "")))
+	assert.True(t, f(newBundle("1.33.2", "1.33")))
+	assert.True(t, f(newBundle("v1.25", "2.0")))
+	assert.False(t, f(newBundle("1.33.3", "")))
+	assert.False(t, f(newBundle("", "1.32")))
+	assert.False(t, f(newBundle("", "1.32.9")))
+	assert.False(t, f(newBundle("", "next")))
+}
@@ -55,6 +55,38 @@ func SuccessorsOf(installedBundle ocv1.BundleMetadata, channels ...declcfg.Chann
 	), nil
 }
 
+// InUpgradeScope returns a predicate that matches bundles whose version is within the
+// given upgrade scope of the installed bundle version: the same major and minor version
+// for the "Patch" scope, and the same major version for the "Minor" scope. Every bundle
+// is within the "Any" scope.
+func InUpgradeScope(installedBundle ocv1.BundleMetadata, scope string) (filter.Predicate[declcfg.Bundle], error) {
+	installedVersionRelease, err := parseInstalledBundleVersionRelease(installedBundle)
+	if err != nil {
+		return nil, err
+	}
+	installed := installedVersionRelease.Version
+
+	var inScope func(bsemver.Version) bool
+	switch scope {
+	case ocv1.UpgradeScopePatch:
+		inScope = func(v bsemver.Version) bool { return v.Major == installed.Major && v.Minor == installed.Minor }
+	case ocv1.UpgradeScopeMinor:
+		inScope = func(v bsemver.Version) bool { return v.Major == installed.Major }
+	case ocv1.UpgradeScopeAny, "":
+		inScope = func(bsemver.Version) bool { return true }
+	default:
+		return nil, fmt.Errorf("unknown upgrade scope %q", scope)
+	}
+
+	return func(candidateBundle declcfg.Bundle) bool {
+		vr, err := bundleutil.GetVersionAndRelease(candidateBundle)
+		if err != nil {
+			return false
+		}
+		return inScope(vr.Version)
+	}, nil
+}
+
 func legacySuccessor(installedBundle ocv1.BundleMetadata, channels ...declcfg.Channel) (filter.Predicate[declcfg.Bundle], error) {
 	installedBundleVersion, err := bsemver.Parse(installedBundle.Version)
 	if err != nil {
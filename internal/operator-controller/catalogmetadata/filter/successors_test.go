@@ -320,3 +320,56 @@ func TestSuccessorsOf_Errors(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestInUpgradeScope(t *testing.T) {
+	bundle := func(version string) declcfg.Bundle {
+		return declcfg.Bundle{
+			Name:       "test-package.v" + version,
+			Properties: []property.Property{property.MustBuildPackage("test-package", version)},
+		}
+	}
+	installedBundle := ocv1.BundleMetadata{Name: "test-package.v1.2.3", Version: "1.2.3"}
+
+	for _, tt := range []struct {
+		scope    string
+		expected map[string]bool
+	}{
+		{
+			scope:    ocv1.UpgradeScopePatch,
+			expected: map[string]bool{"1.2.0": true, "1.2.3": true, "1.2.9": true, "1.3.0": false, "2.2.3": false, "0.2.3": false},
+		},
+		{
+			scope:    ocv1.UpgradeScopeMinor,
+			expected: map[string]bool{"1.2.0": true, "1.2.3": true, "1.2.9": true, "1.3.0": true, "2.2.3": false, "0.2.3": false},
+		},
+		{
+			scope:    ocv1.UpgradeScopeAny,
+			expected: map[string]bool{"1.2.0": true, "1.3.0": true, "2.2.3": true, "0.2.3": true},
+		},
+	} {
+		t.Run(tt.scope, func(t *testing.T) {
+			inScope, err := InUpgradeScope(installedBundle, tt.scope)
+			require.NoError(t, err)
+			for version, expected := range tt.expected {
+				assert.Equal(t, expected, inScope(bundle(version)), "version %s", version)
+			}
+		})
+	}
+
+	t.Run("installed version with release", func(t *testing.T) {
+		inScope, err := InUpgradeScope(ocv1.BundleMetadata{Name: "test-package.v1.2.3+1", Version: "1.2.3+1"}, ocv1.UpgradeScopePatch)
+		require.NoError(t, err)
+		assert.True(t, inScope(bundle("1.2.4+2")))
+		assert.False(t, inScope(bundle("1.3.0+1")))
+	})
+
+	t.Run("invalid installed bundle version", func(t *testing.T) {
+		_, err := InUpgradeScope(ocv1.BundleMetadata{Name: "test", Version: "invalid"}, ocv1.UpgradeScopePatch)
+		require.Error(t, err)
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		_, err := InUpgradeScope(installedBundle, "Major")
+		require.ErrorContains(t, err, `unknown upgrade scope "Major"`)
+	})
+}
@@ -31,6 +31,8 @@ var ConditionTypes = []string{
 	ocv1.TypeChannelDeprecated,
 	ocv1.TypeBundleDeprecated,
 	ocv1.TypeProgressing,
+	ocv1.TypeRolledBack,
+	ocv1.TypeKubernetesUpgradeable,
 }
 
 var ConditionReasons = []string{
@@ -45,4 +47,10 @@ var ConditionReasons = []string{
 	ocv1.ReasonAbsent,
 	ocv1.ReasonRollingOut,
 	ocv1.ReasonProgressDeadlineExceeded,
+	ocv1.ReasonPlanned,
+	ocv1.ReasonUpgradePending,
+	ocv1.ReasonAwaitingMaintenanceWindow,
+	ocv1.ReasonPolicyViolation,
+	ocv1.ReasonCompatible,
+	ocv1.ReasonIncompatible,
 }
@@ -0,0 +1,36 @@
+package config
+
+// Merge merges configuration values read from several sources, in order.
+//
+// The values of a later source take precedence over the values of an earlier one.
+// Objects are merged key by key, recursively. Any other value, including a list,
+// replaces the value of an earlier source, like Helm merges values files.
+//
+// The sources are not modified. The result still has to go through UnmarshalConfig
+// to be validated.
+func Merge(sources ...map[string]any) map[string]any {
+	merged := map[string]any{}
+	for _, src := range sources {
+		mergeInto(merged, src)
+	}
+	return merged
+}
+
+func mergeInto(dst, src map[string]any) {
+	for k, v := range src {
+		srcObj, srcIsObj := v.(map[string]any)
+		dstObj, dstIsObj := dst[k].(map[string]any)
+		switch {
+		case srcIsObj && dstIsObj:
+			mergeInto(dstObj, srcObj)
+		case srcIsObj:
+			// Copy the object so that merging later sources into it
+			// does not modify the source it was read from.
+			obj := map[string]any{}
+			mergeInto(obj, srcObj)
+			dst[k] = obj
+		default:
+			dst[k] = v
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-controller/internal/operator-controller/config"
+)
+
+func TestMerge(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sources  []map[string]any
+		expected map[string]any
+	}{
+		{
+			name:     "no sources",
+			expected: map[string]any{},
+		},
+		{
+			name: "later sources take precedence",
+			sources: []map[string]any{
+				{"a": "1", "b": "1"},
+				{"b": "2", "c": "2"},
+			},
+			expected: map[string]any{"a": "1", "b": "2", "c": "2"},
+		},
+		{
+			name: "objects are merged recursively",
+			sources: []map[string]any{
+				{"proxy": map[string]any{"host": "proxy.example.com", "port": float64(3128)}},
+				{"proxy": map[string]any{"credentials": map[string]any{"user": "admin"}}},
+				{"proxy": map[string]any{"port": float64(8080)}},
+			},
+			expected: map[string]any{"proxy": map[string]any{
+				"host":        "proxy.example.com",
+				"port":        float64(8080),
+				"credentials": map[string]any{"user": "admin"},
+			}},
+		},
+		{
+			name: "lists are replaced",
+			sources: []map[string]any{
+				{"args": []any{"--a", "--b"}},
+				{"args": []any{"--c"}},
+			},
+			expected: map[string]any{"args": []any{"--c"}},
+		},
+		{
+			name: "values of a different type are replaced",
+			sources: []map[string]any{
+				{"a": map[string]any{"b": "c"}, "d": "e"},
+				{"a": "b", "d": map[string]any{"e": "f"}},
+			},
+			expected: map[string]any{"a": "b", "d": map[string]any{"e": "f"}},
+		},
+		{
+			name: "null replaces a value",
+			sources: []map[string]any{
+				{"watchNamespace": "ns"},
+				{"watchNamespace": nil},
+			},
+			expected: map[string]any{"watchNamespace": nil},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, config.Merge(tc.sources...))
+		})
+	}
+}
+
+func TestMergeDoesNotModifySources(t *testing.T) {
+	first := map[string]any{"proxy": map[string]any{"host": "proxy.example.com"}}
+	second := map[string]any{"proxy": map[string]any{"port": float64(8080)}}
+
+	config.Merge(first, second)
+
+	require.Equal(t, map[string]any{"proxy": map[string]any{"host": "proxy.example.com"}}, first)
+	require.Equal(t, map[string]any{"proxy": map[string]any{"port": float64(8080)}}, second)
+}
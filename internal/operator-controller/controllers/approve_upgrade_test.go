@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/utils/ptr"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+func TestApproveUpgradeRelease(t *testing.T) {
+	resolved := ocv1.BundleMetadata{Name: "prometheus.v1.1.0-2", Version: "1.1.0", Release: ptr.To("2")}
+	newState := func() *reconcileState {
+		return &reconcileState{
+			revisionStates: &RevisionStates{Installed: &RevisionMetadata{
+				BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.1.0-1", Version: "1.1.0", Release: ptr.To("1")},
+			}},
+			resolvedRevisionMetadata: &RevisionMetadata{BundleMetadata: resolved},
+		}
+	}
+	newExt := func(approval ocv1.UpgradeApproval) *ocv1.ClusterExtension {
+		approval.Policy = ocv1.UpgradeApprovalManual
+		return &ocv1.ClusterExtension{Spec: ocv1.ClusterExtensionSpec{UpgradeApproval: &approval}}
+	}
+
+	t.Log("By checking approving the version does not approve another release of it")
+	ext := newExt(ocv1.UpgradeApproval{ApprovedVersion: "1.1.0"})
+	res, err := ApproveUpgrade()(context.Background(), newState(), ext)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, &ocv1.PendingUpgrade{Bundle: resolved}, ext.Status.PendingUpgrade)
+	progressingCond := apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeProgressing)
+	require.NotNil(t, progressingCond)
+	require.Equal(t, ocv1.ReasonUpgradePending, progressingCond.Reason)
+	require.Contains(t, progressingCond.Message, `set spec.upgradeApproval.approvedVersion to "1.1.0" and spec.upgradeApproval.approvedRelease to "2"`)
+
+	t.Log("By checking approving another release does not approve the upgrade")
+	ext = newExt(ocv1.UpgradeApproval{ApprovedVersion: "1.1.0", ApprovedRelease: "1"})
+	res, err = ApproveUpgrade()(context.Background(), newState(), ext)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	t.Log("By checking approving the version and release approves the upgrade")
+	ext = newExt(ocv1.UpgradeApproval{ApprovedVersion: "1.1.0", ApprovedRelease: "2"})
+	res, err = ApproveUpgrade()(context.Background(), newState(), ext)
+	require.NoError(t, err)
+	require.Nil(t, res)
+	require.Nil(t, ext.Status.PendingUpgrade)
+
+	t.Log("By checking a release is not approved for a bundle without one")
+	state := newState()
+	state.resolvedRevisionMetadata.Release = nil
+	ext = newExt(ocv1.UpgradeApproval{ApprovedVersion: "1.1.0", ApprovedRelease: "2"})
+	res, err = ApproveUpgrade()(context.Background(), state, ext)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+}
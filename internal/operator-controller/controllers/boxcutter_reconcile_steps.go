@@ -25,6 +25,7 @@ import (
 	"slices"
 
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -54,28 +55,14 @@ func (d *BoxcutterRevisionStatesGetter) GetRevisionStates(ctx context.Context, e
 
 	rs := &RevisionStates{}
 	for _, rev := range existingRevisionList.Items {
+		if ext.Spec.PinnedRevision > 0 && rev.Spec.Revision == ext.Spec.PinnedRevision {
+			rs.Pinned = revisionMetadataFor(&rev)
+		}
 		if rev.Spec.LifecycleState == ocv1.ClusterObjectSetLifecycleStateArchived {
 			continue
 		}
 
-		// TODO: the setting of these annotations (happens in boxcutter applier when we pass in "revisionAnnotations")
-		//   is fairly decoupled from this code where we get the annotations back out. We may want to co-locate
-		//   the set/get logic a bit better to make it more maintainable and less likely to get out of sync.
-		rm := &RevisionMetadata{
-			RevisionName: rev.Name,
-			Package:      rev.Annotations[labels.PackageNameKey],
-			Image:        rev.Annotations[labels.BundleReferenceKey],
-			Conditions:   rev.Status.Conditions,
-			BundleMetadata: ocv1.BundleMetadata{
-				Name:    rev.Annotations[labels.BundleNameKey],
-				Version: rev.Annotations[labels.BundleVersionKey],
-			},
-		}
-		// Only set Release if the annotation key exists (to distinguish "not set" from "explicitly empty")
-		if releaseValue, ok := rev.Annotations[labels.BundleReleaseKey]; ok {
-			rm.Release = &releaseValue
-		}
-
+		rm := revisionMetadataFor(&rev)
 		if apimeta.IsStatusConditionTrue(rev.Status.Conditions, ocv1.ClusterObjectSetTypeSucceeded) {
 			rs.Installed = rm
 		} else {
@@ -83,9 +70,39 @@ func (d *BoxcutterRevisionStatesGetter) GetRevisionStates(ctx context.Context, e
 		}
 	}
 
+	// The latest revision is archived when it was rolled back. It is reported until a newer revision is created.
+	if n := len(existingRevisionList.Items); n > 0 {
+		latest := &existingRevisionList.Items[n-1]
+		if latest.Spec.LifecycleState == ocv1.ClusterObjectSetLifecycleStateArchived &&
+			apimeta.IsStatusConditionTrue(latest.Status.Conditions, ocv1.ClusterObjectSetTypeRolledBack) {
+			rs.RolledBack = revisionMetadataFor(latest)
+		}
+	}
+
 	return rs, nil
 }
 
+func revisionMetadataFor(rev *ocv1.ClusterObjectSet) *RevisionMetadata {
+	// TODO: the setting of these annotations (happens in boxcutter applier when we pass in "revisionAnnotations")
+	//   is fairly decoupled from this code where we get the annotations back out. We may want to co-locate
+	//   the set/get logic a bit better to make it more maintainable and less likely to get out of sync.
+	rm := &RevisionMetadata{
+		RevisionName: rev.Name,
+		Package:      rev.Annotations[labels.PackageNameKey],
+		Image:        rev.Annotations[labels.BundleReferenceKey],
+		Conditions:   rev.Status.Conditions,
+		BundleMetadata: ocv1.BundleMetadata{
+			Name:    rev.Annotations[labels.BundleNameKey],
+			Version: rev.Annotations[labels.BundleVersionKey],
+		},
+	}
+	// Only set Release if the annotation key exists (to distinguish "not set" from "explicitly empty")
+	if releaseValue, ok := rev.Annotations[labels.BundleReleaseKey]; ok {
+		rm.Release = &releaseValue
+	}
+	return rm
+}
+
 func MigrateStorage(m StorageMigrator) ReconcileStepFunc {
 	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
 		objLbls := map[string]string{
@@ -103,22 +120,14 @@ func MigrateStorage(m StorageMigrator) ReconcileStepFunc {
 func ApplyBundleWithBoxcutter(apply func(ctx context.Context, contentFS fs.FS, ext *ocv1.ClusterExtension, objectLabels, revisionAnnotations map[string]string) (bool, string, error)) ReconcileStepFunc {
 	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
 		l := log.FromContext(ctx)
-		revisionAnnotations := map[string]string{
-			labels.BundleNameKey:      state.resolvedRevisionMetadata.Name,
-			labels.PackageNameKey:     state.resolvedRevisionMetadata.Package,
-			labels.BundleVersionKey:   state.resolvedRevisionMetadata.Version,
-			labels.BundleReferenceKey: state.resolvedRevisionMetadata.Image,
-		}
-		if state.resolvedRevisionMetadata.Release != nil {
-			revisionAnnotations[labels.BundleReleaseKey] = *state.resolvedRevisionMetadata.Release
-		}
+		revisionAnnotations := revisionAnnotationsFor(state.resolvedRevisionMetadata)
 		objLbls := map[string]string{
 			labels.OwnerKindKey: ocv1.ClusterExtensionKind,
 			labels.OwnerNameKey: ext.GetName(),
 		}
 
 		l.Info("applying bundle contents")
-		_, _, err := apply(ctx, state.imageFS, ext, objLbls, revisionAnnotations)
+		_, _, err := apply(ctx, state.imageFS, renderedExtension(state, ext), objLbls, revisionAnnotations)
 		if err != nil {
 			// If there was an error applying the resolved bundle,
 			// report the error via the Progressing condition.
@@ -165,6 +174,27 @@ func ApplyBundleWithBoxcutter(apply func(ctx context.Context, contentFS fs.FS, e
 		}
 
 		setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+		setRolledBackStatus(ext, state.revisionStates.RolledBack)
 		return nil, nil
 	}
 }
+
+// setRolledBackStatus sets the RolledBack condition from the rolled back revision, or removes
+// it when the latest revision was not rolled back.
+func setRolledBackStatus(ext *ocv1.ClusterExtension, rolledBack *RevisionMetadata) {
+	var cnd *metav1.Condition
+	if rolledBack != nil {
+		cnd = apimeta.FindStatusCondition(rolledBack.Conditions, ocv1.ClusterObjectSetTypeRolledBack)
+	}
+	if cnd == nil {
+		apimeta.RemoveStatusCondition(&ext.Status.Conditions, ocv1.TypeRolledBack)
+		return
+	}
+	apimeta.SetStatusCondition(&ext.Status.Conditions, metav1.Condition{
+		Type:               ocv1.TypeRolledBack,
+		Status:             metav1.ConditionTrue,
+		Reason:             cnd.Reason,
+		Message:            fmt.Sprintf("upgrade to bundle %q with version %q failed: %s", rolledBack.Name, rolledBack.Version, cnd.Message),
+		ObservedGeneration: ext.GetGeneration(),
+	})
+}
@@ -18,14 +18,20 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"io/fs"
 	"testing"
 	"testing/fstest"
 
 	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/labels"
 )
 
 func TestApplyBundleWithBoxcutter(t *testing.T) {
@@ -148,3 +154,157 @@ func TestApplyBundleWithBoxcutter(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyBundleWithBoxcutterRolledBack(t *testing.T) {
+	installed := &RevisionMetadata{
+		RevisionName:   "ce-1",
+		BundleMetadata: ocv1.BundleMetadata{Name: "test-bundle.v1.0.0", Version: "1.0.0"},
+	}
+	rolledBack := &RevisionMetadata{
+		RevisionName:   "ce-2",
+		BundleMetadata: ocv1.BundleMetadata{Name: "test-bundle.v1.1.0", Version: "1.1.0"},
+		Conditions: []metav1.Condition{{
+			Type:    ocv1.ClusterObjectSetTypeRolledBack,
+			Status:  metav1.ConditionTrue,
+			Reason:  ocv1.ReasonProgressDeadlineExceeded,
+			Message: "Rolled back to revision ce-1. Revision has not rolled out for 10 minute(s).",
+		}},
+	}
+	ext := &ocv1.ClusterExtension{ObjectMeta: metav1.ObjectMeta{Name: "test-ext", Generation: 1}}
+	stepFunc := ApplyBundleWithBoxcutter(func(_ context.Context, _ fs.FS, _ *ocv1.ClusterExtension, _, _ map[string]string) (bool, string, error) {
+		return true, "", nil
+	})
+
+	t.Log("By checking the RolledBack condition is set while the latest revision is rolled back")
+	_, err := stepFunc(context.Background(), &reconcileState{
+		revisionStates:           &RevisionStates{Installed: installed, RolledBack: rolledBack},
+		resolvedRevisionMetadata: rolledBack,
+		imageFS:                  fstest.MapFS{},
+	}, ext)
+	require.NoError(t, err)
+	cnd := apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeRolledBack)
+	require.NotNil(t, cnd)
+	require.Equal(t, metav1.ConditionTrue, cnd.Status)
+	require.Equal(t, ocv1.ReasonProgressDeadlineExceeded, cnd.Reason)
+	require.Equal(t, `upgrade to bundle "test-bundle.v1.1.0" with version "1.1.0" failed: Rolled back to revision ce-1. Revision has not rolled out for 10 minute(s).`, cnd.Message)
+	require.Equal(t, ocv1.BundleMetadata{Name: "test-bundle.v1.0.0", Version: "1.0.0"}, ext.Status.Install.Bundle)
+
+	t.Log("By checking the RolledBack condition is removed once a newer revision is created")
+	_, err = stepFunc(context.Background(), &reconcileState{
+		revisionStates:           &RevisionStates{Installed: installed, RollingOut: []*RevisionMetadata{{RevisionName: "ce-3"}}},
+		resolvedRevisionMetadata: rolledBack,
+		imageFS:                  fstest.MapFS{},
+	}, ext)
+	require.NoError(t, err)
+	require.Nil(t, apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeRolledBack))
+}
+
+func TestBoxcutterRevisionStatesGetterRolledBack(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, ocv1.AddToScheme(scheme))
+	newRevision := func(revision int64, lifecycleState ocv1.ClusterObjectSetLifecycleState, conditions ...metav1.Condition) *ocv1.ClusterObjectSet {
+		return &ocv1.ClusterObjectSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   fmt.Sprintf("test-ext-%d", revision),
+				Labels: map[string]string{labels.OwnerNameKey: "test-ext"},
+				Annotations: map[string]string{
+					labels.BundleNameKey:    fmt.Sprintf("test-bundle.v1.%d.0", revision),
+					labels.BundleVersionKey: fmt.Sprintf("1.%d.0", revision),
+				},
+			},
+			Spec:   ocv1.ClusterObjectSetSpec{Revision: revision, LifecycleState: lifecycleState},
+			Status: ocv1.ClusterObjectSetStatus{Conditions: conditions},
+		}
+	}
+	succeeded := metav1.Condition{Type: ocv1.ClusterObjectSetTypeSucceeded, Status: metav1.ConditionTrue, Reason: ocv1.ReasonSucceeded}
+	rolledBack := metav1.Condition{Type: ocv1.ClusterObjectSetTypeRolledBack, Status: metav1.ConditionTrue, Reason: ocv1.ReasonProgressDeadlineExceeded}
+	ext := &ocv1.ClusterExtension{ObjectMeta: metav1.ObjectMeta{Name: "test-ext"}}
+
+	for _, tc := range []struct {
+		name               string
+		revisions          []client.Object
+		expectedRolledBack string
+	}{
+		{
+			name: "latest revision rolled back",
+			revisions: []client.Object{
+				newRevision(1, ocv1.ClusterObjectSetLifecycleStateActive, succeeded),
+				newRevision(2, ocv1.ClusterObjectSetLifecycleStateArchived, rolledBack),
+			},
+			expectedRolledBack: "test-ext-2",
+		},
+		{
+			name: "rolled back revision superseded by a newer revision",
+			revisions: []client.Object{
+				newRevision(1, ocv1.ClusterObjectSetLifecycleStateActive, succeeded),
+				newRevision(2, ocv1.ClusterObjectSetLifecycleStateArchived, rolledBack),
+				newRevision(3, ocv1.ClusterObjectSetLifecycleStateActive),
+			},
+		},
+		{
+			name: "latest revision archived after an upgrade",
+			revisions: []client.Object{
+				newRevision(1, ocv1.ClusterObjectSetLifecycleStateArchived, succeeded),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			getter := &BoxcutterRevisionStatesGetter{
+				Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.revisions...).Build(),
+			}
+			rs, err := getter.GetRevisionStates(context.Background(), ext)
+			require.NoError(t, err)
+			if tc.expectedRolledBack == "" {
+				require.Nil(t, rs.RolledBack)
+				return
+			}
+			require.NotNil(t, rs.RolledBack)
+			require.Equal(t, tc.expectedRolledBack, rs.RolledBack.RevisionName)
+			require.Equal(t, "1.2.0", rs.RolledBack.Version)
+			require.Equal(t, "test-ext-1", rs.Installed.RevisionName)
+		})
+	}
+}
+
+func TestBoxcutterRevisionStatesGetterPinned(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, ocv1.AddToScheme(scheme))
+	newRevision := func(revision int64, lifecycleState ocv1.ClusterObjectSetLifecycleState) *ocv1.ClusterObjectSet {
+		return &ocv1.ClusterObjectSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   fmt.Sprintf("test-ext-%d", revision),
+				Labels: map[string]string{labels.OwnerNameKey: "test-ext"},
+				Annotations: map[string]string{
+					labels.BundleNameKey:    fmt.Sprintf("test-bundle.v1.%d.0", revision),
+					labels.BundleVersionKey: fmt.Sprintf("1.%d.0", revision),
+				},
+			},
+			Spec: ocv1.ClusterObjectSetSpec{Revision: revision, LifecycleState: lifecycleState},
+		}
+	}
+	getter := &BoxcutterRevisionStatesGetter{
+		Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newRevision(1, ocv1.ClusterObjectSetLifecycleStateArchived),
+			newRevision(2, ocv1.ClusterObjectSetLifecycleStateActive),
+		).Build(),
+	}
+	ext := &ocv1.ClusterExtension{ObjectMeta: metav1.ObjectMeta{Name: "test-ext"}}
+
+	rs, err := getter.GetRevisionStates(context.Background(), ext)
+	require.NoError(t, err)
+	require.Nil(t, rs.Pinned)
+
+	t.Log("By checking an archived revision can be pinned")
+	ext.Spec.PinnedRevision = 1
+	rs, err = getter.GetRevisionStates(context.Background(), ext)
+	require.NoError(t, err)
+	require.NotNil(t, rs.Pinned)
+	require.Equal(t, "test-ext-1", rs.Pinned.RevisionName)
+	require.Equal(t, "1.1.0", rs.Pinned.Version)
+
+	t.Log("By checking a missing revision is not reported as pinned")
+	ext.Spec.PinnedRevision = 3
+	rs, err = getter.GetRevisionStates(context.Background(), ext)
+	require.NoError(t, err)
+	require.Nil(t, rs.Pinned)
+}
@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"sync"
+	"time"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/bundleutil"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/features"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/rukpak/bundle/source"
+	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
+)
+
+// resolvedBundleImage records the digest a bundle image reference resolved to.
+type resolvedBundleImage struct {
+	ref       string
+	canonical string
+	lastPoll  time.Time
+}
+
+// BundleImageResolver unpacks the bundle images of ClusterExtensions whose sourceType
+// is Image. The digest a bundle image reference resolves to is kept in memory, so that
+// a tag is only resolved again when it is due to be polled, when the reference changes
+// or when the controller restarts.
+type BundleImageResolver struct {
+	puller imageutil.Puller
+	cache  imageutil.Cache
+
+	mu       sync.Mutex
+	resolved map[string]resolvedBundleImage
+}
+
+func NewBundleImageResolver(puller imageutil.Puller, cache imageutil.Cache) *BundleImageResolver {
+	return &BundleImageResolver{puller: puller, cache: cache, resolved: map[string]resolvedBundleImage{}}
+}
+
+// Forget drops the digest the bundle image of the ClusterExtension resolved to. It must be
+// called once the ClusterExtension is deleted or no longer installs a bundle image.
+func (r *BundleImageResolver) Forget(extName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.resolved, extName)
+}
+
+// unpack pulls the bundle image of the ClusterExtension and returns its content, the
+// metadata of the bundle it contains and the duration after which the tag of the image
+// must be polled, which is zero when polling is disabled.
+func (r *BundleImageResolver) unpack(ctx context.Context, ext *ocv1.ClusterExtension) (fs.FS, *RevisionMetadata, time.Duration, error) {
+	l := log.FromContext(ctx)
+
+	if ext.Spec.Source.Image == nil {
+		return nil, nil, 0, fmt.Errorf("sourceType %q requires the image field to be set", ocv1.SourceTypeBundleImage)
+	}
+	ref := ext.Spec.Source.Image.Ref
+	var pollInterval time.Duration
+	if ext.Spec.Source.Image.PollIntervalMinutes != nil {
+		pollInterval = time.Duration(*ext.Spec.Source.Image.PollIntervalMinutes) * time.Minute
+	}
+
+	r.mu.Lock()
+	resolved, ok := r.resolved[ext.GetName()]
+	if ok && resolved.ref != ref {
+		// The digest of the previous reference is of no use anymore.
+		delete(r.resolved, ext.GetName())
+		ok = false
+	}
+	r.mu.Unlock()
+
+	now := time.Now()
+	pullRef := ref
+	if ok && (pollInterval == 0 || now.Before(resolved.lastPoll.Add(pollInterval))) {
+		pullRef = resolved.canonical
+	}
+
+	l.V(1).Info("pulling bundle image", "ref", pullRef)
+	imageFS, canonicalRef, _, err := r.puller.Pull(ctx, ext.GetName(), pullRef, r.cache)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("error pulling bundle image %q: %w", pullRef, err)
+	}
+
+	pkg, bm, err := bundleImageMetadata(imageFS)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("error reading bundle image %q: %w", canonicalRef.String(), err)
+	}
+
+	if pullRef == ref {
+		if ok && resolved.canonical != canonicalRef.String() {
+			l.Info("bundle image reference resolved to a new digest", "ref", ref, "previous", resolved.canonical, "current", canonicalRef.String())
+		}
+		resolved = resolvedBundleImage{ref: ref, canonical: canonicalRef.String(), lastPoll: now}
+		r.mu.Lock()
+		r.resolved[ext.GetName()] = resolved
+		r.mu.Unlock()
+	}
+
+	var requeueAfter time.Duration
+	if pollInterval > 0 {
+		requeueAfter = max(time.Until(resolved.lastPoll.Add(pollInterval)), 0)
+	}
+	return imageFS, &RevisionMetadata{
+		Package:        pkg,
+		Image:          canonicalRef.String(),
+		BundleMetadata: *bm,
+	}, requeueAfter, nil
+}
+
+// bundleImageMetadata reads the package name and the metadata of the bundle contained
+// in a bundle image. registry+v1 bundles are described by their ClusterServiceVersion,
+// Helm charts by their Chart.yaml.
+func bundleImageMetadata(imageFS fs.FS) (string, *ocv1.BundleMetadata, error) {
+	if features.OperatorControllerFeatureGate.Enabled(features.HelmChartSupport) {
+		meta := new(chart.Metadata)
+		if ok, _ := imageutil.IsBundleSourceChart(imageFS, meta); ok {
+			vr, err := bundleutil.ParseLegacyVersionRelease(meta.Version)
+			if err != nil {
+				return "", nil, fmt.Errorf("error parsing chart version %q: %w", meta.Version, err)
+			}
+			bm := bundleutil.MetadataFor(fmt.Sprintf("%s.v%s", meta.Name, meta.Version), *vr)
+			return meta.Name, &bm, nil
+		}
+	}
+
+	rv1, err := source.FromFS(imageFS).GetBundle()
+	if err != nil {
+		return "", nil, err
+	}
+	vr, err := bundleutil.ParseLegacyVersionRelease(rv1.CSV.Spec.Version.String())
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing version of ClusterServiceVersion %q: %w", rv1.CSV.Name, err)
+	}
+	bm := bundleutil.MetadataFor(rv1.CSV.Name, *vr)
+	return rv1.PackageName, &bm, nil
+}
@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	catalogclient "github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/client"
 )
 
 type CatalogCache interface {
@@ -64,16 +65,15 @@ func (r *ClusterCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
-	if existingCatalog.Status.ResolvedSource == nil ||
-		existingCatalog.Status.ResolvedSource.Image == nil ||
-		existingCatalog.Status.ResolvedSource.Image.Ref == "" {
+	resolvedRef := catalogclient.ResolvedRef(existingCatalog)
+	if resolvedRef == "" {
 		// Reference is not known yet - skip cache population with no error.
 		// Once the reference is resolved another reconcile cycle
 		// will be triggered and we will progress further.
 		return ctrl.Result{}, nil
 	}
 
-	catalogFsys, err := r.CatalogCache.Get(existingCatalog.Name, existingCatalog.Status.ResolvedSource.Image.Ref)
+	catalogFsys, err := r.CatalogCache.Get(existingCatalog.Name, resolvedRef)
 	if err != nil {
 		l.Info("retrying cache population: found previous error from catalog cache", "cacheErr", err)
 	} else if catalogFsys != nil {
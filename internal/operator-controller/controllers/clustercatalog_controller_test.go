@@ -23,6 +23,7 @@ import (
 
 func TestClusterCatalogReconcilerFinalizers(t *testing.T) {
 	const fakeResolvedRef = "fake/catalog@sha256:fakesha1"
+	const fakeCommit = "0123456789abcdef0123456789abcdef01234567"
 	catalogKey := types.NamespacedName{Name: "test-catalog"}
 
 	for _, tt := range []struct {
@@ -86,6 +87,29 @@ func TestClusterCatalogReconcilerFinalizers(t *testing.T) {
 				return cache, populator
 			},
 		},
+		{
+			name: "git catalog exists - cache unpopulated",
+			catalog: &ocv1.ClusterCatalog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: catalogKey.Name,
+				},
+				Status: ocv1.ClusterCatalogStatus{
+					ResolvedSource: &ocv1.ResolvedCatalogSource{
+						Type: ocv1.SourceTypeGit,
+						Git: &ocv1.ResolvedGitSource{
+							Commit: fakeCommit,
+						},
+					},
+				},
+			},
+			setupMocks: func(ctrl *gomock.Controller) (controllers.CatalogCache, controllers.CatalogCachePopulator) {
+				cache := mockcontrollers.NewMockCatalogCache(ctrl)
+				cache.EXPECT().Get(catalogKey.Name, fakeCommit).Return(nil, nil)
+				populator := mockcontrollers.NewMockCatalogCachePopulator(ctrl)
+				populator.EXPECT().PopulateCache(gomock.Any(), gomock.Any()).Return(nil, nil)
+				return cache, populator
+			},
+		},
 		{
 			name: "catalog exists - catalog not yet resolved",
 			catalog: &ocv1.ClusterCatalog{
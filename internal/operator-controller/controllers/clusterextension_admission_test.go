@@ -514,6 +514,104 @@ func Test_ClusterExtensionAdmissionInlineConfig(t *testing.T) {
 	}
 }
 
+func Test_ClusterExtensionAdmissionConfigReferences(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name              string
+		config            *ocv1.ClusterExtensionConfig
+		additionalConfigs []ocv1.ClusterExtensionConfig
+		errMsg            string
+	}{
+		{
+			name: "accepts ConfigMap reference",
+			config: &ocv1.ClusterExtensionConfig{
+				ConfigType:   ocv1.ClusterExtensionConfigTypeConfigMapRef,
+				ConfigMapRef: &ocv1.ConfigKeyReference{Name: "settings", Key: "values.yaml"},
+			},
+		},
+		{
+			name: "accepts Secret reference",
+			config: &ocv1.ClusterExtensionConfig{
+				ConfigType: ocv1.ClusterExtensionConfigTypeSecretRef,
+				SecretRef:  &ocv1.ConfigKeyReference{Name: "credentials", Key: "values.yaml"},
+			},
+		},
+		{
+			name: "accepts additional configurations",
+			config: &ocv1.ClusterExtensionConfig{
+				ConfigType: ocv1.ClusterExtensionConfigTypeInline,
+				Inline:     &apiextensionsv1.JSON{Raw: []byte(`{"key": "value"}`)},
+			},
+			additionalConfigs: []ocv1.ClusterExtensionConfig{
+				{ConfigType: ocv1.ClusterExtensionConfigTypeConfigMapRef, ConfigMapRef: &ocv1.ConfigKeyReference{Name: "settings", Key: "values.yaml"}},
+				{ConfigType: ocv1.ClusterExtensionConfigTypeSecretRef, SecretRef: &ocv1.ConfigKeyReference{Name: "credentials", Key: "values.yaml"}},
+			},
+		},
+		{
+			name:   "rejects ConfigMapRef without configMapRef",
+			config: &ocv1.ClusterExtensionConfig{ConfigType: ocv1.ClusterExtensionConfigTypeConfigMapRef},
+			errMsg: "configMapRef is required when configType is ConfigMapRef, and forbidden otherwise",
+		},
+		{
+			name: "rejects secretRef with ConfigMapRef",
+			config: &ocv1.ClusterExtensionConfig{
+				ConfigType:   ocv1.ClusterExtensionConfigTypeConfigMapRef,
+				ConfigMapRef: &ocv1.ConfigKeyReference{Name: "settings", Key: "values.yaml"},
+				SecretRef:    &ocv1.ConfigKeyReference{Name: "credentials", Key: "values.yaml"},
+			},
+			errMsg: "secretRef is required when configType is SecretRef, and forbidden otherwise",
+		},
+		{
+			name: "rejects invalid name",
+			config: &ocv1.ClusterExtensionConfig{
+				ConfigType: ocv1.ClusterExtensionConfigTypeSecretRef,
+				SecretRef:  &ocv1.ConfigKeyReference{Name: "Credentials", Key: "values.yaml"},
+			},
+			errMsg: "name must be a valid DNS1123 subdomain",
+		},
+		{
+			name: "rejects invalid key",
+			config: &ocv1.ClusterExtensionConfig{
+				ConfigType: ocv1.ClusterExtensionConfigTypeSecretRef,
+				SecretRef:  &ocv1.ConfigKeyReference{Name: "credentials", Key: "values/yaml"},
+			},
+			errMsg: "spec.config.secretRef.key in body should match",
+		},
+		{
+			name: "rejects invalid additional configuration",
+			additionalConfigs: []ocv1.ClusterExtensionConfig{
+				{ConfigType: ocv1.ClusterExtensionConfigTypeSecretRef},
+			},
+			errMsg: "secretRef is required when configType is SecretRef, and forbidden otherwise",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cl := newClient(t)
+			err := cl.Create(context.Background(), buildClusterExtension(ocv1.ClusterExtensionSpec{
+				Source: ocv1.SourceConfig{
+					SourceType: "Catalog",
+					Catalog: &ocv1.CatalogFilter{
+						PackageName: "package",
+					},
+				},
+				Namespace: "default",
+				ServiceAccount: ocv1.ServiceAccountReference{
+					Name: "default",
+				},
+				Config:            tc.config,
+				AdditionalConfigs: tc.additionalConfigs,
+			}))
+			if tc.errMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.errMsg)
+			}
+		})
+	}
+}
+
 func buildClusterExtension(spec ocv1.ClusterExtensionSpec) *ocv1.ClusterExtension {
 	return &ocv1.ClusterExtension{
 		ObjectMeta: metav1.ObjectMeta{
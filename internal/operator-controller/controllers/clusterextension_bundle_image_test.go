@@ -0,0 +1,227 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+	"time"
+
+	bsemver "github.com/blang/semver/v4"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/image/v5/docker/reference"
+	"go.uber.org/mock/gomock"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/operator-framework/api/pkg/lib/version"
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/controllers"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/labels"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
+	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
+	bundlecsv "github.com/operator-framework/operator-controller/internal/testing/bundle/csv"
+	bundlefs "github.com/operator-framework/operator-controller/internal/testing/bundle/fs"
+	mockcontrollers "github.com/operator-framework/operator-controller/internal/testutil/mock/controllers"
+)
+
+const testBundleImageDigest = "quay.io/operatorhubio/prometheus@sha256:200d4ddb2a73594b91358fe6397424e975205bfbe44614f5846033cad64b3f05"
+
+// recordingPuller records the references it is asked to pull.
+type recordingPuller struct {
+	imageutil.FakePuller
+	refs []string
+}
+
+func (p *recordingPuller) Pull(ctx context.Context, ownerID, ref string, cache imageutil.Cache) (fs.FS, reference.Canonical, time.Time, error) {
+	p.refs = append(p.refs, ref)
+	return p.FakePuller.Pull(ctx, ownerID, ref, cache)
+}
+
+func newBundleImagePuller(t *testing.T) *recordingPuller {
+	csv := bundlecsv.Builder().WithName("prometheus.v1.0.0").Build()
+	csv.Spec.Version = version.OperatorVersion{Version: bsemver.MustParse("1.0.0")}
+	ref, err := reference.ParseNamed(testBundleImageDigest)
+	require.NoError(t, err)
+	canonical, ok := ref.(reference.Canonical)
+	require.True(t, ok)
+	return &recordingPuller{FakePuller: imageutil.FakePuller{
+		ImageFS: bundlefs.Builder().WithPackageName("prometheus").WithCSV(csv).Build(),
+		Ref:     canonical,
+	}}
+}
+
+// withBundleImage installs the prometheus bundle image instead of resolving it from the catalogs.
+func withBundleImage(pollIntervalMinutes *int) extensionOption {
+	return func(ext *ocv1.ClusterExtension) {
+		ext.Spec.Source = ocv1.SourceConfig{
+			SourceType: ocv1.SourceTypeBundleImage,
+			Image: &ocv1.BundleImageSource{
+				Ref:                 "quay.io/operatorhubio/prometheus:latest",
+				PollIntervalMinutes: pollIntervalMinutes,
+			},
+		}
+	}
+}
+
+// unusedResolver fails the test if a bundle is resolved from the catalogs.
+func unusedResolver(t *testing.T) resolve.Func {
+	return func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+		t.Error("unexpected resolution of a bundle image from the catalogs")
+		return nil, nil, nil, errors.New("unexpected resolution")
+	}
+}
+
+func TestClusterExtensionBundleImageInstall(t *testing.T) {
+	puller := newBundleImagePuller(t)
+	var revisionAnnotations map[string]string
+	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
+		d.Resolver = unusedResolver(t)
+		d.ImagePuller = puller
+		applier := mockcontrollers.NewMockApplier(gomock.NewController(t))
+		applier.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ fs.FS, _ *ocv1.ClusterExtension, _, annotations map[string]string) (bool, string, error) {
+				revisionAnnotations = annotations
+				return true, "", nil
+			}).AnyTimes()
+		d.Applier = applier
+	})
+
+	ctx := context.Background()
+	extKey := types.NamespacedName{Name: fmt.Sprintf("cluster-extension-test-%s", rand.String(8))}
+	clusterExtension := newTestExtension(extKey.Name, withBundleImage(nil))
+	require.NoError(t, cl.Create(ctx, clusterExtension))
+
+	t.Log("By running reconcile")
+	res, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+	require.Equal(t, ctrl.Result{}, res)
+
+	t.Log("By checking the bundle was applied from the resolved digest")
+	require.Equal(t, []string{"quay.io/operatorhubio/prometheus:latest"}, puller.refs)
+	require.Equal(t, map[string]string{
+		labels.BundleNameKey:      "prometheus.v1.0.0",
+		labels.PackageNameKey:     "prometheus",
+		labels.BundleVersionKey:   "1.0.0",
+		labels.BundleReferenceKey: testBundleImageDigest,
+	}, revisionAnnotations)
+
+	t.Log("By checking the status fields")
+	require.NoError(t, cl.Get(ctx, extKey, clusterExtension))
+	require.Equal(t, ocv1.BundleMetadata{Name: "prometheus.v1.0.0", Version: "1.0.0", Ref: testBundleImageDigest}, clusterExtension.Status.Install.Bundle)
+	installedCond := apimeta.FindStatusCondition(clusterExtension.Status.Conditions, ocv1.TypeInstalled)
+	require.NotNil(t, installedCond)
+	require.Equal(t, metav1.ConditionTrue, installedCond.Status)
+
+	t.Log("By checking the tag is not resolved again")
+	_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+	require.Equal(t, []string{"quay.io/operatorhubio/prometheus:latest", testBundleImageDigest}, puller.refs)
+
+	t.Log("By checking the tag is resolved when the ref changes")
+	require.NoError(t, cl.Get(ctx, extKey, clusterExtension))
+	clusterExtension.Spec.Source.Image.Ref = "quay.io/operatorhubio/prometheus:v1.0.0"
+	require.NoError(t, cl.Update(ctx, clusterExtension))
+	_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+	require.Equal(t, "quay.io/operatorhubio/prometheus:v1.0.0", puller.refs[len(puller.refs)-1])
+
+	require.NoError(t, cl.DeleteAllOf(ctx, &ocv1.ClusterExtension{}))
+}
+
+func TestClusterExtensionBundleImagePolling(t *testing.T) {
+	puller := newBundleImagePuller(t)
+	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
+		d.Resolver = unusedResolver(t)
+		d.ImagePuller = puller
+		d.Applier = newMockApplier(gomock.NewController(t), true, nil)
+	})
+
+	ctx := context.Background()
+	extKey := types.NamespacedName{Name: fmt.Sprintf("cluster-extension-test-%s", rand.String(8))}
+	pollIntervalMinutes := 5
+	require.NoError(t, cl.Create(ctx, newTestExtension(extKey.Name, withBundleImage(&pollIntervalMinutes))))
+
+	t.Log("By checking the reconcile is requeued for the next poll")
+	res, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+	require.Positive(t, res.RequeueAfter)
+	require.LessOrEqual(t, res.RequeueAfter, 5*time.Minute)
+
+	t.Log("By checking the resolved digest is used until the next poll")
+	res, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+	require.Positive(t, res.RequeueAfter)
+	require.Equal(t, []string{"quay.io/operatorhubio/prometheus:latest", testBundleImageDigest}, puller.refs)
+
+	require.NoError(t, cl.DeleteAllOf(ctx, &ocv1.ClusterExtension{}))
+}
+
+func TestClusterExtensionBundleImageForget(t *testing.T) {
+	puller := newBundleImagePuller(t)
+	bundleImages := controllers.NewBundleImageResolver(puller, nil)
+	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
+		d.Resolver = unusedResolver(t)
+		d.ImagePuller = puller
+		d.BundleImages = bundleImages
+		d.Applier = newMockApplier(gomock.NewController(t), true, nil)
+	})
+
+	ctx := context.Background()
+	extKey := types.NamespacedName{Name: fmt.Sprintf("cluster-extension-test-%s", rand.String(8))}
+	require.NoError(t, cl.Create(ctx, newTestExtension(extKey.Name, withBundleImage(nil))))
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+
+	t.Log("By checking the tag is resolved again once the ClusterExtension is forgotten")
+	bundleImages.Forget(extKey.Name)
+	_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+	require.Equal(t, []string{"quay.io/operatorhubio/prometheus:latest", "quay.io/operatorhubio/prometheus:latest"}, puller.refs)
+
+	require.NoError(t, cl.DeleteAllOf(ctx, &ocv1.ClusterExtension{}))
+}
+
+func TestClusterExtensionBundleImagePullFails(t *testing.T) {
+	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
+		d.Resolver = unusedResolver(t)
+		d.ImagePuller = &imageutil.FakePuller{Error: errors.New("pull failure")}
+		d.Applier = newMockApplier(gomock.NewController(t), true, nil)
+	})
+
+	ctx := context.Background()
+	extKey := types.NamespacedName{Name: fmt.Sprintf("cluster-extension-test-%s", rand.String(8))}
+	clusterExtension := newTestExtension(extKey.Name, withBundleImage(nil))
+	require.NoError(t, cl.Create(ctx, clusterExtension))
+
+	res, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.Error(t, err)
+	require.Equal(t, ctrl.Result{}, res)
+
+	t.Log("By checking the status fields")
+	require.NoError(t, cl.Get(ctx, extKey, clusterExtension))
+	require.Nil(t, clusterExtension.Status.Install)
+	progressingCond := apimeta.FindStatusCondition(clusterExtension.Status.Conditions, ocv1.TypeProgressing)
+	require.NotNil(t, progressingCond)
+	require.Equal(t, metav1.ConditionTrue, progressingCond.Status)
+	require.Equal(t, ocv1.ReasonRetrying, progressingCond.Reason)
+	require.Contains(t, progressingCond.Message, "pull failure")
+
+	require.NoError(t, cl.DeleteAllOf(ctx, &ocv1.ClusterExtension{}))
+}
+
+func TestSourceTypeValidator(t *testing.T) {
+	validate := controllers.SourceTypeValidator(ocv1.SourceTypeCatalog)
+
+	ext := &ocv1.ClusterExtension{Spec: ocv1.ClusterExtensionSpec{Source: ocv1.SourceConfig{SourceType: ocv1.SourceTypeCatalog}}}
+	require.NoError(t, validate(context.Background(), ext))
+
+	ext.Spec.Source.SourceType = ocv1.SourceTypeBundleImage
+	require.EqualError(t, validate(context.Background(), ext), `sourceType "Image" is not supported, supported source types are [Catalog]`)
+}
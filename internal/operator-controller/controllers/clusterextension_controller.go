@@ -23,6 +23,7 @@ import (
 	"io/fs"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"helm.sh/helm/v3/pkg/release"
@@ -48,8 +49,10 @@ import (
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	catalogclient "github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/client"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/conditionsets"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/labels"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
 	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
 	k8sutil "github.com/operator-framework/operator-controller/internal/shared/util/k8s"
 )
@@ -62,9 +65,15 @@ const (
 type reconcileState struct {
 	revisionStates           *RevisionStates
 	resolvedRevisionMetadata *RevisionMetadata
+	resolvedBundle           *declcfg.Bundle
+	resolvedDependencies     []resolve.ResolvedDependency
 	imageFS                  fs.FS
 	resolvedDeprecation      *declcfg.Deprecation
 	hasCatalogData           bool
+	requeueAfter             time.Duration
+	// resolvedConfig is the configuration merged from the configuration sources of the
+	// ClusterExtension, if it does not use spec.config as is.
+	resolvedConfig *ocv1.ClusterExtensionConfig
 }
 
 // ReconcileStepFunc represents a single step in the ClusterExtension reconciliation process.
@@ -81,7 +90,8 @@ type ReconcileSteps []ReconcileStepFunc
 // It takes a context and ClusterExtension object as input and executes each step in the ReconcileSteps slice.
 // If any step returns an error, reconciliation stops and the error is returned.
 // If any step returns a non-nil ctrl.Result, reconciliation stops, and that result is returned.
-// If all steps complete successfully, returns an empty ctrl.Result and nil error.
+// If all steps complete successfully, returns a ctrl.Result requeuing after the requeueAfter
+// duration recorded in the reconcile state, if any, and nil error.
 func (steps *ReconcileSteps) Reconcile(ctx context.Context, ext *ocv1.ClusterExtension) (ctrl.Result, error) {
 	var res *ctrl.Result
 	var err error
@@ -95,7 +105,7 @@ func (steps *ReconcileSteps) Reconcile(ctx context.Context, ext *ocv1.ClusterExt
 			return *res, nil
 		}
 	}
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: s.requeueAfter}, nil
 }
 
 // ClusterExtensionReconciler reconciles a ClusterExtension object
@@ -115,6 +125,13 @@ type Applier interface {
 	Apply(context.Context, fs.FS, *ocv1.ClusterExtension, map[string]string, map[string]string) (bool, string, error)
 }
 
+type Planner interface {
+	// Plan renders the content in the provided fs.FS using the configuration of the provided ClusterExtension,
+	// runs the checks Apply would run and returns the changes applying the content would make to the cluster,
+	// without applying anything. It takes the same labels and storage identifiers as Apply.
+	Plan(context.Context, fs.FS, *ocv1.ClusterExtension, map[string]string, map[string]string) ([]ocv1.PlannedObjectChange, error)
+}
+
 type RevisionStatesGetter interface {
 	GetRevisionStates(ctx context.Context, ext *ocv1.ClusterExtension) (*RevisionStates, error)
 }
@@ -173,11 +190,13 @@ func (r *ClusterExtensionReconciler) Reconcile(ctx context.Context, req ctrl.Req
 // ensureFailureConditionsWithReason keeps every non-deprecation condition present.
 // If one is missing, we add it with the given reason and message so users see why
 // reconcile failed. Deprecation conditions are handled later by SetDeprecationStatus.
+// KubernetesUpgradeable is only reported once a bundle is resolved, as a failure says
+// nothing about whether the cluster can be upgraded.
 //
 //nolint:unparam // reason parameter is designed to be flexible, even if current callers use the same value
 func ensureFailureConditionsWithReason(ext *ocv1.ClusterExtension, reason v1alpha1.ConditionReason, message string) {
 	for _, condType := range conditionsets.ConditionTypes {
-		if isDeprecationCondition(condType) {
+		if isDeprecationCondition(condType) || condType == ocv1.TypeKubernetesUpgradeable {
 			continue
 		}
 		cond := apimeta.FindStatusCondition(ext.Status.Conditions, condType)
@@ -441,10 +460,9 @@ func (r *ClusterExtensionReconciler) SetupWithManager(mgr ctrl.Manager, opts ...
 						return true
 					}
 
-					if oldObject.Status.ResolvedSource != nil && newObject.Status.ResolvedSource != nil {
-						if oldObject.Status.ResolvedSource.Image != nil && newObject.Status.ResolvedSource.Image != nil {
-							return oldObject.Status.ResolvedSource.Image.Ref != newObject.Status.ResolvedSource.Image.Ref
-						}
+					oldRef, newRef := catalogclient.ResolvedRef(oldObject), catalogclient.ResolvedRef(newObject)
+					if oldRef != "" && newRef != "" {
+						return oldRef != newRef
 					}
 					return true
 				},
@@ -509,6 +527,10 @@ type RevisionMetadata struct {
 type RevisionStates struct {
 	Installed  *RevisionMetadata
 	RollingOut []*RevisionMetadata
+	// RolledBack is the latest revision, if it failed to roll out and was rolled back.
+	RolledBack *RevisionMetadata
+	// Pinned is the revision pinned by spec.pinnedRevision, if it exists.
+	Pinned *RevisionMetadata
 }
 
 type HelmRevisionStatesGetter struct {
@@ -0,0 +1,139 @@
+package controllers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	bsemver "github.com/blang/semver/v4"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/labels"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
+)
+
+// withDependencies sets the dependency policy and the dependencies that may be installed.
+func withDependencies(policy ocv1.DependencyPolicy, installable ...string) extensionOption {
+	return func(ext *ocv1.ClusterExtension) {
+		ext.Spec.Source.Catalog.DependencyPolicy = policy
+		ext.Spec.Source.Catalog.InstallableDependencies = installable
+	}
+}
+
+func TestClusterExtensionDependenciesRequireInstalled(t *testing.T) {
+	depPkg := fmt.Sprintf("dep-%s", rand.String(8))
+	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
+		d.Resolver = newTestResolver("1.0.0")
+		d.DependencyResolver = resolve.DependencyFunc(func(ctx context.Context, ext *ocv1.ClusterExtension, bundle *declcfg.Bundle) ([]resolve.ResolvedDependency, error) {
+			b := &declcfg.Bundle{Name: depPkg + ".v2.0.0", Package: depPkg}
+			return []resolve.ResolvedDependency{{
+				PackageName: depPkg,
+				Bundle:      b,
+				Version:     &declcfg.VersionRelease{Version: bsemver.MustParse("2.0.0")},
+				Catalog:     "operatorhub",
+			}}, nil
+		})
+	})
+
+	ctx := context.Background()
+	extKey := types.NamespacedName{Name: fmt.Sprintf("cluster-extension-test-%s", rand.String(8))}
+	clusterExtension := newTestExtension(extKey.Name)
+	require.NoError(t, cl.Create(ctx, clusterExtension))
+
+	t.Log("By running reconcile")
+	res, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+	require.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Second}, res)
+
+	t.Log("By checking the dependency was not installed")
+	depExt := &ocv1.ClusterExtension{}
+	require.Error(t, cl.Get(ctx, types.NamespacedName{Name: depPkg}, depExt))
+
+	t.Log("By checking the status fields")
+	require.NoError(t, cl.Get(ctx, extKey, clusterExtension))
+	require.Equal(t, []ocv1.ResolvedDependency{{
+		PackageName: depPkg,
+		Bundle:      &ocv1.BundleMetadata{Name: depPkg + ".v2.0.0", Version: "2.0.0"},
+		Catalog:     "operatorhub",
+	}}, clusterExtension.Status.ResolvedDependencies)
+
+	progressingCond := apimeta.FindStatusCondition(clusterExtension.Status.Conditions, ocv1.TypeProgressing)
+	require.NotNil(t, progressingCond)
+	require.Equal(t, metav1.ConditionTrue, progressingCond.Status)
+	require.Equal(t, ocv1.ReasonRetrying, progressingCond.Reason)
+	require.Contains(t, progressingCond.Message, fmt.Sprintf("requires packages [%s] that are not installed by any ClusterExtension", depPkg))
+
+	require.NoError(t, cl.DeleteAllOf(ctx, &ocv1.ClusterExtension{}))
+}
+
+func TestClusterExtensionDependenciesInstall(t *testing.T) {
+	depPkg := fmt.Sprintf("dep-%s", rand.String(8))
+	var cl client.Client
+	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
+		d.Resolver = newTestResolver("1.0.0")
+		d.DependencyResolver = resolve.DependencyFunc(func(ctx context.Context, ext *ocv1.ClusterExtension, bundle *declcfg.Bundle) ([]resolve.ResolvedDependency, error) {
+			// The dependency is provided once its ClusterExtension has been created.
+			if err := cl.Get(ctx, types.NamespacedName{Name: depPkg}, &ocv1.ClusterExtension{}); err == nil {
+				return []resolve.ResolvedDependency{{PackageName: depPkg, InstalledBy: depPkg}}, nil
+			}
+			return []resolve.ResolvedDependency{{
+				PackageName: depPkg,
+				Bundle:      &declcfg.Bundle{Name: depPkg + ".v2.0.0", Package: depPkg},
+				Version:     &declcfg.VersionRelease{Version: bsemver.MustParse("2.0.0")},
+				Catalog:     "operatorhub",
+			}}, nil
+		})
+	})
+
+	ctx := context.Background()
+	extKey := types.NamespacedName{Name: fmt.Sprintf("cluster-extension-test-%s", rand.String(8))}
+	clusterExtension := newTestExtension(extKey.Name, withDependencies(ocv1.DependencyPolicyInstall, depPkg))
+	require.NoError(t, cl.Create(ctx, clusterExtension))
+
+	t.Log("By running reconcile")
+	res, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+	require.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Second}, res)
+
+	t.Log("By checking a ClusterExtension was created for the dependency")
+	depExt := &ocv1.ClusterExtension{}
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: depPkg}, depExt))
+	require.Equal(t, extKey.Name, depExt.Annotations[labels.RequiredByKey])
+	require.Equal(t, clusterExtension.Spec.Namespace, depExt.Spec.Namespace)
+	require.Equal(t, clusterExtension.Spec.ServiceAccount, depExt.Spec.ServiceAccount)
+	require.Equal(t, depPkg, depExt.Spec.Source.Catalog.PackageName)
+	require.Equal(t, "2.0.0", depExt.Spec.Source.Catalog.Version)
+	require.Equal(t, []string{depPkg}, depExt.Spec.Source.Catalog.InstallableDependencies)
+	require.Equal(t, map[string]string{ocv1.MetadataNameLabel: "operatorhub"}, depExt.Spec.Source.Catalog.Selector.MatchLabels)
+
+	t.Log("By checking the status fields")
+	require.NoError(t, cl.Get(ctx, extKey, clusterExtension))
+	require.Equal(t, []ocv1.ResolvedDependency{{
+		PackageName:          depPkg,
+		Bundle:               &ocv1.BundleMetadata{Name: depPkg + ".v2.0.0", Version: "2.0.0"},
+		Catalog:              "operatorhub",
+		ClusterExtensionName: depPkg,
+	}}, clusterExtension.Status.ResolvedDependencies)
+
+	progressingCond := apimeta.FindStatusCondition(clusterExtension.Status.Conditions, ocv1.TypeProgressing)
+	require.NotNil(t, progressingCond)
+	require.Equal(t, ocv1.ReasonRetrying, progressingCond.Reason)
+	require.Contains(t, progressingCond.Message, fmt.Sprintf("is waiting for the dependencies [%s] to be installed", depPkg))
+
+	t.Log("By reconciling again while the dependency is being installed")
+	res, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+	require.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Second}, res)
+
+	require.NoError(t, cl.DeleteAllOf(ctx, &ocv1.ClusterExtension{}))
+}
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
+)
+
+func TestRevisionPinningValidator(t *testing.T) {
+	ext := &ocv1.ClusterExtension{}
+	require.NoError(t, RevisionPinningValidator(false)(context.Background(), ext))
+
+	ext.Spec.PinnedRevision = 1
+	require.EqualError(t, RevisionPinningValidator(false)(context.Background(), ext), "pinnedRevision is not supported")
+	require.NoError(t, RevisionPinningValidator(true)(context.Background(), ext))
+}
+
+func TestResolveBundlePinnedRevision(t *testing.T) {
+	installed := &RevisionMetadata{RevisionName: "test-ext-2", BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.1.0", Version: "1.1.0"}}
+	pinned := &RevisionMetadata{RevisionName: "test-ext-1", BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.0.0", Version: "1.0.0"}}
+	// The catalogs are not consulted for a pinned revision.
+	step := ResolveBundle(resolve.Func(func(context.Context, *ocv1.ClusterExtension, *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+		return nil, nil, nil, errors.New("unexpected resolution")
+	}), nil)
+	ext := newTestExtension(func(ext *ocv1.ClusterExtension) { ext.Spec.PinnedRevision = 1 })
+
+	t.Log("By checking the pinned revision is resolved")
+	state := &reconcileState{revisionStates: &RevisionStates{Installed: installed, Pinned: pinned}}
+	res, err := step(context.Background(), state, ext)
+	require.NoError(t, err)
+	require.Nil(t, res)
+	require.Equal(t, pinned, state.resolvedRevisionMetadata)
+
+	t.Log("By checking a missing pinned revision blocks the rollout")
+	state = &reconcileState{revisionStates: &RevisionStates{Installed: installed}}
+	_, err = step(context.Background(), state, ext)
+	require.ErrorContains(t, err, "pinned revision 1 not found")
+	progressingCond := apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeProgressing)
+	require.NotNil(t, progressingCond)
+	require.Equal(t, metav1.ConditionFalse, progressingCond.Status)
+	require.Equal(t, ocv1.ReasonInvalidConfiguration, progressingCond.Reason)
+	installedCond := apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeInstalled)
+	require.NotNil(t, installedCond)
+	require.Equal(t, metav1.ConditionTrue, installedCond.Status)
+}
+
+func TestIsPendingUpgradePinned(t *testing.T) {
+	installed := &RevisionMetadata{BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.1.0", Version: "1.1.0"}}
+	pinned := &RevisionMetadata{BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.0.0", Version: "1.0.0"}}
+
+	require.False(t, isPendingUpgrade(&reconcileState{
+		revisionStates:           &RevisionStates{Installed: installed, Pinned: pinned},
+		resolvedRevisionMetadata: pinned,
+	}), "the rollout of a pinned revision must not be held")
+}
@@ -0,0 +1,159 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/controllers"
+	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
+	mockcontrollers "github.com/operator-framework/operator-controller/internal/testutil/mock/controllers"
+)
+
+// withPlanRolloutMode only plans the rollout of the resolved bundle.
+func withPlanRolloutMode(ext *ocv1.ClusterExtension) {
+	ext.Spec.RolloutMode = ocv1.RolloutModePlan
+}
+
+func TestClusterExtensionPlanDoesNotApply(t *testing.T) {
+	changes := []ocv1.PlannedObjectChange{
+		{Action: ocv1.PlannedActionCreate, APIVersion: "v1", Kind: "ServiceAccount", Namespace: "test-ns", Name: "prometheus-operator"},
+		{Action: ocv1.PlannedActionUpdate, APIVersion: "apps/v1", Kind: "Deployment", Namespace: "test-ns", Name: "prometheus-operator"},
+	}
+	mockCtrl := gomock.NewController(t)
+	planner := mockcontrollers.NewMockPlanner(mockCtrl)
+	planner.EXPECT().Plan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(changes, nil)
+	applier := mockcontrollers.NewMockApplier(mockCtrl)
+	applier.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
+		d.Resolver = newTestResolver("1.0.0")
+		d.ImagePuller = &imageutil.FakePuller{ImageFS: fstest.MapFS{}}
+		d.Planner = planner
+		d.Applier = applier
+	})
+
+	ctx := context.Background()
+	extKey := types.NamespacedName{Name: fmt.Sprintf("cluster-extension-test-%s", rand.String(8))}
+	clusterExtension := newTestExtension(extKey.Name, withPlanRolloutMode)
+	require.NoError(t, cl.Create(ctx, clusterExtension))
+
+	t.Log("By running reconcile")
+	res, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+	require.Equal(t, ctrl.Result{}, res)
+
+	t.Log("By checking the plan is reported in the status")
+	require.NoError(t, cl.Get(ctx, extKey, clusterExtension))
+	require.Nil(t, clusterExtension.Status.Install)
+	require.Equal(t, &ocv1.ClusterExtensionPlan{
+		Bundle:  ocv1.BundleMetadata{Name: "prometheus.v1.0.0", Version: "1.0.0"},
+		Changes: changes,
+	}, clusterExtension.Status.Plan)
+
+	progressingCond := apimeta.FindStatusCondition(clusterExtension.Status.Conditions, ocv1.TypeProgressing)
+	require.NotNil(t, progressingCond)
+	require.Equal(t, metav1.ConditionTrue, progressingCond.Status)
+	require.Equal(t, ocv1.ReasonPlanned, progressingCond.Reason)
+	require.Contains(t, progressingCond.Message, "2 object(s) would be changed")
+
+	installedCond := apimeta.FindStatusCondition(clusterExtension.Status.Conditions, ocv1.TypeInstalled)
+	require.NotNil(t, installedCond)
+	require.Equal(t, metav1.ConditionFalse, installedCond.Status)
+
+	require.NoError(t, cl.DeleteAllOf(ctx, &ocv1.ClusterExtension{}))
+}
+
+func TestClusterExtensionPlanClearedOnApply(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	planner := mockcontrollers.NewMockPlanner(mockCtrl)
+	planner.EXPECT().Plan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
+		d.Resolver = newTestResolver("1.0.0")
+		d.ImagePuller = &imageutil.FakePuller{ImageFS: fstest.MapFS{}}
+		d.Planner = planner
+		d.Applier = newMockApplier(mockCtrl, true, nil)
+	})
+
+	ctx := context.Background()
+	extKey := types.NamespacedName{Name: fmt.Sprintf("cluster-extension-test-%s", rand.String(8))}
+	clusterExtension := newTestExtension(extKey.Name, withPlanRolloutMode)
+	require.NoError(t, cl.Create(ctx, clusterExtension))
+
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+	require.NoError(t, cl.Get(ctx, extKey, clusterExtension))
+	require.NotNil(t, clusterExtension.Status.Plan)
+	require.Empty(t, clusterExtension.Status.Plan.Changes)
+
+	t.Log("By switching the rollout mode to Apply")
+	clusterExtension.Spec.RolloutMode = ocv1.RolloutModeApply
+	require.NoError(t, cl.Update(ctx, clusterExtension))
+	_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+
+	t.Log("By checking the plan is cleared and the bundle is installed")
+	require.NoError(t, cl.Get(ctx, extKey, clusterExtension))
+	require.Nil(t, clusterExtension.Status.Plan)
+	require.NotNil(t, clusterExtension.Status.Install)
+	installedCond := apimeta.FindStatusCondition(clusterExtension.Status.Conditions, ocv1.TypeInstalled)
+	require.NotNil(t, installedCond)
+	require.Equal(t, metav1.ConditionTrue, installedCond.Status)
+
+	require.NoError(t, cl.DeleteAllOf(ctx, &ocv1.ClusterExtension{}))
+}
+
+func TestClusterExtensionPlanFails(t *testing.T) {
+	planner := mockcontrollers.NewMockPlanner(gomock.NewController(t))
+	planner.EXPECT().Plan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("preflight failure"))
+
+	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
+		d.Resolver = newTestResolver("1.0.0")
+		d.ImagePuller = &imageutil.FakePuller{ImageFS: fstest.MapFS{}}
+		d.Planner = planner
+	})
+
+	ctx := context.Background()
+	extKey := types.NamespacedName{Name: fmt.Sprintf("cluster-extension-test-%s", rand.String(8))}
+	clusterExtension := newTestExtension(extKey.Name, withPlanRolloutMode)
+	require.NoError(t, cl.Create(ctx, clusterExtension))
+
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.Error(t, err)
+
+	t.Log("By checking the status fields")
+	require.NoError(t, cl.Get(ctx, extKey, clusterExtension))
+	require.Nil(t, clusterExtension.Status.Plan)
+	progressingCond := apimeta.FindStatusCondition(clusterExtension.Status.Conditions, ocv1.TypeProgressing)
+	require.NotNil(t, progressingCond)
+	require.Equal(t, metav1.ConditionTrue, progressingCond.Status)
+	require.Equal(t, ocv1.ReasonRetrying, progressingCond.Reason)
+	require.Contains(t, progressingCond.Message, "preflight failure")
+
+	require.NoError(t, cl.DeleteAllOf(ctx, &ocv1.ClusterExtension{}))
+}
+
+func TestRolloutModeValidator(t *testing.T) {
+	validate := controllers.RolloutModeValidator(ocv1.RolloutModeApply)
+
+	ext := &ocv1.ClusterExtension{}
+	require.NoError(t, validate(context.Background(), ext))
+
+	ext.Spec.RolloutMode = ocv1.RolloutModeApply
+	require.NoError(t, validate(context.Background(), ext))
+
+	ext.Spec.RolloutMode = ocv1.RolloutModePlan
+	require.EqualError(t, validate(context.Background(), ext), `rolloutMode "Plan" is not supported, supported rollout modes are [Apply]`)
+}
@@ -20,20 +20,29 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/finalizer"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/bundleutil"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/labels"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
+	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
 	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
 )
 
@@ -79,8 +88,14 @@ func ValidateClusterExtension(validators ...ClusterExtensionValidator) Reconcile
 
 		l.V(1).Info("validating cluster extension")
 		var validationErrors []error
+		// Validators return terminal errors with a reason when retrying cannot resolve them
+		var terminalReason string
 		for _, validator := range validators {
 			if err := validator(ctx, ext); err != nil {
+				if reason, ok := errorutil.ExtractTerminalReason(err); ok {
+					terminalReason = reason
+					err = errorutil.UnwrapTerminal(err)
+				}
 				validationErrors = append(validationErrors, err)
 			}
 		}
@@ -93,6 +108,9 @@ func ValidateClusterExtension(validators ...ClusterExtensionValidator) Reconcile
 		// Set status conditions with the validation errors
 		err := fmt.Errorf("operation cannot proceed due to the following validation error(s): %w", errors.Join(validationErrors...))
 		setInstalledStatusConditionUnknown(ext, err.Error())
+		if terminalReason != "" {
+			err = errorutil.NewTerminalError(terminalReason, err)
+		}
 		setStatusProgressing(ext, err)
 		return nil, err
 	}
@@ -137,9 +155,48 @@ func RetrieveRevisionStates(r RevisionStatesGetter) ReconcileStepFunc {
 // (retry resolution). This ensures workload resilience during ClusterCatalog outages while maintaining
 // responsiveness during ClusterCatalog updates.
 func ResolveBundle(r resolve.Resolver, c client.Client) ReconcileStepFunc {
+	// The upgrade excluded by the upgrade scope is read from the report of the resolution,
+	// when the resolver provides one, without reporting the resolution itself.
+	if reporter, ok := r.(resolve.ReportingResolver); ok {
+		return resolveBundle(reporter.ResolveWithReport, false, c)
+	}
+	return resolveBundle(func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, *ocv1.ClusterExtensionResolution, error) {
+		bundle, version, deprecation, err := r.Resolve(ctx, ext, installedBundle)
+		return bundle, version, deprecation, nil, err
+	}, false, c)
+}
+
+// ResolveBundleWithReport is ResolveBundle, and also reports how the bundle was resolved
+// from the catalogs in the ClusterExtension status.
+func ResolveBundleWithReport(r resolve.ReportingResolver, c client.Client) ReconcileStepFunc {
+	return resolveBundle(r.ResolveWithReport, true, c)
+}
+
+type resolveWithReportFunc func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, *ocv1.ClusterExtensionResolution, error)
+
+func resolveBundle(resolveFunc resolveWithReportFunc, reportResolution bool, c client.Client) ReconcileStepFunc {
 	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
 		l := log.FromContext(ctx)
 
+		// A pinned revision is rolled out from its ClusterObjectSet, without consulting the catalogs.
+		if ext.Spec.PinnedRevision > 0 {
+			ext.Status.Resolution = nil
+			ext.Status.OutOfScopeUpgrade = nil
+			installedBundleName := ""
+			if state.revisionStates.Installed != nil {
+				installedBundleName = state.revisionStates.Installed.Name
+			}
+			SetDeprecationStatus(ext, installedBundleName, nil, false)
+			if state.revisionStates.Pinned == nil {
+				err := errorutil.NewTerminalError(ocv1.ReasonInvalidConfiguration, fmt.Errorf("pinned revision %d not found", ext.Spec.PinnedRevision))
+				setStatusProgressing(ext, err)
+				setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+				return nil, err
+			}
+			state.resolvedRevisionMetadata = state.revisionStates.Pinned
+			return nil, nil
+		}
+
 		// If already rolling out, use existing revision and set deprecation to Unknown (no catalog check)
 		if len(state.revisionStates.RollingOut) > 0 {
 			installedBundleName := ""
@@ -151,13 +208,30 @@ func ResolveBundle(r resolve.Resolver, c client.Client) ReconcileStepFunc {
 			return nil, nil
 		}
 
+		// A bundle image is installed as is, without consulting the catalogs. Its
+		// metadata is read from the image content when it is unpacked.
+		if ext.Spec.Source.SourceType == ocv1.SourceTypeBundleImage {
+			ext.Status.Resolution = nil
+			ext.Status.OutOfScopeUpgrade = nil
+			installedBundleName := ""
+			if state.revisionStates.Installed != nil {
+				installedBundleName = state.revisionStates.Installed.Name
+			}
+			SetDeprecationStatus(ext, installedBundleName, nil, false)
+			return nil, nil
+		}
+
 		// Resolve a new bundle from the catalog
 		l.V(1).Info("resolving bundle")
 		var bm *ocv1.BundleMetadata
 		if state.revisionStates.Installed != nil {
 			bm = &state.revisionStates.Installed.BundleMetadata
 		}
-		resolvedBundle, resolvedBundleVersion, resolvedDeprecation, err := r.Resolve(ctx, ext, bm)
+		resolvedBundle, resolvedBundleVersion, resolvedDeprecation, resolution, err := resolveFunc(ctx, ext, bm)
+		ext.Status.Resolution = nil
+		if reportResolution {
+			ext.Status.Resolution = resolution
+		}
 
 		// Get the installed bundle name for deprecation status.
 		// BundleDeprecated should reflect what's currently running, not what we're trying to install.
@@ -185,9 +259,18 @@ func ResolveBundle(r resolve.Resolver, c client.Client) ReconcileStepFunc {
 		SetDeprecationStatus(ext, installedBundleName, resolvedDeprecation, hasCatalogData)
 
 		if err != nil {
+			ext.Status.OutOfScopeUpgrade = nil
 			return handleResolutionError(ctx, c, state, ext, err)
 		}
+		ext.Status.OutOfScopeUpgrade = outOfScopeUpgrade(resolution)
+
+		// Bundles become eligible for upgrades as they soak in their catalog, without the
+		// catalog changing, so resolve them again periodically.
+		if bm != nil && ext.Spec.Source.Catalog.SoakTimeMinutes > 0 {
+			state.requeueAfter = soakTimeRequeueInterval
+		}
 
+		state.resolvedBundle = resolvedBundle
 		state.resolvedRevisionMetadata = &RevisionMetadata{
 			Package: resolvedBundle.Package,
 			Image:   resolvedBundle.Image,
@@ -203,6 +286,210 @@ func ResolveBundle(r resolve.Resolver, c client.Client) ReconcileStepFunc {
 	}
 }
 
+// outOfScopeUpgrade returns the bundle the ClusterExtension would be upgraded to if its
+// upgrade scope did not limit upgrades: the newest bundle the upgrade scope eliminated in
+// the catalog the bundle was resolved from, as reported by the resolution. It returns nil
+// when the upgrade scope does not exclude any newer bundle, or no report is available.
+func outOfScopeUpgrade(resolution *ocv1.ClusterExtensionResolution) *ocv1.BundleMetadata {
+	if resolution == nil {
+		return nil
+	}
+	for _, catalog := range resolution.Catalogs {
+		if catalog.Name == resolution.Catalog {
+			return catalog.OutOfScopeCandidate.DeepCopy()
+		}
+	}
+	return nil
+}
+
+// soakTimeRequeueInterval is how long to wait before resolving the bundle again, to
+// upgrade to bundles that soaked in their catalog in the meantime.
+const soakTimeRequeueInterval = 15 * time.Minute
+
+// dependencyRequeueInterval is how long to wait before checking again whether the
+// dependencies of the resolved bundle have been installed.
+const dependencyRequeueInterval = 30 * time.Second
+
+// ResolveDependencies resolves the dependencies declared by the bundle selected in the
+// ResolveBundle step and reports them in the ClusterExtension status. The dependencies are
+// installed, or awaited, by the InstallDependencies step once the bundle is cleared to roll out.
+func ResolveDependencies(r resolve.DependencyResolver) ReconcileStepFunc {
+	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
+		l := log.FromContext(ctx)
+
+		// Dependencies are only resolved for a bundle freshly resolved from a catalog. A bundle
+		// that is rolling out or maintained during a catalog outage was already checked.
+		if state.resolvedBundle == nil {
+			return nil, nil
+		}
+
+		l.V(1).Info("resolving bundle dependencies")
+		deps, err := r.ResolveDependencies(ctx, ext, state.resolvedBundle)
+		if err != nil {
+			ext.Status.ResolvedDependencies = nil
+			setStatusProgressing(ext, err)
+			setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+			return nil, err
+		}
+		state.resolvedDependencies = deps
+		ext.Status.ResolvedDependencies = resolvedDependenciesStatus(deps)
+		return nil, nil
+	}
+}
+
+// InstallDependencies holds the rollout of the bundle selected in the ResolveBundle step until
+// every dependency resolved by the ResolveDependencies step is provided by an installed
+// ClusterExtension. When the dependencyPolicy of the ClusterExtension is Install, a
+// ClusterExtension is created for each dependency that is not provided by an existing
+// ClusterExtension. It must run after the steps that may hold the rollout, so that
+// dependencies are not installed for a bundle that is only planned or not yet approved.
+func InstallDependencies(c client.Client) ReconcileStepFunc {
+	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
+		if state.resolvedBundle == nil || len(state.resolvedDependencies) == 0 {
+			return nil, nil
+		}
+
+		deps := state.resolvedDependencies
+		autoInstall := ext.Spec.Source.Catalog != nil && ext.Spec.Source.Catalog.DependencyPolicy == ocv1.DependencyPolicyInstall
+		var missing, pending []string
+		for i := range deps {
+			dep := &deps[i]
+			// Only the packages the ClusterExtension opted in are installed with its service account
+			if dep.InstalledBy == "" && autoInstall && slices.Contains(ext.Spec.Source.Catalog.InstallableDependencies, dep.PackageName) {
+				name, err := createDependencyExtension(ctx, c, ext, dep)
+				if err != nil {
+					ext.Status.ResolvedDependencies = resolvedDependenciesStatus(deps)
+					setStatusProgressing(ext, err)
+					setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+					return nil, err
+				}
+				dep.InstalledBy = name
+			}
+			switch {
+			case dep.InstalledBy == "":
+				missing = append(missing, dep.PackageName)
+			case dep.InstalledBundle == nil:
+				pending = append(pending, dep.PackageName)
+			}
+		}
+		ext.Status.ResolvedDependencies = resolvedDependenciesStatus(deps)
+
+		if len(missing) == 0 && len(pending) == 0 {
+			return nil, nil
+		}
+		var msg string
+		switch {
+		case len(missing) > 0 && autoInstall:
+			msg = fmt.Sprintf("bundle %q requires packages %v that are not installed by any ClusterExtension nor listed in installableDependencies", state.resolvedBundle.Name, missing)
+		case len(missing) > 0:
+			msg = fmt.Sprintf("bundle %q requires packages %v that are not installed by any ClusterExtension", state.resolvedBundle.Name, missing)
+		default:
+			msg = fmt.Sprintf("bundle %q is waiting for the dependencies %v to be installed", state.resolvedBundle.Name, pending)
+		}
+		log.FromContext(ctx).Info("holding bundle rollout until its dependencies are installed", "missing", missing, "pending", pending)
+		setStatusProgressing(ext, errors.New(msg))
+		setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+		return &ctrl.Result{RequeueAfter: dependencyRequeueInterval}, nil
+	}
+}
+
+// createDependencyExtension creates a ClusterExtension that installs the bundle selected for
+// a dependency, pinned to the selected version, release and catalog. The new ClusterExtension is
+// named after the package and shares the namespace, service account and installable dependencies
+// of the requiring extension. An existing ClusterExtension of that name is only used when it
+// installs the same bundle in the same way.
+func createDependencyExtension(ctx context.Context, c client.Client, ext *ocv1.ClusterExtension, dep *resolve.ResolvedDependency) (string, error) {
+	depExt := &ocv1.ClusterExtension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: dep.PackageName,
+			Annotations: map[string]string{
+				labels.RequiredByKey: ext.GetName(),
+			},
+		},
+		Spec: ocv1.ClusterExtensionSpec{
+			Namespace:      ext.Spec.Namespace,
+			ServiceAccount: ext.Spec.ServiceAccount,
+			Source: ocv1.SourceConfig{
+				SourceType: ocv1.SourceTypeCatalog,
+				Catalog: &ocv1.CatalogFilter{
+					PackageName: dep.PackageName,
+					Version:     bundleutil.VersionString(*dep.Version),
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{ocv1.MetadataNameLabel: dep.Catalog},
+					},
+					UpgradeConstraintPolicy: ocv1.UpgradeConstraintPolicyCatalogProvided,
+					DependencyPolicy:        ocv1.DependencyPolicyInstall,
+					InstallableDependencies: ext.Spec.Source.Catalog.InstallableDependencies,
+				},
+			},
+		},
+	}
+
+	err := c.Create(ctx, depExt)
+	if err == nil {
+		log.FromContext(ctx).Info("created ClusterExtension for bundle dependency", "clusterExtension", depExt.Name, "bundle", dep.Bundle.Name)
+		return depExt.Name, nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return "", fmt.Errorf("error creating ClusterExtension for dependency on package %q: %w", dep.PackageName, err)
+	}
+
+	existing := &ocv1.ClusterExtension{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(depExt), existing); err != nil {
+		return "", fmt.Errorf("error getting ClusterExtension %q: %w", depExt.Name, err)
+	}
+	if mismatches := dependencyExtensionMismatches(existing, depExt); len(mismatches) > 0 {
+		return "", fmt.Errorf("unable to create ClusterExtension for dependency on package %q: ClusterExtension %q already exists with a different %s", dep.PackageName, depExt.Name, strings.Join(mismatches, ", "))
+	}
+	return existing.Name, nil
+}
+
+// dependencyExtensionMismatches returns the fields in which an existing ClusterExtension differs
+// from the ClusterExtension that would be created to install a dependency.
+func dependencyExtensionMismatches(existing, expected *ocv1.ClusterExtension) []string {
+	if existing.Spec.Source.Catalog == nil || existing.Spec.Source.Catalog.PackageName != expected.Spec.Source.Catalog.PackageName {
+		return []string{"package"}
+	}
+	var mismatches []string
+	if existing.Spec.Source.Catalog.Version != expected.Spec.Source.Catalog.Version {
+		mismatches = append(mismatches, "version")
+	}
+	if !equality.Semantic.DeepEqual(existing.Spec.Source.Catalog.Selector, expected.Spec.Source.Catalog.Selector) {
+		mismatches = append(mismatches, "catalog selector")
+	}
+	if existing.Spec.Namespace != expected.Spec.Namespace {
+		mismatches = append(mismatches, "namespace")
+	}
+	if existing.Spec.ServiceAccount != expected.Spec.ServiceAccount {
+		mismatches = append(mismatches, "service account")
+	}
+	return mismatches
+}
+
+// resolvedDependenciesStatus converts resolved dependencies into their status representation.
+func resolvedDependenciesStatus(deps []resolve.ResolvedDependency) []ocv1.ResolvedDependency {
+	if len(deps) == 0 {
+		return nil
+	}
+	out := make([]ocv1.ResolvedDependency, 0, len(deps))
+	for _, dep := range deps {
+		status := ocv1.ResolvedDependency{
+			PackageName:          dep.PackageName,
+			Catalog:              dep.Catalog,
+			ClusterExtensionName: dep.InstalledBy,
+		}
+		switch {
+		case dep.InstalledBundle != nil:
+			status.Bundle = dep.InstalledBundle.DeepCopy()
+		case dep.Bundle != nil:
+			bm := bundleutil.MetadataFor(dep.Bundle.Name, *dep.Version)
+			status.Bundle = &bm
+		}
+		out = append(out, status)
+	}
+	return out
+}
+
 // handleResolutionError handles the case when bundle resolution fails.
 //
 // Decision logic (evaluated in order):
@@ -219,6 +506,14 @@ func ResolveBundle(r resolve.Resolver, c client.Client) ReconcileStepFunc {
 func handleResolutionError(ctx context.Context, c client.Client, state *reconcileState, ext *ocv1.ClusterExtension, err error) (*ctrl.Result, error) {
 	l := log.FromContext(ctx)
 
+	// Terminal errors, such as extension policy violations, are not retried: the
+	// ClusterExtension is reconciled again when what caused them changes.
+	if errors.Is(err, reconcile.TerminalError(nil)) {
+		setStatusProgressing(ext, err)
+		setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+		return nil, err
+	}
+
 	// No installed bundle and resolution failed - cannot proceed
 	if state.revisionStates.Installed == nil {
 		msg := fmt.Sprintf("failed to resolve bundle: %v", err)
@@ -359,10 +654,126 @@ func CheckCatalogsExist(ctx context.Context, c client.Client, ext *ocv1.ClusterE
 	return len(catalogList.Items) > 0, nil
 }
 
-func UnpackBundle(i imageutil.Puller, cache imageutil.Cache) ReconcileStepFunc {
+// SourceTypeValidator returns a validator that checks the sourceType of the
+// ClusterExtension is one of the given source types.
+func SourceTypeValidator(sourceTypes ...string) ClusterExtensionValidator {
+	return func(_ context.Context, ext *ocv1.ClusterExtension) error {
+		if !slices.Contains(sourceTypes, ext.Spec.Source.SourceType) {
+			return fmt.Errorf("sourceType %q is not supported, supported source types are %v", ext.Spec.Source.SourceType, sourceTypes)
+		}
+		return nil
+	}
+}
+
+// RolloutModeValidator returns a validator that checks the rolloutMode of the
+// ClusterExtension is one of the given rollout modes. An empty rolloutMode is
+// always valid, as it defaults to Apply.
+func RolloutModeValidator(rolloutModes ...string) ClusterExtensionValidator {
+	return func(_ context.Context, ext *ocv1.ClusterExtension) error {
+		if ext.Spec.RolloutMode != "" && !slices.Contains(rolloutModes, ext.Spec.RolloutMode) {
+			return fmt.Errorf("rolloutMode %q is not supported, supported rollout modes are %v", ext.Spec.RolloutMode, rolloutModes)
+		}
+		return nil
+	}
+}
+
+// UpgradeApprovalValidator returns a validator that checks the upgrade approval policy
+// of the ClusterExtension is one of the given policies. ClusterExtensions without an
+// upgrade approval policy are always valid, as their upgrades are approved automatically.
+func UpgradeApprovalValidator(policies ...string) ClusterExtensionValidator {
+	return func(_ context.Context, ext *ocv1.ClusterExtension) error {
+		if ext.Spec.UpgradeApproval != nil && !slices.Contains(policies, ext.Spec.UpgradeApproval.Policy) {
+			return fmt.Errorf("upgrade approval policy %q is not supported, supported policies are %v", ext.Spec.UpgradeApproval.Policy, policies)
+		}
+		return nil
+	}
+}
+
+// UpgradeScopeValidator returns a validator that checks the upgrade scope of the
+// ClusterExtension is one of the given scopes. An empty upgrade scope is always
+// valid, as it does not limit upgrades.
+func UpgradeScopeValidator(scopes ...string) ClusterExtensionValidator {
+	return func(_ context.Context, ext *ocv1.ClusterExtension) error {
+		if ext.Spec.Source.Catalog == nil || ext.Spec.Source.Catalog.UpgradeScope == "" {
+			return nil
+		}
+		if scope := ext.Spec.Source.Catalog.UpgradeScope; !slices.Contains(scopes, scope) {
+			return fmt.Errorf("upgrade scope %q is not supported, supported upgrade scopes are %v", scope, scopes)
+		}
+		return nil
+	}
+}
+
+// SoakTimeValidator returns a validator that rejects ClusterExtensions holding upgrades
+// until bundles soaked in their catalog when soak times are not enabled.
+func SoakTimeValidator(enabled bool) ClusterExtensionValidator {
+	return func(_ context.Context, ext *ocv1.ClusterExtension) error {
+		if ext.Spec.Source.Catalog != nil && ext.Spec.Source.Catalog.SoakTimeMinutes > 0 && !enabled {
+			return errors.New("soakTimeMinutes is not supported")
+		}
+		return nil
+	}
+}
+
+// RollbackPolicyValidator returns a validator that checks the rollback policy of the
+// ClusterExtension is one of the given policies, and that a progress deadline is set
+// for revisions to be rolled back automatically.
+func RollbackPolicyValidator(policies ...string) ClusterExtensionValidator {
+	return func(_ context.Context, ext *ocv1.ClusterExtension) error {
+		policy := ext.Spec.RollbackPolicy
+		if policy != "" && !slices.Contains(policies, policy) {
+			return fmt.Errorf("rollback policy %q is not supported, supported policies are %v", policy, policies)
+		}
+		if policy == ocv1.RollbackPolicyAutomatic && ext.Spec.ProgressDeadlineMinutes == 0 {
+			return fmt.Errorf("rollback policy %q requires progressDeadlineMinutes to be set", policy)
+		}
+		return nil
+	}
+}
+
+// RevisionPinningValidator returns a validator that rejects ClusterExtensions pinning
+// one of their revisions when revision pinning is not enabled.
+func RevisionPinningValidator(enabled bool) ClusterExtensionValidator {
+	return func(_ context.Context, ext *ocv1.ClusterExtension) error {
+		if ext.Spec.PinnedRevision > 0 && !enabled {
+			return errors.New("pinnedRevision is not supported")
+		}
+		return nil
+	}
+}
+
+// UnpackBundle pulls the content of the bundle to roll out. Bundle images of ClusterExtensions
+// whose sourceType is Image are unpacked with bundleImages, which must be told to forget a
+// ClusterExtension once it is deleted.
+func UnpackBundle(i imageutil.Puller, cache imageutil.Cache, bundleImages *BundleImageResolver) ReconcileStepFunc {
 	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
 		l := log.FromContext(ctx)
 
+		if ext.Spec.PinnedRevision > 0 || ext.Spec.Source.SourceType != ocv1.SourceTypeBundleImage {
+			bundleImages.Forget(ext.GetName())
+		}
+
+		// The content of a pinned revision is read from its ClusterObjectSet.
+		if ext.Spec.PinnedRevision > 0 {
+			state.imageFS = nil
+			return nil, nil
+		}
+
+		// Bundle images are not resolved by the ResolveBundle step, unless a
+		// revision of the bundle is still rolling out.
+		if state.resolvedRevisionMetadata == nil && ext.Spec.Source.SourceType == ocv1.SourceTypeBundleImage {
+			imageFS, revision, requeueAfter, err := bundleImages.unpack(ctx, ext)
+			if err != nil {
+				setStatusProgressing(ext, err)
+				setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+				return nil, err
+			}
+			state.resolvedRevisionMetadata = revision
+			state.imageFS = imageFS
+			state.requeueAfter = requeueAfter
+			return nil, nil
+		}
+
 		// Defensive check: resolvedRevisionMetadata should be set by ResolveBundle step
 		if state.resolvedRevisionMetadata == nil {
 			return nil, fmt.Errorf("unable to retrieve bundle information")
@@ -407,18 +818,173 @@ func UnpackBundle(i imageutil.Puller, cache imageutil.Cache) ReconcileStepFunc {
 	}
 }
 
-func ApplyBundle(a Applier) ReconcileStepFunc {
+// maxPlannedObjectChanges is the maximum number of changes listed in status.plan.changes.
+const maxPlannedObjectChanges = 512
+
+// PlanBundle computes the changes rolling out the resolved bundle would make to the cluster when
+// the rolloutMode of the ClusterExtension is Plan. The plan is reported in the ClusterExtension status
+// and reconciliation stops before the bundle is applied. In any other rolloutMode, the plan is cleared
+// and reconciliation continues.
+func PlanBundle(p Planner) ReconcileStepFunc {
 	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
+		if ext.Spec.RolloutMode != ocv1.RolloutModePlan {
+			ext.Status.Plan = nil
+			return nil, nil
+		}
 		l := log.FromContext(ctx)
-		revisionAnnotations := map[string]string{
-			labels.BundleNameKey:      state.resolvedRevisionMetadata.Name,
-			labels.PackageNameKey:     state.resolvedRevisionMetadata.Package,
-			labels.BundleVersionKey:   state.resolvedRevisionMetadata.Version,
-			labels.BundleReferenceKey: state.resolvedRevisionMetadata.Image,
+
+		if state.resolvedRevisionMetadata == nil {
+			err := errors.New("unable to plan rollout: no bundle was resolved")
+			ext.Status.Plan = nil
+			setStatusProgressing(ext, err)
+			setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+			return nil, err
 		}
-		if state.resolvedRevisionMetadata.Release != nil {
-			revisionAnnotations[labels.BundleReleaseKey] = *state.resolvedRevisionMetadata.Release
+
+		if state.imageFS == nil && ext.Spec.PinnedRevision == 0 {
+			// The content of the installed bundle is unavailable while its catalog is, and
+			// the installation is maintained as is. There is nothing to roll out.
+			if state.revisionStates != nil && state.revisionStates.Installed != nil &&
+				isSameBundle(state.revisionStates.Installed.BundleMetadata, state.resolvedRevisionMetadata.BundleMetadata) {
+				ext.Status.Plan = &ocv1.ClusterExtensionPlan{Bundle: state.resolvedRevisionMetadata.BundleMetadata}
+				setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+				apimeta.SetStatusCondition(&ext.Status.Conditions, metav1.Condition{
+					Type:   ocv1.TypeProgressing,
+					Status: metav1.ConditionTrue,
+					Reason: ocv1.ReasonPlanned,
+					Message: fmt.Sprintf("bundle %q with version %q is installed and its content is unavailable, no changes are planned",
+						state.resolvedRevisionMetadata.Name, state.resolvedRevisionMetadata.Version),
+					ObservedGeneration: ext.GetGeneration(),
+				})
+				return &ctrl.Result{RequeueAfter: state.requeueAfter}, nil
+			}
+			err := errors.New("unable to plan rollout: bundle content unavailable")
+			ext.Status.Plan = nil
+			setStatusProgressing(ext, wrapErrorWithResolutionInfo(state.resolvedRevisionMetadata.BundleMetadata, err))
+			setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+			return nil, err
 		}
+
+		revisionAnnotations := revisionAnnotationsFor(state.resolvedRevisionMetadata)
+		objLbls := map[string]string{
+			labels.OwnerKindKey: ocv1.ClusterExtensionKind,
+			labels.OwnerNameKey: ext.GetName(),
+		}
+
+		l.Info("planning bundle rollout")
+		changes, err := p.Plan(ctx, state.imageFS, renderedExtension(state, ext), objLbls, revisionAnnotations)
+		if err != nil {
+			ext.Status.Plan = nil
+			setStatusProgressing(ext, wrapErrorWithResolutionInfo(state.resolvedRevisionMetadata.BundleMetadata, err))
+			setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+			return nil, err
+		}
+
+		ext.Status.Plan = &ocv1.ClusterExtensionPlan{
+			Bundle: state.resolvedRevisionMetadata.BundleMetadata,
+			// status.plan.changes is bounded, the number of changes is reported in the condition.
+			Changes: changes[:min(len(changes), maxPlannedObjectChanges)],
+		}
+		setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+		apimeta.SetStatusCondition(&ext.Status.Conditions, metav1.Condition{
+			Type:   ocv1.TypeProgressing,
+			Status: metav1.ConditionTrue,
+			Reason: ocv1.ReasonPlanned,
+			Message: fmt.Sprintf("planned rollout of bundle %q with version %q: %d object(s) would be changed, set rolloutMode to %q to roll it out",
+				state.resolvedRevisionMetadata.Name, state.resolvedRevisionMetadata.Version, len(changes), ocv1.RolloutModeApply),
+			ObservedGeneration: ext.GetGeneration(),
+		})
+		return &ctrl.Result{RequeueAfter: state.requeueAfter}, nil
+	}
+}
+
+// ApproveUpgrade holds the rollout of the resolved bundle when it is an upgrade of the installed
+// bundle that has not been approved. An upgrade is approved when the upgrade approval policy of the
+// ClusterExtension is Manual and its approvedVersion and approvedRelease are the version and release
+// of the resolved bundle. Pending upgrades are reported in the ClusterExtension status and
+// reconciliation stops before the bundle is applied. Installations, approved upgrades and upgrades that are already rolling out continue.
+func ApproveUpgrade() ReconcileStepFunc {
+	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
+		approval := ext.Spec.UpgradeApproval
+		if approval == nil || approval.Policy != ocv1.UpgradeApprovalManual || !isPendingUpgrade(state) ||
+			isApproved(approval, state.resolvedRevisionMetadata.BundleMetadata) {
+			ext.Status.PendingUpgrade = nil
+			return nil, nil
+		}
+
+		log.FromContext(ctx).Info("upgrade pending approval", "bundle", state.resolvedRevisionMetadata.Name, "version", state.resolvedRevisionMetadata.Version)
+		ext.Status.PendingUpgrade = &ocv1.PendingUpgrade{Bundle: state.resolvedRevisionMetadata.BundleMetadata}
+		setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+		apimeta.SetStatusCondition(&ext.Status.Conditions, metav1.Condition{
+			Type:               ocv1.TypeProgressing,
+			Status:             metav1.ConditionFalse,
+			Reason:             ocv1.ReasonUpgradePending,
+			Message:            pendingApprovalMessage(state.resolvedRevisionMetadata.BundleMetadata),
+			ObservedGeneration: ext.GetGeneration(),
+		})
+		return &ctrl.Result{RequeueAfter: state.requeueAfter}, nil
+	}
+}
+
+// isApproved returns true when the approved version and release are the version and release of the bundle.
+func isApproved(approval *ocv1.UpgradeApproval, bundle ocv1.BundleMetadata) bool {
+	return approval.ApprovedVersion == bundle.Version && approval.ApprovedRelease == ptr.Deref(bundle.Release, "")
+}
+
+func pendingApprovalMessage(bundle ocv1.BundleMetadata) string {
+	if release := ptr.Deref(bundle.Release, ""); release != "" {
+		return fmt.Sprintf("upgrade to bundle %q with version %q and release %q is pending approval, set spec.upgradeApproval.approvedVersion to %q and spec.upgradeApproval.approvedRelease to %q to approve it",
+			bundle.Name, bundle.Version, release, bundle.Version, release)
+	}
+	return fmt.Sprintf("upgrade to bundle %q with version %q is pending approval, set spec.upgradeApproval.approvedVersion to %q to approve it",
+		bundle.Name, bundle.Version, bundle.Version)
+}
+
+// isPendingUpgrade returns true when a bundle is installed and the resolved bundle is neither
+// the installed bundle nor a bundle that is already rolling out.
+func isPendingUpgrade(state *reconcileState) bool {
+	if state.revisionStates == nil || state.revisionStates.Installed == nil {
+		return false
+	}
+	// Rolling out a pinned revision is requested explicitly, it is not held.
+	if state.revisionStates.Pinned != nil {
+		return false
+	}
+	resolved := state.resolvedRevisionMetadata.BundleMetadata
+	if isSameBundle(state.revisionStates.Installed.BundleMetadata, resolved) {
+		return false
+	}
+	// A bundle that was rolled back is not rolled out again, there is nothing to hold.
+	if rb := state.revisionStates.RolledBack; rb != nil && isSameBundle(rb.BundleMetadata, resolved) {
+		return false
+	}
+	return !slices.ContainsFunc(state.revisionStates.RollingOut, func(rm *RevisionMetadata) bool {
+		return isSameBundle(rm.BundleMetadata, resolved)
+	})
+}
+
+func isSameBundle(a, b ocv1.BundleMetadata) bool {
+	return a.Name == b.Name && a.Version == b.Version && ptr.Deref(a.Release, "") == ptr.Deref(b.Release, "")
+}
+
+// revisionAnnotationsFor returns the annotations identifying the revision of a bundle.
+func revisionAnnotationsFor(rm *RevisionMetadata) map[string]string {
+	revisionAnnotations := map[string]string{
+		labels.BundleNameKey:      rm.Name,
+		labels.PackageNameKey:     rm.Package,
+		labels.BundleVersionKey:   rm.Version,
+		labels.BundleReferenceKey: rm.Image,
+	}
+	if rm.Release != nil {
+		revisionAnnotations[labels.BundleReleaseKey] = *rm.Release
+	}
+	return revisionAnnotations
+}
+
+func ApplyBundle(a Applier) ReconcileStepFunc {
+	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
+		l := log.FromContext(ctx)
+		revisionAnnotations := revisionAnnotationsFor(state.resolvedRevisionMetadata)
 		objLbls := map[string]string{
 			labels.OwnerKindKey: ocv1.ClusterExtensionKind,
 			labels.OwnerNameKey: ext.GetName(),
@@ -434,7 +1000,7 @@ func ApplyBundle(a Applier) ReconcileStepFunc {
 		// to ensure exponential backoff can occur:
 		//   - Permission errors (it is not possible to watch changes to permissions.
 		//     The only way to eventually recover from permission errors is to keep retrying).
-		rolloutSucceeded, rolloutStatus, err := a.Apply(ctx, state.imageFS, ext, objLbls, revisionAnnotations)
+		rolloutSucceeded, rolloutStatus, err := a.Apply(ctx, state.imageFS, renderedExtension(state, ext), objLbls, revisionAnnotations)
 
 		// Set installed status
 		if rolloutSucceeded {
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	bsemver "github.com/blang/semver/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
+)
+
+var _ resolve.ReportingResolver = (*fakeReportingResolver)(nil)
+
+type fakeReportingResolver struct {
+	bundle *declcfg.Bundle
+	report *ocv1.ClusterExtensionResolution
+	err    error
+}
+
+func (f *fakeReportingResolver) Resolve(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+	bundle, version, deprecation, _, err := f.ResolveWithReport(ctx, ext, installedBundle)
+	return bundle, version, deprecation, err
+}
+
+func (f *fakeReportingResolver) ResolveWithReport(context.Context, *ocv1.ClusterExtension, *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, *ocv1.ClusterExtensionResolution, error) {
+	if f.err != nil {
+		return nil, nil, nil, f.report, f.err
+	}
+	return f.bundle, &declcfg.VersionRelease{Version: bsemver.MustParse("1.0.0")}, nil, f.report, nil
+}
+
+func TestResolveBundleWithReport(t *testing.T) {
+	report := &ocv1.ClusterExtensionResolution{
+		Bundle:  "prometheus.v1.0.0",
+		Catalog: "operatorhubio",
+		Message: `bundle "prometheus.v1.0.0" resolved from catalog "operatorhubio", the only catalog providing candidates`,
+		Catalogs: []ocv1.CatalogResolution{{
+			Name: "operatorhubio", Result: ocv1.CatalogResolutionSelected, Bundles: 1, Candidates: 1, BestCandidate: "prometheus.v1.0.0",
+		}},
+	}
+
+	t.Log("By checking the report of a successful resolution is set in the status")
+	r := &fakeReportingResolver{bundle: &declcfg.Bundle{Name: "prometheus.v1.0.0", Package: "prometheus"}, report: report}
+	ext := newTestExtension()
+	_, err := ResolveBundleWithReport(r, nil)(context.Background(), &reconcileState{revisionStates: &RevisionStates{}}, ext)
+	require.NoError(t, err)
+	require.Equal(t, report, ext.Status.Resolution)
+
+	t.Log("By checking the report of a failed resolution is set in the status")
+	failed := &ocv1.ClusterExtensionResolution{
+		Message:  `no bundles found for package "prometheus"`,
+		Catalogs: []ocv1.CatalogResolution{{Name: "operatorhubio", Result: ocv1.CatalogResolutionPackageNotFound}},
+	}
+	r = &fakeReportingResolver{report: failed, err: errors.New(`no bundles found for package "prometheus"`)}
+	_, err = ResolveBundleWithReport(r, nil)(context.Background(), &reconcileState{revisionStates: &RevisionStates{}}, ext)
+	require.Error(t, err)
+	require.Equal(t, failed, ext.Status.Resolution)
+
+	t.Log("By checking the report is removed from the status when resolutions are not reported")
+	_, err = ResolveBundle(r, nil)(context.Background(), &reconcileState{revisionStates: &RevisionStates{}}, ext)
+	require.Error(t, err)
+	require.Nil(t, ext.Status.Resolution)
+
+	t.Log("By checking the report is removed from the status when the bundle is not resolved from the catalogs")
+	ext = newTestExtension()
+	ext.Status.Resolution = report.DeepCopy()
+	ext.Spec.Source = ocv1.SourceConfig{SourceType: ocv1.SourceTypeBundleImage}
+	_, err = ResolveBundleWithReport(r, nil)(context.Background(), &reconcileState{revisionStates: &RevisionStates{}}, ext)
+	require.NoError(t, err)
+	require.Nil(t, ext.Status.Resolution)
+}
@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+func TestRollbackPolicyValidator(t *testing.T) {
+	ext := &ocv1.ClusterExtension{}
+	require.NoError(t, RollbackPolicyValidator(ocv1.RollbackPolicyNone)(context.Background(), ext))
+
+	ext.Spec.RollbackPolicy = ocv1.RollbackPolicyNone
+	require.NoError(t, RollbackPolicyValidator(ocv1.RollbackPolicyNone)(context.Background(), ext))
+
+	ext.Spec.RollbackPolicy = ocv1.RollbackPolicyAutomatic
+	require.EqualError(t, RollbackPolicyValidator(ocv1.RollbackPolicyNone)(context.Background(), ext),
+		`rollback policy "Automatic" is not supported, supported policies are [None]`)
+	require.EqualError(t, RollbackPolicyValidator(ocv1.RollbackPolicyNone, ocv1.RollbackPolicyAutomatic)(context.Background(), ext),
+		`rollback policy "Automatic" requires progressDeadlineMinutes to be set`)
+
+	ext.Spec.ProgressDeadlineMinutes = 10
+	require.NoError(t, RollbackPolicyValidator(ocv1.RollbackPolicyNone, ocv1.RollbackPolicyAutomatic)(context.Background(), ext))
+}
+
+func TestIsPendingUpgradeRolledBack(t *testing.T) {
+	installed := &RevisionMetadata{BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.0.0", Version: "1.0.0"}}
+	resolved := &RevisionMetadata{BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.1.0", Version: "1.1.0"}}
+
+	require.True(t, isPendingUpgrade(&reconcileState{
+		revisionStates:           &RevisionStates{Installed: installed},
+		resolvedRevisionMetadata: resolved,
+	}))
+	require.False(t, isPendingUpgrade(&reconcileState{
+		revisionStates:           &RevisionStates{Installed: installed, RolledBack: resolved},
+		resolvedRevisionMetadata: resolved,
+	}), "a bundle that was rolled back is not rolled out again and must not be held")
+}
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+func TestResolveBundleRequeuesWhileSoaking(t *testing.T) {
+	installed := &RevisionMetadata{BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.2.3", Version: "1.2.3"}}
+	newExt := func(soakTimeMinutes int32) *ocv1.ClusterExtension {
+		return newTestExtension(func(ext *ocv1.ClusterExtension) { ext.Spec.Source.Catalog.SoakTimeMinutes = soakTimeMinutes })
+	}
+	r := newTestResolver("1.2.3")
+
+	t.Log("By checking the bundle is resolved again later while upgrades soak")
+	state := &reconcileState{revisionStates: &RevisionStates{Installed: installed}}
+	_, err := ResolveBundle(r, nil)(context.Background(), state, newExt(60))
+	require.NoError(t, err)
+	require.Equal(t, soakTimeRequeueInterval, state.requeueAfter)
+
+	t.Log("By checking the bundle is not resolved again when upgrades do not soak")
+	state = &reconcileState{revisionStates: &RevisionStates{Installed: installed}}
+	_, err = ResolveBundle(r, nil)(context.Background(), state, newExt(0))
+	require.NoError(t, err)
+	require.Zero(t, state.requeueAfter)
+
+	t.Log("By checking the bundle is not resolved again when no bundle is installed")
+	state = &reconcileState{revisionStates: &RevisionStates{}}
+	_, err = ResolveBundle(r, nil)(context.Background(), state, newExt(60))
+	require.NoError(t, err)
+	require.Zero(t, state.requeueAfter)
+}
+
+func TestSoakTimeValidator(t *testing.T) {
+	ext := newTestExtension()
+	require.NoError(t, SoakTimeValidator(false)(context.Background(), ext))
+
+	ext.Spec.Source.Catalog.SoakTimeMinutes = 60
+	require.EqualError(t, SoakTimeValidator(false)(context.Background(), ext), "soakTimeMinutes is not supported")
+	require.NoError(t, SoakTimeValidator(true)(context.Background(), ext))
+}
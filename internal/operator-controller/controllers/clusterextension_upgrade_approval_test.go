@@ -0,0 +1,154 @@
+package controllers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/controllers"
+	imageutil "github.com/operator-framework/operator-controller/internal/shared/util/image"
+	mockcontrollers "github.com/operator-framework/operator-controller/internal/testutil/mock/controllers"
+)
+
+// withUpgradeApproval sets how upgrades of the ClusterExtension are approved.
+func withUpgradeApproval(approval *ocv1.UpgradeApproval) extensionOption {
+	return func(ext *ocv1.ClusterExtension) {
+		ext.Spec.UpgradeApproval = approval
+	}
+}
+
+// upgradeApprovalTestDeps installs prometheus 1.0.0 and resolves prometheus 1.1.0.
+func upgradeApprovalTestDeps(t *testing.T, applier controllers.Applier) reconcilerOption {
+	return func(d *deps) {
+		d.Resolver = newTestResolver("1.1.0")
+		d.RevisionStatesGetter = newMockRevisionStatesGetter(gomock.NewController(t), &controllers.RevisionStates{
+			Installed: &controllers.RevisionMetadata{
+				Package:        "prometheus",
+				BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.0.0", Version: "1.0.0"},
+				Image:          "quay.io/operatorhubio/prometheus@fake1.0.0",
+			},
+		}, nil)
+		d.ImagePuller = &imageutil.FakePuller{ImageFS: fstest.MapFS{}}
+		d.Applier = applier
+	}
+}
+
+func TestClusterExtensionManualUpgradeApproval(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	applier := mockcontrollers.NewMockApplier(mockCtrl)
+	cl, reconciler := newClientAndReconciler(t, upgradeApprovalTestDeps(t, applier))
+
+	ctx := context.Background()
+	extKey := types.NamespacedName{Name: fmt.Sprintf("cluster-extension-test-%s", rand.String(8))}
+	clusterExtension := newTestExtension(extKey.Name, withUpgradeApproval(&ocv1.UpgradeApproval{Policy: ocv1.UpgradeApprovalManual}))
+	require.NoError(t, cl.Create(ctx, clusterExtension))
+
+	t.Log("By running reconcile without approval")
+	res, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+	require.Equal(t, ctrl.Result{}, res)
+
+	t.Log("By checking the upgrade is pending")
+	require.NoError(t, cl.Get(ctx, extKey, clusterExtension))
+	require.Equal(t, &ocv1.PendingUpgrade{Bundle: ocv1.BundleMetadata{Name: "prometheus.v1.1.0", Version: "1.1.0"}}, clusterExtension.Status.PendingUpgrade)
+	require.Equal(t, ocv1.BundleMetadata{Name: "prometheus.v1.0.0", Version: "1.0.0"}, clusterExtension.Status.Install.Bundle)
+	progressingCond := apimeta.FindStatusCondition(clusterExtension.Status.Conditions, ocv1.TypeProgressing)
+	require.NotNil(t, progressingCond)
+	require.Equal(t, metav1.ConditionFalse, progressingCond.Status)
+	require.Equal(t, ocv1.ReasonUpgradePending, progressingCond.Reason)
+	require.Contains(t, progressingCond.Message, `set spec.upgradeApproval.approvedVersion to "1.1.0"`)
+	installedCond := apimeta.FindStatusCondition(clusterExtension.Status.Conditions, ocv1.TypeInstalled)
+	require.NotNil(t, installedCond)
+	require.Equal(t, metav1.ConditionTrue, installedCond.Status)
+
+	t.Log("By approving another version")
+	clusterExtension.Spec.UpgradeApproval.ApprovedVersion = "1.0.1"
+	require.NoError(t, cl.Update(ctx, clusterExtension))
+	_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+	require.NoError(t, cl.Get(ctx, extKey, clusterExtension))
+	require.NotNil(t, clusterExtension.Status.PendingUpgrade)
+
+	t.Log("By approving the pending version")
+	applier.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, "", nil)
+	clusterExtension.Spec.UpgradeApproval.ApprovedVersion = "1.1.0"
+	require.NoError(t, cl.Update(ctx, clusterExtension))
+	_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+
+	t.Log("By checking the upgrade is rolled out")
+	require.NoError(t, cl.Get(ctx, extKey, clusterExtension))
+	require.Nil(t, clusterExtension.Status.PendingUpgrade)
+	require.Equal(t, ocv1.BundleMetadata{Name: "prometheus.v1.1.0", Version: "1.1.0"}, clusterExtension.Status.Install.Bundle)
+
+	require.NoError(t, cl.DeleteAllOf(ctx, &ocv1.ClusterExtension{}))
+}
+
+func TestClusterExtensionAutomaticUpgradeApproval(t *testing.T) {
+	for _, approval := range []*ocv1.UpgradeApproval{
+		nil,
+		{Policy: ocv1.UpgradeApprovalAutomatic},
+	} {
+		t.Run(fmt.Sprintf("%v", approval), func(t *testing.T) {
+			cl, reconciler := newClientAndReconciler(t, upgradeApprovalTestDeps(t, newMockApplier(gomock.NewController(t), true, nil)))
+
+			ctx := context.Background()
+			extKey := types.NamespacedName{Name: fmt.Sprintf("cluster-extension-test-%s", rand.String(8))}
+			clusterExtension := newTestExtension(extKey.Name, withUpgradeApproval(approval))
+			require.NoError(t, cl.Create(ctx, clusterExtension))
+
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+			require.NoError(t, err)
+
+			require.NoError(t, cl.Get(ctx, extKey, clusterExtension))
+			require.Nil(t, clusterExtension.Status.PendingUpgrade)
+			require.Equal(t, ocv1.BundleMetadata{Name: "prometheus.v1.1.0", Version: "1.1.0"}, clusterExtension.Status.Install.Bundle)
+
+			require.NoError(t, cl.DeleteAllOf(ctx, &ocv1.ClusterExtension{}))
+		})
+	}
+}
+
+func TestClusterExtensionManualUpgradeApprovalInstall(t *testing.T) {
+	cl, reconciler := newClientAndReconciler(t, func(d *deps) {
+		upgradeApprovalTestDeps(t, newMockApplier(gomock.NewController(t), true, nil))(d)
+		d.RevisionStatesGetter = newMockRevisionStatesGetter(gomock.NewController(t), &controllers.RevisionStates{}, nil)
+	})
+
+	ctx := context.Background()
+	extKey := types.NamespacedName{Name: fmt.Sprintf("cluster-extension-test-%s", rand.String(8))}
+	clusterExtension := newTestExtension(extKey.Name, withUpgradeApproval(&ocv1.UpgradeApproval{Policy: ocv1.UpgradeApprovalManual}))
+	require.NoError(t, cl.Create(ctx, clusterExtension))
+
+	t.Log("By checking the initial installation does not need to be approved")
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: extKey})
+	require.NoError(t, err)
+	require.NoError(t, cl.Get(ctx, extKey, clusterExtension))
+	require.Nil(t, clusterExtension.Status.PendingUpgrade)
+	require.Equal(t, ocv1.BundleMetadata{Name: "prometheus.v1.1.0", Version: "1.1.0"}, clusterExtension.Status.Install.Bundle)
+
+	require.NoError(t, cl.DeleteAllOf(ctx, &ocv1.ClusterExtension{}))
+}
+
+func TestUpgradeApprovalValidator(t *testing.T) {
+	validate := controllers.UpgradeApprovalValidator(ocv1.UpgradeApprovalAutomatic)
+
+	ext := &ocv1.ClusterExtension{}
+	require.NoError(t, validate(context.Background(), ext))
+
+	ext.Spec.UpgradeApproval = &ocv1.UpgradeApproval{Policy: ocv1.UpgradeApprovalAutomatic}
+	require.NoError(t, validate(context.Background(), ext))
+
+	ext.Spec.UpgradeApproval.Policy = ocv1.UpgradeApprovalManual
+	require.EqualError(t, validate(context.Background(), ext), `upgrade approval policy "Manual" is not supported, supported policies are [Automatic]`)
+}
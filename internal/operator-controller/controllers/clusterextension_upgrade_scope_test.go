@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+func TestResolveBundleOutOfScopeUpgrade(t *testing.T) {
+	installed := &RevisionMetadata{BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.2.3", Version: "1.2.3"}}
+	ext := newTestExtension(func(ext *ocv1.ClusterExtension) { ext.Spec.Source.Catalog.UpgradeScope = ocv1.UpgradeScopePatch })
+	outOfScope := &ocv1.BundleMetadata{Name: "prometheus.v2.0.0", Version: "2.0.0"}
+	newResolver := func(selected string) *fakeReportingResolver {
+		return &fakeReportingResolver{
+			bundle: &declcfg.Bundle{Name: "prometheus.v1.2.4", Package: "prometheus"},
+			report: &ocv1.ClusterExtensionResolution{
+				Bundle:  "prometheus.v1.2.4",
+				Catalog: selected,
+				Catalogs: []ocv1.CatalogResolution{
+					{Name: "a", Result: ocv1.CatalogResolutionSelected, BestCandidate: "prometheus.v1.2.4", OutOfScopeCandidate: outOfScope},
+					{Name: "b", Result: ocv1.CatalogResolutionSelected, BestCandidate: "prometheus.v1.2.4"},
+				},
+			},
+		}
+	}
+
+	t.Log("By checking the newest bundle outside of the scope is reported from the resolution report")
+	state := &reconcileState{revisionStates: &RevisionStates{Installed: installed}}
+	_, err := ResolveBundle(newResolver("a"), nil)(context.Background(), state, ext)
+	require.NoError(t, err)
+	require.Equal(t, "prometheus.v1.2.4", state.resolvedBundle.Name)
+	require.Equal(t, outOfScope, ext.Status.OutOfScopeUpgrade)
+	require.Nil(t, ext.Status.Resolution, "the resolution is only reported by ResolveBundleWithReport")
+
+	t.Log("By checking only the catalog the bundle was resolved from is considered")
+	_, err = ResolveBundle(newResolver("b"), nil)(context.Background(), &reconcileState{revisionStates: &RevisionStates{Installed: installed}}, ext)
+	require.NoError(t, err)
+	require.Nil(t, ext.Status.OutOfScopeUpgrade)
+
+	t.Log("By checking nothing is reported by resolvers without a report")
+	ext.Status.OutOfScopeUpgrade = outOfScope
+	_, err = ResolveBundle(newTestResolver("1.2.4"), nil)(context.Background(), &reconcileState{revisionStates: &RevisionStates{Installed: installed}}, ext)
+	require.NoError(t, err)
+	require.Nil(t, ext.Status.OutOfScopeUpgrade)
+
+	t.Log("By checking nothing is reported when resolution fails")
+	ext.Status.OutOfScopeUpgrade = outOfScope
+	failing := newResolver("a")
+	failing.err = errors.New("found bundles for package \"prometheus\" in multiple catalogs with the same priority [a b]")
+	_, err = ResolveBundle(failing, nil)(context.Background(), &reconcileState{revisionStates: &RevisionStates{}}, ext)
+	require.Error(t, err)
+	require.Nil(t, ext.Status.OutOfScopeUpgrade)
+}
+
+func TestUpgradeScopeValidator(t *testing.T) {
+	validate := UpgradeScopeValidator(ocv1.UpgradeScopeAny)
+
+	ext := newTestExtension()
+	require.NoError(t, validate(context.Background(), ext))
+
+	ext.Spec.Source.Catalog.UpgradeScope = ocv1.UpgradeScopeAny
+	require.NoError(t, validate(context.Background(), ext))
+
+	ext.Spec.Source.Catalog.UpgradeScope = ocv1.UpgradeScopePatch
+	require.EqualError(t, validate(context.Background(), ext), `upgrade scope "Patch" is not supported, supported upgrade scopes are [Any]`)
+}
@@ -3,14 +3,11 @@
 package controllers
 
 import (
-	"bytes"
-	"compress/gzip"
 	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"strings"
 	"time"
 
@@ -42,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/applier"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/labels"
 )
 
@@ -126,6 +124,10 @@ func (c *ClusterObjectSetReconciler) reconcile(ctx context.Context, cos *ocv1.Cl
 		return c.delete(ctx, cos)
 	}
 
+	if rollingBack, err := c.rollBack(ctx, cos); rollingBack || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	remaining, hasDeadline := durationUntilDeadline(c.Clock, cos)
 	isDeadlineExceeded := hasDeadline && remaining <= 0
 
@@ -330,6 +332,62 @@ func (c *ClusterObjectSetReconciler) archive(ctx context.Context, revisionEngine
 	return ctrl.Result{}, nil
 }
 
+// rollBack rolls back a revision that exceeded its progress deadline when its rollback policy
+// is Automatic, and the latest previous revision that succeeded is still active. It returns
+// true when the revision is being rolled back, in which case it must not be reconciled.
+//
+// The RolledBack condition is set first, and the revision is archived on the next reconcile,
+// so that the reason of the failure is recorded even if archiving the revision fails.
+// Archiving the revision hands the objects it took over back to the previous revision.
+func (c *ClusterObjectSetReconciler) rollBack(ctx context.Context, cos *ocv1.ClusterObjectSet) (bool, error) {
+	if cos.Spec.RollbackPolicy != ocv1.RollbackPolicyAutomatic ||
+		cos.Spec.LifecycleState == ocv1.ClusterObjectSetLifecycleStateArchived {
+		return false, nil
+	}
+
+	if meta.IsStatusConditionTrue(cos.Status.Conditions, ocv1.ClusterObjectSetTypeRolledBack) {
+		patch := []byte(`{"spec":{"lifecycleState":"Archived"}}`)
+		if err := c.Client.Patch(ctx, cos.DeepCopy(), client.RawPatch(types.MergePatchType, patch)); err != nil {
+			return true, fmt.Errorf("archiving rolled back revision: %w", err)
+		}
+		return true, nil
+	}
+
+	progressing := meta.FindStatusCondition(cos.Status.Conditions, ocv1.ClusterObjectSetTypeProgressing)
+	if progressing == nil || progressing.Status != metav1.ConditionFalse ||
+		progressing.Reason != ocv1.ReasonProgressDeadlineExceeded {
+		return false, nil
+	}
+
+	previous, err := c.listPreviousRevisions(ctx, cos)
+	if err != nil {
+		return false, fmt.Errorf("listing previous revisions: %v", err)
+	}
+	var target *ocv1.ClusterObjectSet
+	for _, r := range previous {
+		if !meta.IsStatusConditionTrue(r.Status.Conditions, ocv1.ClusterObjectSetTypeSucceeded) {
+			continue
+		}
+		if target == nil || r.Spec.Revision > target.Spec.Revision {
+			target = r
+		}
+	}
+	if target == nil {
+		// Nothing to roll back to, e.g. the initial installation failed.
+		return false, nil
+	}
+
+	log.FromContext(ctx).Info("rolling back revision", "previousRevision", target.Name, "reason", progressing.Reason)
+	meta.SetStatusCondition(&cos.Status.Conditions, metav1.Condition{
+		Type:               ocv1.ClusterObjectSetTypeRolledBack,
+		Status:             metav1.ConditionTrue,
+		Reason:             progressing.Reason,
+		Message:            fmt.Sprintf("Rolled back to revision %s. %s", target.Name, progressing.Message),
+		ObservedGeneration: cos.Generation,
+	})
+	return true, nil
+}
+
 type Sourcoser interface {
 	Source(handler handler.EventHandler, predicates ...predicate.Predicate) source.Source
 }
@@ -499,7 +557,7 @@ func (c *ClusterObjectSetReconciler) buildBoxcutterPhases(ctx context.Context, c
 			case specObj.Object.Object != nil:
 				obj = specObj.Object.DeepCopy()
 			case specObj.Ref.Name != "":
-				resolved, err := c.resolveObjectRef(ctx, specObj.Ref)
+				resolved, err := applier.ResolveObjectRef(ctx, c.Client, specObj.Ref)
 				if err != nil {
 					return nil, nil, nil, fmt.Errorf("resolving ref in phase %q: %w", specPhase.Name, err)
 				}
@@ -539,47 +597,6 @@ func (c *ClusterObjectSetReconciler) buildBoxcutterPhases(ctx context.Context, c
 	return phases, observedPhases, opts, nil
 }
 
-// resolveObjectRef fetches the referenced Secret, reads the value at the specified key,
-// auto-detects gzip compression, and deserializes into an unstructured.Unstructured.
-func (c *ClusterObjectSetReconciler) resolveObjectRef(ctx context.Context, ref ocv1.ObjectSourceRef) (*unstructured.Unstructured, error) {
-	secret := &corev1.Secret{}
-	key := client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}
-	if err := c.Client.Get(ctx, key, secret); err != nil {
-		return nil, fmt.Errorf("getting Secret %s/%s: %w", ref.Namespace, ref.Name, err)
-	}
-
-	data, ok := secret.Data[ref.Key]
-	if !ok {
-		return nil, fmt.Errorf("key %q not found in Secret %s/%s", ref.Key, ref.Namespace, ref.Name)
-	}
-
-	// Auto-detect gzip compression (magic bytes 0x1f 0x8b)
-	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
-		reader, err := gzip.NewReader(bytes.NewReader(data))
-		if err != nil {
-			return nil, fmt.Errorf("creating gzip reader for key %q in Secret %s/%s: %w", ref.Key, ref.Namespace, ref.Name, err)
-		}
-		defer reader.Close()
-		const maxDecompressedSize = 10 * 1024 * 1024 // 10 MiB
-		limited := io.LimitReader(reader, maxDecompressedSize+1)
-		decompressed, err := io.ReadAll(limited)
-		if err != nil {
-			return nil, fmt.Errorf("decompressing key %q in Secret %s/%s: %w", ref.Key, ref.Namespace, ref.Name, err)
-		}
-		if len(decompressed) > maxDecompressedSize {
-			return nil, fmt.Errorf("decompressed data for key %q in Secret %s/%s exceeds maximum size (%d bytes)", ref.Key, ref.Namespace, ref.Name, maxDecompressedSize)
-		}
-		data = decompressed
-	}
-
-	obj := &unstructured.Unstructured{}
-	if err := json.Unmarshal(data, &obj.Object); err != nil {
-		return nil, fmt.Errorf("unmarshaling object from key %q in Secret %s/%s: %w", ref.Key, ref.Namespace, ref.Name, err)
-	}
-
-	return obj, nil
-}
-
 // EffectiveCollisionProtection resolves the collision protection value using
 // the inheritance hierarchy: object > phase > spec > default ("Prevent").
 func EffectiveCollisionProtection(cp ...ocv1.CollisionProtection) ocv1.CollisionProtection {
@@ -805,7 +822,7 @@ func (c *ClusterObjectSetReconciler) verifyReferencedSecretsImmutable(ctx contex
 		if err := c.Client.Get(ctx, key, secret); err != nil {
 			if apierrors.IsNotFound(err) {
 				// Secret not yet available — skip verification.
-				// resolveObjectRef will handle the not-found with a retryable error.
+				// ResolveObjectRef will handle the not-found with a retryable error.
 				continue
 			}
 			return fmt.Errorf("getting Secret %s/%s: %w", ref.namespace, ref.name, err)
@@ -1102,6 +1102,107 @@ func Test_ClusterObjectSetReconciler_Reconcile_ProgressDeadline(t *testing.T) {
 	}
 }
 
+func Test_ClusterObjectSetReconciler_Reconcile_Rollback(t *testing.T) {
+	testScheme := newScheme(t)
+	require.NoError(t, corev1.AddToScheme(testScheme))
+
+	deadlineExceeded := metav1.Condition{
+		Type:    ocv1.ClusterObjectSetTypeProgressing,
+		Status:  metav1.ConditionFalse,
+		Reason:  ocv1.ReasonProgressDeadlineExceeded,
+		Message: "Revision has not rolled out for 10 minute(s). Last status: Revision 1.0.0 is rolling out.",
+	}
+	succeeded := metav1.Condition{
+		Type:   ocv1.ClusterObjectSetTypeSucceeded,
+		Status: metav1.ConditionTrue,
+		Reason: ocv1.ReasonSucceeded,
+	}
+	newRevisions := func(rollbackPolicy string, rev1Conditions, rev2Conditions []metav1.Condition) []client.Object {
+		ext := newTestClusterExtension()
+		rev1 := newTestClusterObjectSet(t, "test-ext-1", ext, testScheme)
+		rev1.Status.Conditions = rev1Conditions
+		rev2 := newTestClusterObjectSet(t, "test-ext-2", ext, testScheme)
+		rev2.Spec.ProgressDeadlineMinutes = 10
+		rev2.Spec.RollbackPolicy = rollbackPolicy
+		rev2.Status.Conditions = rev2Conditions
+		return []client.Object{ext, rev1, rev2}
+	}
+
+	for _, tc := range []struct {
+		name         string
+		existingObjs []client.Object
+		validate     func(*testing.T, *ocv1.ClusterObjectSet)
+	}{
+		{
+			name:         "RolledBack is set when the progress deadline is exceeded",
+			existingObjs: newRevisions(ocv1.RollbackPolicyAutomatic, []metav1.Condition{succeeded}, []metav1.Condition{deadlineExceeded}),
+			validate: func(t *testing.T, rev *ocv1.ClusterObjectSet) {
+				require.Equal(t, ocv1.ClusterObjectSetLifecycleStateActive, rev.Spec.LifecycleState)
+				cnd := meta.FindStatusCondition(rev.Status.Conditions, ocv1.ClusterObjectSetTypeRolledBack)
+				require.NotNil(t, cnd)
+				require.Equal(t, metav1.ConditionTrue, cnd.Status)
+				require.Equal(t, ocv1.ReasonProgressDeadlineExceeded, cnd.Reason)
+				require.Equal(t, "Rolled back to revision test-ext-1. "+deadlineExceeded.Message, cnd.Message)
+			},
+		},
+		{
+			name: "rolled back revision is archived",
+			existingObjs: newRevisions(ocv1.RollbackPolicyAutomatic, []metav1.Condition{succeeded}, []metav1.Condition{deadlineExceeded, {
+				Type:   ocv1.ClusterObjectSetTypeRolledBack,
+				Status: metav1.ConditionTrue,
+				Reason: ocv1.ReasonProgressDeadlineExceeded,
+			}}),
+			validate: func(t *testing.T, rev *ocv1.ClusterObjectSet) {
+				require.Equal(t, ocv1.ClusterObjectSetLifecycleStateArchived, rev.Spec.LifecycleState)
+			},
+		},
+		{
+			name:         "not rolled back without a previous revision that succeeded",
+			existingObjs: newRevisions(ocv1.RollbackPolicyAutomatic, nil, []metav1.Condition{deadlineExceeded}),
+			validate: func(t *testing.T, rev *ocv1.ClusterObjectSet) {
+				require.Equal(t, ocv1.ClusterObjectSetLifecycleStateActive, rev.Spec.LifecycleState)
+				require.Nil(t, meta.FindStatusCondition(rev.Status.Conditions, ocv1.ClusterObjectSetTypeRolledBack))
+			},
+		},
+		{
+			name:         "not rolled back without the Automatic rollback policy",
+			existingObjs: newRevisions("", []metav1.Condition{succeeded}, []metav1.Condition{deadlineExceeded}),
+			validate: func(t *testing.T, rev *ocv1.ClusterObjectSet) {
+				require.Equal(t, ocv1.ClusterObjectSetLifecycleStateActive, rev.Spec.LifecycleState)
+				require.Nil(t, meta.FindStatusCondition(rev.Status.Conditions, ocv1.ClusterObjectSetTypeRolledBack))
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			testClient := fake.NewClientBuilder().
+				WithScheme(testScheme).
+				WithStatusSubresource(&ocv1.ClusterObjectSet{}).
+				WithObjects(tc.existingObjs...).
+				Build()
+
+			mockEngine := newMockRevisionEngineWithReconcile(mockCtrl,
+				func(ctx context.Context, rev machinerytypes.Revision, opts ...machinerytypes.RevisionReconcileOption) (machinery.RevisionResult, error) {
+					return newMockRevisionResult(mockCtrl, revisionResultConfig{inTransition: true}), nil
+				}, nil,
+			)
+			_, err := (&controllers.ClusterObjectSetReconciler{
+				Client:                testClient,
+				RevisionEngineFactory: newMockRevisionEngineFactoryWithEngine(mockCtrl, mockEngine, nil),
+				TrackingCache:         newMockTrackingCache(mockCtrl, testClient, nil),
+				Clock:                 clocktesting.NewFakeClock(time.Now()),
+			}).Reconcile(t.Context(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: "test-ext-2"},
+			})
+			require.NoError(t, err)
+
+			rev := &ocv1.ClusterObjectSet{}
+			require.NoError(t, testClient.Get(t.Context(), client.ObjectKey{Name: "test-ext-2"}, rev))
+			tc.validate(t, rev)
+		})
+	}
+}
+
 func newTestClusterExtension() *ocv1.ClusterExtension {
 	return &ocv1.ClusterExtension{
 		ObjectMeta: metav1.ObjectMeta{
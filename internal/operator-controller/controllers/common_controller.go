@@ -65,6 +65,9 @@ func setInstalledStatusFromRevisionStates(ext *ocv1.ClusterExtension, revisionSt
 	installStatus := &ocv1.ClusterExtensionInstallStatus{
 		Bundle: revisionStates.Installed.BundleMetadata,
 	}
+	if ext.Spec.Source.SourceType == ocv1.SourceTypeBundleImage {
+		installStatus.Bundle.Ref = revisionStates.Installed.Image
+	}
 	setInstallStatus(ext, installStatus)
 	setInstalledStatusConditionSuccess(ext, fmt.Sprintf("Installed bundle %s successfully", revisionStates.Installed.Image))
 }
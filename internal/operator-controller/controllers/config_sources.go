@@ -0,0 +1,255 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crhandler "sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/yaml"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/config"
+	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
+)
+
+// configSources returns the configuration sources of a ClusterExtension, in the order they are merged.
+func configSources(ext *ocv1.ClusterExtension) []ocv1.ClusterExtensionConfig {
+	var sources []ocv1.ClusterExtensionConfig
+	if ext.Spec.Config != nil {
+		sources = append(sources, *ext.Spec.Config)
+	}
+	return append(sources, ext.Spec.AdditionalConfigs...)
+}
+
+// ConfigTypeValidator returns a validator that checks the type of each configuration
+// source of the ClusterExtension is one of the given config types.
+func ConfigTypeValidator(configTypes ...ocv1.ClusterExtensionConfigType) ClusterExtensionValidator {
+	return func(_ context.Context, ext *ocv1.ClusterExtension) error {
+		for _, src := range configSources(ext) {
+			if !slices.Contains(configTypes, src.ConfigType) {
+				return fmt.Errorf("configType %q is not supported, supported config types are %v", src.ConfigType, configTypes)
+			}
+		}
+		return nil
+	}
+}
+
+// ConfigReaderGetter returns the reader the ConfigMaps and Secrets referenced in the configuration
+// of a ClusterExtension are read with. The reader must have the permissions of the service account
+// of the ClusterExtension, so that a ClusterExtension can only be configured from the ConfigMaps and
+// Secrets its service account is allowed to read.
+type ConfigReaderGetter interface {
+	ConfigReaderFor(context.Context, *ocv1.ClusterExtension) (client.Reader, error)
+}
+
+// ConfigReaderGetterFunc is a function that implements ConfigReaderGetter.
+type ConfigReaderGetterFunc func(context.Context, *ocv1.ClusterExtension) (client.Reader, error)
+
+func (f ConfigReaderGetterFunc) ConfigReaderFor(ctx context.Context, ext *ocv1.ClusterExtension) (client.Reader, error) {
+	return f(ctx, ext)
+}
+
+// secretConfigPollInterval is the interval at which the configuration of a ClusterExtension
+// referencing Secrets is resolved again. Unlike ConfigMaps, Secrets are not watched: the
+// operator-controller is not allowed to list the Secrets of the cluster.
+const secretConfigPollInterval = 5 * time.Minute
+
+// ResolveConfig reads the configuration sources of the ClusterExtension and merges them, in order,
+// into the configuration the bundle is rendered with. ConfigMaps and Secrets are read from the
+// namespace of the ClusterExtension with the reader returned by the given ConfigReaderGetter.
+// A ClusterExtension configured with at most one inline source is rendered with its spec.config
+// as is. The objects of a pinned revision are already rendered, so its configuration is not resolved.
+//
+// Referenced ConfigMaps, Secrets or keys that do not exist, and values that are not objects, are
+// reported as invalid configuration. They are not retried: changes to the referenced ConfigMaps are
+// watched instead, and the configuration of a ClusterExtension referencing Secrets is resolved
+// again every secretConfigPollInterval.
+func ResolveConfig(g ConfigReaderGetter) ReconcileStepFunc {
+	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
+		sources := configSources(ext)
+		if ext.Spec.PinnedRevision > 0 || len(sources) == 0 || (len(sources) == 1 && sources[0].ConfigType == ocv1.ClusterExtensionConfigTypeInline) {
+			return nil, nil
+		}
+
+		var pollSecrets bool
+		if slices.ContainsFunc(sources, func(src ocv1.ClusterExtensionConfig) bool {
+			return src.ConfigType == ocv1.ClusterExtensionConfigTypeSecretRef
+		}) {
+			pollSecrets = true
+			if state.requeueAfter == 0 || state.requeueAfter > secretConfigPollInterval {
+				state.requeueAfter = secretConfigPollInterval
+			}
+		}
+
+		r, err := g.ConfigReaderFor(ctx, ext)
+		if err != nil {
+			err = fmt.Errorf("error getting the client of service account %q: %w", ext.Spec.ServiceAccount.Name, err)
+			setStatusProgressing(ext, err)
+			setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+			return nil, err
+		}
+
+		values := make([]map[string]any, 0, len(sources))
+		for _, src := range sources {
+			v, err := readConfigSource(ctx, r, ext, src)
+			if err != nil {
+				setStatusProgressing(ext, err)
+				setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+				if pollSecrets && errors.Is(err, reconcile.TerminalError(nil)) {
+					// The referenced Secrets are not watched, the configuration is resolved again once they may have changed.
+					return &ctrl.Result{RequeueAfter: secretConfigPollInterval}, nil
+				}
+				return nil, err
+			}
+			values = append(values, v)
+		}
+
+		merged, err := json.Marshal(config.Merge(values...))
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling merged configuration: %w", err)
+		}
+		log.FromContext(ctx).V(1).Info("resolved configuration", "sources", len(sources))
+		state.resolvedConfig = &ocv1.ClusterExtensionConfig{
+			ConfigType: ocv1.ClusterExtensionConfigTypeInline,
+			Inline:     &apiextensionsv1.JSON{Raw: merged},
+		}
+		return nil, nil
+	}
+}
+
+// readConfigSource reads the configuration values of a single configuration source.
+func readConfigSource(ctx context.Context, r client.Reader, ext *ocv1.ClusterExtension, src ocv1.ClusterExtensionConfig) (map[string]any, error) {
+	namespace := ext.Spec.Namespace
+	var (
+		data []byte
+		desc string
+	)
+	switch src.ConfigType {
+	case ocv1.ClusterExtensionConfigTypeInline:
+		if src.Inline == nil {
+			return nil, nil
+		}
+		data, desc = src.Inline.Raw, "inline configuration"
+	case ocv1.ClusterExtensionConfigTypeConfigMapRef:
+		ref := src.ConfigMapRef
+		desc = fmt.Sprintf("key %q of ConfigMap %q", ref.Key, ref.Name)
+		var cm corev1.ConfigMap
+		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &cm); err != nil {
+			return nil, configSourceReadError(ext, desc, err)
+		}
+		if v, ok := cm.Data[ref.Key]; ok {
+			data = []byte(v)
+		} else if v, ok := cm.BinaryData[ref.Key]; ok {
+			data = v
+		} else {
+			return nil, errorutil.NewTerminalError(ocv1.ReasonInvalidConfiguration, fmt.Errorf("%s not found", desc))
+		}
+	case ocv1.ClusterExtensionConfigTypeSecretRef:
+		ref := src.SecretRef
+		desc = fmt.Sprintf("key %q of Secret %q", ref.Key, ref.Name)
+		var secret corev1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, &secret); err != nil {
+			return nil, configSourceReadError(ext, desc, err)
+		}
+		v, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, errorutil.NewTerminalError(ocv1.ReasonInvalidConfiguration, fmt.Errorf("%s not found", desc))
+		}
+		data = v
+	default:
+		return nil, errorutil.NewTerminalError(ocv1.ReasonInvalidConfiguration, fmt.Errorf("unknown configType %q", src.ConfigType))
+	}
+
+	// Values are not included in errors, they may be sensitive.
+	var values map[string]any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, errorutil.NewTerminalError(ocv1.ReasonInvalidConfiguration, fmt.Errorf("%s is not a valid JSON or YAML object", desc))
+	}
+	return values, nil
+}
+
+func configSourceReadError(ext *ocv1.ClusterExtension, desc string, err error) error {
+	if apierrors.IsNotFound(err) {
+		return errorutil.NewTerminalError(ocv1.ReasonInvalidConfiguration, fmt.Errorf("%s not found", desc))
+	}
+	if apierrors.IsForbidden(err) {
+		// Permissions are not watched, reading the source is retried.
+		return fmt.Errorf("service account %q is not allowed to read %s: %w", ext.Spec.ServiceAccount.Name, desc, err)
+	}
+	return fmt.Errorf("error reading %s: %w", desc, err)
+}
+
+// renderedExtension returns the ClusterExtension the bundle is rendered with: ext itself, or a copy
+// of ext whose spec.config is the configuration resolved from its configuration sources.
+func renderedExtension(state *reconcileState, ext *ocv1.ClusterExtension) *ocv1.ClusterExtension {
+	if state.resolvedConfig == nil {
+		return ext
+	}
+	rendered := ext.DeepCopy()
+	rendered.Spec.Config = state.resolvedConfig
+	rendered.Spec.AdditionalConfigs = nil
+	return rendered
+}
+
+// WithConfigSourceWatches reconciles the ClusterExtensions referencing ConfigMaps in their
+// configuration when the referenced ConfigMaps change. Only the metadata of the ConfigMaps is
+// watched, through the given cache, so that their data is not held in memory. Secrets are not
+// watched, see ResolveConfig.
+func WithConfigSourceWatches(cache crcache.Cache, cl client.Reader) ControllerBuilderOption {
+	return func(builder *ctrl.Builder) {
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+		builder.WatchesRawSource(source.Kind(cache, client.Object(obj),
+			crhandler.EnqueueRequestsFromMapFunc(clusterExtensionRequestsForConfigSource(cl, ocv1.ClusterExtensionConfigTypeConfigMapRef))))
+	}
+}
+
+// clusterExtensionRequestsForConfigSource returns reconcile requests for the ClusterExtensions whose
+// configuration references the changed ConfigMap or Secret.
+func clusterExtensionRequestsForConfigSource(cl client.Reader, configType ocv1.ClusterExtensionConfigType) crhandler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var extList ocv1.ClusterExtensionList
+		if err := cl.List(ctx, &extList); err != nil {
+			log.FromContext(ctx).Error(err, "unable to enqueue cluster extensions for configuration source reconcile")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, ext := range extList.Items {
+			if ext.Spec.Namespace != obj.GetNamespace() {
+				continue
+			}
+			if slices.ContainsFunc(configSources(&ext), func(src ocv1.ClusterExtensionConfig) bool {
+				return src.ConfigType == configType && configSourceName(src) == obj.GetName()
+			}) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: ext.GetName()}})
+			}
+		}
+		return requests
+	}
+}
+
+// configSourceName returns the name of the ConfigMap or Secret referenced by a configuration source.
+func configSourceName(src ocv1.ClusterExtensionConfig) string {
+	switch {
+	case src.ConfigMapRef != nil:
+		return src.ConfigMapRef.Name
+	case src.SecretRef != nil:
+		return src.SecretRef.Name
+	}
+	return ""
+}
@@ -0,0 +1,257 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+func inlineConfig(raw string) ocv1.ClusterExtensionConfig {
+	return ocv1.ClusterExtensionConfig{
+		ConfigType: ocv1.ClusterExtensionConfigTypeInline,
+		Inline:     &apiextensionsv1.JSON{Raw: []byte(raw)},
+	}
+}
+
+func configMapConfig(name, key string) ocv1.ClusterExtensionConfig {
+	return ocv1.ClusterExtensionConfig{
+		ConfigType:   ocv1.ClusterExtensionConfigTypeConfigMapRef,
+		ConfigMapRef: &ocv1.ConfigKeyReference{Name: name, Key: key},
+	}
+}
+
+func secretConfig(name, key string) ocv1.ClusterExtensionConfig {
+	return ocv1.ClusterExtensionConfig{
+		ConfigType: ocv1.ClusterExtensionConfigTypeSecretRef,
+		SecretRef:  &ocv1.ConfigKeyReference{Name: name, Key: key},
+	}
+}
+
+func TestConfigTypeValidator(t *testing.T) {
+	validate := ConfigTypeValidator(ocv1.ClusterExtensionConfigTypeInline)
+	ext := &ocv1.ClusterExtension{}
+	require.NoError(t, validate(context.Background(), ext))
+
+	ext.Spec.Config = ptr.To(inlineConfig(`{"a": "b"}`))
+	require.NoError(t, validate(context.Background(), ext))
+
+	ext.Spec.AdditionalConfigs = []ocv1.ClusterExtensionConfig{secretConfig("creds", "values.yaml")}
+	require.EqualError(t, validate(context.Background(), ext), `configType "SecretRef" is not supported, supported config types are [Inline]`)
+	require.NoError(t, ConfigTypeValidator(ocv1.ClusterExtensionConfigTypeInline, ocv1.ClusterExtensionConfigTypeSecretRef)(context.Background(), ext))
+}
+
+func TestResolveConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "install-ns"},
+			Data: map[string]string{
+				"values.yaml": "proxy:\n  host: proxy.example.com\n  port: 3128\nreplicas: 2\n",
+				"invalid":     "- not\n- an object\n",
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "install-ns"},
+			Data: map[string][]byte{
+				"values.yaml": []byte(`{"proxy": {"password": "s3cr3t"}}`),
+				"invalid":     []byte("password: s3cr3t: oops"),
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "other-ns"},
+			Data:       map[string]string{"values.yaml": "replicas: 5\n"},
+		},
+	).Build()
+	readerGetter := ConfigReaderGetterFunc(func(context.Context, *ocv1.ClusterExtension) (client.Reader, error) {
+		return reader, nil
+	})
+
+	for _, tc := range []struct {
+		name              string
+		config            *ocv1.ClusterExtensionConfig
+		additionalConfigs []ocv1.ClusterExtensionConfig
+		pinnedRevision    int64
+		expectedConfig    string
+		expectedErr       string
+		// expectedRequeue is set when the configuration references Secrets, which are polled.
+		expectedRequeue bool
+	}{
+		{
+			name: "no configuration",
+		},
+		{
+			name:   "inline configuration is used as is",
+			config: ptr.To(inlineConfig(`{"replicas": 1}`)),
+		},
+		{
+			name:           "configuration of a ConfigMap",
+			config:         ptr.To(configMapConfig("settings", "values.yaml")),
+			expectedConfig: `{"proxy": {"host": "proxy.example.com", "port": 3128}, "replicas": 2}`,
+		},
+		{
+			name:   "sources are merged in order",
+			config: ptr.To(inlineConfig(`{"replicas": 1, "debug": true}`)),
+			additionalConfigs: []ocv1.ClusterExtensionConfig{
+				configMapConfig("settings", "values.yaml"),
+				secretConfig("creds", "values.yaml"),
+			},
+			expectedConfig:  `{"debug": true, "proxy": {"host": "proxy.example.com", "password": "s3cr3t", "port": 3128}, "replicas": 2}`,
+			expectedRequeue: true,
+		},
+		{
+			name:              "inline configurations are merged",
+			config:            ptr.To(inlineConfig(`{"replicas": 1, "debug": true}`)),
+			additionalConfigs: []ocv1.ClusterExtensionConfig{inlineConfig(`{"replicas": 3}`)},
+			expectedConfig:    `{"debug": true, "replicas": 3}`,
+		},
+		{
+			name:           "configuration of a pinned revision is not resolved",
+			config:         ptr.To(configMapConfig("missing", "values.yaml")),
+			pinnedRevision: 1,
+		},
+		{
+			name:        "missing ConfigMap",
+			config:      ptr.To(configMapConfig("missing", "values.yaml")),
+			expectedErr: `key "values.yaml" of ConfigMap "missing" not found`,
+		},
+		{
+			name:            "missing Secret key",
+			config:          ptr.To(secretConfig("creds", "missing")),
+			expectedErr:     `key "missing" of Secret "creds" not found`,
+			expectedRequeue: true,
+		},
+		{
+			name:        "ConfigMap value is not an object",
+			config:      ptr.To(configMapConfig("settings", "invalid")),
+			expectedErr: `key "invalid" of ConfigMap "settings" is not a valid JSON or YAML object`,
+		},
+		{
+			name:            "Secret value is not an object",
+			config:          ptr.To(secretConfig("creds", "invalid")),
+			expectedErr:     `key "invalid" of Secret "creds" is not a valid JSON or YAML object`,
+			expectedRequeue: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ext := &ocv1.ClusterExtension{Spec: ocv1.ClusterExtensionSpec{
+				Namespace:         "install-ns",
+				Config:            tc.config,
+				AdditionalConfigs: tc.additionalConfigs,
+				PinnedRevision:    tc.pinnedRevision,
+			}}
+			state := &reconcileState{revisionStates: &RevisionStates{}}
+			res, err := ResolveConfig(readerGetter)(context.Background(), state, ext)
+
+			if tc.expectedErr != "" {
+				if tc.expectedRequeue {
+					require.NoError(t, err)
+					require.Equal(t, &ctrl.Result{RequeueAfter: secretConfigPollInterval}, res)
+				} else {
+					require.Nil(t, res)
+					require.ErrorContains(t, err, tc.expectedErr)
+				}
+				require.Nil(t, state.resolvedConfig)
+				cond := apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeProgressing)
+				require.NotNil(t, cond)
+				require.Equal(t, metav1.ConditionFalse, cond.Status)
+				require.Equal(t, ocv1.ReasonInvalidConfiguration, cond.Reason)
+				require.Contains(t, cond.Message, tc.expectedErr)
+				require.NotContains(t, cond.Message, "s3cr3t")
+				return
+			}
+			require.Nil(t, res)
+			require.NoError(t, err)
+			if tc.expectedRequeue {
+				require.Equal(t, secretConfigPollInterval, state.requeueAfter)
+			} else {
+				require.Zero(t, state.requeueAfter)
+			}
+			rendered := renderedExtension(state, ext)
+			if tc.expectedConfig == "" {
+				require.Nil(t, state.resolvedConfig)
+				require.Same(t, ext, rendered)
+				return
+			}
+			require.NotNil(t, state.resolvedConfig)
+			require.Equal(t, ocv1.ClusterExtensionConfigTypeInline, rendered.Spec.Config.ConfigType)
+			require.JSONEq(t, tc.expectedConfig, string(rendered.Spec.Config.Inline.Raw))
+			require.Empty(t, rendered.Spec.AdditionalConfigs)
+			require.Equal(t, tc.config, ext.Spec.Config, "the ClusterExtension must not be modified")
+		})
+	}
+}
+
+func TestClusterExtensionRequestsForConfigSource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, ocv1.AddToScheme(scheme))
+	newExt := func(name, namespace string, config *ocv1.ClusterExtensionConfig, additionalConfigs ...ocv1.ClusterExtensionConfig) client.Object {
+		return &ocv1.ClusterExtension{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       ocv1.ClusterExtensionSpec{Namespace: namespace, Config: config, AdditionalConfigs: additionalConfigs},
+		}
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newExt("inline", "install-ns", ptr.To(inlineConfig(`{"a": "b"}`))),
+		newExt("configmap", "install-ns", ptr.To(configMapConfig("settings", "values.yaml"))),
+		newExt("additional", "install-ns", nil, secretConfig("creds", "values.yaml"), configMapConfig("settings", "other.yaml")),
+		newExt("other-namespace", "other-ns", ptr.To(configMapConfig("settings", "values.yaml"))),
+		newExt("secret-named-settings", "install-ns", ptr.To(secretConfig("settings", "values.yaml"))),
+	).Build()
+
+	obj := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "install-ns"}}
+	requests := clusterExtensionRequestsForConfigSource(cl, ocv1.ClusterExtensionConfigTypeConfigMapRef)(context.Background(), obj)
+	require.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "configmap"}},
+		{NamespacedName: types.NamespacedName{Name: "additional"}},
+	}, requests)
+
+	obj = &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "install-ns"}}
+	requests = clusterExtensionRequestsForConfigSource(cl, ocv1.ClusterExtensionConfigTypeSecretRef)(context.Background(), obj)
+	require.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "additional"}}}, requests)
+}
+
+func TestResolveConfigWithoutPermissions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(_ context.Context, _ client.WithWatch, key client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+			return apierrors.NewForbidden(corev1.Resource("secrets"), key.Name, errors.New("no RBAC policy matched"))
+		},
+	}).Build()
+	var gotExt *ocv1.ClusterExtension
+	readerGetter := ConfigReaderGetterFunc(func(_ context.Context, ext *ocv1.ClusterExtension) (client.Reader, error) {
+		gotExt = ext
+		return reader, nil
+	})
+
+	ext := &ocv1.ClusterExtension{Spec: ocv1.ClusterExtensionSpec{
+		Namespace:      "install-ns",
+		ServiceAccount: ocv1.ServiceAccountReference{Name: "installer"},
+		Config:         ptr.To(secretConfig("creds", "values.yaml")),
+	}}
+	res, err := ResolveConfig(readerGetter)(context.Background(), &reconcileState{revisionStates: &RevisionStates{}}, ext)
+	require.Nil(t, res)
+	require.ErrorContains(t, err, `service account "installer" is not allowed to read key "values.yaml" of Secret "creds"`)
+	require.Same(t, ext, gotExt)
+	cond := apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeProgressing)
+	require.NotNil(t, cond)
+	require.Equal(t, ocv1.ReasonRetrying, cond.Reason)
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crhandler "sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
+)
+
+// ExtensionPolicyValidator returns a validator that checks the ClusterExtension satisfies the
+// ExtensionPolicies returned by listPolicies. Violations are terminal errors with the
+// PolicyViolation reason, as only changing the ClusterExtension or the ExtensionPolicies
+// resolves them. When listPolicies is nil, ExtensionPolicies are not enforced.
+func ExtensionPolicyValidator(listPolicies func(context.Context) ([]ocv1.ExtensionPolicy, error)) ClusterExtensionValidator {
+	return func(ctx context.Context, ext *ocv1.ClusterExtension) error {
+		if listPolicies == nil {
+			return nil
+		}
+		policies, err := listPolicies(ctx)
+		if err != nil {
+			return fmt.Errorf("error listing extension policies: %w", err)
+		}
+		slices.SortFunc(policies, func(a, b ocv1.ExtensionPolicy) int { return strings.Compare(a.Name, b.Name) })
+
+		var violations []error
+		for _, policy := range policies {
+			violations = append(violations, extensionPolicyViolations(policy, ext)...)
+		}
+		if len(violations) == 0 {
+			return nil
+		}
+		return errorutil.NewTerminalError(ocv1.ReasonPolicyViolation, errors.Join(violations...))
+	}
+}
+
+// extensionPolicyViolations returns how the spec of the ClusterExtension violates the policy.
+// The bundles the ClusterExtension may be resolved to are checked during resolution.
+func extensionPolicyViolations(policy ocv1.ExtensionPolicy, ext *ocv1.ClusterExtension) []error {
+	var violations []error
+	restrictsSource := len(policy.Spec.AllowedPackages) > 0 || len(policy.Spec.AllowedCatalogs) > 0
+	if restrictsSource && ext.Spec.Source.SourceType != ocv1.SourceTypeCatalog {
+		violations = append(violations, fmt.Errorf("extension policy %q only allows ClusterExtensions sourced from catalogs", policy.Name))
+	}
+	if len(policy.Spec.AllowedPackages) > 0 && ext.Spec.Source.Catalog != nil && !slices.Contains(policy.Spec.AllowedPackages, ext.Spec.Source.Catalog.PackageName) {
+		violations = append(violations, fmt.Errorf("extension policy %q does not allow package %q", policy.Name, ext.Spec.Source.Catalog.PackageName))
+	}
+	if policy.Spec.CRDUpgradeSafety == ocv1.CRDUpgradeSafetyRequired && crdUpgradeSafetyDisabled(ext) {
+		violations = append(violations, fmt.Errorf("extension policy %q requires the CRD Upgrade Safety pre-flight check", policy.Name))
+	}
+	return violations
+}
+
+func crdUpgradeSafetyDisabled(ext *ocv1.ClusterExtension) bool {
+	install := ext.Spec.Install
+	if install == nil || install.Preflight == nil || install.Preflight.CRDUpgradeSafety == nil {
+		return false
+	}
+	return install.Preflight.CRDUpgradeSafety.Enforcement == ocv1.CRDUpgradeSafetyEnforcementNone
+}
+
+// WithExtensionPolicyWatches reconciles every ClusterExtension when an ExtensionPolicy
+// changes, as any of them may start or stop violating it.
+func WithExtensionPolicyWatches(cl client.Reader) ControllerBuilderOption {
+	return func(builder *ctrl.Builder) {
+		builder.Watches(&ocv1.ExtensionPolicy{}, crhandler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+			clusterExtensions := metav1.PartialObjectMetadataList{}
+			clusterExtensions.SetGroupVersionKind(ocv1.GroupVersion.WithKind("ClusterExtensionList"))
+			if err := cl.List(ctx, &clusterExtensions); err != nil {
+				log.FromContext(ctx).Error(err, "unable to enqueue cluster extensions for extension policy reconcile")
+				return nil
+			}
+			requests := make([]reconcile.Request, 0, len(clusterExtensions.Items))
+			for _, ext := range clusterExtensions.Items {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&ext)})
+			}
+			return requests
+		}))
+	}
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
+	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
+)
+
+func TestExtensionPolicyValidator(t *testing.T) {
+	policies := []ocv1.ExtensionPolicy{
+		{ObjectMeta: metav1.ObjectMeta{Name: "packages"}, Spec: ocv1.ExtensionPolicySpec{AllowedPackages: []string{"prometheus"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "crd-upgrade-safety"}, Spec: ocv1.ExtensionPolicySpec{CRDUpgradeSafety: ocv1.CRDUpgradeSafetyRequired}},
+	}
+	validate := ExtensionPolicyValidator(func(context.Context) ([]ocv1.ExtensionPolicy, error) {
+		return policies, nil
+	})
+	newExt := func(packageName string) *ocv1.ClusterExtension {
+		return newTestExtension(func(ext *ocv1.ClusterExtension) { ext.Spec.Source.Catalog.PackageName = packageName })
+	}
+
+	t.Log("By checking a ClusterExtension satisfying every policy is valid")
+	require.NoError(t, validate(context.Background(), newExt("prometheus")))
+
+	t.Log("By checking every violation is reported with the PolicyViolation reason")
+	ext := newExt("argocd-operator")
+	ext.Spec.Install = &ocv1.ClusterExtensionInstallConfig{Preflight: &ocv1.PreflightConfig{
+		CRDUpgradeSafety: &ocv1.CRDUpgradeSafetyPreflightConfig{Enforcement: ocv1.CRDUpgradeSafetyEnforcementNone},
+	}}
+	err := validate(context.Background(), ext)
+	reason, ok := errorutil.ExtractTerminalReason(err)
+	require.True(t, ok)
+	require.Equal(t, ocv1.ReasonPolicyViolation, reason)
+	require.EqualError(t, errorutil.UnwrapTerminal(err), `extension policy "crd-upgrade-safety" requires the CRD Upgrade Safety pre-flight check
+extension policy "packages" does not allow package "argocd-operator"`)
+
+	t.Log("By checking bundle images are not allowed when packages are restricted")
+	ext = &ocv1.ClusterExtension{Spec: ocv1.ClusterExtensionSpec{Source: ocv1.SourceConfig{SourceType: ocv1.SourceTypeBundleImage}}}
+	require.EqualError(t, errorutil.UnwrapTerminal(validate(context.Background(), ext)), `extension policy "packages" only allows ClusterExtensions sourced from catalogs`)
+
+	t.Log("By checking policies are not enforced when they are not listed")
+	require.NoError(t, ExtensionPolicyValidator(nil)(context.Background(), newExt("argocd-operator")))
+
+	t.Log("By checking policies that cannot be listed are retried")
+	err = ExtensionPolicyValidator(func(context.Context) ([]ocv1.ExtensionPolicy, error) {
+		return nil, errors.New("boom")
+	})(context.Background(), newExt("prometheus"))
+	require.EqualError(t, err, "error listing extension policies: boom")
+	_, ok = errorutil.ExtractTerminalReason(err)
+	require.False(t, ok)
+}
+
+func TestValidateClusterExtensionPolicyViolation(t *testing.T) {
+	step := ValidateClusterExtension(
+		func(context.Context, *ocv1.ClusterExtension) error {
+			return errorutil.NewTerminalError(ocv1.ReasonPolicyViolation, errors.New(`extension policy "packages" does not allow package "argocd-operator"`))
+		},
+	)
+	ext := &ocv1.ClusterExtension{}
+	_, err := step(context.Background(), &reconcileState{}, ext)
+	require.Error(t, err)
+
+	progressingCond := apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeProgressing)
+	require.NotNil(t, progressingCond)
+	require.Equal(t, metav1.ConditionFalse, progressingCond.Status)
+	require.Equal(t, ocv1.ReasonPolicyViolation, progressingCond.Reason)
+	require.Equal(t, `operation cannot proceed due to the following validation error(s): extension policy "packages" does not allow package "argocd-operator"`, progressingCond.Message)
+}
+
+func TestResolveBundlePolicyViolation(t *testing.T) {
+	policyErr := errorutil.NewTerminalError(ocv1.ReasonPolicyViolation, errors.New(`no bundles found for package "prometheus" allowed by extension policies`))
+	r := resolve.Func(func(context.Context, *ocv1.ClusterExtension, *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+		return nil, nil, nil, policyErr
+	})
+	ext := newTestExtension()
+	installed := &RevisionMetadata{BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.2.3", Version: "1.2.3"}}
+
+	// The installed bundle is not kept as is: the catalogs are not checked for a fallback
+	_, err := ResolveBundle(r, nil)(context.Background(), &reconcileState{revisionStates: &RevisionStates{Installed: installed}}, ext)
+	require.ErrorIs(t, err, policyErr)
+
+	progressingCond := apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeProgressing)
+	require.NotNil(t, progressingCond)
+	require.Equal(t, metav1.ConditionFalse, progressingCond.Status)
+	require.Equal(t, ocv1.ReasonPolicyViolation, progressingCond.Reason)
+	require.Equal(t, `no bundles found for package "prometheus" allowed by extension policies`, progressingCond.Message)
+}
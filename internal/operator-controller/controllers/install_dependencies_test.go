@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	bsemver "github.com/blang/semver/v4"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
+)
+
+// reconcileDependencyGate runs the gate step before the InstallDependencies step, as the
+// operator-controller does, for the prometheus.v1.1.0 bundle requiring a missing package.
+// It returns the result of the reconcile and the ClusterExtensions that exist afterwards.
+func reconcileDependencyGate(t *testing.T, gate ReconcileStepFunc, installed *RevisionMetadata, ext *ocv1.ClusterExtension) (ctrl.Result, []ocv1.ClusterExtension) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, ocv1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	steps := ReconcileSteps{
+		func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
+			state.revisionStates = &RevisionStates{Installed: installed}
+			state.resolvedRevisionMetadata = &RevisionMetadata{
+				Package:        "prometheus",
+				BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.1.0", Version: "1.1.0"},
+			}
+			state.resolvedBundle = &declcfg.Bundle{Name: "prometheus.v1.1.0", Package: "prometheus"}
+			state.resolvedDependencies = []resolve.ResolvedDependency{{
+				PackageName: "dep",
+				Bundle:      &declcfg.Bundle{Name: "dep.v2.0.0", Package: "dep"},
+				Version:     &declcfg.VersionRelease{Version: bsemver.MustParse("2.0.0")},
+				Catalog:     "operatorhub",
+			}}
+			state.imageFS = fstest.MapFS{}
+			return nil, nil
+		},
+		gate,
+		InstallDependencies(cl),
+	}
+	res, err := steps.Reconcile(context.Background(), ext)
+	require.NoError(t, err)
+
+	exts := &ocv1.ClusterExtensionList{}
+	require.NoError(t, cl.List(context.Background(), exts))
+	return res, exts.Items
+}
+
+// withDependencyInstall installs the dep package when it is required.
+func withDependencyInstall(ext *ocv1.ClusterExtension) {
+	ext.Spec.Source.Catalog.DependencyPolicy = ocv1.DependencyPolicyInstall
+	ext.Spec.Source.Catalog.InstallableDependencies = []string{"dep"}
+}
+
+func TestInstallDependenciesAfterPlanBundle(t *testing.T) {
+	step := PlanBundle(planFunc(func(context.Context, fs.FS, *ocv1.ClusterExtension, map[string]string, map[string]string) ([]ocv1.PlannedObjectChange, error) {
+		return nil, nil
+	}))
+
+	t.Log("By checking dependencies are not installed for a planned rollout")
+	ext := newTestExtension(withDependencyInstall)
+	ext.Spec.RolloutMode = ocv1.RolloutModePlan
+	res, exts := reconcileDependencyGate(t, step, nil, ext)
+	require.Equal(t, ctrl.Result{}, res)
+	require.Empty(t, exts)
+	require.NotNil(t, ext.Status.Plan)
+
+	t.Log("By checking dependencies are installed once the rollout is applied")
+	ext = newTestExtension(withDependencyInstall)
+	ext.Spec.RolloutMode = ocv1.RolloutModeApply
+	res, exts = reconcileDependencyGate(t, step, nil, ext)
+	require.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Second}, res)
+	require.Len(t, exts, 1)
+	require.Equal(t, "dep", exts[0].Name)
+}
+
+func TestInstallDependenciesAfterApproveUpgrade(t *testing.T) {
+	installed := &RevisionMetadata{BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.0.0", Version: "1.0.0"}}
+
+	t.Log("By checking dependencies are not installed while the upgrade is pending approval")
+	ext := newTestExtension(withDependencyInstall)
+	ext.Spec.UpgradeApproval = &ocv1.UpgradeApproval{Policy: ocv1.UpgradeApprovalManual}
+	res, exts := reconcileDependencyGate(t, ApproveUpgrade(), installed, ext)
+	require.Equal(t, ctrl.Result{}, res)
+	require.Empty(t, exts)
+	require.NotNil(t, ext.Status.PendingUpgrade)
+
+	t.Log("By checking dependencies are installed once the upgrade is approved")
+	ext = newTestExtension(withDependencyInstall)
+	ext.Spec.UpgradeApproval = &ocv1.UpgradeApproval{Policy: ocv1.UpgradeApprovalManual, ApprovedVersion: "1.1.0"}
+	res, exts = reconcileDependencyGate(t, ApproveUpgrade(), installed, ext)
+	require.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Second}, res)
+	require.Len(t, exts, 1)
+	require.Nil(t, ext.Status.PendingUpgrade)
+}
+
+func TestInstallDependenciesAfterAwaitMaintenanceWindow(t *testing.T) {
+	installed := &RevisionMetadata{BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.0.0", Version: "1.0.0"}}
+	newExt := func() *ocv1.ClusterExtension {
+		ext := newTestExtension(withDependencyInstall)
+		ext.Spec.MaintenanceWindows = &ocv1.MaintenanceWindows{Windows: []ocv1.MaintenanceWindow{
+			{Schedule: "0 2 * * 6", DurationMinutes: 120},
+		}}
+		return ext
+	}
+	// Friday
+	clk := clocktesting.NewFakeClock(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC))
+
+	t.Log("By checking dependencies are not installed outside the maintenance windows")
+	ext := newExt()
+	res, exts := reconcileDependencyGate(t, AwaitMaintenanceWindow(clk), installed, ext)
+	require.Equal(t, ctrl.Result{RequeueAfter: 14 * time.Hour}, res)
+	require.Empty(t, exts)
+
+	t.Log("By checking dependencies are installed once the window opens")
+	clk.SetTime(time.Date(2026, 10, 17, 2, 30, 0, 0, time.UTC))
+	ext = newExt()
+	res, exts = reconcileDependencyGate(t, AwaitMaintenanceWindow(clk), installed, ext)
+	require.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Second}, res)
+	require.Len(t, exts, 1)
+}
+
+func TestInstallDependenciesNotInstallable(t *testing.T) {
+	passThrough := func(context.Context, *reconcileState, *ocv1.ClusterExtension) (*ctrl.Result, error) { return nil, nil }
+
+	t.Log("By checking dependencies that are not listed in installableDependencies are not installed")
+	ext := newTestExtension(withDependencyInstall)
+	ext.Spec.Source.Catalog.InstallableDependencies = []string{"other"}
+	res, exts := reconcileDependencyGate(t, passThrough, nil, ext)
+	require.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Second}, res)
+	require.Empty(t, exts)
+	cond := apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeProgressing)
+	require.NotNil(t, cond)
+	require.Contains(t, cond.Message, `bundle "prometheus.v1.1.0" requires packages [dep] that are not installed by any ClusterExtension nor listed in installableDependencies`)
+}
+
+func TestCreateDependencyExtension(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, ocv1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ext := newTestExtension(withDependencyInstall)
+	ext.Name = "prometheus"
+	ext.Spec.Namespace = "monitoring"
+	ext.Spec.ServiceAccount = ocv1.ServiceAccountReference{Name: "installer"}
+	dep := &resolve.ResolvedDependency{
+		PackageName: "dep",
+		Bundle:      &declcfg.Bundle{Name: "dep.v2.0.0-1", Package: "dep"},
+		Version:     &declcfg.VersionRelease{Version: bsemver.MustParse("2.0.0"), Release: declcfg.Release{bsemver.PRVersion{VersionNum: 1, IsNum: true}}},
+		Catalog:     "operatorhub",
+	}
+
+	t.Log("By checking the dependency is pinned to its version and release")
+	name, err := createDependencyExtension(context.Background(), cl, ext, dep)
+	require.NoError(t, err)
+	depExt := &ocv1.ClusterExtension{}
+	require.NoError(t, cl.Get(context.Background(), client.ObjectKey{Name: name}, depExt))
+	require.Equal(t, "2.0.0+1", depExt.Spec.Source.Catalog.Version)
+
+	t.Log("By checking an existing ClusterExtension installing the dependency the same way is used")
+	name, err = createDependencyExtension(context.Background(), cl, ext, dep)
+	require.NoError(t, err)
+	require.Equal(t, "dep", name)
+
+	t.Log("By checking an existing ClusterExtension installing the dependency differently is rejected")
+	depExt.Spec.Source.Catalog.Version = "1.0.0"
+	depExt.Spec.ServiceAccount.Name = "other"
+	require.NoError(t, cl.Update(context.Background(), depExt))
+	_, err = createDependencyExtension(context.Background(), cl, ext, dep)
+	require.EqualError(t, err, `unable to create ClusterExtension for dependency on package "dep": ClusterExtension "dep" already exists with a different version, service account`)
+}
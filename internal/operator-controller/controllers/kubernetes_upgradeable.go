@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	bsemver "github.com/blang/semver/v4"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/bundleutil"
+)
+
+// CheckKubernetesUpgradeable reports in the KubernetesUpgradeable condition whether the bundle
+// resolved by the ResolveBundle step supports the minor version of Kubernetes following the
+// one returned by kubeVersion, so that cluster upgrades can be planned around ClusterExtensions.
+// The condition is removed when the ClusterExtension is not installed from a catalog, and is
+// left as is while a revision rolls out.
+func CheckKubernetesUpgradeable(kubeVersion func(context.Context) (*bsemver.Version, error)) ReconcileStepFunc {
+	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
+		if ext.Spec.PinnedRevision > 0 || ext.Spec.Source.SourceType != ocv1.SourceTypeCatalog {
+			apimeta.RemoveStatusCondition(&ext.Status.Conditions, ocv1.TypeKubernetesUpgradeable)
+			return nil, nil
+		}
+		if state.resolvedBundle == nil || state.resolvedRevisionMetadata == nil {
+			return nil, nil
+		}
+
+		clusterVersion, err := kubeVersion(ctx)
+		if err != nil {
+			err = fmt.Errorf("error getting the Kubernetes version of the cluster: %w", err)
+			setStatusProgressing(ext, err)
+			setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+			return nil, err
+		}
+		maxVersion, err := bundleutil.GetMaxKubeVersion(*state.resolvedBundle)
+		if err != nil {
+			err = fmt.Errorf("error getting the maximum Kubernetes version of bundle %q: %w", state.resolvedBundle.Name, err)
+			setStatusProgressing(ext, err)
+			setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+			return nil, err
+		}
+
+		cond := metav1.Condition{
+			Type:               ocv1.TypeKubernetesUpgradeable,
+			Status:             metav1.ConditionTrue,
+			Reason:             ocv1.ReasonCompatible,
+			ObservedGeneration: ext.GetGeneration(),
+		}
+		version := state.resolvedRevisionMetadata.Version
+		switch {
+		case maxVersion == nil:
+			cond.Message = fmt.Sprintf("extension %q at version %q does not declare a maximum Kubernetes version", ext.Name, version)
+		case clusterVersion.Major > maxVersion.Major || (clusterVersion.Major == maxVersion.Major && clusterVersion.Minor >= maxVersion.Minor):
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = ocv1.ReasonIncompatible
+			cond.Message = fmt.Sprintf("extension %q at version %q blocks moving to Kubernetes %d.%d", ext.Name, version, maxVersion.Major, maxVersion.Minor+1)
+		default:
+			cond.Message = fmt.Sprintf("extension %q at version %q supports Kubernetes up to %d.%d", ext.Name, version, maxVersion.Major, maxVersion.Minor)
+		}
+		SetStatusCondition(&ext.Status.Conditions, cond)
+		return nil, nil
+	}
+}
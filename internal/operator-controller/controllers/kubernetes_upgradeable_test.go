@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	bsemver "github.com/blang/semver/v4"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/bundleutil"
+)
+
+func TestCheckKubernetesUpgradeable(t *testing.T) {
+	step := CheckKubernetesUpgradeable(func(context.Context) (*bsemver.Version, error) {
+		v := bsemver.MustParse("1.33.2")
+		return &v, nil
+	})
+	newExt := func() *ocv1.ClusterExtension {
+		return newTestExtension(func(ext *ocv1.ClusterExtension) { ext.Name = "prometheus" })
+	}
+	resolvedState := func(maxKubeVersion string) *reconcileState {
+		bundle := &declcfg.Bundle{Name: "prometheus.v1.2.3", Package: "prometheus"}
+		if maxKubeVersion != "" {
+			bundle.Properties = []property.Property{{Type: bundleutil.PropertyMaxKubeVersion, Value: json.RawMessage(`"` + maxKubeVersion + `"`)}}
+		}
+		return &reconcileState{
+			resolvedBundle:           bundle,
+			resolvedRevisionMetadata: &RevisionMetadata{BundleMetadata: ocv1.BundleMetadata{Name: bundle.Name, Version: "1.2.3"}},
+		}
+	}
+	requireCondition := func(t *testing.T, ext *ocv1.ClusterExtension, status metav1.ConditionStatus, reason, message string) {
+		cond := apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeKubernetesUpgradeable)
+		require.NotNil(t, cond)
+		require.Equal(t, status, cond.Status)
+		require.Equal(t, reason, cond.Reason)
+		require.Equal(t, message, cond.Message)
+	}
+
+	t.Run("bundle supporting the next Kubernetes version is compatible", func(t *testing.T) {
+		ext := newExt()
+		_, err := step(context.Background(), resolvedState("1.34"), ext)
+		require.NoError(t, err)
+		requireCondition(t, ext, metav1.ConditionTrue, ocv1.ReasonCompatible, `extension "prometheus" at version "1.2.3" supports Kubernetes up to 1.34`)
+	})
+
+	t.Run("bundle not declaring a maximum Kubernetes version is compatible", func(t *testing.T) {
+		ext := newExt()
+		_, err := step(context.Background(), resolvedState(""), ext)
+		require.NoError(t, err)
+		requireCondition(t, ext, metav1.ConditionTrue, ocv1.ReasonCompatible, `extension "prometheus" at version "1.2.3" does not declare a maximum Kubernetes version`)
+	})
+
+	t.Run("bundle not supporting the next Kubernetes version blocks the cluster upgrade", func(t *testing.T) {
+		ext := newExt()
+		_, err := step(context.Background(), resolvedState("1.33"), ext)
+		require.NoError(t, err)
+		requireCondition(t, ext, metav1.ConditionFalse, ocv1.ReasonIncompatible, `extension "prometheus" at version "1.2.3" blocks moving to Kubernetes 1.34`)
+	})
+
+	t.Run("condition is kept while a revision rolls out", func(t *testing.T) {
+		ext := newExt()
+		_, err := step(context.Background(), resolvedState("1.33"), ext)
+		require.NoError(t, err)
+		_, err = step(context.Background(), &reconcileState{resolvedRevisionMetadata: &RevisionMetadata{}}, ext)
+		require.NoError(t, err)
+		requireCondition(t, ext, metav1.ConditionFalse, ocv1.ReasonIncompatible, `extension "prometheus" at version "1.2.3" blocks moving to Kubernetes 1.34`)
+	})
+
+	t.Run("condition is removed for bundle images", func(t *testing.T) {
+		ext := newExt()
+		_, err := step(context.Background(), resolvedState("1.33"), ext)
+		require.NoError(t, err)
+		ext.Spec.Source = ocv1.SourceConfig{SourceType: ocv1.SourceTypeBundleImage}
+		_, err = step(context.Background(), &reconcileState{}, ext)
+		require.NoError(t, err)
+		require.Nil(t, apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeKubernetesUpgradeable))
+	})
+}
@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+// maintenanceWindow is a parsed ocv1.MaintenanceWindow.
+type maintenanceWindow struct {
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+// parseMaintenanceWindows parses the schedules and the time zone of the maintenance windows.
+func parseMaintenanceWindows(mw *ocv1.MaintenanceWindows) ([]maintenanceWindow, *time.Location, error) {
+	loc := time.UTC
+	if mw.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(mw.TimeZone); err != nil {
+			return nil, nil, fmt.Errorf("invalid maintenance window time zone %q: %w", mw.TimeZone, err)
+		}
+	}
+
+	windows := make([]maintenanceWindow, 0, len(mw.Windows))
+	for _, w := range mw.Windows {
+		// The time zone of the windows is set by timeZone, as for the schedules of CronJobs.
+		if strings.Contains(w.Schedule, "TZ") {
+			return nil, nil, fmt.Errorf("invalid maintenance window schedule %q: the time zone must be set with timeZone", w.Schedule)
+		}
+		schedule, err := cron.ParseStandard(w.Schedule)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid maintenance window schedule %q: %w", w.Schedule, err)
+		}
+		windows = append(windows, maintenanceWindow{schedule: schedule, duration: time.Duration(w.DurationMinutes) * time.Minute})
+	}
+	return windows, loc, nil
+}
+
+// nextMaintenanceWindow returns whether one of the windows is open at now and, when none
+// is, the time the next window opens. The returned time is zero when no window ever opens.
+func nextMaintenanceWindow(windows []maintenanceWindow, loc *time.Location, now time.Time) (bool, time.Time) {
+	now = now.In(loc)
+	var next time.Time
+	for _, w := range windows {
+		// The window opened at the first activation after now-duration is open when
+		// that activation is not after now.
+		opens := w.schedule.Next(now.Add(-w.duration))
+		if opens.IsZero() {
+			continue
+		}
+		if !opens.After(now) {
+			return true, time.Time{}
+		}
+		if next.IsZero() || opens.Before(next) {
+			next = opens
+		}
+	}
+	return false, next
+}
+
+// MaintenanceWindowsValidator returns a validator that checks the maintenance windows
+// of the ClusterExtension can be parsed. When enabled is false, ClusterExtensions with
+// maintenance windows are rejected.
+func MaintenanceWindowsValidator(enabled bool) ClusterExtensionValidator {
+	return func(_ context.Context, ext *ocv1.ClusterExtension) error {
+		if ext.Spec.MaintenanceWindows == nil {
+			return nil
+		}
+		if !enabled {
+			return errors.New("maintenanceWindows is not supported")
+		}
+		_, _, err := parseMaintenanceWindows(ext.Spec.MaintenanceWindows)
+		return err
+	}
+}
+
+// AwaitMaintenanceWindow holds the rollout of the resolved bundle when it is an upgrade of the
+// installed bundle and none of the maintenance windows of the ClusterExtension is open. Held
+// upgrades are reported in the ClusterExtension status, along with the time the next window
+// opens, and the ClusterExtension is requeued at that time.
+func AwaitMaintenanceWindow(clk clock.Clock) ReconcileStepFunc {
+	return func(ctx context.Context, state *reconcileState, ext *ocv1.ClusterExtension) (*ctrl.Result, error) {
+		if ext.Spec.MaintenanceWindows == nil || !isPendingUpgrade(state) {
+			return nil, nil
+		}
+
+		windows, loc, err := parseMaintenanceWindows(ext.Spec.MaintenanceWindows)
+		if err != nil {
+			setStatusProgressing(ext, err)
+			setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+			return nil, err
+		}
+		now := clk.Now()
+		open, next := nextMaintenanceWindow(windows, loc, now)
+		if open {
+			return nil, nil
+		}
+
+		ext.Status.PendingUpgrade = &ocv1.PendingUpgrade{Bundle: state.resolvedRevisionMetadata.BundleMetadata}
+		setInstalledStatusFromRevisionStates(ext, state.revisionStates)
+		if next.IsZero() {
+			apimeta.SetStatusCondition(&ext.Status.Conditions, metav1.Condition{
+				Type:   ocv1.TypeProgressing,
+				Status: metav1.ConditionFalse,
+				Reason: ocv1.ReasonAwaitingMaintenanceWindow,
+				Message: fmt.Sprintf("upgrade to bundle %q with version %q is held: no maintenance window opens within the next five years",
+					state.resolvedRevisionMetadata.Name, state.resolvedRevisionMetadata.Version),
+				ObservedGeneration: ext.GetGeneration(),
+			})
+			return &ctrl.Result{RequeueAfter: state.requeueAfter}, nil
+		}
+
+		log.FromContext(ctx).Info("upgrade held until the next maintenance window", "bundle", state.resolvedRevisionMetadata.Name,
+			"version", state.resolvedRevisionMetadata.Version, "nextWindow", next)
+		apimeta.SetStatusCondition(&ext.Status.Conditions, metav1.Condition{
+			Type:   ocv1.TypeProgressing,
+			Status: metav1.ConditionFalse,
+			Reason: ocv1.ReasonAwaitingMaintenanceWindow,
+			Message: fmt.Sprintf("upgrade to bundle %q with version %q is held until the next maintenance window opens at %s",
+				state.resolvedRevisionMetadata.Name, state.resolvedRevisionMetadata.Version, next.Format(time.RFC3339)),
+			ObservedGeneration: ext.GetGeneration(),
+		})
+		requeueAfter := next.Sub(now)
+		if state.requeueAfter > 0 {
+			requeueAfter = min(requeueAfter, state.requeueAfter)
+		}
+		return &ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+}
@@ -0,0 +1,192 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+func TestNextMaintenanceWindow(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name         string
+		mw           ocv1.MaintenanceWindows
+		now          time.Time
+		expectOpen   bool
+		expectedNext time.Time
+	}{
+		{
+			name: "before the window opens",
+			mw: ocv1.MaintenanceWindows{Windows: []ocv1.MaintenanceWindow{
+				{Schedule: "0 2 * * 6", DurationMinutes: 120},
+			}},
+			// Friday
+			now:          time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "when the window opens",
+			mw: ocv1.MaintenanceWindows{Windows: []ocv1.MaintenanceWindow{
+				{Schedule: "0 2 * * 6", DurationMinutes: 120},
+			}},
+			now:        time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC),
+			expectOpen: true,
+		},
+		{
+			name: "while the window is open",
+			mw: ocv1.MaintenanceWindows{Windows: []ocv1.MaintenanceWindow{
+				{Schedule: "0 2 * * 6", DurationMinutes: 120},
+			}},
+			now:        time.Date(2026, 10, 17, 3, 59, 0, 0, time.UTC),
+			expectOpen: true,
+		},
+		{
+			name: "when the window closes",
+			mw: ocv1.MaintenanceWindows{Windows: []ocv1.MaintenanceWindow{
+				{Schedule: "0 2 * * 6", DurationMinutes: 120},
+			}},
+			now:          time.Date(2026, 10, 17, 4, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2026, 10, 24, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "earliest of several windows",
+			mw: ocv1.MaintenanceWindows{Windows: []ocv1.MaintenanceWindow{
+				{Schedule: "0 2 * * 6", DurationMinutes: 120},
+				{Schedule: "30 22 * * 1-5", DurationMinutes: 60},
+			}},
+			now:          time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+			expectedNext: time.Date(2026, 10, 16, 22, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "window spanning midnight",
+			mw: ocv1.MaintenanceWindows{Windows: []ocv1.MaintenanceWindow{
+				{Schedule: "0 23 * * *", DurationMinutes: 180},
+			}},
+			now:        time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC),
+			expectOpen: true,
+		},
+		{
+			name: "schedule evaluated in the time zone",
+			mw: ocv1.MaintenanceWindows{TimeZone: "Europe/Berlin", Windows: []ocv1.MaintenanceWindow{
+				{Schedule: "0 2 * * 6", DurationMinutes: 120},
+			}},
+			// 04:30 in Berlin, the window would be open in UTC
+			now:          time.Date(2026, 10, 17, 2, 30, 0, 0, time.UTC),
+			expectedNext: time.Date(2026, 10, 24, 2, 0, 0, 0, berlin),
+		},
+		{
+			name: "schedule that never activates",
+			mw: ocv1.MaintenanceWindows{Windows: []ocv1.MaintenanceWindow{
+				{Schedule: "0 2 30 2 *", DurationMinutes: 120},
+			}},
+			now: time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			windows, loc, err := parseMaintenanceWindows(&tc.mw)
+			require.NoError(t, err)
+			open, next := nextMaintenanceWindow(windows, loc, tc.now)
+			require.Equal(t, tc.expectOpen, open)
+			require.True(t, tc.expectedNext.Equal(next), "expected %s, got %s", tc.expectedNext, next)
+		})
+	}
+}
+
+func TestMaintenanceWindowsValidator(t *testing.T) {
+	ext := &ocv1.ClusterExtension{}
+	require.NoError(t, MaintenanceWindowsValidator(false)(context.Background(), ext))
+
+	ext.Spec.MaintenanceWindows = &ocv1.MaintenanceWindows{Windows: []ocv1.MaintenanceWindow{{Schedule: "0 2 * * 6", DurationMinutes: 60}}}
+	require.EqualError(t, MaintenanceWindowsValidator(false)(context.Background(), ext), "maintenanceWindows is not supported")
+	require.NoError(t, MaintenanceWindowsValidator(true)(context.Background(), ext))
+
+	ext.Spec.MaintenanceWindows.Windows[0].Schedule = "0 2 * *"
+	require.ErrorContains(t, MaintenanceWindowsValidator(true)(context.Background(), ext), `invalid maintenance window schedule "0 2 * *"`)
+
+	ext.Spec.MaintenanceWindows.Windows[0].Schedule = "TZ=Europe/Berlin 0 2 * * 6"
+	require.EqualError(t, MaintenanceWindowsValidator(true)(context.Background(), ext),
+		`invalid maintenance window schedule "TZ=Europe/Berlin 0 2 * * 6": the time zone must be set with timeZone`)
+
+	ext.Spec.MaintenanceWindows.Windows[0].Schedule = "0 2 * * 6"
+	ext.Spec.MaintenanceWindows.TimeZone = "Mars/Olympus_Mons"
+	require.ErrorContains(t, MaintenanceWindowsValidator(true)(context.Background(), ext), `invalid maintenance window time zone "Mars/Olympus_Mons"`)
+}
+
+func TestAwaitMaintenanceWindow(t *testing.T) {
+	installed := &RevisionMetadata{BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.0.0", Version: "1.0.0"}}
+	resolved := &RevisionMetadata{BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.1.0", Version: "1.1.0"}}
+	newExt := func() *ocv1.ClusterExtension {
+		return &ocv1.ClusterExtension{Spec: ocv1.ClusterExtensionSpec{
+			MaintenanceWindows: &ocv1.MaintenanceWindows{Windows: []ocv1.MaintenanceWindow{
+				{Schedule: "0 2 * * 6", DurationMinutes: 120},
+			}},
+		}}
+	}
+	// Friday
+	clk := clocktesting.NewFakeClock(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC))
+	step := AwaitMaintenanceWindow(clk)
+
+	t.Log("By checking an upgrade is held until the window opens")
+	ext := newExt()
+	state := &reconcileState{
+		revisionStates:           &RevisionStates{Installed: installed},
+		resolvedRevisionMetadata: resolved,
+	}
+	res, err := step(context.Background(), state, ext)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, 14*time.Hour, res.RequeueAfter)
+	require.Equal(t, &ocv1.PendingUpgrade{Bundle: resolved.BundleMetadata}, ext.Status.PendingUpgrade)
+	progressingCond := apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeProgressing)
+	require.NotNil(t, progressingCond)
+	require.Equal(t, metav1.ConditionFalse, progressingCond.Status)
+	require.Equal(t, ocv1.ReasonAwaitingMaintenanceWindow, progressingCond.Reason)
+	require.Contains(t, progressingCond.Message, "opens at 2026-10-17T02:00:00Z")
+	installedCond := apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeInstalled)
+	require.NotNil(t, installedCond)
+	require.Equal(t, metav1.ConditionTrue, installedCond.Status)
+
+	t.Log("By checking the requeue of the bundle image poll is kept when it is sooner")
+	ext = newExt()
+	state.requeueAfter = time.Hour
+	res, err = step(context.Background(), state, ext)
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, res.RequeueAfter)
+
+	t.Log("By checking an installation is not held")
+	ext = newExt()
+	res, err = step(context.Background(), &reconcileState{
+		revisionStates:           &RevisionStates{},
+		resolvedRevisionMetadata: resolved,
+	}, ext)
+	require.NoError(t, err)
+	require.Nil(t, res)
+	require.Nil(t, ext.Status.PendingUpgrade)
+
+	t.Log("By checking an upgrade that is rolling out is not held")
+	res, err = step(context.Background(), &reconcileState{
+		revisionStates:           &RevisionStates{Installed: installed, RollingOut: []*RevisionMetadata{resolved}},
+		resolvedRevisionMetadata: resolved,
+	}, ext)
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	t.Log("By checking an upgrade is rolled out once the window opens")
+	clk.SetTime(time.Date(2026, 10, 17, 2, 30, 0, 0, time.UTC))
+	res, err = step(context.Background(), &reconcileState{
+		revisionStates:           &RevisionStates{Installed: installed},
+		resolvedRevisionMetadata: resolved,
+	}, ext)
+	require.NoError(t, err)
+	require.Nil(t, res)
+	require.Nil(t, ext.Status.PendingUpgrade)
+}
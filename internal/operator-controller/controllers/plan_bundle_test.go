@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+type planFunc func(context.Context, fs.FS, *ocv1.ClusterExtension, map[string]string, map[string]string) ([]ocv1.PlannedObjectChange, error)
+
+func (f planFunc) Plan(ctx context.Context, contentFS fs.FS, ext *ocv1.ClusterExtension, objLbls map[string]string, storageAnnotations map[string]string) ([]ocv1.PlannedObjectChange, error) {
+	return f(ctx, contentFS, ext, objLbls, storageAnnotations)
+}
+
+func TestPlanBundleWithoutContent(t *testing.T) {
+	installed := &RevisionMetadata{BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.0.0", Version: "1.0.0"}}
+	step := PlanBundle(planFunc(func(context.Context, fs.FS, *ocv1.ClusterExtension, map[string]string, map[string]string) ([]ocv1.PlannedObjectChange, error) {
+		t.Fatal("unexpected call to Plan")
+		return nil, nil
+	}))
+	newExt := func() *ocv1.ClusterExtension {
+		return &ocv1.ClusterExtension{Spec: ocv1.ClusterExtensionSpec{RolloutMode: ocv1.RolloutModePlan}}
+	}
+
+	t.Log("By checking no changes are planned while the content of the installed bundle is unavailable")
+	ext := newExt()
+	res, err := step(context.Background(), &reconcileState{
+		revisionStates:           &RevisionStates{Installed: installed},
+		resolvedRevisionMetadata: installed,
+	}, ext)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, &ocv1.ClusterExtensionPlan{Bundle: installed.BundleMetadata}, ext.Status.Plan)
+	progressingCond := apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeProgressing)
+	require.NotNil(t, progressingCond)
+	require.Equal(t, metav1.ConditionTrue, progressingCond.Status)
+	require.Equal(t, ocv1.ReasonPlanned, progressingCond.Reason)
+	require.Contains(t, progressingCond.Message, "no changes are planned")
+
+	t.Log("By checking planning an upgrade without its content fails")
+	ext = newExt()
+	_, err = step(context.Background(), &reconcileState{
+		revisionStates:           &RevisionStates{Installed: installed},
+		resolvedRevisionMetadata: &RevisionMetadata{BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.1.0", Version: "1.1.0"}},
+	}, ext)
+	require.ErrorContains(t, err, "bundle content unavailable")
+	require.Nil(t, ext.Status.Plan)
+
+	t.Log("By checking planning without a resolved bundle fails")
+	ext = newExt()
+	_, err = step(context.Background(), &reconcileState{revisionStates: &RevisionStates{}}, ext)
+	require.ErrorContains(t, err, "no bundle was resolved")
+	require.Nil(t, ext.Status.Plan)
+}
+
+func TestPlanBundleLimitsListedChanges(t *testing.T) {
+	changes := make([]ocv1.PlannedObjectChange, maxPlannedObjectChanges+88)
+	for i := range changes {
+		changes[i] = ocv1.PlannedObjectChange{Action: ocv1.PlannedActionCreate, APIVersion: "v1", Kind: "ConfigMap", Name: fmt.Sprintf("cm-%d", i)}
+	}
+	step := PlanBundle(planFunc(func(context.Context, fs.FS, *ocv1.ClusterExtension, map[string]string, map[string]string) ([]ocv1.PlannedObjectChange, error) {
+		return changes, nil
+	}))
+
+	ext := &ocv1.ClusterExtension{Spec: ocv1.ClusterExtensionSpec{RolloutMode: ocv1.RolloutModePlan}}
+	_, err := step(context.Background(), &reconcileState{
+		revisionStates:           &RevisionStates{},
+		resolvedRevisionMetadata: &RevisionMetadata{BundleMetadata: ocv1.BundleMetadata{Name: "prometheus.v1.0.0", Version: "1.0.0"}},
+		imageFS:                  fstest.MapFS{},
+	}, ext)
+	require.NoError(t, err)
+	require.Equal(t, changes[:maxPlannedObjectChanges], ext.Status.Plan.Changes)
+	progressingCond := apimeta.FindStatusCondition(ext.Status.Conditions, ocv1.TypeProgressing)
+	require.NotNil(t, progressingCond)
+	require.Contains(t, progressingCond.Message, "600 object(s) would be changed")
+}
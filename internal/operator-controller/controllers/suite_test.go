@@ -17,19 +17,26 @@ limitations under the License.
 package controllers_test
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
 	"testing"
 	"time"
 
+	bsemver "github.com/blang/semver/v4"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	crfinalizer "sigs.k8s.io/controller-runtime/pkg/finalizer"
 
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/controllers"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
@@ -75,9 +82,12 @@ type deps struct {
 	RevisionStatesGetter controllers.RevisionStatesGetter
 	Finalizers           crfinalizer.Finalizers
 	Resolver             resolve.Resolver
+	DependencyResolver   resolve.DependencyResolver
 	ImagePuller          image.Puller
 	ImageCache           image.Cache
+	BundleImages         *controllers.BundleImageResolver
 	Applier              controllers.Applier
+	Planner              controllers.Planner
 	Validators           []controllers.ClusterExtensionValidator
 }
 
@@ -106,8 +116,23 @@ func newClientAndReconciler(t *testing.T, opts ...reconcilerOption) (client.Clie
 	if r := d.Resolver; r != nil {
 		reconciler.ReconcileSteps = append(reconciler.ReconcileSteps, controllers.ResolveBundle(r, cl))
 	}
+	if r := d.DependencyResolver; r != nil {
+		reconciler.ReconcileSteps = append(reconciler.ReconcileSteps, controllers.ResolveDependencies(r))
+	}
 	if i := d.ImagePuller; i != nil {
-		reconciler.ReconcileSteps = append(reconciler.ReconcileSteps, controllers.UnpackBundle(i, d.ImageCache))
+		if d.BundleImages == nil {
+			d.BundleImages = controllers.NewBundleImageResolver(i, d.ImageCache)
+		}
+		reconciler.ReconcileSteps = append(reconciler.ReconcileSteps, controllers.UnpackBundle(i, d.ImageCache, d.BundleImages))
+	}
+	if p := d.Planner; p != nil {
+		reconciler.ReconcileSteps = append(reconciler.ReconcileSteps, controllers.PlanBundle(p))
+	}
+	if d.Applier != nil {
+		reconciler.ReconcileSteps = append(reconciler.ReconcileSteps, controllers.ApproveUpgrade())
+	}
+	if d.DependencyResolver != nil {
+		reconciler.ReconcileSteps = append(reconciler.ReconcileSteps, controllers.InstallDependencies(cl))
 	}
 	if a := d.Applier; a != nil {
 		reconciler.ReconcileSteps = append(reconciler.ReconcileSteps, controllers.ApplyBundle(a))
@@ -116,6 +141,42 @@ func newClientAndReconciler(t *testing.T, opts ...reconcilerOption) (client.Clie
 	return cl, reconciler
 }
 
+// extensionOption customizes the ClusterExtension returned by newTestExtension.
+type extensionOption func(*ocv1.ClusterExtension)
+
+// newTestExtension returns a ClusterExtension installing the prometheus package from
+// the catalogs into a random namespace with a random service account.
+func newTestExtension(name string, opts ...extensionOption) *ocv1.ClusterExtension {
+	ext := &ocv1.ClusterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: ocv1.ClusterExtensionSpec{
+			Source: ocv1.SourceConfig{
+				SourceType: ocv1.SourceTypeCatalog,
+				Catalog:    &ocv1.CatalogFilter{PackageName: "prometheus"},
+			},
+			Namespace:      fmt.Sprintf("test-ns-%s", rand.String(8)),
+			ServiceAccount: ocv1.ServiceAccountReference{Name: fmt.Sprintf("test-sa-%s", rand.String(8))},
+		},
+	}
+	for _, opt := range opts {
+		opt(ext)
+	}
+	return ext
+}
+
+// newTestResolver returns a resolver that always resolves the given version of
+// the prometheus package.
+func newTestResolver(version string) resolve.Func {
+	return func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+		v := declcfg.VersionRelease{Version: bsemver.MustParse(version)}
+		return &declcfg.Bundle{
+			Name:    "prometheus.v" + version,
+			Package: "prometheus",
+			Image:   "quay.io/operatorhubio/prometheus@fake" + version,
+		}, &v, nil, nil
+	}
+}
+
 var config *rest.Config
 
 func TestMain(m *testing.M) {
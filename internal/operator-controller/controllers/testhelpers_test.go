@@ -1,9 +1,17 @@
 package controllers
 
 import (
+	"context"
 	"strconv"
 	"strings"
 	"testing"
+
+	bsemver "github.com/blang/semver/v4"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/resolve"
 )
 
 // ExtractRevisionNumber parses the revision number from a test revision name.
@@ -33,3 +41,27 @@ func ExtractRevisionNumber(t *testing.T, name string) int64 {
 
 	return revNum
 }
+
+// extensionOption customizes the ClusterExtension returned by newTestExtension.
+type extensionOption func(*ocv1.ClusterExtension)
+
+// newTestExtension returns a ClusterExtension installing the prometheus package
+// from the catalogs.
+func newTestExtension(opts ...extensionOption) *ocv1.ClusterExtension {
+	ext := &ocv1.ClusterExtension{Spec: ocv1.ClusterExtensionSpec{Source: ocv1.SourceConfig{
+		SourceType: ocv1.SourceTypeCatalog,
+		Catalog:    &ocv1.CatalogFilter{PackageName: "prometheus"},
+	}}}
+	for _, opt := range opts {
+		opt(ext)
+	}
+	return ext
+}
+
+// newTestResolver returns a resolver that always resolves the given version of
+// the prometheus package.
+func newTestResolver(version string) resolve.Func {
+	return func(context.Context, *ocv1.ClusterExtension, *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+		return &declcfg.Bundle{Name: "prometheus.v" + version, Package: "prometheus"}, &declcfg.VersionRelease{Version: bsemver.MustParse(version)}, nil, nil
+	}
+}
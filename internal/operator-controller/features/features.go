@@ -20,6 +20,20 @@ const (
 	BoxcutterRuntime                  featuregate.Feature = "BoxcutterRuntime"
 	DeploymentConfig                  featuregate.Feature = "DeploymentConfig"
 	BundleReleaseSupport              featuregate.Feature = "BundleReleaseSupport"
+	BundleDependencyResolution        featuregate.Feature = "BundleDependencyResolution"
+	BundleImageSource                 featuregate.Feature = "BundleImageSource"
+	RolloutPlan                       featuregate.Feature = "RolloutPlan"
+	UpgradeApproval                   featuregate.Feature = "UpgradeApproval"
+	MaintenanceWindows                featuregate.Feature = "MaintenanceWindows"
+	AutomaticRollback                 featuregate.Feature = "AutomaticRollback"
+	RevisionPinning                   featuregate.Feature = "RevisionPinning"
+	ConfigSourceReferences            featuregate.Feature = "ConfigSourceReferences"
+	PerPackageCatalogFetching         featuregate.Feature = "PerPackageCatalogFetching"
+	ResolutionReport                  featuregate.Feature = "ResolutionReport"
+	UpgradeScope                      featuregate.Feature = "UpgradeScope"
+	SoakTime                          featuregate.Feature = "SoakTime"
+	ExtensionPolicy                   featuregate.Feature = "ExtensionPolicy"
+	KubernetesVersionCompatibility    featuregate.Feature = "KubernetesVersionCompatibility"
 )
 
 var operatorControllerFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
@@ -101,6 +115,125 @@ var operatorControllerFeatureGates = map[featuregate.Feature]featuregate.Feature
 		PreRelease:    featuregate.Alpha,
 		LockToDefault: false,
 	},
+
+	// BundleDependencyResolution enables resolution of the dependencies declared
+	// by bundles via olm.package.required, olm.gvk.required and olm.constraint
+	// properties. When disabled, bundles declaring dependencies are rejected.
+	BundleDependencyResolution: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// BundleImageSource enables the "Image" sourceType, which installs a bundle
+	// image directly instead of resolving a bundle from the catalogs.
+	BundleImageSource: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// RolloutPlan enables the "Plan" rolloutMode, which reports the changes
+	// rolling out the resolved bundle would make instead of applying them.
+	RolloutPlan: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// UpgradeApproval enables the "Manual" upgrade approval policy, which holds
+	// upgrades to a newly resolved bundle until its version is approved.
+	UpgradeApproval: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// MaintenanceWindows enables spec.maintenanceWindows, which holds upgrades
+	// to a newly resolved bundle until a maintenance window opens.
+	MaintenanceWindows: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// AutomaticRollback enables spec.rollbackPolicy, which archives a revision that
+	// exceeded its progress deadline in favour of the previous successful revision.
+	// It requires the BoxcutterRuntime feature.
+	AutomaticRollback: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// RevisionPinning enables spec.pinnedRevision, which rolls out the content of a
+	// previous revision again, without resolving the bundle from the catalogs.
+	// It requires the BoxcutterRuntime feature.
+	RevisionPinning: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// ConfigSourceReferences enables the "ConfigMapRef" and "SecretRef" config types,
+	// which read the configuration of a ClusterExtension from ConfigMaps and Secrets.
+	// The metadata of all ConfigMaps is watched to roll out their changes.
+	ConfigSourceReferences: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// PerPackageCatalogFetching fetches the content of the packages resolved from a catalog
+	// through the metas endpoint of catalogd, rather than the content of the entire catalog.
+	// It requires the APIV1MetasHandler feature of catalogd.
+	PerPackageCatalogFetching: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// ResolutionReport reports in status.resolution of ClusterExtensions how their bundle
+	// was resolved from the catalogs, or why no bundle could be resolved.
+	ResolutionReport: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// UpgradeScope enables spec.source.catalog.upgradeScope, which limits automatic
+	// upgrades to the patch or minor releases of the installed bundle version.
+	UpgradeScope: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// SoakTime enables spec.source.catalog.soakTimeMinutes, which holds automatic upgrades
+	// to bundles until they have been in a catalog for long enough.
+	// It requires the BundleFirstSeen feature of catalogd.
+	SoakTime: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// ExtensionPolicy enforces the ExtensionPolicies of the cluster, which constrain the
+	// packages, catalogs, and versions that ClusterExtensions may install.
+	ExtensionPolicy: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
+
+	// KubernetesVersionCompatibility excludes bundles that do not support the Kubernetes
+	// version of the cluster from resolution, and reports whether the resolved bundles
+	// block upgrading the cluster to the next minor version of Kubernetes.
+	KubernetesVersionCompatibility: {
+		Default:       false,
+		PreRelease:    featuregate.Alpha,
+		LockToDefault: false,
+	},
 }
 
 var OperatorControllerFeatureGate featuregate.MutableFeatureGate = featuregate.NewFeatureGate()
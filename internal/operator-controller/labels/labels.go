@@ -65,4 +65,9 @@ const (
 	// that were created during migration from Helm releases. This label is used
 	// to distinguish migrated revisions from those created by normal Boxcutter operation.
 	MigratedFromHelmKey = "olm.operatorframework.io/migrated-from-helm"
+
+	// RequiredByKey is the annotation key used to record the name of the
+	// ClusterExtension whose bundle dependencies caused a ClusterExtension
+	// to be created.
+	RequiredByKey = "olm.operatorframework.io/required-by"
 )
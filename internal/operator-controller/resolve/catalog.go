@@ -6,20 +6,28 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	bsemver "github.com/blang/semver/v4"
+	"golang.org/x/sync/singleflight"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/bundleutil"
+	catalogclient "github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/client"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/compare"
 	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/filter"
+	"github.com/operator-framework/operator-controller/internal/shared/firstseen"
+	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
 	filterutil "github.com/operator-framework/operator-controller/internal/shared/util/filter"
 	slicesutil "github.com/operator-framework/operator-controller/internal/shared/util/slices"
 )
@@ -29,6 +37,35 @@ type ValidationFunc func(*declcfg.Bundle) error
 type CatalogResolver struct {
 	WalkCatalogsFunc func(context.Context, string, CatalogWalkFunc, ...client.ListOption) error
 	Validations      []ValidationFunc
+
+	// ListExtensionsFunc lists the ClusterExtensions installed on the cluster. It is
+	// required to resolve bundle dependencies and olm.constraint properties; when it
+	// is nil, candidate bundles are not checked for unsatisfiable constraints.
+	ListExtensionsFunc func(context.Context) ([]ocv1.ClusterExtension, error)
+
+	// ListExtensionPoliciesFunc lists the ExtensionPolicies of the cluster. Bundles that
+	// do not satisfy them are not candidates; when it is nil, no policy applies.
+	ListExtensionPoliciesFunc func(context.Context) ([]ocv1.ExtensionPolicy, error)
+
+	// KubernetesVersionFunc returns the version of Kubernetes of the cluster. Bundles that
+	// do not support it are not candidates; when it is nil, any version is supported.
+	KubernetesVersionFunc func(context.Context) (*bsemver.Version, error)
+
+	// WalkCatalogIndexesFunc walks the indexes of the bundles of the catalogs, which tell the
+	// packages that provide a required GVK or satisfy an olm.constraint; when it is nil, the
+	// content of the entire catalogs is walked instead.
+	WalkCatalogIndexesFunc func(context.Context, CatalogIndexWalkFunc, ...client.ListOption) error
+
+	// Clock tells how long bundles soaked in their catalog; when it is nil, the
+	// current time is used.
+	Clock clock.PassiveClock
+}
+
+func (r *CatalogResolver) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
 }
 
 type foundBundle struct {
@@ -39,45 +76,60 @@ type foundBundle struct {
 
 // Resolve returns a Bundle from a catalog that needs to get installed on the cluster.
 func (r *CatalogResolver) Resolve(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
+	bundle, version, deprecation, _, err := r.ResolveWithReport(ctx, ext, installedBundle)
+	return bundle, version, deprecation, err
+}
+
+// ResolveWithReport is Resolve, and also reports how the bundle was resolved.
+// The report is returned along with resolution errors once the catalogs have
+// been walked, and is nil otherwise.
+func (r *CatalogResolver) ResolveWithReport(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, *ocv1.ClusterExtensionResolution, error) {
 	l := log.FromContext(ctx)
 	packageName := ext.Spec.Source.Catalog.PackageName
 	versionRange := ext.Spec.Source.Catalog.Version
 	channels := ext.Spec.Source.Catalog.Channels
 
-	// unless overridden, default to selecting all bundles
-	var selector = labels.Everything()
-	var err error
-	if ext.Spec.Source.Catalog != nil {
-		selector, err = metav1.LabelSelectorAsSelector(ext.Spec.Source.Catalog.Selector)
-		if err != nil {
-			return nil, nil, nil, fmt.Errorf("desired catalog selector is invalid: %w", err)
-		}
-		// A nothing (empty) selector selects everything
-		if selector == labels.Nothing() {
-			selector = labels.Everything()
-		}
+	selector, err := catalogSelector(ext)
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
 
-	var versionRangeConstraints bsemver.Range
+	var versionRangeConstraints filterutil.Predicate[declcfg.Bundle]
 	if versionRange != "" {
-		versionRangeConstraints, err = compare.NewVersionRange(versionRange)
+		versionRangeConstraints, err = versionRangePredicate(versionRange)
 		if err != nil {
-			return nil, nil, nil, fmt.Errorf("desired version range %q is invalid: %w", versionRange, err)
+			return nil, nil, nil, nil, fmt.Errorf("desired version range %q is invalid: %w", versionRange, err)
 		}
 	}
 
-	type catStat struct {
-		CatalogName    string `json:"catalogName"`
-		PackageFound   bool   `json:"packageFound"`
-		TotalBundles   int    `json:"totalBundles"`
-		MatchedBundles int    `json:"matchedBundles"`
-	}
-
-	var catStats []*catStat
+	report := &ocv1.ClusterExtensionResolution{}
+	// bestDeprecated records the catalogs whose best candidate is deprecated
+	bestDeprecated := sets.New[string]()
 
 	var resolvedBundles []foundBundle
 	var priorDeprecation *declcfg.Deprecation
 
+	var constraintResolution *dependencyResolution
+	var unsatisfied []unsatisfiedConstraint
+
+	var policies []ocv1.ExtensionPolicy
+	if r.ListExtensionPoliciesFunc != nil {
+		if policies, err = r.ListExtensionPoliciesFunc(ctx); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("error listing extension policies: %w", err)
+		}
+	}
+	// policyEliminated records whether the extension policies eliminated any candidate
+	var policyEliminated bool
+
+	var kubeVersion *bsemver.Version
+	if r.KubernetesVersionFunc != nil {
+		if kubeVersion, err = r.KubernetesVersionFunc(ctx); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("error getting the Kubernetes version of the cluster: %w", err)
+		}
+	}
+	// kubeVersionEliminated records whether the Kubernetes version eliminated any candidate
+	var kubeVersionEliminated bool
+
 	listOptions := []client.ListOption{
 		client.MatchingLabelsSelector{Selector: selector},
 	}
@@ -86,27 +138,30 @@ func (r *CatalogResolver) Resolve(ctx context.Context, ext *ocv1.ClusterExtensio
 			return fmt.Errorf("error getting package %q from catalog %q: %w", packageName, cat.Name, err)
 		}
 
-		cs := catStat{CatalogName: cat.Name}
-		catStats = append(catStats, &cs)
+		report.Catalogs = append(report.Catalogs, ocv1.CatalogResolution{Name: cat.Name, Priority: cat.Spec.Priority})
+		cs := &report.Catalogs[len(report.Catalogs)-1]
 
 		if isFBCEmpty(packageFBC) {
+			cs.Result = ocv1.CatalogResolutionPackageNotFound
 			return nil
 		}
+		cs.Bundles = int32(len(packageFBC.Bundles)) //nolint:gosec
 
-		cs.PackageFound = true
-		cs.TotalBundles = len(packageFBC.Bundles)
-
-		var predicates []filterutil.Predicate[declcfg.Bundle]
+		type namedPredicate struct {
+			name      string
+			predicate filterutil.Predicate[declcfg.Bundle]
+		}
+		var predicates []namedPredicate
 		if len(channels) > 0 {
 			channelSet := sets.New(channels...)
 			filteredChannels := slices.DeleteFunc(packageFBC.Channels, func(c declcfg.Channel) bool {
 				return !channelSet.Has(c.Name)
 			})
-			predicates = append(predicates, filter.InAnyChannel(filteredChannels...))
+			predicates = append(predicates, namedPredicate{ocv1.ResolutionFilterChannel, filter.InAnyChannel(filteredChannels...)})
 		}
 
 		if versionRangeConstraints != nil {
-			predicates = append(predicates, filter.InSemverRange(versionRangeConstraints))
+			predicates = append(predicates, namedPredicate{ocv1.ResolutionFilterVersionRange, versionRangeConstraints})
 		}
 
 		if ext.Spec.Source.Catalog.UpgradeConstraintPolicy != ocv1.UpgradeConstraintPolicySelfCertified && installedBundle != nil {
@@ -114,13 +169,93 @@ func (r *CatalogResolver) Resolve(ctx context.Context, ext *ocv1.ClusterExtensio
 			if err != nil {
 				return fmt.Errorf("error finding upgrade edges: %w", err)
 			}
-			predicates = append(predicates, successorPredicate)
+			predicates = append(predicates, namedPredicate{ocv1.ResolutionFilterSuccessor, successorPredicate})
+		}
+
+		if upgradeScope := ext.Spec.Source.Catalog.UpgradeScope; upgradeScope != "" && upgradeScope != ocv1.UpgradeScopeAny && installedBundle != nil {
+			scopePredicate, err := filter.InUpgradeScope(*installedBundle, upgradeScope)
+			if err != nil {
+				return fmt.Errorf("error limiting upgrades to scope %q: %w", upgradeScope, err)
+			}
+			predicates = append(predicates, namedPredicate{ocv1.ResolutionFilterUpgradeScope, scopePredicate})
+		}
+
+		// Bundles are only upgraded to once they soaked in the catalog, while the installed
+		// bundle remains a candidate.
+		if soakTime := ext.Spec.Source.Catalog.SoakTimeMinutes; soakTime > 0 && installedBundle != nil {
+			firstSeen, err := firstseen.FromMetas(packageFBC.Others)
+			if err != nil {
+				return fmt.Errorf("error reading when bundles were first seen in catalog %q: %w", cat.Name, err)
+			}
+			// Without any record, no upgrade would ever soak, so the catalog is reported rather
+			// than silently holding upgrades.
+			if len(firstSeen) == 0 {
+				return fmt.Errorf("catalog %q does not record when the bundles of package %q were first seen, which soak times require: "+
+					"enable the BundleFirstSeen feature gate of catalogd", cat.Name, packageName)
+			}
+			soaked := filter.FirstSeenBefore(firstSeen, r.now().Add(-time.Duration(soakTime)*time.Minute))
+			predicates = append(predicates, namedPredicate{ocv1.ResolutionFilterSoakTime, filterutil.Or(soaked, func(b declcfg.Bundle) bool {
+				return b.Name == installedBundle.Name
+			})})
+		}
+
+		if len(policies) > 0 {
+			var deprecation *declcfg.Deprecation
+			if len(packageFBC.Deprecations) > 0 {
+				deprecation = &packageFBC.Deprecations[0]
+			}
+			policyPredicate, err := extensionPolicyPredicate(policies, cat.Name, packageName, deprecation)
+			if err != nil {
+				return err
+			}
+			predicates = append(predicates, namedPredicate{ocv1.ResolutionFilterExtensionPolicy, policyPredicate})
+		}
+
+		if kubeVersion != nil {
+			predicates = append(predicates, namedPredicate{ocv1.ResolutionFilterKubernetesVersion, filter.CompatibleWithKubernetes(*kubeVersion)})
+		}
+
+		// Apply the predicates one after the other to get the candidate bundles,
+		// recording how many candidates each of them eliminates. The bundles only
+		// the upgrade scope eliminates are kept aside to report the newest of them.
+		var outOfScope []declcfg.Bundle
+		for _, p := range predicates {
+			if p.name == ocv1.ResolutionFilterUpgradeScope {
+				outOfScope = slices.DeleteFunc(slices.Clone(packageFBC.Bundles), p.predicate)
+			} else {
+				outOfScope = filterutil.InPlace(outOfScope, p.predicate)
+			}
+			before := len(packageFBC.Bundles)
+			packageFBC.Bundles = filterutil.InPlace(packageFBC.Bundles, p.predicate)
+			eliminated := before - len(packageFBC.Bundles)
+			cs.Filters = append(cs.Filters, ocv1.ResolutionFilter{Name: p.name, Eliminated: int32(eliminated)}) //nolint:gosec
+			if p.name == ocv1.ResolutionFilterExtensionPolicy && eliminated > 0 {
+				policyEliminated = true
+			}
+			if p.name == ocv1.ResolutionFilterKubernetesVersion && eliminated > 0 {
+				kubeVersionEliminated = true
+			}
 		}
 
-		// Apply the predicates to get the candidate bundles
-		packageFBC.Bundles = filterutil.InPlace(packageFBC.Bundles, filterutil.And(predicates...))
-		cs.MatchedBundles = len(packageFBC.Bundles)
+		// Eliminate the candidates declaring olm.constraint properties that nothing
+		// available on the cluster or in the selected catalogs can satisfy.
+		if r.ListExtensionsFunc != nil && slices.ContainsFunc(packageFBC.Bundles, hasConstraints) {
+			if constraintResolution == nil {
+				if constraintResolution, err = r.newDependencyResolution(ctx, ext, &declcfg.Bundle{Package: packageName}); err != nil {
+					return err
+				}
+			}
+			var eliminated []unsatisfiedConstraint
+			packageFBC.Bundles, eliminated, err = constraintResolution.constraintsSatisfiable(ctx, packageFBC.Bundles)
+			if err != nil {
+				return err
+			}
+			unsatisfied = append(unsatisfied, eliminated...)
+			cs.Filters = append(cs.Filters, ocv1.ResolutionFilter{Name: ocv1.ResolutionFilterConstraints, Eliminated: int32(len(eliminated))}) //nolint:gosec
+		}
+		cs.Candidates = int32(len(packageFBC.Bundles)) //nolint:gosec
 		if len(packageFBC.Bundles) == 0 {
+			cs.Result = ocv1.CatalogResolutionNoCandidates
 			return nil
 		}
 
@@ -133,6 +268,11 @@ func (r *CatalogResolver) Resolve(ctx context.Context, ext *ocv1.ClusterExtensio
 		if len(packageFBC.Deprecations) > 0 {
 			thisDeprecation = &packageFBC.Deprecations[0]
 			byDeprecation = compare.ByDeprecationFunc(*thisDeprecation)
+			for _, b := range packageFBC.Bundles {
+				if isDeprecated(b, thisDeprecation) {
+					cs.DeprecatedCandidates++
+				}
+			}
 		}
 
 		// Sort the bundles by deprecation and then by version
@@ -144,10 +284,15 @@ func (r *CatalogResolver) Resolve(ctx context.Context, ext *ocv1.ClusterExtensio
 		})
 
 		thisBundle := packageFBC.Bundles[0]
+		cs.BestCandidate = thisBundle.Name
+		cs.OutOfScopeCandidate = newestOutOfScope(outOfScope, thisBundle)
+		currentIsDeprecated := isDeprecated(thisBundle, thisDeprecation)
+		if currentIsDeprecated {
+			bestDeprecated.Insert(cat.Name)
+		}
 
 		if len(resolvedBundles) != 0 {
 			// We've already found one or more package candidates
-			currentIsDeprecated := isDeprecated(thisBundle, thisDeprecation)
 			priorIsDeprecated := isDeprecated(*resolvedBundles[len(resolvedBundles)-1].bundle, priorDeprecation)
 			if currentIsDeprecated && !priorIsDeprecated {
 				// Skip this deprecated package and retain the non-deprecated package(s)
@@ -163,7 +308,7 @@ func (r *CatalogResolver) Resolve(ctx context.Context, ext *ocv1.ClusterExtensio
 		priorDeprecation = thisDeprecation
 		return nil
 	}, listOptions...); err != nil {
-		return nil, nil, nil, fmt.Errorf("error walking catalogs: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("error walking catalogs: %w", err)
 	}
 
 	// Resolve for priority
@@ -176,22 +321,39 @@ func (r *CatalogResolver) Resolve(ctx context.Context, ext *ocv1.ClusterExtensio
 			resolvedBundles = []foundBundle{resolvedBundles[0]}
 		}
 	}
+	reportCatalogResults(report, resolvedBundles, bestDeprecated)
 
 	// Check for ambiguity
 	if len(resolvedBundles) != 1 {
-		l.Info("resolution failed", "stats", catStats)
-		return nil, nil, nil, resolutionError{
-			PackageName:     packageName,
-			Version:         versionRange,
-			Channels:        channels,
-			InstalledBundle: installedBundle,
-			ResolvedBundles: resolvedBundles,
+		l.Info("resolution failed", "catalogs", report.Catalogs)
+		err := resolutionError{
+			PackageName:            packageName,
+			Version:                versionRange,
+			Channels:               channels,
+			InstalledBundle:        installedBundle,
+			UpgradeScope:           ext.Spec.Source.Catalog.UpgradeScope,
+			SoakTimeMinutes:        ext.Spec.Source.Catalog.SoakTimeMinutes,
+			ExtensionPolicies:      policyEliminated,
+			ResolvedBundles:        resolvedBundles,
+			UnsatisfiedConstraints: unsatisfied,
+		}
+		if kubeVersionEliminated {
+			err.KubernetesVersion = kubeVersion
 		}
+		report.Message = err.Error()
+		// Bundles are only missing because of the extension policies until they change
+		if len(resolvedBundles) == 0 && policyEliminated {
+			return nil, nil, nil, report, errorutil.NewTerminalError(ocv1.ReasonPolicyViolation, err)
+		}
+		return nil, nil, nil, report, err
 	}
 	resolvedBundle := resolvedBundles[0].bundle
+	report.Bundle = resolvedBundle.Name
+	report.Catalog = resolvedBundles[0].catalog
+	report.Message = resolutionSummary(report)
 	resolvedBundleVersion, err := bundleutil.GetVersionAndRelease(*resolvedBundle)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("error getting resolved bundle version for bundle %q: %w", resolvedBundle.Name, err)
+		return nil, nil, nil, report, fmt.Errorf("error getting resolved bundle version for bundle %q: %w", resolvedBundle.Name, err)
 	}
 
 	// Run validations against the resolved bundle to ensure only valid resolved bundles are being returned
@@ -200,12 +362,85 @@ func (r *CatalogResolver) Resolve(ctx context.Context, ext *ocv1.ClusterExtensio
 	//                constrained in order to eliminate the invalid bundle from the resolution.
 	for _, validation := range r.Validations {
 		if err := validation(resolvedBundle); err != nil {
-			return nil, nil, nil, fmt.Errorf("validating bundle %q: %w", resolvedBundle.Name, err)
+			return nil, nil, nil, report, fmt.Errorf("validating bundle %q: %w", resolvedBundle.Name, err)
 		}
 	}
 
-	l.V(4).Info("resolution succeeded", "stats", catStats)
-	return resolvedBundle, resolvedBundleVersion, priorDeprecation, nil
+	l.V(4).Info("resolution succeeded", "catalogs", report.Catalogs)
+	return resolvedBundle, resolvedBundleVersion, priorDeprecation, report, nil
+}
+
+// reportCatalogResults sets the result of the catalogs providing candidates, given the
+// bundles left once deprecation and priority are taken into account.
+func reportCatalogResults(report *ocv1.ClusterExtensionResolution, resolvedBundles []foundBundle, bestDeprecated sets.Set[string]) {
+	resolvedCatalogs := sets.New[string]()
+	for _, b := range resolvedBundles {
+		resolvedCatalogs.Insert(b.catalog)
+	}
+	for i := range report.Catalogs {
+		cs := &report.Catalogs[i]
+		switch {
+		case cs.Result != "":
+		case resolvedCatalogs.Has(cs.Name) && len(resolvedBundles) == 1:
+			cs.Result = ocv1.CatalogResolutionSelected
+		case resolvedCatalogs.Has(cs.Name):
+			cs.Result = ocv1.CatalogResolutionAmbiguous
+		case bestDeprecated.Has(cs.Name) && !bestDeprecated.HasAny(resolvedCatalogs.UnsortedList()...):
+			cs.Result = ocv1.CatalogResolutionDeprecated
+		default:
+			cs.Result = ocv1.CatalogResolutionLowerPriority
+		}
+	}
+}
+
+// resolutionSummary explains why the bundle was resolved from its catalog.
+func resolutionSummary(report *ocv1.ClusterExtensionResolution) string {
+	var selected ocv1.CatalogResolution
+	var lowerPriority, deprecated []string
+	for _, cs := range report.Catalogs {
+		switch cs.Result {
+		case ocv1.CatalogResolutionSelected:
+			selected = cs
+		case ocv1.CatalogResolutionLowerPriority:
+			lowerPriority = append(lowerPriority, cs.Name)
+		case ocv1.CatalogResolutionDeprecated:
+			deprecated = append(deprecated, cs.Name)
+		}
+	}
+
+	slices.Sort(lowerPriority)
+	slices.Sort(deprecated)
+
+	msg := fmt.Sprintf("bundle %q resolved from catalog %q", report.Bundle, report.Catalog)
+	if len(lowerPriority) == 0 && len(deprecated) == 0 {
+		return msg + ", the only catalog providing candidates"
+	}
+	var reasons []string
+	if len(lowerPriority) > 0 {
+		reasons = append(reasons, fmt.Sprintf("its priority %d is higher than the priority of catalogs %v", selected.Priority, lowerPriority))
+	}
+	if len(deprecated) > 0 {
+		reasons = append(reasons, fmt.Sprintf("the best candidates of catalogs %v are deprecated", deprecated))
+	}
+	return fmt.Sprintf("%s: %s", msg, strings.Join(reasons, "; "))
+}
+
+// catalogSelector returns the label selector used to choose the ClusterCatalogs
+// that are considered when resolving content for the ClusterExtension.
+func catalogSelector(ext *ocv1.ClusterExtension) (labels.Selector, error) {
+	// unless overridden, default to selecting all bundles
+	if ext.Spec.Source.Catalog == nil {
+		return labels.Everything(), nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(ext.Spec.Source.Catalog.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("desired catalog selector is invalid: %w", err)
+	}
+	// A nothing (empty) selector selects everything
+	if selector == labels.Nothing() {
+		return labels.Everything(), nil
+	}
+	return selector, nil
 }
 
 type resolutionError struct {
@@ -213,7 +448,20 @@ type resolutionError struct {
 	Version         string
 	Channels        []string
 	InstalledBundle *ocv1.BundleMetadata
+	UpgradeScope    string
+	SoakTimeMinutes int32
 	ResolvedBundles []foundBundle
+
+	// ExtensionPolicies records whether ExtensionPolicies eliminated candidate bundles.
+	ExtensionPolicies bool
+
+	// KubernetesVersion is the version of Kubernetes of the cluster, when candidate
+	// bundles were eliminated because they do not support it.
+	KubernetesVersion *bsemver.Version
+
+	// UnsatisfiedConstraints lists the candidate bundles that were eliminated
+	// because of an olm.constraint that cannot be satisfied.
+	UnsatisfiedConstraints []unsatisfiedConstraint
 }
 
 func (rei resolutionError) Error() string {
@@ -236,6 +484,22 @@ func (rei resolutionError) Error() string {
 		sb.WriteString(fmt.Sprintf("in channels %v ", rei.Channels))
 	}
 
+	if rei.InstalledBundle != nil && rei.UpgradeScope != "" && rei.UpgradeScope != ocv1.UpgradeScopeAny {
+		sb.WriteString(fmt.Sprintf("within upgrade scope %q ", rei.UpgradeScope))
+	}
+
+	if rei.InstalledBundle != nil && rei.SoakTimeMinutes > 0 {
+		sb.WriteString(fmt.Sprintf("available for at least %d minutes ", rei.SoakTimeMinutes))
+	}
+
+	if len(rei.ResolvedBundles) == 0 && rei.ExtensionPolicies {
+		sb.WriteString("allowed by extension policies ")
+	}
+
+	if len(rei.ResolvedBundles) == 0 && rei.KubernetesVersion != nil {
+		sb.WriteString(fmt.Sprintf("compatible with Kubernetes %s ", rei.KubernetesVersion))
+	}
+
 	matchedCatalogs := make([]string, 0, len(rei.ResolvedBundles))
 	for _, r := range rei.ResolvedBundles {
 		matchedCatalogs = append(matchedCatalogs, r.catalog)
@@ -245,7 +509,17 @@ func (rei resolutionError) Error() string {
 		sb.WriteString(fmt.Sprintf("in multiple catalogs with the same priority %v ", matchedCatalogs))
 	}
 
-	return strings.TrimSpace(sb.String())
+	msg := strings.TrimSpace(sb.String())
+	if len(rei.ResolvedBundles) == 0 && len(rei.UnsatisfiedConstraints) > 0 {
+		unsatisfied := slices.Clone(rei.UnsatisfiedConstraints)
+		slices.SortFunc(unsatisfied, func(a, b unsatisfiedConstraint) int { return strings.Compare(a.bundle, b.bundle) })
+		reasons := make([]string, 0, len(unsatisfied))
+		for _, u := range unsatisfied {
+			reasons = append(reasons, u.String())
+		}
+		msg = fmt.Sprintf("%s: %s", msg, strings.Join(reasons, "; "))
+	}
+	return msg
 }
 
 func isDeprecated(bundle declcfg.Bundle, deprecation *declcfg.Deprecation) bool {
@@ -260,6 +534,21 @@ func isDeprecated(bundle declcfg.Bundle, deprecation *declcfg.Deprecation) bool
 	return false
 }
 
+// versionRangePredicate returns a predicate matching the bundles in a version range. A
+// pinned version carrying a release as its build metadata, such as "1.2.3+2", only
+// matches that release of the version.
+func versionRangePredicate(versionRange string) (filterutil.Predicate[declcfg.Bundle], error) {
+	constraints, err := compare.NewVersionRange(versionRange)
+	if err != nil {
+		return nil, err
+	}
+	inRange := filter.InSemverRange(constraints)
+	if pinned, err := bundleutil.ParseLegacyVersionRelease(strings.TrimPrefix(strings.TrimSpace(versionRange), "v")); err == nil && len(pinned.Release) > 0 {
+		return filterutil.And(inRange, filter.ExactVersionRelease(*pinned)), nil
+	}
+	return inRange, nil
+}
+
 type CatalogWalkFunc func(context.Context, *ocv1.ClusterCatalog, *declcfg.DeclarativeConfig, error) error
 
 func CatalogWalker(
@@ -267,24 +556,11 @@ func CatalogWalker(
 	getPackage func(context.Context, *ocv1.ClusterCatalog, string) (*declcfg.DeclarativeConfig, error),
 ) func(ctx context.Context, packageName string, f CatalogWalkFunc, catalogListOpts ...client.ListOption) error {
 	return func(ctx context.Context, packageName string, f CatalogWalkFunc, catalogListOpts ...client.ListOption) error {
-		l := log.FromContext(ctx)
-		catalogs, err := listCatalogs(ctx, catalogListOpts...)
+		catalogs, err := availableCatalogs(ctx, listCatalogs, catalogListOpts...)
 		if err != nil {
-			return fmt.Errorf("error listing catalogs: %w", err)
+			return err
 		}
 
-		// Remove disabled catalogs from consideration
-		catalogs = slices.DeleteFunc(catalogs, func(c ocv1.ClusterCatalog) bool {
-			if c.Spec.AvailabilityMode == ocv1.AvailabilityModeUnavailable {
-				l.Info("excluding ClusterCatalog from resolution process since it is disabled", "catalog", c.Name)
-				return true
-			}
-			return false
-		})
-
-		availableCatalogNames := slicesutil.Map(catalogs, func(c ocv1.ClusterCatalog) string { return c.Name })
-		l.Info("using ClusterCatalogs for resolution", "catalogs", availableCatalogNames)
-
 		for i := range catalogs {
 			cat := &catalogs[i]
 
@@ -300,9 +576,135 @@ func CatalogWalker(
 	}
 }
 
+// CatalogIndexWalkFunc is called with the bundles of the index of each catalog
+type CatalogIndexWalkFunc func(context.Context, *ocv1.ClusterCatalog, []declcfg.Bundle, error) error
+
+// CatalogIndexWalker returns a function suitable for CatalogResolver.WalkCatalogIndexesFunc.
+// The index of a catalog holds the package, name and properties of all its bundles, apart
+// from their olm.bundle.object properties. It is built from the content of the entire catalog
+// once per version of that content, and kept in memory, so that the packages providing a GVK
+// or satisfying an olm.constraint are found without loading the content of the entire catalog
+// on every resolution.
+func CatalogIndexWalker(
+	listCatalogs func(context.Context, ...client.ListOption) ([]ocv1.ClusterCatalog, error),
+	getPackage func(context.Context, *ocv1.ClusterCatalog, string) (*declcfg.DeclarativeConfig, error),
+) func(ctx context.Context, f CatalogIndexWalkFunc, catalogListOpts ...client.ListOption) error {
+	indexes := &catalogIndexes{getPackage: getPackage, indexes: map[string]catalogIndex{}}
+	return func(ctx context.Context, f CatalogIndexWalkFunc, catalogListOpts ...client.ListOption) error {
+		catalogs, err := availableCatalogs(ctx, listCatalogs, catalogListOpts...)
+		if err != nil {
+			return err
+		}
+
+		for i := range catalogs {
+			cat := &catalogs[i]
+			bundles, indexErr := indexes.get(ctx, cat)
+			if walkErr := f(ctx, cat, bundles, indexErr); walkErr != nil {
+				return walkErr
+			}
+		}
+		return nil
+	}
+}
+
+// availableCatalogs lists the catalogs that are not disabled
+func availableCatalogs(ctx context.Context, listCatalogs func(context.Context, ...client.ListOption) ([]ocv1.ClusterCatalog, error), catalogListOpts ...client.ListOption) ([]ocv1.ClusterCatalog, error) {
+	l := log.FromContext(ctx)
+	catalogs, err := listCatalogs(ctx, catalogListOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error listing catalogs: %w", err)
+	}
+
+	// Remove disabled catalogs from consideration
+	catalogs = slices.DeleteFunc(catalogs, func(c ocv1.ClusterCatalog) bool {
+		if c.Spec.AvailabilityMode == ocv1.AvailabilityModeUnavailable {
+			l.Info("excluding ClusterCatalog from resolution process since it is disabled", "catalog", c.Name)
+			return true
+		}
+		return false
+	})
+
+	availableCatalogNames := slicesutil.Map(catalogs, func(c ocv1.ClusterCatalog) string { return c.Name })
+	l.Info("using ClusterCatalogs for resolution", "catalogs", availableCatalogNames)
+	return catalogs, nil
+}
+
+// catalogIndex is the index of a version of the content of a catalog
+type catalogIndex struct {
+	resolvedRef string
+	bundles     []declcfg.Bundle
+}
+
+// catalogIndexes keeps the index of the current version of the content of each catalog
+type catalogIndexes struct {
+	getPackage func(context.Context, *ocv1.ClusterCatalog, string) (*declcfg.DeclarativeConfig, error)
+	// builds deduplicates concurrent builds of the index of the same content
+	builds singleflight.Group
+
+	mu      sync.Mutex
+	indexes map[string]catalogIndex
+}
+
+func (c *catalogIndexes) get(ctx context.Context, cat *ocv1.ClusterCatalog) ([]declcfg.Bundle, error) {
+	resolvedRef := catalogclient.ResolvedRef(cat)
+	c.mu.Lock()
+	index, ok := c.indexes[cat.Name]
+	c.mu.Unlock()
+	if ok && resolvedRef != "" && index.resolvedRef == resolvedRef {
+		return index.bundles, nil
+	}
+
+	bundles, err, _ := c.builds.Do(fmt.Sprintf("%s@%s", cat.Name, resolvedRef), func() (interface{}, error) {
+		fbc, err := c.getPackage(ctx, cat, "")
+		if err != nil {
+			return nil, err
+		}
+		bundles := make([]declcfg.Bundle, 0, len(fbc.Bundles))
+		for _, b := range fbc.Bundles {
+			bundles = append(bundles, declcfg.Bundle{
+				Schema:  b.Schema,
+				Package: b.Package,
+				Name:    b.Name,
+				Properties: slices.DeleteFunc(slices.Clone(b.Properties), func(p property.Property) bool {
+					return p.Type == property.TypeBundleObject
+				}),
+			})
+		}
+		if resolvedRef != "" {
+			c.mu.Lock()
+			c.indexes[cat.Name] = catalogIndex{resolvedRef: resolvedRef, bundles: bundles}
+			c.mu.Unlock()
+		}
+		return bundles, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bundles.([]declcfg.Bundle), nil
+}
+
 func isFBCEmpty(fbc *declcfg.DeclarativeConfig) bool {
 	if fbc == nil {
 		return true
 	}
 	return len(fbc.Packages) == 0 && len(fbc.Channels) == 0 && len(fbc.Bundles) == 0 && len(fbc.Deprecations) == 0 && len(fbc.Others) == 0
 }
+
+// newestOutOfScope returns the metadata of the newest of the bundles eliminated by the
+// upgrade scope, or nil when none of them is newer than the best candidate.
+func newestOutOfScope(outOfScope []declcfg.Bundle, bestCandidate declcfg.Bundle) *ocv1.BundleMetadata {
+	if len(outOfScope) == 0 {
+		return nil
+	}
+	// Bundles are compared in descending order of version
+	newest := slices.MinFunc(outOfScope, compare.ByVersionAndRelease)
+	if compare.ByVersionAndRelease(newest, bestCandidate) >= 0 {
+		return nil
+	}
+	version, err := bundleutil.GetVersionAndRelease(newest)
+	if err != nil {
+		return nil
+	}
+	metadata := bundleutil.MetadataFor(newest.Name, *version)
+	return &metadata
+}
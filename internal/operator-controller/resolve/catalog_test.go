@@ -4,7 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	bsemver "github.com/blang/semver/v4"
 	"github.com/stretchr/testify/assert"
@@ -12,6 +16,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/rand"
+	clocktesting "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -19,6 +24,8 @@ import (
 	"github.com/operator-framework/operator-registry/alpha/property"
 
 	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	catalogclient "github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/client"
+	"github.com/operator-framework/operator-controller/internal/shared/firstseen"
 )
 
 func TestInvalidClusterExtensionVersionRange(t *testing.T) {
@@ -160,6 +167,33 @@ func TestVersionExists(t *testing.T) {
 	assert.Equal(t, ptr.To(packageDeprecation(pkgName)), gotDeprecation)
 }
 
+func TestVersionReleasePinned(t *testing.T) {
+	pkgName := randPkg()
+	w := staticCatalogWalker{
+		"a": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return &declcfg.DeclarativeConfig{
+				Packages: []declcfg.Package{{Name: pkgName}},
+				Channels: []declcfg.Channel{{Package: pkgName, Name: "alpha", Entries: []declcfg.ChannelEntry{
+					{Name: bundleName(pkgName, "1.0.0+1")},
+					{Name: bundleName(pkgName, "1.0.0+2"), Replaces: bundleName(pkgName, "1.0.0+1")},
+				}}},
+				Bundles: []declcfg.Bundle{genBundle(pkgName, "1.0.0+1"), genBundle(pkgName, "1.0.0+2")},
+			}, nil, nil
+		},
+	}
+	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
+
+	ce := buildFooClusterExtension(pkgName, []string{}, "1.0.0+1", ocv1.UpgradeConstraintPolicyCatalogProvided)
+	gotBundle, _, _, err := r.Resolve(context.Background(), ce, nil)
+	require.NoError(t, err)
+	assert.Equal(t, bundleName(pkgName, "1.0.0+1"), gotBundle.Name)
+
+	ce = buildFooClusterExtension(pkgName, []string{}, "1.0.0", ocv1.UpgradeConstraintPolicyCatalogProvided)
+	gotBundle, _, _, err = r.Resolve(context.Background(), ce, nil)
+	require.NoError(t, err)
+	assert.Equal(t, bundleName(pkgName, "1.0.0+2"), gotBundle.Name)
+}
+
 func TestChannelDoesNotExist(t *testing.T) {
 	pkgName := randPkg()
 	w := staticCatalogWalker{
@@ -525,6 +559,160 @@ func TestDowngradeNotFound(t *testing.T) {
 	assert.EqualError(t, err, fmt.Sprintf(`error upgrading from currently installed version "1.0.2": no bundles found for package %q matching version ">0.1.0 <1.0.0"`, pkgName))
 }
 
+func TestUpgradeScope(t *testing.T) {
+	pkgName := randPkg()
+	w := staticCatalogWalker{
+		"a": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), nil, nil
+		},
+	}
+	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
+	installedBundle := &ocv1.BundleMetadata{
+		Name:    bundleName(pkgName, "1.0.2"),
+		Version: "1.0.2",
+	}
+
+	t.Run("successor outside of the scope is not resolved", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		ce.Spec.Source.Catalog.UpgradeScope = ocv1.UpgradeScopeMinor
+		// 1.0.2 => 2.0.0 is an upgrade edge, but it changes the major version.
+		gotBundle, _, _, report, err := r.ResolveWithReport(context.Background(), ce, installedBundle)
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "1.0.2"), *gotBundle)
+		require.Len(t, report.Catalogs, 1)
+		assert.Equal(t, []ocv1.ResolutionFilter{
+			{Name: ocv1.ResolutionFilterSuccessor, Eliminated: 4},
+			{Name: ocv1.ResolutionFilterUpgradeScope, Eliminated: 1},
+		}, report.Catalogs[0].Filters)
+		assert.Equal(t, &ocv1.BundleMetadata{Name: bundleName(pkgName, "2.0.0"), Version: "2.0.0"}, report.Catalogs[0].OutOfScopeCandidate)
+	})
+
+	t.Run("only newer bundles within the other filters are reported outside of the scope", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "<2.0.0", ocv1.UpgradeConstraintPolicySelfCertified)
+		ce.Spec.Source.Catalog.UpgradeScope = ocv1.UpgradeScopePatch
+		// 2.0.0 and 3.0.0 are outside of the version range, and the bundles the upgrade scope
+		// eliminates are older than the installed bundle.
+		_, _, _, report, err := r.ResolveWithReport(context.Background(), ce, installedBundle)
+		require.NoError(t, err)
+		require.Len(t, report.Catalogs, 1)
+		assert.Nil(t, report.Catalogs[0].OutOfScopeCandidate)
+	})
+
+	t.Run("successor within the scope is resolved", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		ce.Spec.Source.Catalog.UpgradeScope = ocv1.UpgradeScopeAny
+		gotBundle, _, _, err := r.Resolve(context.Background(), ce, installedBundle)
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "2.0.0"), *gotBundle)
+	})
+
+	t.Run("scope applies to self-certified upgrades", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicySelfCertified)
+		ce.Spec.Source.Catalog.UpgradeScope = ocv1.UpgradeScopePatch
+		// 1.0.0 and 1.0.1 are deprecated, so the installed bundle is preferred.
+		gotBundle, _, _, err := r.Resolve(context.Background(), ce, installedBundle)
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "1.0.2"), *gotBundle)
+	})
+
+	t.Run("scope does not apply to initial installs", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		ce.Spec.Source.Catalog.UpgradeScope = ocv1.UpgradeScopePatch
+		gotBundle, _, _, err := r.Resolve(context.Background(), ce, nil)
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "3.0.0"), *gotBundle)
+	})
+
+	t.Run("no bundle within the scope", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, ">=2.0.0", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		ce.Spec.Source.Catalog.UpgradeScope = ocv1.UpgradeScopeMinor
+		_, _, _, err := r.Resolve(context.Background(), ce, installedBundle)
+		assert.EqualError(t, err, fmt.Sprintf(`error upgrading from currently installed version "1.0.2": no bundles found for package %q matching version ">=2.0.0" within upgrade scope "Minor"`, pkgName))
+	})
+}
+
+func TestSoakTime(t *testing.T) {
+	pkgName := randPkg()
+	clk := clocktesting.NewFakeClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))
+	now := clk.Now()
+	firstSeen := func(version string, age time.Duration) declcfg.Meta {
+		meta, err := firstseen.NewMeta(pkgName, bundleName(pkgName, version), now.Add(-age))
+		require.NoError(t, err)
+		return *meta
+	}
+	w := staticCatalogWalker{
+		"a": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			fbc := genPackage(pkgName)
+			fbc.Others = []declcfg.Meta{
+				firstSeen("1.0.2", 30*24*time.Hour),
+				firstSeen("2.0.0", 3*24*time.Hour),
+				firstSeen("3.0.0", time.Hour),
+			}
+			return fbc, nil, nil
+		},
+	}
+	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs, Clock: clk}
+	installedBundle := &ocv1.BundleMetadata{
+		Name:    bundleName(pkgName, "1.0.2"),
+		Version: "1.0.2",
+	}
+
+	t.Run("bundle that soaked long enough is resolved", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicySelfCertified)
+		ce.Spec.Source.Catalog.SoakTimeMinutes = 2 * 24 * 60
+		gotBundle, _, _, report, err := r.ResolveWithReport(context.Background(), ce, installedBundle)
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "2.0.0"), *gotBundle)
+		require.Len(t, report.Catalogs, 1)
+		// 0.1.0, 1.0.0 and 1.0.1 have no first seen time, and 3.0.0 did not soak long enough
+		assert.Equal(t, []ocv1.ResolutionFilter{{Name: ocv1.ResolutionFilterSoakTime, Eliminated: 4}}, report.Catalogs[0].Filters)
+	})
+
+	t.Run("installed bundle is resolved while upgrades soak", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicySelfCertified)
+		ce.Spec.Source.Catalog.SoakTimeMinutes = 7 * 24 * 60
+		gotBundle, _, _, err := r.Resolve(context.Background(), ce, installedBundle)
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "1.0.2"), *gotBundle)
+	})
+
+	t.Run("soak time does not apply to initial installs", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		ce.Spec.Source.Catalog.SoakTimeMinutes = 7 * 24 * 60
+		gotBundle, _, _, err := r.Resolve(context.Background(), ce, nil)
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "3.0.0"), *gotBundle)
+	})
+
+	t.Run("no bundle soaked long enough", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, ">=2.0.0", ocv1.UpgradeConstraintPolicySelfCertified)
+		ce.Spec.Source.Catalog.SoakTimeMinutes = 7 * 24 * 60
+		_, _, _, err := r.Resolve(context.Background(), ce, installedBundle)
+		assert.EqualError(t, err, fmt.Sprintf(`error upgrading from currently installed version "1.0.2": no bundles found for package %q matching version ">=2.0.0" available for at least 10080 minutes`, pkgName))
+	})
+
+	t.Run("catalog without first seen records is reported", func(t *testing.T) {
+		unrecorded := CatalogResolver{WalkCatalogsFunc: staticCatalogWalker{
+			"b": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+				return genPackage(pkgName), nil, nil
+			},
+		}.WalkCatalogs, Clock: clk}
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicySelfCertified)
+		ce.Spec.Source.Catalog.SoakTimeMinutes = 60
+		_, _, _, err := unrecorded.Resolve(context.Background(), ce, installedBundle)
+		assert.EqualError(t, err, fmt.Sprintf(`error walking catalogs: catalog "b" does not record when the bundles of package %q were first seen, which soak times require: enable the BundleFirstSeen feature gate of catalogd`, pkgName))
+	})
+
+	t.Run("bundles soak as time passes", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicySelfCertified)
+		ce.Spec.Source.Catalog.SoakTimeMinutes = 2 * 24 * 60
+		clk.Step(2 * 24 * time.Hour)
+		gotBundle, _, _, err := r.Resolve(context.Background(), ce, installedBundle)
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "3.0.0"), *gotBundle)
+	})
+}
+
 func TestCatalogWalker(t *testing.T) {
 	t.Run("error listing catalogs", func(t *testing.T) {
 		w := CatalogWalker(
@@ -579,6 +767,61 @@ func TestCatalogWalker(t *testing.T) {
 	})
 }
 
+func TestCatalogIndexWalker(t *testing.T) {
+	catalog := func(ref string) ocv1.ClusterCatalog {
+		return ocv1.ClusterCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: "a"},
+			Status: ocv1.ClusterCatalogStatus{ResolvedSource: &ocv1.ResolvedCatalogSource{
+				Type:  ocv1.SourceTypeImage,
+				Image: &ocv1.ResolvedImageSource{Ref: ref},
+			}},
+		}
+	}
+	catalogs := []ocv1.ClusterCatalog{catalog("quay.io/a@sha256:1")}
+	listCatalogs := func(context.Context, ...client.ListOption) ([]ocv1.ClusterCatalog, error) {
+		return catalogs, nil
+	}
+	var fetches []string
+	var fetchErr error
+	getPackage := func(_ context.Context, cat *ocv1.ClusterCatalog, pkgName string) (*declcfg.DeclarativeConfig, error) {
+		fetches = append(fetches, cat.Status.ResolvedSource.Image.Ref+"/"+pkgName)
+		b := genBundle("foo", "1.0.0")
+		b.Properties = append(b.Properties, property.Property{Type: property.TypeBundleObject, Value: []byte(`{"data":""}`)})
+		return &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{b}}, fetchErr
+	}
+	w := CatalogIndexWalker(listCatalogs, getPackage)
+	walk := func() ([]declcfg.Bundle, error) {
+		var index []declcfg.Bundle
+		err := w(context.Background(), func(_ context.Context, _ *ocv1.ClusterCatalog, bundles []declcfg.Bundle, err error) error {
+			index = append(index, bundles...)
+			return err
+		})
+		return index, err
+	}
+
+	t.Log("By checking the index holds the bundles without their objects")
+	index, err := walk()
+	require.NoError(t, err)
+	assert.Equal(t, []declcfg.Bundle{genBundle("foo", "1.0.0")}, index)
+
+	t.Log("By checking the index is built once per version of the catalog content")
+	_, err = walk()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"quay.io/a@sha256:1/"}, fetches)
+
+	t.Log("By checking errors building the index are not kept")
+	catalogs = []ocv1.ClusterCatalog{catalog("quay.io/a@sha256:2")}
+	fetchErr = errors.New("fake error")
+	_, err = walk()
+	require.EqualError(t, err, "fake error")
+	fetchErr = nil
+	_, err = walk()
+	require.NoError(t, err)
+	_, err = walk()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"quay.io/a@sha256:1/", "quay.io/a@sha256:2/", "quay.io/a@sha256:2/"}, fetches)
+}
+
 func buildFooClusterExtension(pkg string, channels []string, version string, upgradeConstraintPolicy ocv1.UpgradeConstraintPolicy) *ocv1.ClusterExtension {
 	return &ocv1.ClusterExtension{
 		ObjectMeta: metav1.ObjectMeta{
@@ -881,6 +1124,107 @@ func TestMultiplePriority(t *testing.T) {
 	assert.Nil(t, gotDeprecation)
 }
 
+func TestResolutionReport(t *testing.T) {
+	pkgName := randPkg()
+	w := staticCatalogWalker{
+		"a": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), &ocv1.ClusterCatalogSpec{Priority: 1}, nil
+		},
+		"b": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), &ocv1.ClusterCatalogSpec{Priority: 0}, nil
+		},
+		"c": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return &declcfg.DeclarativeConfig{}, nil, nil
+		},
+		"d": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return &declcfg.DeclarativeConfig{
+				Packages: []declcfg.Package{{Name: pkgName}},
+				Channels: []declcfg.Channel{{Package: pkgName, Name: "alpha", Entries: []declcfg.ChannelEntry{{Name: bundleName(pkgName, "0.1.0")}}}},
+				Bundles:  []declcfg.Bundle{genBundle(pkgName, "0.1.0")},
+			}, &ocv1.ClusterCatalogSpec{Priority: 2}, nil
+		},
+	}
+	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
+	ce := buildFooClusterExtension(pkgName, []string{"alpha"}, ">=1.0.0", ocv1.UpgradeConstraintPolicyCatalogProvided)
+	gotBundle, _, _, report, err := r.ResolveWithReport(context.Background(), ce, nil)
+	require.NoError(t, err)
+	require.Equal(t, bundleName(pkgName, "2.0.0"), gotBundle.Name)
+
+	require.Equal(t, bundleName(pkgName, "2.0.0"), report.Bundle)
+	require.Equal(t, "a", report.Catalog)
+	require.Equal(t, fmt.Sprintf(`bundle %q resolved from catalog "a": its priority 1 is higher than the priority of catalogs [b]`, bundleName(pkgName, "2.0.0")), report.Message)
+	filters := func(channel, versionRange int32) []ocv1.ResolutionFilter {
+		return []ocv1.ResolutionFilter{
+			{Name: ocv1.ResolutionFilterChannel, Eliminated: channel},
+			{Name: ocv1.ResolutionFilterVersionRange, Eliminated: versionRange},
+		}
+	}
+	require.ElementsMatch(t, []ocv1.CatalogResolution{
+		{
+			Name: "a", Priority: 1, Result: ocv1.CatalogResolutionSelected, Bundles: 6, Filters: filters(1, 1),
+			Candidates: 4, DeprecatedCandidates: 2, BestCandidate: bundleName(pkgName, "2.0.0"),
+		},
+		{
+			Name: "b", Priority: 0, Result: ocv1.CatalogResolutionLowerPriority, Bundles: 6, Filters: filters(1, 1),
+			Candidates: 4, DeprecatedCandidates: 2, BestCandidate: bundleName(pkgName, "2.0.0"),
+		},
+		{Name: "c", Result: ocv1.CatalogResolutionPackageNotFound},
+		{Name: "d", Priority: 2, Result: ocv1.CatalogResolutionNoCandidates, Bundles: 1, Filters: filters(0, 1)},
+	}, report.Catalogs)
+}
+
+func TestResolutionReportDeprecated(t *testing.T) {
+	pkgName := randPkg()
+	w := staticCatalogWalker{
+		"a": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), nil, nil
+		},
+		"b": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return &declcfg.DeclarativeConfig{
+				Packages: []declcfg.Package{{Name: pkgName}},
+				Channels: []declcfg.Channel{{Package: pkgName, Name: "alpha", Entries: []declcfg.ChannelEntry{{Name: bundleName(pkgName, "1.0.0")}}}},
+				Bundles:  []declcfg.Bundle{genBundle(pkgName, "1.0.0")},
+			}, nil, nil
+		},
+	}
+	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
+	ce := buildFooClusterExtension(pkgName, []string{}, ">=1.0.0 <=1.0.1", ocv1.UpgradeConstraintPolicyCatalogProvided)
+	_, _, _, report, err := r.ResolveWithReport(context.Background(), ce, nil)
+	require.NoError(t, err)
+	require.Equal(t, "b", report.Catalog)
+	require.Contains(t, report.Message, "the best candidates of catalogs [a] are deprecated")
+	for _, cs := range report.Catalogs {
+		switch cs.Name {
+		case "a":
+			require.Equal(t, ocv1.CatalogResolutionDeprecated, cs.Result)
+			require.Equal(t, int32(2), cs.DeprecatedCandidates)
+		case "b":
+			require.Equal(t, ocv1.CatalogResolutionSelected, cs.Result)
+		}
+	}
+}
+
+func TestResolutionReportAmbiguous(t *testing.T) {
+	pkgName := randPkg()
+	w := staticCatalogWalker{
+		"a": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), nil, nil
+		},
+		"b": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), nil, nil
+		},
+	}
+	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
+	ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+	_, _, _, report, err := r.ResolveWithReport(context.Background(), ce, nil)
+	require.Error(t, err)
+	require.Empty(t, report.Bundle)
+	require.Equal(t, err.Error(), report.Message)
+	for _, cs := range report.Catalogs {
+		require.Equal(t, ocv1.CatalogResolutionAmbiguous, cs.Result)
+	}
+}
+
 func TestMultipleChannels(t *testing.T) {
 	pkgName := randPkg()
 	w := staticCatalogWalker{
@@ -934,6 +1278,58 @@ func TestAllCatalogsDisabled(t *testing.T) {
 	assert.Contains(t, err.Error(), "no bundles found for package")
 }
 
+type gitCatalogCache map[string]fs.FS
+
+func (c gitCatalogCache) Get(catalogName, resolvedRef string) (fs.FS, error) {
+	return c[catalogName+"@"+resolvedRef], nil
+}
+
+func (c gitCatalogCache) Put(string, string, io.Reader, error) (fs.FS, error) {
+	return nil, errors.New("unexpected cache population")
+}
+
+func TestGitCatalog(t *testing.T) {
+	const commit = "0123456789abcdef0123456789abcdef01234567"
+	pkgName := randPkg()
+	listCatalogs := func(ctx context.Context, options ...client.ListOption) ([]ocv1.ClusterCatalog, error) {
+		return []ocv1.ClusterCatalog{{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-catalog"},
+			Spec: ocv1.ClusterCatalogSpec{
+				Source: ocv1.CatalogSource{
+					Type: ocv1.SourceTypeGit,
+					Git:  &ocv1.GitSource{URL: "https://github.com/my-org/my-catalog.git"},
+				},
+				AvailabilityMode: ocv1.AvailabilityModeAvailable,
+			},
+			Status: ocv1.ClusterCatalogStatus{
+				Conditions: []metav1.Condition{{Type: ocv1.TypeServing, Status: metav1.ConditionTrue}},
+				ResolvedSource: &ocv1.ResolvedCatalogSource{
+					Type: ocv1.SourceTypeGit,
+					Git:  &ocv1.ResolvedGitSource{Commit: commit},
+				},
+			},
+		}}, nil
+	}
+	catalogFS := fstest.MapFS{
+		pkgName + "/package.json": &fstest.MapFile{Data: []byte(fmt.Sprintf(`{"schema": "olm.package", "name": %q}`, pkgName))},
+		pkgName + "/channel.json": &fstest.MapFile{Data: []byte(fmt.Sprintf(
+			`{"schema": "olm.channel", "package": %[1]q, "name": "alpha", "entries": [{"name": "%[1]s.v1.0.0"}]}`, pkgName))},
+		pkgName + "/bundle.json": &fstest.MapFile{Data: []byte(fmt.Sprintf(
+			`{"schema": "olm.bundle", "package": %[1]q, "name": "%[1]s.v1.0.0", "properties": [{"type": "olm.package", "value": {"packageName": %[1]q, "version": "1.0.0"}}]}`, pkgName))},
+	}
+	catalogClient := catalogclient.New(gitCatalogCache{"git-catalog@" + commit: catalogFS}, nil)
+
+	r := CatalogResolver{
+		WalkCatalogsFunc: CatalogWalker(listCatalogs, catalogClient.GetPackage),
+	}
+
+	ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+	gotBundle, gotVersion, _, err := r.Resolve(context.Background(), ce, nil)
+	require.NoError(t, err)
+	assert.Equal(t, bundleName(pkgName, "1.0.0"), gotBundle.Name)
+	assert.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("1.0.0")}, *gotVersion)
+}
+
 func TestSomeCatalogsDisabled(t *testing.T) {
 	pkgName := randPkg()
 	listCatalogs := func(ctx context.Context, options ...client.ListOption) ([]ocv1.ClusterCatalog, error) {
@@ -974,3 +1370,94 @@ func TestSomeCatalogsDisabled(t *testing.T) {
 	require.NotNil(t, gotBundle)
 	require.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("3.0.0")}, *gotVersion)
 }
+
+func TestUnsatisfiableConstraintsEliminateBundles(t *testing.T) {
+	requiresDep := func(t *testing.T, versionRange string) property.Property {
+		return constraintProperty(t, map[string]any{
+			"package": map[string]any{"packageName": "dep", "versionRange": versionRange},
+		})
+	}
+	w := packageCatalogWalker{
+		"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+			bundleWithProps("root", "1.0.0", requiresDep(t, "<2.0.0")),
+			bundleWithProps("root", "2.0.0", requiresDep(t, ">=2.0.0")),
+			genBundle("dep", "1.0.0"),
+		}}},
+	}
+	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs, ListExtensionsFunc: installedExtensions()}
+	ce := buildFooClusterExtension("root", nil, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+	gotBundle, _, _, err := r.Resolve(context.Background(), ce, nil)
+	require.NoError(t, err)
+	assert.Equal(t, bundleName("root", "1.0.0"), gotBundle.Name)
+
+	t.Log("By satisfying the constraint of the latest bundle with an installed extension")
+	r.ListExtensionsFunc = installedExtensions(installedExtension("my-dep", "dep", "2.1.0"))
+	gotBundle, _, _, err = r.Resolve(context.Background(), ce, nil)
+	require.NoError(t, err)
+	assert.Equal(t, bundleName("root", "2.0.0"), gotBundle.Name)
+}
+
+func TestUnsatisfiableConstraintsError(t *testing.T) {
+	w := packageCatalogWalker{
+		"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+			bundleWithProps("root", "1.0.0", constraintProperty(t, map[string]any{
+				"cel": map[string]any{"rule": `properties.exists(p, p.type == "certified")`},
+			})),
+			bundleWithProps("root", "2.0.0", constraintProperty(t, map[string]any{
+				"failureMessage": "requires dep 3.x",
+				"package":        map[string]any{"packageName": "dep", "versionRange": ">=3.0.0"},
+			})),
+			genBundle("dep", "1.0.0"),
+		}}},
+	}
+	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs, ListExtensionsFunc: installedExtensions()}
+	ce := buildFooClusterExtension("root", nil, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	require.EqualError(t, err, `no bundles found for package "root": `+
+		`bundle "root.v1.0.0" requires cel rule "properties.exists(p, p.type == \"certified\")" which cannot be satisfied; `+
+		`bundle "root.v2.0.0" requires constraint "requires dep 3.x" which cannot be satisfied`)
+}
+
+func TestUnsatisfiableConstraintsEvaluationError(t *testing.T) {
+	w := packageCatalogWalker{
+		"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+			bundleWithProps("root", "1.0.0", constraintProperty(t, map[string]any{
+				"cel": map[string]any{"rule": `properties.exists(p, p.type == "olm.package" && p.value.certified)`},
+			})),
+			genBundle("dep", "1.0.0"),
+		}}},
+	}
+	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs, ListExtensionsFunc: installedExtensions()}
+	ce := buildFooClusterExtension("root", nil, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+	_, _, _, err := r.Resolve(context.Background(), ce, nil)
+	require.EqualError(t, err, `no bundles found for package "root": `+
+		`bundle "root.v1.0.0" requires cel rule "properties.exists(p, p.type == \"olm.package\" && p.value.certified)" which cannot be satisfied: `+
+		`unable to evaluate it against bundle "dep.v1.0.0": error evaluating cel rule "properties.exists(p, p.type == \"olm.package\" && p.value.certified)": no such key: certified`)
+}
+
+func TestConflictingConstraintsEliminateBundles(t *testing.T) {
+	conflictsWith := func(t *testing.T, pkgName string) property.Property {
+		return constraintProperty(t, map[string]any{
+			"not": map[string]any{"constraints": []any{
+				map[string]any{"package": map[string]any{"packageName": pkgName}},
+			}},
+		})
+	}
+	w := packageCatalogWalker{
+		"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+			bundleWithProps("root", "1.0.0", conflictsWith(t, "root")),
+			bundleWithProps("root", "2.0.0", conflictsWith(t, "legacy")),
+		}}},
+	}
+	r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs, ListExtensionsFunc: installedExtensions()}
+	ce := buildFooClusterExtension("root", nil, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+	gotBundle, _, _, err := r.Resolve(context.Background(), ce, nil)
+	require.NoError(t, err)
+	assert.Equal(t, bundleName("root", "2.0.0"), gotBundle.Name)
+
+	t.Log("By installing the package the latest bundle conflicts with")
+	r.ListExtensionsFunc = installedExtensions(installedExtension("my-legacy", "legacy", "1.0.0"))
+	gotBundle, _, _, err = r.Resolve(context.Background(), ce, nil)
+	require.NoError(t, err)
+	assert.Equal(t, bundleName("root", "1.0.0"), gotBundle.Name)
+}
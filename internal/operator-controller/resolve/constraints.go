@@ -0,0 +1,242 @@
+package resolve
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	bsemver "github.com/blang/semver/v4"
+
+	"github.com/operator-framework/api/pkg/constraints"
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	"github.com/operator-framework/operator-controller/internal/operator-controller/bundleutil"
+	slicesutil "github.com/operator-framework/operator-controller/internal/shared/util/slices"
+)
+
+// celEnvironment is shared by all evaluators; building it registers the
+// semver_compare function and is comparatively expensive.
+var celEnvironment = sync.OnceValue(constraints.NewCelEnvironment)
+
+// constraintEvaluator evaluates olm.constraint values against the properties of
+// a bundle. A bundle matches a constraint when:
+//   - cel: the rule evaluates to true for the bundle properties
+//   - package: the bundle belongs to the package and its version is in range
+//   - gvk: the bundle provides the GVK
+//   - all: the bundle matches every nested constraint
+//   - any: the bundle matches at least one nested constraint
+//   - not: the bundle matches none of the nested constraints
+//
+// Constraints that cannot be evaluated against a particular bundle, such as a
+// cel rule that fails on its properties, do not match that bundle. The failure
+// is recorded so that callers can report it rather than leaving it looking
+// like a plain mismatch.
+type constraintEvaluator struct {
+	programs map[string]constraints.CelProgram
+	failures []string
+}
+
+func newConstraintEvaluator() *constraintEvaluator {
+	return &constraintEvaluator{programs: map[string]constraints.CelProgram{}}
+}
+
+// matches reports whether the bundle satisfies the constraint. Invalid
+// constraints are reported as errors, while a constraint that fails to evaluate
+// for a particular bundle does not match it and is recorded as a failure.
+func (e *constraintEvaluator) matches(c constraints.Constraint, b *declcfg.Bundle) (bool, error) {
+	switch {
+	case c.Cel != nil:
+		prog, err := e.program(c.Cel.Rule)
+		if err != nil {
+			return false, err
+		}
+		props, err := celProperties(b)
+		if err != nil {
+			e.fail(b, fmt.Errorf("error converting properties for cel rule %q: %w", c.Cel.Rule, err))
+			return false, nil
+		}
+		ok, err := prog.Evaluate(map[string]interface{}{constraints.PropertiesKey: props})
+		if err != nil {
+			e.fail(b, fmt.Errorf("error evaluating cel rule %q: %w", c.Cel.Rule, err))
+			return false, nil
+		}
+		return ok, nil
+	case c.Package != nil:
+		if b.Package != c.Package.PackageName {
+			return false, nil
+		}
+		if c.Package.VersionRange == "" {
+			return true, nil
+		}
+		versionRange, err := bsemver.ParseRange(c.Package.VersionRange)
+		if err != nil {
+			return false, fmt.Errorf("invalid version range %q: %w", c.Package.VersionRange, err)
+		}
+		v, err := bundleutil.GetVersionAndRelease(*b)
+		if err != nil {
+			e.fail(b, err)
+			return false, nil
+		}
+		return versionRange(v.Version), nil
+	case c.GVK != nil:
+		return providesGVK(b, property.GVKRequired{Group: c.GVK.Group, Version: c.GVK.Version, Kind: c.GVK.Kind}), nil
+	case c.All != nil:
+		for _, sub := range c.All.Constraints {
+			ok, err := e.matches(sub, b)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	case c.Any != nil:
+		for _, sub := range c.Any.Constraints {
+			ok, err := e.matches(sub, b)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case c.Not != nil:
+		for _, sub := range c.Not.Constraints {
+			ok, err := e.matches(sub, b)
+			if err != nil || ok {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+	return false, errors.New("constraint does not declare any of cel, package, gvk, all, any or not")
+}
+
+// maxReportedFailures bounds the number of evaluation failures that are
+// included in a single message.
+const maxReportedFailures = 3
+
+func (e *constraintEvaluator) fail(b *declcfg.Bundle, err error) {
+	msg := fmt.Sprintf("bundle %q: %v", b.Name, err)
+	if !slices.Contains(e.failures, msg) {
+		e.failures = append(e.failures, msg)
+	}
+}
+
+// failed reports whether evaluations failed since the failures were last reset.
+func (e *constraintEvaluator) failed() bool {
+	return len(e.failures) > 0
+}
+
+// resetFailures discards the recorded failures.
+func (e *constraintEvaluator) resetFailures() {
+	e.failures = nil
+}
+
+// takeFailures returns an error summarizing the failures recorded since they
+// were last reset, or nil when there are none, and resets them.
+func (e *constraintEvaluator) takeFailures() error {
+	failures := e.failures
+	e.resetFailures()
+	if len(failures) == 0 {
+		return nil
+	}
+	if len(failures) > maxReportedFailures {
+		failures = append(failures[:maxReportedFailures:maxReportedFailures], fmt.Sprintf("and %d more", len(failures)-maxReportedFailures))
+	}
+	return fmt.Errorf("unable to evaluate it against %s", strings.Join(failures, "; "))
+}
+
+func (e *constraintEvaluator) program(rule string) (constraints.CelProgram, error) {
+	if prog, ok := e.programs[rule]; ok {
+		return prog, nil
+	}
+	prog, err := celEnvironment().Validate(rule)
+	if err != nil {
+		return prog, fmt.Errorf("invalid cel rule %q: %w", rule, err)
+	}
+	e.programs[rule] = prog
+	return prog, nil
+}
+
+// celProperties converts bundle properties into the list of {type, value}
+// maps that cel rules are evaluated against.
+func celProperties(b *declcfg.Bundle) ([]map[string]interface{}, error) {
+	props := make([]map[string]interface{}, 0, len(b.Properties))
+	for _, p := range b.Properties {
+		var v interface{}
+		if err := json.Unmarshal(p.Value, &v); err != nil {
+			return nil, err
+		}
+		props = append(props, map[string]interface{}{"type": p.Type, "value": v})
+	}
+	return props, nil
+}
+
+// describeConstraint renders a constraint for use in error messages. The
+// failure message provided by the bundle author takes precedence.
+func describeConstraint(c constraints.Constraint) string {
+	if c.FailureMessage != "" {
+		return fmt.Sprintf("constraint %q", c.FailureMessage)
+	}
+	return renderConstraint(c)
+}
+
+func renderConstraint(c constraints.Constraint) string {
+	compound := func(op string, cc *constraints.CompoundConstraint) string {
+		return fmt.Sprintf("%s [%s]", op, strings.Join(slicesutil.Map(cc.Constraints, renderConstraint), ", "))
+	}
+	switch {
+	case c.Cel != nil:
+		return fmt.Sprintf("cel rule %q", c.Cel.Rule)
+	case c.Package != nil && c.Package.VersionRange != "":
+		return fmt.Sprintf("package %q with version range %q", c.Package.PackageName, c.Package.VersionRange)
+	case c.Package != nil:
+		return fmt.Sprintf("package %q", c.Package.PackageName)
+	case c.GVK != nil:
+		return fmt.Sprintf("GVK %s/%s, Kind=%s", c.GVK.Group, c.GVK.Version, c.GVK.Kind)
+	case c.All != nil:
+		return compound("all of", c.All)
+	case c.Any != nil:
+		return compound("any of", c.Any)
+	case c.Not != nil:
+		return compound("none of", c.Not)
+	}
+	return "empty constraint"
+}
+
+func hasConstraints(b declcfg.Bundle) bool {
+	return slices.ContainsFunc(b.Properties, func(p property.Property) bool { return p.Type == property.TypeConstraint })
+}
+
+// bundleConstraints returns the olm.constraint values declared by the bundle.
+func bundleConstraints(b *declcfg.Bundle) ([]constraints.Constraint, error) {
+	var cs []constraints.Constraint
+	for _, p := range b.Properties {
+		if p.Type != property.TypeConstraint {
+			continue
+		}
+		c, err := constraints.Parse(p.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s property of bundle %q: %w", property.TypeConstraint, b.Name, err)
+		}
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
+
+// unsatisfiedConstraint records a candidate bundle that was eliminated from
+// resolution because one of its olm.constraint properties cannot be satisfied,
+// along with the failures to evaluate it that may explain why.
+type unsatisfiedConstraint struct {
+	bundle     string
+	constraint string
+	err        error
+}
+
+func (u unsatisfiedConstraint) String() string {
+	if u.err != nil {
+		return fmt.Sprintf("bundle %q requires %s which cannot be satisfied: %v", u.bundle, u.constraint, u.err)
+	}
+	return fmt.Sprintf("bundle %q requires %s which cannot be satisfied", u.bundle, u.constraint)
+}
@@ -0,0 +1,103 @@
+package resolve
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/api/pkg/constraints"
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+)
+
+func TestConstraintEvaluatorMatches(t *testing.T) {
+	b := bundleWithProps("dep", "1.2.0", property.MustBuildGVK("example.com", "v1", "Widget"))
+	pkg := func(name, versionRange string) constraints.Constraint {
+		return constraints.Constraint{Package: &constraints.PackageConstraint{PackageName: name, VersionRange: versionRange}}
+	}
+	cel := func(rule string) constraints.Constraint {
+		return constraints.Constraint{Cel: &constraints.Cel{Rule: rule}}
+	}
+	compound := func(cs ...constraints.Constraint) *constraints.CompoundConstraint {
+		return &constraints.CompoundConstraint{Constraints: cs}
+	}
+
+	for _, tt := range []struct {
+		name       string
+		constraint constraints.Constraint
+		expect     bool
+		expectErr  string
+	}{
+		{name: "package in range", constraint: pkg("dep", ">=1.0.0 <2.0.0"), expect: true},
+		{name: "package out of range", constraint: pkg("dep", ">=2.0.0"), expect: false},
+		{name: "other package", constraint: pkg("other", ""), expect: false},
+		{name: "invalid version range", constraint: pkg("dep", "not-a-range"), expectErr: `invalid version range "not-a-range"`},
+		{name: "gvk", constraint: constraints.Constraint{GVK: &constraints.GVKConstraint{Group: "example.com", Version: "v1", Kind: "Widget"}}, expect: true},
+		{name: "cel match", constraint: cel(`properties.exists(p, p.type == "olm.package" && semver_compare(p.value.version, "1.1.0") > 0)`), expect: true},
+		{name: "cel no match", constraint: cel(`properties.exists(p, p.type == "certified")`), expect: false},
+		{name: "invalid cel rule", constraint: cel(`properties.exists(`), expectErr: `invalid cel rule "properties.exists("`},
+		{name: "all", constraint: constraints.Constraint{All: compound(pkg("dep", ""), pkg("dep", ">=1.0.0"))}, expect: true},
+		{name: "all with failure", constraint: constraints.Constraint{All: compound(pkg("dep", ""), pkg("other", ""))}, expect: false},
+		{name: "any", constraint: constraints.Constraint{Any: compound(pkg("other", ""), pkg("dep", ""))}, expect: true},
+		{name: "any without match", constraint: constraints.Constraint{Any: compound(pkg("other", ""))}, expect: false},
+		{name: "not", constraint: constraints.Constraint{Not: compound(pkg("other", ""))}, expect: true},
+		{name: "not with match", constraint: constraints.Constraint{Not: compound(pkg("other", ""), pkg("dep", ""))}, expect: false},
+		{name: "empty", constraint: constraints.Constraint{}, expectErr: "constraint does not declare any of cel, package, gvk, all, any or not"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newConstraintEvaluator().matches(tt.constraint, &b)
+			if tt.expectErr != "" {
+				require.ErrorContains(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, got)
+		})
+	}
+}
+
+func TestDescribeConstraint(t *testing.T) {
+	c := constraints.Constraint{Any: &constraints.CompoundConstraint{Constraints: []constraints.Constraint{
+		{Package: &constraints.PackageConstraint{PackageName: "dep", VersionRange: ">=1.0.0"}},
+		{Not: &constraints.CompoundConstraint{Constraints: []constraints.Constraint{
+			{GVK: &constraints.GVKConstraint{Group: "example.com", Version: "v1", Kind: "Widget"}},
+		}}},
+		{Cel: &constraints.Cel{Rule: "true"}},
+	}}}
+	assert.Equal(t, `any of [package "dep" with version range ">=1.0.0", none of [GVK example.com/v1, Kind=Widget], cel rule "true"]`, describeConstraint(c))
+
+	c.FailureMessage = "requires dep"
+	assert.Equal(t, `constraint "requires dep"`, describeConstraint(c))
+}
+
+func TestConstraintEvaluatorFailures(t *testing.T) {
+	e := newConstraintEvaluator()
+	failing := constraints.Constraint{Cel: &constraints.Cel{Rule: `properties.exists(p, p.type == "olm.package" && p.value.certified)`}}
+	for _, version := range []string{"1.0.0", "2.0.0", "3.0.0", "4.0.0"} {
+		b := bundleWithProps("dep", version)
+		ok, err := e.matches(failing, &b)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+	b := bundleWithProps("dep", "1.0.0")
+	_, err := e.matches(failing, &b)
+	require.NoError(t, err)
+	assert.True(t, e.failed())
+
+	err = e.takeFailures()
+	require.EqualError(t, err, `unable to evaluate it against `+
+		`bundle "dep.v1.0.0": error evaluating cel rule "properties.exists(p, p.type == \"olm.package\" && p.value.certified)": no such key: certified; `+
+		`bundle "dep.v2.0.0": error evaluating cel rule "properties.exists(p, p.type == \"olm.package\" && p.value.certified)": no such key: certified; `+
+		`bundle "dep.v3.0.0": error evaluating cel rule "properties.exists(p, p.type == \"olm.package\" && p.value.certified)": no such key: certified; `+
+		`and 1 more`)
+	assert.False(t, e.failed())
+	require.NoError(t, e.takeFailures())
+
+	t.Log("By matching a package constraint against a bundle without a version")
+	b = declcfg.Bundle{Name: "dep.unversioned", Package: "dep"}
+	ok, err := e.matches(constraints.Constraint{Package: &constraints.PackageConstraint{PackageName: "dep", VersionRange: ">=1.0.0"}}, &b)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.ErrorContains(t, e.takeFailures(), `unable to evaluate it against bundle "dep.unversioned": `)
+}
@@ -0,0 +1,809 @@
+package resolve
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	bsemver "github.com/blang/semver/v4"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/operator-framework/api/pkg/constraints"
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/bundleutil"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/compare"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/filter"
+)
+
+// DependencyResolver resolves the dependencies declared by a bundle that is
+// about to be installed for a ClusterExtension.
+type DependencyResolver interface {
+	// ResolveDependencies returns the set of packages that satisfy the dependencies
+	// of bundle, including transitive dependencies. Installed ClusterExtensions
+	// are considered before any catalog content.
+	ResolveDependencies(ctx context.Context, ext *ocv1.ClusterExtension, bundle *declcfg.Bundle) ([]ResolvedDependency, error)
+}
+
+type DependencyFunc func(ctx context.Context, ext *ocv1.ClusterExtension, bundle *declcfg.Bundle) ([]ResolvedDependency, error)
+
+func (f DependencyFunc) ResolveDependencies(ctx context.Context, ext *ocv1.ClusterExtension, bundle *declcfg.Bundle) ([]ResolvedDependency, error) {
+	return f(ctx, ext, bundle)
+}
+
+// ResolvedDependency is a package selected to satisfy one or more dependencies.
+// Exactly one of Bundle and InstalledBy is set.
+type ResolvedDependency struct {
+	PackageName string
+
+	// Bundle, Version and Catalog describe the bundle selected from a catalog
+	// when the dependency is not provided by an existing ClusterExtension.
+	Bundle  *declcfg.Bundle
+	Version *declcfg.VersionRelease
+	Catalog string
+
+	// InstalledBy is the name of the ClusterExtension that provides the dependency.
+	// InstalledBundle is nil while that ClusterExtension has not installed a bundle yet.
+	InstalledBy     string
+	InstalledBundle *ocv1.BundleMetadata
+}
+
+// requirement is a single dependency declared by a bundle. A conflict is an
+// olm.constraint with a top-level not: rather than selecting a dependency, it
+// forbids the bundles matching any of its nested constraints.
+type requirement struct {
+	requiredBy string
+	pkg        *property.PackageRequired
+	gvk        *property.GVKRequired
+	constraint *constraints.Constraint
+	conflict   *constraints.Constraint
+}
+
+func (r requirement) String() string {
+	switch {
+	case r.constraint != nil:
+		return describeConstraint(*r.constraint)
+	case r.conflict != nil:
+		return describeConstraint(*r.conflict)
+	case r.pkg != nil && r.pkg.VersionRange != "":
+		return fmt.Sprintf("package %q with version range %q", r.pkg.PackageName, r.pkg.VersionRange)
+	case r.pkg != nil:
+		return fmt.Sprintf("package %q", r.pkg.PackageName)
+	case r.gvk != nil:
+		return fmt.Sprintf("GVK %s/%s, Kind=%s", r.gvk.Group, r.gvk.Version, r.gvk.Kind)
+	}
+	return "unknown requirement"
+}
+
+// bundleRequirements returns the dependencies declared by the properties of the bundle.
+func bundleRequirements(b *declcfg.Bundle) ([]requirement, error) {
+	props, err := property.Parse(b.Properties)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing properties of bundle %q: %w", b.Name, err)
+	}
+	var reqs []requirement
+	for i := range props.PackagesRequired {
+		reqs = append(reqs, requirement{requiredBy: b.Name, pkg: &props.PackagesRequired[i]})
+	}
+	for i := range props.GVKsRequired {
+		reqs = append(reqs, requirement{requiredBy: b.Name, gvk: &props.GVKsRequired[i]})
+	}
+	cs, err := bundleConstraints(b)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cs {
+		switch {
+		case c.Not != nil:
+			reqs = append(reqs, requirement{requiredBy: b.Name, conflict: &c})
+		case c.FailureMessage != "":
+			// Keep the author's failure message for reporting by resolving
+			// the constraint as a whole.
+			reqs = append(reqs, requirement{requiredBy: b.Name, constraint: &c})
+		case c.Package != nil:
+			reqs = append(reqs, requirement{requiredBy: b.Name, pkg: &property.PackageRequired{
+				PackageName:  c.Package.PackageName,
+				VersionRange: c.Package.VersionRange,
+			}})
+		case c.GVK != nil:
+			reqs = append(reqs, requirement{requiredBy: b.Name, gvk: &property.GVKRequired{
+				Group:   c.GVK.Group,
+				Version: c.GVK.Version,
+				Kind:    c.GVK.Kind,
+			}})
+		default:
+			reqs = append(reqs, requirement{requiredBy: b.Name, constraint: &c})
+		}
+	}
+	return reqs, nil
+}
+
+// providesGVK returns true if the bundle declares an olm.gvk property matching the requirement.
+func providesGVK(b *declcfg.Bundle, gvk property.GVKRequired) bool {
+	for _, p := range b.Properties {
+		if p.Type != property.TypeGVK {
+			continue
+		}
+		var provided property.GVK
+		if err := json.Unmarshal(p.Value, &provided); err != nil {
+			continue
+		}
+		if provided.Group == gvk.Group && provided.Version == gvk.Version && provided.Kind == gvk.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// dependencyCandidate is a bundle that may be selected to satisfy a dependency.
+type dependencyCandidate struct {
+	bundle     declcfg.Bundle
+	catalog    string
+	priority   int32
+	deprecated bool
+}
+
+// sortCandidates orders candidates the same way bundles are preferred during
+// resolution: non-deprecated bundles first, then by catalog priority and finally
+// by version and release, highest first.
+func sortCandidates(candidates []dependencyCandidate) {
+	slices.SortStableFunc(candidates, func(a, b dependencyCandidate) int {
+		if a.deprecated != b.deprecated {
+			if a.deprecated {
+				return 1
+			}
+			return -1
+		}
+		if c := cmp.Compare(b.priority, a.priority); c != 0 {
+			return c
+		}
+		return compare.ByVersionAndRelease(a.bundle, b.bundle)
+	})
+}
+
+// preferred returns the leading candidates that share the deprecation status
+// and catalog priority of the first (most preferred) candidate.
+func preferred(candidates []dependencyCandidate) []dependencyCandidate {
+	if len(candidates) == 0 {
+		return nil
+	}
+	end := 1
+	for end < len(candidates) && candidates[end].deprecated == candidates[0].deprecated && candidates[end].priority == candidates[0].priority {
+		end++
+	}
+	return candidates[:end]
+}
+
+// dependencyResolution holds the state of a single dependency resolution.
+type dependencyResolution struct {
+	resolver    *CatalogResolver
+	listOptions []client.ListOption
+	evaluator   *constraintEvaluator
+
+	// kubeVersion is the version of Kubernetes of the cluster that candidates must
+	// support, or nil when their compatibility is not checked.
+	kubeVersion *bsemver.Version
+
+	root      *declcfg.Bundle
+	installed map[string]*ocv1.ClusterExtension
+
+	selected map[string]*ResolvedDependency
+	order    []string
+	queue    []requirement
+
+	packageCandidates map[string][]dependencyCandidate
+	packageBundles    map[string][]dependencyCandidate
+	index             []declcfg.Bundle
+	indexLoaded       bool
+	installedBundles  map[string]*declcfg.Bundle
+}
+
+// newDependencyResolution prepares the resolution of the dependencies of the bundle
+// resolved for ext. The ClusterExtension itself is never considered to provide
+// any of its own dependencies.
+func (r *CatalogResolver) newDependencyResolution(ctx context.Context, ext *ocv1.ClusterExtension, root *declcfg.Bundle) (*dependencyResolution, error) {
+	selector, err := catalogSelector(ext)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &dependencyResolution{
+		resolver:          r,
+		listOptions:       []client.ListOption{client.MatchingLabelsSelector{Selector: selector}},
+		evaluator:         newConstraintEvaluator(),
+		root:              root,
+		installed:         map[string]*ocv1.ClusterExtension{},
+		selected:          map[string]*ResolvedDependency{},
+		packageCandidates: map[string][]dependencyCandidate{},
+		packageBundles:    map[string][]dependencyCandidate{},
+		installedBundles:  map[string]*declcfg.Bundle{},
+	}
+	if r.KubernetesVersionFunc != nil {
+		if d.kubeVersion, err = r.KubernetesVersionFunc(ctx); err != nil {
+			return nil, fmt.Errorf("error getting the Kubernetes version of the cluster: %w", err)
+		}
+	}
+	if r.ListExtensionsFunc == nil {
+		return d, nil
+	}
+	installed, err := r.ListExtensionsFunc(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing ClusterExtensions: %w", err)
+	}
+	for i := range installed {
+		ie := &installed[i]
+		if ie.Name == ext.Name || ie.Spec.Source.Catalog == nil {
+			continue
+		}
+		d.installed[ie.Spec.Source.Catalog.PackageName] = ie
+	}
+	return d, nil
+}
+
+// ResolveDependencies resolves the dependencies declared by bundle across all the
+// ClusterCatalogs selected by the ClusterExtension. Packages already managed by
+// installed ClusterExtensions are always preferred; other dependencies are satisfied
+// by the most preferred catalog bundle, using the same deprecation and priority
+// ordering as Resolve.
+func (r *CatalogResolver) ResolveDependencies(ctx context.Context, ext *ocv1.ClusterExtension, bundle *declcfg.Bundle) ([]ResolvedDependency, error) {
+	l := log.FromContext(ctx)
+
+	d, err := r.newDependencyResolution(ctx, ext, bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs, err := bundleRequirements(bundle)
+	if err != nil {
+		return nil, err
+	}
+	d.queue = append(d.queue, reqs...)
+
+	// Conflicts are checked once every dependency has been selected.
+	var conflicts []requirement
+	for len(d.queue) > 0 {
+		req := d.queue[0]
+		d.queue = d.queue[1:]
+
+		var err error
+		switch {
+		case req.conflict != nil:
+			conflicts = append(conflicts, req)
+		case req.pkg != nil:
+			err = d.resolvePackage(ctx, req)
+		case req.gvk != nil:
+			gvk := *req.gvk
+			err = d.resolveMatching(ctx, func(b *declcfg.Bundle) (bool, error) { return providesGVK(b, gvk), nil })
+		case req.constraint != nil:
+			c := *req.constraint
+			err = d.resolveMatching(ctx, func(b *declcfg.Bundle) (bool, error) { return d.evaluator.matches(c, b) })
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error resolving dependency on %s of bundle %q: %w", req, req.requiredBy, err)
+		}
+	}
+	for _, req := range conflicts {
+		if err := d.checkConflict(ctx, req); err != nil {
+			return nil, fmt.Errorf("bundle %q requires %s: %w", req.requiredBy, req, err)
+		}
+	}
+
+	resolved := make([]ResolvedDependency, 0, len(d.order))
+	for _, pkg := range d.order {
+		resolved = append(resolved, *d.selected[pkg])
+	}
+	l.V(4).Info("dependency resolution succeeded", "bundle", bundle.Name, "dependencies", d.order)
+	return resolved, nil
+}
+
+func (d *dependencyResolution) resolvePackage(ctx context.Context, req requirement) error {
+	pkgName := req.pkg.PackageName
+	var versionRange bsemver.Range
+	if req.pkg.VersionRange != "" {
+		var err error
+		versionRange, err = bsemver.ParseRange(req.pkg.VersionRange)
+		if err != nil {
+			return fmt.Errorf("invalid version range %q: %w", req.pkg.VersionRange, err)
+		}
+	}
+	inRange := func(v bsemver.Version) bool { return versionRange == nil || versionRange(v) }
+
+	if pkgName == d.root.Package {
+		v, err := bundleutil.GetVersionAndRelease(*d.root)
+		if err != nil {
+			return err
+		}
+		if !inRange(v.Version) {
+			return fmt.Errorf("bundle %q being installed does not satisfy the required version range", d.root.Name)
+		}
+		return nil
+	}
+
+	if dep, ok := d.selected[pkgName]; ok {
+		switch {
+		case dep.Version != nil:
+			if !inRange(dep.Version.Version) {
+				return fmt.Errorf("conflicts with bundle %q already selected for package %q", dep.Bundle.Name, pkgName)
+			}
+			return nil
+		case dep.InstalledBundle != nil:
+			return d.checkInstalledVersion(dep.InstalledBy, dep.InstalledBundle, inRange)
+		}
+		return nil
+	}
+
+	if ie, ok := d.installed[pkgName]; ok {
+		dep := &ResolvedDependency{PackageName: pkgName, InstalledBy: ie.Name}
+		if ie.Status.Install != nil {
+			dep.InstalledBundle = &ie.Status.Install.Bundle
+			if err := d.checkInstalledVersion(ie.Name, dep.InstalledBundle, inRange); err != nil {
+				return err
+			}
+		}
+		d.selectDependency(dep)
+		return nil
+	}
+
+	candidates, err := d.candidatesForPackage(ctx, pkgName)
+	if err != nil {
+		return err
+	}
+	candidates = slices.DeleteFunc(slices.Clone(candidates), func(c dependencyCandidate) bool {
+		v, err := bundleutil.GetVersionAndRelease(c.bundle)
+		return err != nil || !inRange(v.Version)
+	})
+	if len(candidates) == 0 {
+		return fmt.Errorf("no bundles found for package %q in the selected catalogs", pkgName)
+	}
+	return d.selectCandidate(pkgName, candidates)
+}
+
+func (d *dependencyResolution) checkInstalledVersion(extName string, bm *ocv1.BundleMetadata, inRange func(bsemver.Version) bool) error {
+	v, err := bsemver.Parse(bm.Version)
+	if err != nil {
+		return fmt.Errorf("error parsing version %q of bundle %q installed by ClusterExtension %q: %w", bm.Version, bm.Name, extName, err)
+	}
+	if !inRange(v) {
+		return fmt.Errorf("version %q installed by ClusterExtension %q does not satisfy the required version range", bm.Version, extName)
+	}
+	return nil
+}
+
+// resolveMatching satisfies a requirement that any bundle matching the provided
+// function fulfills, such as a required GVK or an olm.constraint.
+// The root bundle never satisfies its own requirements.
+func (d *dependencyResolution) resolveMatching(ctx context.Context, match func(*declcfg.Bundle) (bool, error)) error {
+	d.evaluator.resetFailures()
+	for _, pkg := range d.order {
+		dep := d.selected[pkg]
+		if dep.Bundle == nil {
+			continue
+		}
+		if ok, err := match(dep.Bundle); err != nil || ok {
+			return err
+		}
+	}
+
+	// Prefer any installed extension that already satisfies the requirement.
+	if ie, err := d.matchingInstalledExtension(ctx, match); err != nil || ie != nil {
+		if ie != nil {
+			if _, ok := d.selected[ie.Spec.Source.Catalog.PackageName]; !ok {
+				d.selectDependency(&ResolvedDependency{PackageName: ie.Spec.Source.Catalog.PackageName, InstalledBy: ie.Name, InstalledBundle: &ie.Status.Install.Bundle})
+			}
+		}
+		return err
+	}
+
+	candidates, err := d.matchingCandidates(ctx, match)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		if err := d.evaluator.takeFailures(); err != nil {
+			return fmt.Errorf("no bundles satisfying it found in the selected catalogs: %w", err)
+		}
+		return fmt.Errorf("no bundles satisfying it found in the selected catalogs")
+	}
+
+	// A package that is managed by a ClusterExtension which has not installed
+	// a bundle yet is preferred over introducing a new package.
+	for _, c := range candidates {
+		if ie, ok := d.installed[c.bundle.Package]; ok && ie.Status.Install == nil {
+			d.selectDependency(&ResolvedDependency{PackageName: c.bundle.Package, InstalledBy: ie.Name})
+			return nil
+		}
+	}
+
+	// Packages that are already selected or installed do not satisfy the requirement
+	// with their current bundle and must not be selected a second time.
+	candidates = slices.DeleteFunc(candidates, func(c dependencyCandidate) bool {
+		_, selected := d.selected[c.bundle.Package]
+		_, installed := d.installed[c.bundle.Package]
+		return selected || installed
+	})
+	if len(candidates) == 0 {
+		return fmt.Errorf("bundles satisfying it are only found in packages that are already installed or selected with a bundle that does not satisfy it")
+	}
+
+	sortCandidates(candidates)
+	packages := map[string]struct{}{}
+	for _, c := range preferred(candidates) {
+		packages[c.bundle.Package] = struct{}{}
+	}
+	if len(packages) > 1 {
+		names := make([]string, 0, len(packages))
+		for pkg := range packages {
+			names = append(names, pkg)
+		}
+		slices.Sort(names)
+		return fmt.Errorf("found bundles satisfying it in multiple packages %v", names)
+	}
+	pkgName := candidates[0].bundle.Package
+	candidates = slices.DeleteFunc(candidates, func(c dependencyCandidate) bool { return c.bundle.Package != pkgName })
+	return d.selectCandidate(pkgName, candidates)
+}
+
+// checkConflict fails when a selected dependency, other than the bundle declaring
+// the conflict, or an installed ClusterExtension matches any of the constraints
+// nested in the not constraint of the requirement. It also fails when they cannot
+// be evaluated against any of these bundles, as a conflict could go unnoticed.
+func (d *dependencyResolution) checkConflict(ctx context.Context, req requirement) error {
+	d.evaluator.resetFailures()
+	conflicting := constraints.Constraint{Any: req.conflict.Not}
+	match := func(b *declcfg.Bundle) (bool, error) { return d.evaluator.matches(conflicting, b) }
+	for _, pkg := range d.order {
+		dep := d.selected[pkg]
+		if dep.Bundle == nil || dep.Bundle.Name == req.requiredBy {
+			continue
+		}
+		ok, err := match(dep.Bundle)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return fmt.Errorf("bundle %q selected as a dependency conflicts with it", dep.Bundle.Name)
+		}
+	}
+	ie, err := d.matchingInstalledExtension(ctx, match)
+	if err != nil {
+		return err
+	}
+	if ie != nil {
+		return fmt.Errorf("bundle %q installed by ClusterExtension %q conflicts with it", ie.Status.Install.Bundle.Name, ie.Name)
+	}
+	return d.evaluator.takeFailures()
+}
+
+// matchingInstalledExtension returns the first ClusterExtension, ordered by package
+// name, whose installed bundle matches the provided function.
+func (d *dependencyResolution) matchingInstalledExtension(ctx context.Context, match func(*declcfg.Bundle) (bool, error)) (*ocv1.ClusterExtension, error) {
+	installedPackages := make([]string, 0, len(d.installed))
+	for pkg := range d.installed {
+		installedPackages = append(installedPackages, pkg)
+	}
+	slices.Sort(installedPackages)
+	for _, pkg := range installedPackages {
+		ie := d.installed[pkg]
+		if ie.Status.Install == nil {
+			continue
+		}
+		b, err := d.installedBundle(ctx, pkg, ie.Status.Install.Bundle)
+		if err != nil {
+			return nil, err
+		}
+		ok, err := match(b)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return ie, nil
+		}
+	}
+	return nil, nil
+}
+
+// constraintsSatisfiable filters out the bundles declaring an olm.constraint that
+// is satisfied by neither an installed ClusterExtension nor any bundle of another
+// package in the selected catalogs, or a not constraint that an installed
+// ClusterExtension conflicts with. The eliminated bundles are returned along with
+// the first constraint each of them failed and the failures to evaluate it.
+func (d *dependencyResolution) constraintsSatisfiable(ctx context.Context, bundles []declcfg.Bundle) ([]declcfg.Bundle, []unsatisfiedConstraint, error) {
+	var unsatisfied []unsatisfiedConstraint
+	kept := bundles[:0]
+	for i := range bundles {
+		b := &bundles[i]
+		cs, err := bundleConstraints(b)
+		if err != nil {
+			return nil, nil, err
+		}
+		var failed *constraints.Constraint
+		var reason error
+		for j := range cs {
+			d.evaluator.resetFailures()
+			ok, err := d.satisfiable(ctx, b, cs[j])
+			if err != nil {
+				return nil, nil, fmt.Errorf("error evaluating %s of bundle %q: %w", describeConstraint(cs[j]), b.Name, err)
+			}
+			if !ok {
+				failed = &cs[j]
+				reason = d.evaluator.takeFailures()
+				break
+			}
+		}
+		if failed != nil {
+			unsatisfied = append(unsatisfied, unsatisfiedConstraint{bundle: b.Name, constraint: describeConstraint(*failed), err: reason})
+			continue
+		}
+		kept = append(kept, *b)
+	}
+	return kept, unsatisfied, nil
+}
+
+func (d *dependencyResolution) satisfiable(ctx context.Context, b *declcfg.Bundle, c constraints.Constraint) (bool, error) {
+	if c.Not != nil {
+		conflicting := constraints.Constraint{Any: c.Not}
+		ie, err := d.matchingInstalledExtension(ctx, func(candidate *declcfg.Bundle) (bool, error) {
+			return d.evaluator.matches(conflicting, candidate)
+		})
+		// An installed bundle that the constraint cannot be evaluated against
+		// might conflict with it.
+		return ie == nil && !d.evaluator.failed(), err
+	}
+	match := func(candidate *declcfg.Bundle) (bool, error) { return d.evaluator.matches(c, candidate) }
+	if ie, err := d.matchingInstalledExtension(ctx, match); err != nil || ie != nil {
+		return ie != nil, err
+	}
+	// Bundles of the package of b cannot satisfy its constraints, so they are
+	// not evaluated to avoid reporting failures that are irrelevant.
+	packages, err := d.matchingPackages(ctx, func(candidate *declcfg.Bundle) (bool, error) {
+		if candidate.Package == b.Package {
+			return false, nil
+		}
+		return match(candidate)
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, pkg := range packages {
+		candidates, err := d.candidatesForPackage(ctx, pkg)
+		if err != nil {
+			return false, err
+		}
+		for i := range candidates {
+			if ok, err := match(&candidates[i].bundle); err != nil || ok {
+				return ok, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// selectCandidate selects the most preferred of the candidate bundles of a single
+// package and queues the dependencies the selected bundle declares itself.
+func (d *dependencyResolution) selectCandidate(pkgName string, candidates []dependencyCandidate) error {
+	sortCandidates(candidates)
+	catalogs := map[string]struct{}{}
+	for _, c := range preferred(candidates) {
+		catalogs[c.catalog] = struct{}{}
+	}
+	if len(catalogs) > 1 {
+		names := make([]string, 0, len(catalogs))
+		for name := range catalogs {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return fmt.Errorf("found bundles for package %q in multiple catalogs with the same priority %v", pkgName, names)
+	}
+
+	c := candidates[0]
+	v, err := bundleutil.GetVersionAndRelease(c.bundle)
+	if err != nil {
+		return fmt.Errorf("error getting version of bundle %q: %w", c.bundle.Name, err)
+	}
+	for _, validation := range d.resolver.Validations {
+		if err := validation(&c.bundle); err != nil {
+			return fmt.Errorf("validating bundle %q: %w", c.bundle.Name, err)
+		}
+	}
+	reqs, err := bundleRequirements(&c.bundle)
+	if err != nil {
+		return err
+	}
+	d.selectDependency(&ResolvedDependency{PackageName: pkgName, Bundle: &c.bundle, Version: v, Catalog: c.catalog})
+	d.queue = append(d.queue, reqs...)
+	return nil
+}
+
+func (d *dependencyResolution) selectDependency(dep *ResolvedDependency) {
+	d.selected[dep.PackageName] = dep
+	d.order = append(d.order, dep.PackageName)
+}
+
+// candidatesForPackage returns the bundles of a package across all selected catalogs
+// that support the Kubernetes version of the cluster.
+func (d *dependencyResolution) candidatesForPackage(ctx context.Context, pkgName string) ([]dependencyCandidate, error) {
+	if candidates, ok := d.packageCandidates[pkgName]; ok {
+		return candidates, nil
+	}
+	candidates, err := d.bundlesForPackage(ctx, pkgName)
+	if err != nil {
+		return nil, err
+	}
+	if d.kubeVersion != nil {
+		compatible := filter.CompatibleWithKubernetes(*d.kubeVersion)
+		candidates = slices.DeleteFunc(slices.Clone(candidates), func(c dependencyCandidate) bool { return !compatible(c.bundle) })
+	}
+	d.packageCandidates[pkgName] = candidates
+	return candidates, nil
+}
+
+// bundlesForPackage returns all the bundles of a package across all selected catalogs,
+// whatever the Kubernetes versions they support.
+func (d *dependencyResolution) bundlesForPackage(ctx context.Context, pkgName string) ([]dependencyCandidate, error) {
+	if bundles, ok := d.packageBundles[pkgName]; ok {
+		return bundles, nil
+	}
+	bundles, err := d.walkCandidates(ctx, pkgName)
+	if err != nil {
+		return nil, err
+	}
+	d.packageBundles[pkgName] = bundles
+	return bundles, nil
+}
+
+// matchingCandidates returns the bundles of packages other than the root package
+// that match the provided function, across all selected catalogs. Only the content
+// of the packages that the indexes of the catalogs tell match is loaded.
+func (d *dependencyResolution) matchingCandidates(ctx context.Context, match func(*declcfg.Bundle) (bool, error)) ([]dependencyCandidate, error) {
+	packages, err := d.matchingPackages(ctx, match)
+	if err != nil {
+		return nil, err
+	}
+	var matching []dependencyCandidate
+	for _, pkg := range packages {
+		if pkg == d.root.Package {
+			continue
+		}
+		candidates, err := d.candidatesForPackage(ctx, pkg)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range candidates {
+			ok, err := match(&c.bundle)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				matching = append(matching, c)
+			}
+		}
+	}
+	return matching, nil
+}
+
+// matchingPackages returns the names, in order, of the packages with bundles that
+// match the provided function in the indexes of the selected catalogs.
+func (d *dependencyResolution) matchingPackages(ctx context.Context, match func(*declcfg.Bundle) (bool, error)) ([]string, error) {
+	index, err := d.catalogIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	packages := map[string]struct{}{}
+	for i := range index {
+		if _, ok := packages[index[i].Package]; ok {
+			continue
+		}
+		ok, err := match(&index[i])
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			packages[index[i].Package] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(packages))
+	for pkg := range packages {
+		names = append(names, pkg)
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// catalogIndex returns the bundles of the indexes of all selected catalogs. Without
+// an index walker, the content of the entire catalogs is loaded instead.
+func (d *dependencyResolution) catalogIndex(ctx context.Context) ([]declcfg.Bundle, error) {
+	if d.indexLoaded {
+		return d.index, nil
+	}
+	var index []declcfg.Bundle
+	var err error
+	if d.resolver.WalkCatalogIndexesFunc != nil {
+		err = d.resolver.WalkCatalogIndexesFunc(ctx, func(ctx context.Context, cat *ocv1.ClusterCatalog, bundles []declcfg.Bundle, err error) error {
+			if err != nil {
+				return fmt.Errorf("error getting index of catalog %q: %w", cat.Name, err)
+			}
+			index = append(index, bundles...)
+			return nil
+		}, d.listOptions...)
+	} else {
+		err = d.resolver.WalkCatalogsFunc(ctx, "", func(ctx context.Context, cat *ocv1.ClusterCatalog, fbc *declcfg.DeclarativeConfig, err error) error {
+			if err != nil {
+				return fmt.Errorf("error getting content of catalog %q: %w", cat.Name, err)
+			}
+			if fbc != nil {
+				index = append(index, fbc.Bundles...)
+			}
+			return nil
+		}, d.listOptions...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error walking catalogs: %w", err)
+	}
+	d.index, d.indexLoaded = index, true
+	return index, nil
+}
+
+// walkCandidates collects the bundles of a package found in the selected catalogs.
+func (d *dependencyResolution) walkCandidates(ctx context.Context, pkgName string) ([]dependencyCandidate, error) {
+	var candidates []dependencyCandidate
+	if err := d.resolver.WalkCatalogsFunc(ctx, pkgName, func(ctx context.Context, cat *ocv1.ClusterCatalog, fbc *declcfg.DeclarativeConfig, err error) error {
+		if err != nil {
+			return fmt.Errorf("error getting package %q from catalog %q: %w", pkgName, cat.Name, err)
+		}
+		if isFBCEmpty(fbc) {
+			return nil
+		}
+		deprecations := map[string]*declcfg.Deprecation{}
+		for i := range fbc.Deprecations {
+			if _, ok := deprecations[fbc.Deprecations[i].Package]; !ok {
+				deprecations[fbc.Deprecations[i].Package] = &fbc.Deprecations[i]
+			}
+		}
+		for _, b := range fbc.Bundles {
+			candidates = append(candidates, dependencyCandidate{
+				bundle:     b,
+				catalog:    cat.GetName(),
+				priority:   cat.Spec.Priority,
+				deprecated: isDeprecated(b, deprecations[b.Package]),
+			})
+		}
+		return nil
+	}, d.listOptions...); err != nil {
+		return nil, fmt.Errorf("error walking catalogs: %w", err)
+	}
+	return candidates, nil
+}
+
+// installedBundle looks up the catalog content of a bundle installed by a
+// ClusterExtension, even when it does not support the Kubernetes version of the
+// cluster anymore. When none of the selected catalogs contains it anymore, a
+// bundle carrying only its olm.package property is returned instead.
+func (d *dependencyResolution) installedBundle(ctx context.Context, pkgName string, bm ocv1.BundleMetadata) (*declcfg.Bundle, error) {
+	key := strings.Join([]string{pkgName, bm.Name}, "/")
+	if b, ok := d.installedBundles[key]; ok {
+		return b, nil
+	}
+	candidates, err := d.bundlesForPackage(ctx, pkgName)
+	if err != nil {
+		return nil, err
+	}
+	found := &declcfg.Bundle{
+		Package:    pkgName,
+		Name:       bm.Name,
+		Properties: []property.Property{property.MustBuildPackage(pkgName, bm.Version)},
+	}
+	for i := range candidates {
+		if candidates[i].bundle.Name == bm.Name {
+			found = &candidates[i].bundle
+			break
+		}
+	}
+	d.installedBundles[key] = found
+	return found, nil
+}
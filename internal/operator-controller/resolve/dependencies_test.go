@@ -0,0 +1,510 @@
+package resolve
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	bsemver "github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+)
+
+// packageCatalogWalker serves catalog content per package, mimicking the
+// catalog client: an empty package name returns the entire catalog.
+type packageCatalogWalker map[string]struct {
+	priority int32
+	fbc      *declcfg.DeclarativeConfig
+}
+
+func (w packageCatalogWalker) WalkCatalogs(ctx context.Context, pkgName string, f CatalogWalkFunc, opts ...client.ListOption) error {
+	options := client.ListOptions{}
+	for _, opt := range opts {
+		opt.ApplyToList(&options)
+	}
+	for name, c := range w {
+		cat := &ocv1.ClusterCatalog{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{ocv1.MetadataNameLabel: name}},
+			Spec:       ocv1.ClusterCatalogSpec{Priority: c.priority},
+		}
+		if options.LabelSelector != nil && !options.LabelSelector.Matches(labels.Set(cat.Labels)) {
+			continue
+		}
+		fbc := &declcfg.DeclarativeConfig{}
+		for _, b := range c.fbc.Bundles {
+			if pkgName == "" || b.Package == pkgName {
+				fbc.Bundles = append(fbc.Bundles, b)
+			}
+		}
+		for _, d := range c.fbc.Deprecations {
+			if pkgName == "" || d.Package == pkgName {
+				fbc.Deprecations = append(fbc.Deprecations, d)
+			}
+		}
+		if err := f(ctx, cat, fbc, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bundleWithProps(pkg, version string, props ...property.Property) declcfg.Bundle {
+	b := genBundle(pkg, version)
+	b.Properties = append(b.Properties, props...)
+	return b
+}
+
+func constraintProperty(t *testing.T, v any) property.Property {
+	raw, err := json.Marshal(v)
+	require.NoError(t, err)
+	return property.Property{Type: property.TypeConstraint, Value: raw}
+}
+
+func installedExtension(name, pkg, version string) ocv1.ClusterExtension {
+	ce := buildFooClusterExtension(pkg, nil, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+	ce.Name = name
+	if version != "" {
+		ce.Status.Install = &ocv1.ClusterExtensionInstallStatus{
+			Bundle: ocv1.BundleMetadata{Name: bundleName(pkg, version), Version: version},
+		}
+	}
+	return *ce
+}
+
+func installedExtensions(installed ...ocv1.ClusterExtension) func(context.Context) ([]ocv1.ClusterExtension, error) {
+	return func(context.Context) ([]ocv1.ClusterExtension, error) {
+		return installed, nil
+	}
+}
+
+func dependencyNames(deps []ResolvedDependency) []string {
+	var names []string
+	for _, d := range deps {
+		switch {
+		case d.Bundle != nil:
+			names = append(names, d.Catalog+":"+d.Bundle.Name)
+		case d.InstalledBundle != nil:
+			names = append(names, d.InstalledBy+":"+d.InstalledBundle.Name)
+		default:
+			names = append(names, d.InstalledBy+":<pending>")
+		}
+	}
+	return names
+}
+
+func TestResolveDependencies(t *testing.T) {
+	widget := property.GVKRequired{Group: "example.com", Version: "v1", Kind: "Widget"}
+	providesWidget := property.MustBuildGVK(widget.Group, widget.Version, widget.Kind)
+
+	for _, tt := range []struct {
+		name       string
+		root       declcfg.Bundle
+		catalogs   packageCatalogWalker
+		installed  []ocv1.ClusterExtension
+		expectDeps []string
+		expectErr  string
+	}{
+		{
+			name: "no dependencies",
+			root: genBundle("root", "1.0.0"),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("dep", "1.0.0")}}},
+			},
+			expectDeps: nil,
+		},
+		{
+			name: "package dependency picks highest version in range",
+			root: bundleWithProps("root", "1.0.0", property.MustBuildPackageRequired("dep", ">=1.0.0 <2.0.0")),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+					genBundle("dep", "1.0.0"), genBundle("dep", "1.1.0"), genBundle("dep", "2.0.0"),
+				}}},
+			},
+			expectDeps: []string{"a:dep.v1.1.0"},
+		},
+		{
+			name: "package dependency declared via olm.constraint",
+			root: bundleWithProps("root", "1.0.0", constraintProperty(t, map[string]any{
+				"failureMessage": "requires dep",
+				"package":        map[string]any{"packageName": "dep", "versionRange": "<2.0.0"},
+			})),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("dep", "1.0.0"), genBundle("dep", "2.0.0")}}},
+			},
+			expectDeps: []string{"a:dep.v1.0.0"},
+		},
+		{
+			name: "transitive gvk dependency",
+			root: bundleWithProps("root", "1.0.0", property.MustBuildPackageRequired("dep", "")),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+					bundleWithProps("dep", "1.0.0", property.MustBuildGVKRequired(widget.Group, widget.Version, widget.Kind)),
+					bundleWithProps("base", "0.1.0", providesWidget),
+					genBundle("base", "0.2.0"),
+				}}},
+			},
+			expectDeps: []string{"a:dep.v1.0.0", "a:base.v0.1.0"},
+		},
+		{
+			name: "dependency satisfied by installed extension",
+			root: bundleWithProps("root", "1.0.0", property.MustBuildPackageRequired("dep", ">=1.0.0")),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("dep", "1.0.0"), genBundle("dep", "2.0.0")}}},
+			},
+			installed:  []ocv1.ClusterExtension{installedExtension("my-dep", "dep", "1.0.0")},
+			expectDeps: []string{"my-dep:dep.v1.0.0"},
+		},
+		{
+			name:       "dependency provided by extension not yet installed",
+			root:       bundleWithProps("root", "1.0.0", property.MustBuildPackageRequired("dep", ">=1.0.0")),
+			catalogs:   packageCatalogWalker{},
+			installed:  []ocv1.ClusterExtension{installedExtension("my-dep", "dep", "")},
+			expectDeps: []string{"my-dep:<pending>"},
+		},
+		{
+			name: "gvk dependency satisfied by installed extension",
+			root: bundleWithProps("root", "1.0.0", property.MustBuildGVKRequired(widget.Group, widget.Version, widget.Kind)),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+					bundleWithProps("base", "0.1.0", providesWidget),
+					bundleWithProps("other", "1.0.0", providesWidget),
+				}}},
+			},
+			installed:  []ocv1.ClusterExtension{installedExtension("base", "base", "0.1.0")},
+			expectDeps: []string{"base:base.v0.1.0"},
+		},
+		{
+			name: "installed extension outside of required range",
+			root: bundleWithProps("root", "1.0.0", property.MustBuildPackageRequired("dep", ">=2.0.0")),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("dep", "2.0.0")}}},
+			},
+			installed: []ocv1.ClusterExtension{installedExtension("my-dep", "dep", "1.0.0")},
+			expectErr: `error resolving dependency on package "dep" with version range ">=2.0.0" of bundle "root.v1.0.0": version "1.0.0" installed by ClusterExtension "my-dep" does not satisfy the required version range`,
+		},
+		{
+			name: "prefers non-deprecated bundles and higher priority catalogs",
+			root: bundleWithProps("root", "1.0.0", property.MustBuildPackageRequired("dep", "")),
+			catalogs: packageCatalogWalker{
+				"low": {priority: 0, fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("dep", "1.0.2")}}},
+				"high": {priority: 10, fbc: &declcfg.DeclarativeConfig{
+					Bundles:      []declcfg.Bundle{genBundle("dep", "1.0.0"), genBundle("dep", "1.0.1")},
+					Deprecations: []declcfg.Deprecation{packageDeprecation("dep")},
+				}},
+				"mid": {priority: 5, fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("dep", "0.9.0")}}},
+			},
+			expectDeps: []string{"mid:dep.v0.9.0"},
+		},
+		{
+			name: "same package in multiple catalogs with the same priority",
+			root: bundleWithProps("root", "1.0.0", property.MustBuildPackageRequired("dep", "")),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("dep", "1.0.0")}}},
+				"b": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("dep", "1.0.1")}}},
+			},
+			expectErr: `error resolving dependency on package "dep" of bundle "root.v1.0.0": found bundles for package "dep" in multiple catalogs with the same priority [a b]`,
+		},
+		{
+			name: "gvk provided by multiple packages",
+			root: bundleWithProps("root", "1.0.0", property.MustBuildGVKRequired(widget.Group, widget.Version, widget.Kind)),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+					bundleWithProps("base", "0.1.0", providesWidget),
+					bundleWithProps("other", "1.0.0", providesWidget),
+				}}},
+			},
+			expectErr: `error resolving dependency on GVK example.com/v1, Kind=Widget of bundle "root.v1.0.0": found bundles satisfying it in multiple packages [base other]`,
+		},
+		{
+			name: "cel constraint selects matching package",
+			root: bundleWithProps("root", "1.0.0", constraintProperty(t, map[string]any{
+				"cel": map[string]any{"rule": `properties.exists(p, p.type == "olm.package" && p.value.packageName == "dep" && semver_compare(p.value.version, "1.1.0") >= 0)`},
+			})),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+					genBundle("dep", "1.0.0"), genBundle("dep", "1.1.0"), genBundle("dep", "1.2.0"), genBundle("other", "2.0.0"),
+				}}},
+			},
+			expectDeps: []string{"a:dep.v1.2.0"},
+		},
+		{
+			name: "cel constraint not satisfied by the bundle itself",
+			root: bundleWithProps("root", "1.0.0", constraintProperty(t, map[string]any{
+				"cel": map[string]any{"rule": `properties.exists(p, p.type == "olm.package" && p.value.packageName == "root")`},
+			})),
+			catalogs:  packageCatalogWalker{"a": {fbc: &declcfg.DeclarativeConfig{}}},
+			expectErr: `error resolving dependency on cel rule "properties.exists(p, p.type == \"olm.package\" && p.value.packageName == \"root\")" of bundle "root.v1.0.0": no bundles satisfying it found in the selected catalogs`,
+		},
+		{
+			name: "not constraint does not conflict with the bundle itself",
+			root: bundleWithProps("root", "1.0.0", constraintProperty(t, map[string]any{
+				"not": map[string]any{"constraints": []any{
+					map[string]any{"package": map[string]any{"packageName": "root"}},
+				}},
+			})),
+			catalogs:   packageCatalogWalker{"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("root", "2.0.0")}}}},
+			expectDeps: nil,
+		},
+		{
+			name: "not constraint conflicts with installed extension",
+			root: bundleWithProps("root", "1.0.0", constraintProperty(t, map[string]any{
+				"not": map[string]any{"constraints": []any{
+					map[string]any{"package": map[string]any{"packageName": "legacy"}},
+				}},
+			})),
+			catalogs:  packageCatalogWalker{"a": {fbc: &declcfg.DeclarativeConfig{}}},
+			installed: []ocv1.ClusterExtension{installedExtension("my-legacy", "legacy", "1.0.0")},
+			expectErr: `bundle "root.v1.0.0" requires none of [package "legacy"]: bundle "legacy.v1.0.0" installed by ClusterExtension "my-legacy" conflicts with it`,
+		},
+		{
+			name: "not constraint conflicts with selected dependency",
+			root: bundleWithProps("root", "1.0.0",
+				property.MustBuildPackageRequired("dep", ""),
+				constraintProperty(t, map[string]any{
+					"failureMessage": "cannot run alongside dep 1.x",
+					"not": map[string]any{"constraints": []any{
+						map[string]any{"package": map[string]any{"packageName": "dep", "versionRange": "<2.0.0"}},
+					}},
+				}),
+			),
+			catalogs:  packageCatalogWalker{"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("dep", "1.0.0")}}}},
+			expectErr: `bundle "root.v1.0.0" requires constraint "cannot run alongside dep 1.x": bundle "dep.v1.0.0" selected as a dependency conflicts with it`,
+		},
+		{
+			name: "all constraint",
+			root: bundleWithProps("root", "1.0.0", constraintProperty(t, map[string]any{
+				"all": map[string]any{"constraints": []any{
+					map[string]any{"gvk": map[string]any{"group": widget.Group, "version": widget.Version, "kind": widget.Kind}},
+					map[string]any{"package": map[string]any{"packageName": "other", "versionRange": ">=1.0.0"}},
+				}},
+			})),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+					bundleWithProps("base", "0.1.0", providesWidget),
+					bundleWithProps("other", "0.9.0", providesWidget),
+					bundleWithProps("other", "1.0.0", providesWidget),
+				}}},
+			},
+			expectDeps: []string{"a:other.v1.0.0"},
+		},
+		{
+			name: "any constraint satisfied by installed extension",
+			root: bundleWithProps("root", "1.0.0", constraintProperty(t, map[string]any{
+				"any": map[string]any{"constraints": []any{
+					map[string]any{"package": map[string]any{"packageName": "dep"}},
+					map[string]any{"package": map[string]any{"packageName": "alt"}},
+				}},
+			})),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("dep", "1.0.0")}}},
+			},
+			installed:  []ocv1.ClusterExtension{installedExtension("my-alt", "alt", "1.0.0")},
+			expectDeps: []string{"my-alt:alt.v1.0.0"},
+		},
+		{
+			name: "not constraint excludes bundles",
+			root: bundleWithProps("root", "1.0.0", constraintProperty(t, map[string]any{
+				"all": map[string]any{"constraints": []any{
+					map[string]any{"package": map[string]any{"packageName": "dep"}},
+					map[string]any{"not": map[string]any{"constraints": []any{
+						map[string]any{"package": map[string]any{"packageName": "dep", "versionRange": ">=1.1.0"}},
+					}}},
+				}},
+			})),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+					genBundle("dep", "1.0.0"), genBundle("dep", "1.1.0"),
+				}}},
+			},
+			expectDeps: []string{"a:dep.v1.0.0"},
+		},
+		{
+			name: "unsatisfiable constraint reports its failure message",
+			root: bundleWithProps("root", "1.0.0", constraintProperty(t, map[string]any{
+				"failureMessage": "requires a certified dependency",
+				"cel":            map[string]any{"rule": `properties.exists(p, p.type == "certified")`},
+			})),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("dep", "1.0.0")}}},
+			},
+			expectErr: `error resolving dependency on constraint "requires a certified dependency" of bundle "root.v1.0.0": no bundles satisfying it found in the selected catalogs`,
+		},
+		{
+			name: "constraint that cannot be evaluated reports why",
+			root: bundleWithProps("root", "1.0.0", constraintProperty(t, map[string]any{
+				"cel": map[string]any{"rule": `properties.exists(p, p.type == "olm.package" && p.value.certified)`},
+			})),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("dep", "1.0.0")}}},
+			},
+			expectErr: `error resolving dependency on cel rule "properties.exists(p, p.type == \"olm.package\" && p.value.certified)" of bundle "root.v1.0.0": ` +
+				`no bundles satisfying it found in the selected catalogs: unable to evaluate it against bundle "dep.v1.0.0": ` +
+				`error evaluating cel rule "properties.exists(p, p.type == \"olm.package\" && p.value.certified)": no such key: certified`,
+		},
+		{
+			name: "conflict that cannot be evaluated fails",
+			root: bundleWithProps("root", "1.0.0",
+				property.MustBuildPackageRequired("dep", ""),
+				constraintProperty(t, map[string]any{
+					"not": map[string]any{"constraints": []any{
+						map[string]any{"cel": map[string]any{"rule": `properties.exists(p, p.type == "olm.package" && p.value.legacy)`}},
+					}},
+				}),
+			),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("dep", "1.0.0")}}},
+			},
+			expectErr: `bundle "root.v1.0.0" requires none of [cel rule "properties.exists(p, p.type == \"olm.package\" && p.value.legacy)"]: ` +
+				`unable to evaluate it against bundle "dep.v1.0.0": error evaluating cel rule "properties.exists(p, p.type == \"olm.package\" && p.value.legacy)": no such key: legacy`,
+		},
+		{
+			name: "conflicting version ranges",
+			root: bundleWithProps("root", "1.0.0",
+				property.MustBuildPackageRequired("dep", "<2.0.0"),
+				property.MustBuildPackageRequired("lib", ""),
+			),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+					genBundle("dep", "1.0.0"), genBundle("dep", "2.0.0"),
+					bundleWithProps("lib", "1.0.0", property.MustBuildPackageRequired("dep", ">=2.0.0")),
+				}}},
+			},
+			expectErr: `error resolving dependency on package "dep" with version range ">=2.0.0" of bundle "lib.v1.0.0": conflicts with bundle "dep.v1.0.0" already selected for package "dep"`,
+		},
+		{
+			name:      "missing package",
+			root:      bundleWithProps("root", "1.0.0", property.MustBuildPackageRequired("dep", "")),
+			catalogs:  packageCatalogWalker{"a": {fbc: &declcfg.DeclarativeConfig{}}},
+			expectErr: `error resolving dependency on package "dep" of bundle "root.v1.0.0": no bundles found for package "dep" in the selected catalogs`,
+		},
+		{
+			name: "cyclic dependencies",
+			root: bundleWithProps("root", "1.0.0", property.MustBuildPackageRequired("dep", "")),
+			catalogs: packageCatalogWalker{
+				"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+					bundleWithProps("dep", "1.0.0", property.MustBuildPackageRequired("root", "1.0.0")),
+				}}},
+			},
+			expectDeps: []string{"a:dep.v1.0.0"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := CatalogResolver{
+				WalkCatalogsFunc:   tt.catalogs.WalkCatalogs,
+				ListExtensionsFunc: installedExtensions(tt.installed...),
+			}
+			ce := buildFooClusterExtension(tt.root.Package, nil, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+			deps, err := r.ResolveDependencies(context.Background(), ce, &tt.root)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectDeps, dependencyNames(deps))
+		})
+	}
+}
+
+func TestResolveDependenciesCatalogIndex(t *testing.T) {
+	widget := property.GVK{Group: "example.com", Version: "v1", Kind: "Widget"}
+	catalogs := packageCatalogWalker{
+		"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+			bundleWithProps("base", "1.0.0", property.MustBuildGVK(widget.Group, widget.Version, widget.Kind)),
+			genBundle("other", "1.0.0"),
+		}}},
+	}
+	var walked []string
+	r := CatalogResolver{
+		WalkCatalogsFunc: func(ctx context.Context, pkgName string, f CatalogWalkFunc, opts ...client.ListOption) error {
+			walked = append(walked, pkgName)
+			return catalogs.WalkCatalogs(ctx, pkgName, f, opts...)
+		},
+		WalkCatalogIndexesFunc: func(ctx context.Context, f CatalogIndexWalkFunc, opts ...client.ListOption) error {
+			return catalogs.WalkCatalogs(ctx, "", func(ctx context.Context, cat *ocv1.ClusterCatalog, fbc *declcfg.DeclarativeConfig, err error) error {
+				return f(ctx, cat, fbc.Bundles, err)
+			}, opts...)
+		},
+	}
+	root := bundleWithProps("root", "1.0.0", property.MustBuildGVKRequired(widget.Group, widget.Version, widget.Kind))
+	ce := buildFooClusterExtension("root", nil, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+
+	deps, err := r.ResolveDependencies(context.Background(), ce, &root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a:base.v1.0.0"}, dependencyNames(deps))
+	assert.Equal(t, []string{"base"}, walked, "only the content of the package providing the GVK is loaded")
+}
+
+func TestResolveDependenciesVersion(t *testing.T) {
+	r := CatalogResolver{WalkCatalogsFunc: packageCatalogWalker{
+		"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("dep", "1.2.3")}}},
+	}.WalkCatalogs}
+	root := bundleWithProps("root", "1.0.0", property.MustBuildPackageRequired("dep", ""))
+	ce := buildFooClusterExtension("root", nil, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+
+	deps, err := r.ResolveDependencies(context.Background(), ce, &root)
+	require.NoError(t, err)
+	require.Len(t, deps, 1)
+	assert.Equal(t, "dep", deps[0].PackageName)
+	assert.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("1.2.3")}, *deps[0].Version)
+}
+
+func TestResolveDependenciesInvalidCelRule(t *testing.T) {
+	r := CatalogResolver{WalkCatalogsFunc: packageCatalogWalker{
+		"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{genBundle("dep", "1.0.0")}}},
+	}.WalkCatalogs}
+	root := bundleWithProps("root", "1.0.0", constraintProperty(t, map[string]any{
+		"cel": map[string]any{"rule": `properties.exists(p, p.type ==`},
+	}))
+	ce := buildFooClusterExtension("root", nil, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+
+	_, err := r.ResolveDependencies(context.Background(), ce, &root)
+	require.ErrorContains(t, err, `error resolving dependency on cel rule "properties.exists(p, p.type ==" of bundle "root.v1.0.0": invalid cel rule`)
+}
+
+func TestResolveDependenciesKubernetesVersion(t *testing.T) {
+	r := CatalogResolver{
+		WalkCatalogsFunc: packageCatalogWalker{
+			"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+				genBundle("dep", "1.2.3"),
+				bundleWithProps("dep", "2.0.0", property.MustBuild(&property.CSVMetadata{MinKubeVersion: "1.34.0"})),
+			}}},
+		}.WalkCatalogs,
+		KubernetesVersionFunc: func(context.Context) (*bsemver.Version, error) {
+			v := bsemver.MustParse("1.33.2")
+			return &v, nil
+		},
+	}
+	root := bundleWithProps("root", "1.0.0", property.MustBuildPackageRequired("dep", ""))
+	ce := buildFooClusterExtension("root", nil, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+
+	deps, err := r.ResolveDependencies(context.Background(), ce, &root)
+	require.NoError(t, err)
+	require.Len(t, deps, 1)
+	assert.Equal(t, declcfg.VersionRelease{Version: bsemver.MustParse("1.2.3")}, *deps[0].Version)
+
+	t.Log("By checking installed bundles that do not support the Kubernetes version still conflict")
+	r.WalkCatalogsFunc = packageCatalogWalker{
+		"a": {fbc: &declcfg.DeclarativeConfig{Bundles: []declcfg.Bundle{
+			bundleWithProps("base", "1.0.0",
+				property.MustBuildGVK("example.com", "v1", "Widget"),
+				property.MustBuild(&property.CSVMetadata{MinKubeVersion: "1.34.0"}),
+			),
+		}}},
+	}.WalkCatalogs
+	r.ListExtensionsFunc = installedExtensions(installedExtension("my-base", "base", "1.0.0"))
+	root = bundleWithProps("root", "1.0.0", constraintProperty(t, map[string]any{
+		"not": map[string]any{"constraints": []any{
+			map[string]any{"gvk": map[string]any{"group": "example.com", "version": "v1", "kind": "Widget"}},
+		}},
+	}))
+	_, err = r.ResolveDependencies(context.Background(), ce, &root)
+	require.ErrorContains(t, err, `bundle "base.v1.0.0" installed by ClusterExtension "my-base" conflicts with it`)
+}
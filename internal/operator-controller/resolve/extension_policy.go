@@ -0,0 +1,41 @@
+package resolve
+
+import (
+	"fmt"
+	"slices"
+
+	bsemver "github.com/blang/semver/v4"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/filter"
+	filterutil "github.com/operator-framework/operator-controller/internal/shared/util/filter"
+)
+
+// extensionPolicyPredicate returns a predicate matching the bundles of the package in the
+// named catalog that satisfy every one of the given ExtensionPolicies.
+func extensionPolicyPredicate(policies []ocv1.ExtensionPolicy, catalog, packageName string, deprecation *declcfg.Deprecation) (filterutil.Predicate[declcfg.Bundle], error) {
+	var predicates []filterutil.Predicate[declcfg.Bundle]
+	for _, policy := range policies {
+		if len(policy.Spec.AllowedCatalogs) > 0 && !slices.Contains(policy.Spec.AllowedCatalogs, catalog) {
+			return func(declcfg.Bundle) bool { return false }, nil
+		}
+		for _, mv := range policy.Spec.MaxVersions {
+			if mv.PackageName != packageName {
+				continue
+			}
+			maxVersion, err := bsemver.ParseTolerant(mv.Version)
+			if err != nil {
+				return nil, fmt.Errorf("extension policy %q has an invalid maximum version %q for package %q: %w", policy.Name, mv.Version, packageName, err)
+			}
+			predicates = append(predicates, filter.InSemverRange(maxVersion.GTE))
+		}
+		if policy.Spec.DeprecatedBundles == ocv1.DeprecatedBundlesForbid {
+			predicates = append(predicates, func(b declcfg.Bundle) bool {
+				return !isDeprecated(b, deprecation)
+			})
+		}
+	}
+	return filterutil.And(predicates...), nil
+}
@@ -0,0 +1,107 @@
+package resolve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	errorutil "github.com/operator-framework/operator-controller/internal/shared/util/error"
+)
+
+func TestExtensionPolicies(t *testing.T) {
+	pkgName := randPkg()
+	w := staticCatalogWalker{
+		"a": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), nil, nil
+		},
+		"b": func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			return genPackage(pkgName), nil, nil
+		},
+	}
+	newPolicy := func(name string, spec ocv1.ExtensionPolicySpec) ocv1.ExtensionPolicy {
+		return ocv1.ExtensionPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+	}
+	resolverFor := func(policies ...ocv1.ExtensionPolicy) *CatalogResolver {
+		return &CatalogResolver{
+			WalkCatalogsFunc: w.WalkCatalogs,
+			ListExtensionPoliciesFunc: func(context.Context) ([]ocv1.ExtensionPolicy, error) {
+				return policies, nil
+			},
+		}
+	}
+	onlyCatalogB := newPolicy("only-b", ocv1.ExtensionPolicySpec{AllowedCatalogs: []string{"b"}})
+
+	t.Run("bundles of catalogs that are not allowed are not candidates", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		gotBundle, _, _, report, err := resolverFor(onlyCatalogB).ResolveWithReport(context.Background(), ce, nil)
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "3.0.0"), *gotBundle)
+		assert.Equal(t, "b", report.Catalog)
+	})
+
+	t.Run("bundles above the maximum version of the package are not candidates", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		r := resolverFor(onlyCatalogB, newPolicy("max", ocv1.ExtensionPolicySpec{MaxVersions: []ocv1.PackageMaxVersion{
+			{PackageName: pkgName, Version: "2.0.0"},
+			{PackageName: "other", Version: "0.1.0"},
+		}}))
+		gotBundle, _, _, report, err := r.ResolveWithReport(context.Background(), ce, nil)
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "2.0.0"), *gotBundle)
+		require.Len(t, report.Catalogs, 2)
+		for _, cs := range report.Catalogs {
+			if cs.Name == "b" {
+				assert.Equal(t, []ocv1.ResolutionFilter{{Name: ocv1.ResolutionFilterExtensionPolicy, Eliminated: 1}}, cs.Filters)
+			}
+		}
+	})
+
+	t.Run("deprecated bundles are not candidates when forbidden", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "<1.0.2", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		r := resolverFor(onlyCatalogB, newPolicy("no-deprecated", ocv1.ExtensionPolicySpec{DeprecatedBundles: ocv1.DeprecatedBundlesForbid}))
+		gotBundle, _, _, err := r.Resolve(context.Background(), ce, nil)
+		require.NoError(t, err)
+		assert.Equal(t, genBundle(pkgName, "0.1.0"), *gotBundle)
+	})
+
+	t.Run("no bundle allowed by the policies is a policy violation", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		r := resolverFor(newPolicy("max", ocv1.ExtensionPolicySpec{MaxVersions: []ocv1.PackageMaxVersion{{PackageName: pkgName, Version: "0.0.1"}}}))
+		_, _, _, report, err := r.ResolveWithReport(context.Background(), ce, nil)
+		require.Error(t, err)
+		reason, ok := errorutil.ExtractTerminalReason(err)
+		require.True(t, ok)
+		assert.Equal(t, ocv1.ReasonPolicyViolation, reason)
+		assert.EqualError(t, errorutil.UnwrapTerminal(err), fmt.Sprintf("no bundles found for package %q allowed by extension policies", pkgName))
+		assert.Equal(t, errorutil.UnwrapTerminal(err).Error(), report.Message)
+	})
+
+	t.Run("ambiguous resolutions are not policy violations", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		r := resolverFor(newPolicy("max", ocv1.ExtensionPolicySpec{MaxVersions: []ocv1.PackageMaxVersion{{PackageName: pkgName, Version: "2.0.0"}}}))
+		_, _, _, err := r.Resolve(context.Background(), ce, nil)
+		require.Error(t, err)
+		_, ok := errorutil.ExtractTerminalReason(err)
+		assert.False(t, ok)
+	})
+
+	t.Run("policies that cannot be listed fail the resolution", func(t *testing.T) {
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		r := &CatalogResolver{
+			WalkCatalogsFunc: w.WalkCatalogs,
+			ListExtensionPoliciesFunc: func(context.Context) ([]ocv1.ExtensionPolicy, error) {
+				return nil, errors.New("boom")
+			},
+		}
+		_, _, _, err := r.Resolve(context.Background(), ce, nil)
+		assert.EqualError(t, err, "error listing extension policies: boom")
+	})
+}
@@ -0,0 +1,72 @@
+package resolve
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	bsemver "github.com/blang/semver/v4"
+	"k8s.io/client-go/discovery"
+	"k8s.io/utils/clock"
+)
+
+// KubernetesVersionRefreshInterval is how long the version discovered by
+// DiscoverKubernetesVersion is used before it is discovered again.
+const KubernetesVersionRefreshInterval = 10 * time.Minute
+
+// DiscoverKubernetesVersion returns a function suitable for CatalogResolver.KubernetesVersionFunc,
+// which discovers the version of the Kubernetes API server. Its pre-release and build metadata
+// are dropped, so that distributions reporting versions such as "v1.33.2+k3s1" are considered
+// to run Kubernetes 1.33.2.
+//
+// The version is discovered once and cached, and discovered again when it is older than
+// KubernetesVersionRefreshInterval, so that the function can be shared by all the callers of
+// a reconcile. When the version cannot be discovered again, the cached version is returned.
+func DiscoverKubernetesVersion(dc discovery.ServerVersionInterface) func(context.Context) (*bsemver.Version, error) {
+	return discoverKubernetesVersion(dc, clock.RealClock{})
+}
+
+func discoverKubernetesVersion(dc discovery.ServerVersionInterface, clk clock.PassiveClock) func(context.Context) (*bsemver.Version, error) {
+	var (
+		mu           sync.Mutex
+		cached       *bsemver.Version
+		discoveredAt time.Time
+	)
+	return func(context.Context) (*bsemver.Version, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if cached != nil && clk.Since(discoveredAt) < KubernetesVersionRefreshInterval {
+			return copyVersion(cached), nil
+		}
+		v, err := serverVersion(dc)
+		if err != nil {
+			if cached != nil {
+				return copyVersion(cached), nil
+			}
+			return nil, err
+		}
+		cached, discoveredAt = v, clk.Now()
+		return copyVersion(cached), nil
+	}
+}
+
+func serverVersion(dc discovery.ServerVersionInterface) (*bsemver.Version, error) {
+	info, err := dc.ServerVersion()
+	if err != nil {
+		return nil, err
+	}
+	v, err := bsemver.ParseTolerant(info.GitVersion)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing server version %q: %w", info.GitVersion, err)
+	}
+	v.Pre = nil
+	v.Build = nil
+	return &v, nil
+}
+
+// copyVersion returns a copy of v, so that callers cannot modify the cached version
+func copyVersion(v *bsemver.Version) *bsemver.Version {
+	c := *v
+	return &c
+}
@@ -0,0 +1,134 @@
+package resolve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	bsemver "github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/property"
+
+	ocv1 "github.com/operator-framework/operator-controller/api/v1"
+	"github.com/operator-framework/operator-controller/internal/operator-controller/bundleutil"
+)
+
+func TestKubernetesVersion(t *testing.T) {
+	pkgName := randPkg()
+	withKubeVersions := func(minKubeVersion, maxKubeVersion string, versions ...string) func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+		return func() (*declcfg.DeclarativeConfig, *ocv1.ClusterCatalogSpec, error) {
+			fbc := genPackage(pkgName)
+			for i, b := range fbc.Bundles {
+				for _, v := range versions {
+					if b.Name == bundleName(pkgName, v) {
+						fbc.Bundles[i].Properties = append(fbc.Bundles[i].Properties,
+							property.MustBuild(&property.CSVMetadata{MinKubeVersion: minKubeVersion}),
+							property.Property{Type: bundleutil.PropertyMaxKubeVersion, Value: json.RawMessage(fmt.Sprintf("%q", maxKubeVersion))},
+						)
+					}
+				}
+			}
+			return fbc, nil, nil
+		}
+	}
+	kubeVersion := func(context.Context) (*bsemver.Version, error) {
+		v := bsemver.MustParse("1.33.2")
+		return &v, nil
+	}
+
+	t.Run("bundles that do not support the Kubernetes version are not candidates", func(t *testing.T) {
+		w := staticCatalogWalker{"a": withKubeVersions("1.34.0", "1.36", "3.0.0")}
+		r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs, KubernetesVersionFunc: kubeVersion}
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		gotBundle, _, _, report, err := r.ResolveWithReport(context.Background(), ce, nil)
+		require.NoError(t, err)
+		assert.Equal(t, bundleName(pkgName, "2.0.0"), gotBundle.Name)
+		assert.Equal(t, []ocv1.ResolutionFilter{{Name: ocv1.ResolutionFilterKubernetesVersion, Eliminated: 1}}, report.Catalogs[0].Filters)
+	})
+
+	t.Run("no bundle supporting the Kubernetes version is reported", func(t *testing.T) {
+		w := staticCatalogWalker{"a": withKubeVersions("1.25.0", "1.32", "0.1.0", "1.0.0", "1.0.1", "1.0.2", "2.0.0", "3.0.0")}
+		r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs, KubernetesVersionFunc: kubeVersion}
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		_, _, _, report, err := r.ResolveWithReport(context.Background(), ce, nil)
+		require.EqualError(t, err, fmt.Sprintf("no bundles found for package %q compatible with Kubernetes 1.33.2", pkgName))
+		assert.Equal(t, err.Error(), report.Message)
+	})
+
+	t.Run("bundles are not checked without the Kubernetes version", func(t *testing.T) {
+		w := staticCatalogWalker{"a": withKubeVersions("1.34.0", "1.36", "3.0.0")}
+		r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs}
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		gotBundle, _, _, err := r.Resolve(context.Background(), ce, nil)
+		require.NoError(t, err)
+		assert.Equal(t, bundleName(pkgName, "3.0.0"), gotBundle.Name)
+	})
+
+	t.Run("Kubernetes version that cannot be discovered fails the resolution", func(t *testing.T) {
+		w := staticCatalogWalker{"a": withKubeVersions("1.34.0", "1.36", "3.0.0")}
+		r := CatalogResolver{WalkCatalogsFunc: w.WalkCatalogs, KubernetesVersionFunc: func(context.Context) (*bsemver.Version, error) {
+			return nil, errors.New("boom")
+		}}
+		ce := buildFooClusterExtension(pkgName, []string{}, "", ocv1.UpgradeConstraintPolicyCatalogProvided)
+		_, _, _, err := r.Resolve(context.Background(), ce, nil)
+		assert.EqualError(t, err, "error getting the Kubernetes version of the cluster: boom")
+	})
+}
+
+func TestDiscoverKubernetesVersion(t *testing.T) {
+	dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}, FakedServerVersion: &version.Info{GitVersion: "v1.33.2-rc.1+k3s1"}}
+	v, err := DiscoverKubernetesVersion(dc)(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, bsemver.MustParse("1.33.2"), *v)
+
+	dc.FakedServerVersion = &version.Info{GitVersion: "unknown"}
+	_, err = DiscoverKubernetesVersion(dc)(context.Background())
+	assert.ErrorContains(t, err, `error parsing server version "unknown"`)
+}
+
+func TestDiscoverKubernetesVersionCache(t *testing.T) {
+	dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}, FakedServerVersion: &version.Info{GitVersion: "v1.33.2"}}
+	clk := clocktesting.NewFakePassiveClock(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC))
+	kubeVersion := discoverKubernetesVersion(dc, clk)
+
+	t.Log("By checking the version is discovered once")
+	_, err := kubeVersion(context.Background())
+	require.NoError(t, err)
+	dc.FakedServerVersion = &version.Info{GitVersion: "v1.34.0"}
+	v, err := kubeVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, bsemver.MustParse("1.33.2"), *v)
+	assert.Len(t, dc.Actions(), 1)
+
+	t.Log("By checking the version is discovered again once the cached version is stale")
+	clk.SetTime(clk.Now().Add(KubernetesVersionRefreshInterval))
+	v, err = kubeVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, bsemver.MustParse("1.34.0"), *v)
+	assert.Len(t, dc.Actions(), 2)
+
+	t.Log("By checking the cached version is used when it cannot be discovered again")
+	dc.PrependReactor("get", "version", func(clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("unavailable")
+	})
+	clk.SetTime(clk.Now().Add(KubernetesVersionRefreshInterval))
+	v, err = kubeVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, bsemver.MustParse("1.34.0"), *v)
+	assert.Len(t, dc.Actions(), 3)
+
+	t.Log("By checking the error is returned when no version was discovered")
+	_, err = discoverKubernetesVersion(dc, clk)(context.Background())
+	assert.ErrorContains(t, err, "unavailable")
+}
@@ -17,3 +17,9 @@ type Func func(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle
 func (f Func) Resolve(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, error) {
 	return f(ctx, ext, installedBundle)
 }
+
+// ReportingResolver is a Resolver that also reports how the bundle was resolved.
+type ReportingResolver interface {
+	Resolver
+	ResolveWithReport(ctx context.Context, ext *ocv1.ClusterExtension, installedBundle *ocv1.BundleMetadata) (*declcfg.Bundle, *declcfg.VersionRelease, *declcfg.Deprecation, *ocv1.ClusterExtensionResolution, error)
+}
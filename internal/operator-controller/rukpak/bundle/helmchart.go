@@ -0,0 +1,31 @@
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"helm.sh/helm/v3/pkg/chart"
+
+	"github.com/operator-framework/operator-controller/internal/operator-controller/config"
+)
+
+// HelmChart is a bundle packaged as a native Helm chart.
+type HelmChart struct {
+	Chart *chart.Chart
+}
+
+var _ config.SchemaProvider = (*HelmChart)(nil)
+
+// GetConfigSchema returns the schema of the chart values, read from the values.schema.json
+// file of the chart. It returns nil if the chart does not have one, in which case any
+// values are accepted.
+func (hc *HelmChart) GetConfigSchema() (map[string]any, error) {
+	if hc.Chart == nil || len(hc.Chart.Schema) == 0 {
+		return nil, nil
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(hc.Chart.Schema, &schema); err != nil {
+		return nil, fmt.Errorf("error parsing values.schema.json of chart %q: %w", hc.Chart.Name(), err)
+	}
+	return schema, nil
+}
@@ -0,0 +1,52 @@
+// Package firstseen defines the blobs catalogd adds to the content of catalogs to record
+// when it first stored each of their bundles, and reads them back.
+package firstseen
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+)
+
+// Schema is the schema of the blobs recording when a bundle was first seen. Blobs of
+// this schema are reserved to catalogd: they are removed from the content of catalog
+// sources before catalogd records its own.
+const Schema = "olm.catalogd.firstSeen"
+
+// Record records when catalogd first stored a bundle of a catalog. It has the package
+// and name of the bundle it refers to.
+type Record struct {
+	Schema    string    `json:"schema"`
+	Package   string    `json:"package"`
+	Name      string    `json:"name"`
+	FirstSeen time.Time `json:"firstSeen"`
+}
+
+// NewMeta returns the blob recording that the named bundle of pkg was first seen at the given time.
+func NewMeta(pkg, name string, firstSeen time.Time) (*declcfg.Meta, error) {
+	blob, err := json.Marshal(Record{Schema: Schema, Package: pkg, Name: name, FirstSeen: firstSeen.UTC()})
+	if err != nil {
+		return nil, err
+	}
+	// Blobs are stored as JSON lines
+	blob = append(blob, '\n')
+	return &declcfg.Meta{Schema: Schema, Package: pkg, Name: name, Blob: blob}, nil
+}
+
+// FromMetas returns when each bundle recorded by the given blobs was first seen, keyed by
+// bundle name. Blobs of other schemas are ignored.
+func FromMetas(metas []declcfg.Meta) (map[string]time.Time, error) {
+	times := map[string]time.Time{}
+	for _, meta := range metas {
+		if meta.Schema != Schema {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(meta.Blob, &r); err != nil {
+			return nil, err
+		}
+		times[r.Name] = r.FirstSeen
+	}
+	return times, nil
+}
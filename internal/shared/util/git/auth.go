@@ -0,0 +1,111 @@
+package git
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// KnownHostsKey is the key of an ssh auth Secret holding the known_hosts
+// entries used to verify the host key of the git server.
+const KnownHostsKey = "known_hosts"
+
+type authMethod = transport.AuthMethod
+
+// Auth holds the credentials used to access a git repository.
+type Auth struct {
+	Username string
+	Password string
+
+	SSHPrivateKey []byte
+	KnownHosts    []byte
+}
+
+// AuthFromSecret reads the credentials held by the Secret. Basic auth credentials
+// are read from the "username" and "password" keys, and ssh credentials from the
+// "ssh-privatekey" and "known_hosts" keys.
+func AuthFromSecret(secret *corev1.Secret) (*Auth, error) {
+	auth := &Auth{
+		Username:      string(secret.Data[corev1.BasicAuthUsernameKey]),
+		Password:      string(secret.Data[corev1.BasicAuthPasswordKey]),
+		SSHPrivateKey: secret.Data[corev1.SSHAuthPrivateKey],
+		KnownHosts:    secret.Data[KnownHostsKey],
+	}
+	if auth.Password == "" && len(auth.SSHPrivateKey) == 0 {
+		return nil, fmt.Errorf("secret %s/%s must contain either the %q or the %q key", secret.Namespace, secret.Name, corev1.BasicAuthPasswordKey, corev1.SSHAuthPrivateKey)
+	}
+	return auth, nil
+}
+
+// authMethod returns the go-git authentication method matching the transport
+// of the url. A nil Auth results in anonymous access over http(s), while ssh
+// urls always require a private key and the known_hosts entries used to verify
+// the host key of the git server.
+func (a *Auth) authMethod(url string) (authMethod, error) {
+	ep, err := transport.NewEndpoint(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository url %q: %w", url, err)
+	}
+	if a == nil {
+		if ep.Protocol == "ssh" {
+			return nil, fmt.Errorf("an auth Secret with an ssh private key and known_hosts is required to access %q", url)
+		}
+		return nil, nil
+	}
+	switch ep.Protocol {
+	case "http", "https":
+		if a.Password == "" {
+			return nil, fmt.Errorf("a password is required to access %q", url)
+		}
+		return &githttp.BasicAuth{Username: a.Username, Password: a.Password}, nil
+	case "ssh":
+		if len(a.SSHPrivateKey) == 0 {
+			return nil, fmt.Errorf("an ssh private key is required to access %q", url)
+		}
+		user := ep.User
+		if user == "" {
+			user = gitssh.DefaultUsername
+		}
+		keys, err := gitssh.NewPublicKeys(user, a.SSHPrivateKey, "")
+		if err != nil {
+			return nil, fmt.Errorf("invalid ssh private key: %w", err)
+		}
+		if len(a.KnownHosts) == 0 {
+			return nil, fmt.Errorf("known_hosts entries are required to verify the host key of %q", url)
+		}
+		if keys.HostKeyCallback, err = knownHostsCallback(a.KnownHosts); err != nil {
+			return nil, err
+		}
+		return keys, nil
+	}
+	return nil, nil
+}
+
+// knownHostsCallback verifies host keys against the known_hosts entries. The
+// entries are loaded into memory, so the temporary file holding them is removed
+// right away.
+func knownHostsCallback(knownHosts []byte) (ssh.HostKeyCallback, error) {
+	f, err := os.CreateTemp("", "known_hosts-")
+	if err != nil {
+		return nil, fmt.Errorf("error creating known_hosts file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(knownHosts)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error writing known_hosts file: %w", err)
+	}
+	cb, err := knownhosts.New(f.Name())
+	if err != nil {
+		return nil, fmt.Errorf("invalid known_hosts: %w", err)
+	}
+	return cb, nil
+}
@@ -0,0 +1,30 @@
+package git
+
+import (
+	"context"
+	"io/fs"
+	"time"
+)
+
+var _ Puller = (*FakePuller)(nil)
+
+// FakePuller is a test fake that returns preconfigured values for the Puller interface
+type FakePuller struct {
+	FS      fs.FS
+	Commit  string
+	ModTime time.Time
+	Error   error
+
+	DeleteErr error
+}
+
+func (p *FakePuller) Pull(_ context.Context, _ string, _ Source) (fs.FS, string, time.Time, error) {
+	if p.Error != nil {
+		return nil, "", time.Time{}, p.Error
+	}
+	return p.FS, p.Commit, p.ModTime, nil
+}
+
+func (p *FakePuller) Delete(_ context.Context, _ string) error {
+	return p.DeleteErr
+}
@@ -0,0 +1,258 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	fsutil "github.com/operator-framework/operator-controller/internal/shared/util/fs"
+)
+
+// Source identifies the content to pull from a git repository.
+type Source struct {
+	// URL is the location of the repository.
+	URL string
+	// Ref is the branch, tag or commit SHA to pull. The default branch
+	// of the repository is pulled when it is empty.
+	Ref string
+	// Directory is the path, relative to the root of the repository, of the
+	// directory whose contents are returned.
+	Directory string
+	// Auth holds the credentials used to access the repository, if any.
+	Auth *Auth
+}
+
+type Puller interface {
+	// Pull checks out the commit Source.Ref currently points to, and returns the
+	// contents of Source.Directory at that commit along with the commit SHA and
+	// the time the commit was checked out.
+	Pull(context.Context, string, Source) (fs.FS, string, time.Time, error)
+	// Delete removes all the content pulled for the owner.
+	Delete(context.Context, string) error
+}
+
+var commitSHA = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// supportedProtocols are the transports repositories can be pulled with. Local
+// repositories are not supported, as they would expose the filesystem of the puller.
+var supportedProtocols = []string{"http", "https", "ssh"}
+
+// GoGitPuller pulls git repositories with go-git, keeping the checkout of the
+// latest commit pulled for each owner in a directory under BasePath.
+type GoGitPuller struct {
+	BasePath string
+
+	// allowFile allows pulling local repositories, for testing purposes.
+	allowFile bool
+}
+
+func (p *GoGitPuller) Pull(ctx context.Context, ownerID string, src Source) (fs.FS, string, time.Time, error) {
+	l := log.FromContext(ctx, "url", src.URL, "ref", src.Ref)
+	ctx = log.IntoContext(ctx, l)
+
+	if src.Directory != "" && !filepath.IsLocal(src.Directory) {
+		return nil, "", time.Time{}, reconcile.TerminalError(fmt.Errorf("directory %q must be a relative path within the repository", src.Directory))
+	}
+	if err := p.validateURL(src.URL); err != nil {
+		return nil, "", time.Time{}, reconcile.TerminalError(err)
+	}
+	auth, err := src.Auth.authMethod(src.URL)
+	if err != nil {
+		return nil, "", time.Time{}, reconcile.TerminalError(err)
+	}
+
+	refName, commit, err := resolveRef(ctx, src, auth)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+	l = l.WithValues("commit", commit)
+
+	checkoutPath := p.checkoutPath(ownerID, commit)
+	modTime, err := fsutil.GetDirectoryModTime(checkoutPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		l.V(1).Info("checking out commit")
+		if err := p.checkout(ctx, ownerID, src, auth, refName, commit); err != nil {
+			return nil, "", time.Time{}, err
+		}
+		if modTime, err = fsutil.GetDirectoryModTime(checkoutPath); err != nil {
+			return nil, "", time.Time{}, fmt.Errorf("error getting mod time of checkout: %w", err)
+		}
+	case errors.Is(err, fsutil.ErrNotDirectory):
+		if err := fsutil.DeleteReadOnlyRecursive(checkoutPath); err != nil {
+			return nil, "", time.Time{}, err
+		}
+		return nil, "", time.Time{}, fmt.Errorf("unexpected file found at checkout path %q", checkoutPath)
+	case err != nil:
+		return nil, "", time.Time{}, err
+	default:
+		l.V(1).Info("commit already checked out")
+	}
+
+	contentPath := filepath.Join(checkoutPath, src.Directory)
+	if fi, err := os.Stat(contentPath); err != nil || !fi.IsDir() {
+		return nil, "", time.Time{}, fmt.Errorf("directory %q not found in commit %s", src.Directory, commit)
+	}
+	if err := p.garbageCollect(ownerID, commit); err != nil {
+		l.Error(err, "error removing previous checkouts")
+	}
+	return os.DirFS(contentPath), commit, modTime, nil
+}
+
+func (p *GoGitPuller) validateURL(url string) error {
+	ep, err := transport.NewEndpoint(url)
+	if err != nil {
+		return fmt.Errorf("invalid repository url %q: %w", url, err)
+	}
+	if slices.Contains(supportedProtocols, ep.Protocol) || (p.allowFile && ep.Protocol == "file") {
+		return nil
+	}
+	return fmt.Errorf("unsupported protocol %q of repository url %q, must be one of %v", ep.Protocol, url, supportedProtocols)
+}
+
+func (p *GoGitPuller) Delete(_ context.Context, ownerID string) error {
+	return fsutil.DeleteReadOnlyRecursive(p.ownerIDPath(ownerID))
+}
+
+func (p *GoGitPuller) ownerIDPath(ownerID string) string {
+	return filepath.Join(p.BasePath, ownerID)
+}
+
+func (p *GoGitPuller) checkoutPath(ownerID, commit string) string {
+	return filepath.Join(p.ownerIDPath(ownerID), commit)
+}
+
+// resolveRef lists the references of the remote repository to find the reference
+// and commit matching the source ref. The returned reference name is empty when
+// the source ref is a commit SHA.
+func resolveRef(ctx context.Context, src Source, auth authMethod) (plumbing.ReferenceName, string, error) {
+	if commitSHA.MatchString(src.Ref) {
+		return "", src.Ref, nil
+	}
+
+	remote := gogit.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: gogit.DefaultRemoteName, URLs: []string{src.URL}})
+	refs, err := remote.ListContext(ctx, &gogit.ListOptions{Auth: auth, PeelingOption: gogit.AppendPeeled})
+	if err != nil {
+		return "", "", fmt.Errorf("error listing references of %q: %w", src.URL, err)
+	}
+	byName := make(map[plumbing.ReferenceName]*plumbing.Reference, len(refs))
+	for _, ref := range refs {
+		byName[ref.Name()] = ref
+	}
+
+	var candidates []plumbing.ReferenceName
+	switch {
+	case src.Ref == "":
+		head, ok := byName[plumbing.HEAD]
+		if !ok {
+			return "", "", fmt.Errorf("repository %q does not advertise a default branch", src.URL)
+		}
+		if head.Type() == plumbing.HashReference {
+			return "", head.Hash().String(), nil
+		}
+		candidates = []plumbing.ReferenceName{head.Target()}
+	case strings.HasPrefix(src.Ref, "refs/"):
+		candidates = []plumbing.ReferenceName{plumbing.ReferenceName(src.Ref)}
+	default:
+		candidates = []plumbing.ReferenceName{plumbing.NewBranchReferenceName(src.Ref), plumbing.NewTagReferenceName(src.Ref)}
+	}
+	for _, name := range candidates {
+		ref, ok := byName[name]
+		if !ok {
+			continue
+		}
+		// Annotated tags are advertised along with the commit they point to.
+		if peeled, ok := byName[name+"^{}"]; ok {
+			ref = peeled
+		}
+		return name, ref.Hash().String(), nil
+	}
+	return "", "", fmt.Errorf("ref %q not found in repository %q", src.Ref, src.URL)
+}
+
+// checkout fetches the commit into a temporary repository, checks it out and
+// moves the resulting tree, without its .git directory, in place.
+func (p *GoGitPuller) checkout(ctx context.Context, ownerID string, src Source, auth authMethod, refName plumbing.ReferenceName, commit string) error {
+	if err := os.MkdirAll(p.ownerIDPath(ownerID), 0700); err != nil {
+		return fmt.Errorf("error creating checkout directory: %w", err)
+	}
+	tmpDir, err := os.MkdirTemp(p.ownerIDPath(ownerID), ".checkout-")
+	if err != nil {
+		return fmt.Errorf("error creating temporary checkout directory: %w", err)
+	}
+	defer func() {
+		_ = fsutil.DeleteReadOnlyRecursive(tmpDir)
+	}()
+
+	repo, err := gogit.PlainInit(tmpDir, false)
+	if err != nil {
+		return fmt.Errorf("error initializing repository: %w", err)
+	}
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: gogit.DefaultRemoteName, URLs: []string{src.URL}}); err != nil {
+		return fmt.Errorf("error creating remote: %w", err)
+	}
+
+	// A named reference is fetched on its own, without history. A commit SHA may
+	// not be fetched directly from every server, so all the branches and tags are
+	// fetched instead.
+	fetchOpts := &gogit.FetchOptions{Auth: auth, Tags: gogit.NoTags}
+	if refName != "" {
+		fetchOpts.Depth = 1
+		fetchOpts.RefSpecs = []config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", refName, refName))}
+	} else {
+		fetchOpts.Tags = gogit.AllTags
+		fetchOpts.RefSpecs = []config.RefSpec{"+refs/heads/*:refs/remotes/origin/*"}
+	}
+	if err := repo.FetchContext(ctx, fetchOpts); err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("error fetching %q: %w", src.URL, err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("error getting worktree: %w", err)
+	}
+	if err := wt.Checkout(&gogit.CheckoutOptions{Hash: plumbing.NewHash(commit), Force: true}); err != nil {
+		return fmt.Errorf("error checking out commit %s: %w", commit, err)
+	}
+	if err := os.RemoveAll(filepath.Join(tmpDir, gogit.GitDirName)); err != nil {
+		return fmt.Errorf("error removing git metadata from checkout: %w", err)
+	}
+	if err := fsutil.SetReadOnlyRecursive(tmpDir); err != nil {
+		return fmt.Errorf("error making checkout read-only: %w", err)
+	}
+	if err := os.Rename(tmpDir, p.checkoutPath(ownerID, commit)); err != nil {
+		return fmt.Errorf("error moving checkout into place: %w", err)
+	}
+	return nil
+}
+
+// garbageCollect removes every checkout of the owner other than the one for keep.
+func (p *GoGitPuller) garbageCollect(ownerID, keep string) error {
+	entries, err := os.ReadDir(p.ownerIDPath(ownerID))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, entry := range entries {
+		if entry.Name() == keep {
+			continue
+		}
+		errs = append(errs, fsutil.DeleteReadOnlyRecursive(filepath.Join(p.ownerIDPath(ownerID), entry.Name())))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,230 @@
+package git
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/osfs"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// testRepo is a bare repository on disk, populated through an in-memory worktree.
+type testRepo struct {
+	t    *testing.T
+	path string
+	repo *gogit.Repository
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	path := t.TempDir()
+	storage := filesystem.NewStorage(osfs.New(path), cache.NewObjectLRUDefault())
+	repo, err := gogit.Init(storage, memfs.New())
+	require.NoError(t, err)
+	return &testRepo{t: t, path: path, repo: repo}
+}
+
+func (r *testRepo) url() string {
+	return "file://" + r.path
+}
+
+// commit writes the files to the worktree and commits them on the current branch.
+func (r *testRepo) commit(files map[string]string) string {
+	wt, err := r.repo.Worktree()
+	require.NoError(r.t, err)
+	for name, content := range files {
+		require.NoError(r.t, wt.Filesystem.MkdirAll(filepath.Dir(name), 0700))
+		f, err := wt.Filesystem.Create(name)
+		require.NoError(r.t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(r.t, err)
+		require.NoError(r.t, f.Close())
+		_, err = wt.Add(name)
+		require.NoError(r.t, err)
+	}
+	hash, err := wt.Commit("update", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(r.t, err)
+	return hash.String()
+}
+
+func (r *testRepo) branch(name string) {
+	wt, err := r.repo.Worktree()
+	require.NoError(r.t, err)
+	require.NoError(r.t, wt.Checkout(&gogit.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(name), Create: true, Keep: true}))
+}
+
+func (r *testRepo) tag(name, commit string, annotated bool) {
+	var opts *gogit.CreateTagOptions
+	if annotated {
+		opts = &gogit.CreateTagOptions{Message: name, Tagger: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()}}
+	}
+	_, err := r.repo.CreateTag(name, plumbing.NewHash(commit), opts)
+	require.NoError(r.t, err)
+}
+
+func readFile(t *testing.T, fsys fs.FS, name string) string {
+	data, err := fs.ReadFile(fsys, name)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestGoGitPullerPull(t *testing.T) {
+	repo := newTestRepo(t)
+	first := repo.commit(map[string]string{"catalog/index.yaml": "v1", "README.md": "readme"})
+	repo.tag("v1", first, true)
+	repo.tag("light", first, false)
+	repo.branch("dev")
+	dev := repo.commit(map[string]string{"catalog/index.yaml": "dev"})
+	repo.branch("main")
+	second := repo.commit(map[string]string{"catalog/index.yaml": "v2"})
+
+	for _, tt := range []struct {
+		name          string
+		ref           string
+		expectCommit  string
+		expectContent string
+	}{
+		{name: "default branch", ref: "", expectCommit: second, expectContent: "v2"},
+		{name: "branch", ref: "dev", expectCommit: dev, expectContent: "dev"},
+		{name: "fully qualified branch", ref: "refs/heads/dev", expectCommit: dev, expectContent: "dev"},
+		{name: "annotated tag", ref: "v1", expectCommit: first, expectContent: "v1"},
+		{name: "lightweight tag", ref: "light", expectCommit: first, expectContent: "v1"},
+		{name: "commit", ref: first, expectCommit: first, expectContent: "v1"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &GoGitPuller{BasePath: t.TempDir(), allowFile: true}
+			fsys, commit, modTime, err := p.Pull(context.Background(), "test", Source{URL: repo.url(), Ref: tt.ref, Directory: "catalog"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectCommit, commit)
+			assert.False(t, modTime.IsZero())
+			assert.Equal(t, tt.expectContent, readFile(t, fsys, "index.yaml"))
+
+			_, err = fs.Stat(fsys, "../README.md")
+			require.Error(t, err)
+			_, err = os.Stat(filepath.Join(p.BasePath, "test", commit, ".git"))
+			require.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
+
+func TestGoGitPullerPullUpdates(t *testing.T) {
+	repo := newTestRepo(t)
+	first := repo.commit(map[string]string{"index.yaml": "v1"})
+	p := &GoGitPuller{BasePath: t.TempDir(), allowFile: true}
+
+	_, commit, modTime, err := p.Pull(context.Background(), "test", Source{URL: repo.url()})
+	require.NoError(t, err)
+	require.Equal(t, first, commit)
+
+	t.Log("By pulling again without new commits")
+	_, commit, secondModTime, err := p.Pull(context.Background(), "test", Source{URL: repo.url()})
+	require.NoError(t, err)
+	require.Equal(t, first, commit)
+	require.Equal(t, modTime, secondModTime)
+
+	t.Log("By pulling a new commit")
+	second := repo.commit(map[string]string{"index.yaml": "v2"})
+	fsys, commit, _, err := p.Pull(context.Background(), "test", Source{URL: repo.url()})
+	require.NoError(t, err)
+	require.Equal(t, second, commit)
+	assert.Equal(t, "v2", readFile(t, fsys, "index.yaml"))
+
+	t.Log("By checking the previous checkout was removed")
+	entries, err := os.ReadDir(filepath.Join(p.BasePath, "test"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, second, entries[0].Name())
+
+	t.Log("By deleting the owner's content")
+	require.NoError(t, p.Delete(context.Background(), "test"))
+	_, err = os.Stat(filepath.Join(p.BasePath, "test"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestGoGitPullerPullErrors(t *testing.T) {
+	repo := newTestRepo(t)
+	repo.commit(map[string]string{"index.yaml": "v1"})
+
+	for _, tt := range []struct {
+		name           string
+		src            Source
+		expectErr      string
+		expectTerminal bool
+	}{
+		{name: "unknown ref", src: Source{URL: repo.url(), Ref: "missing"}, expectErr: `ref "missing" not found in repository`},
+		{name: "missing directory", src: Source{URL: repo.url(), Directory: "catalog"}, expectErr: `directory "catalog" not found in commit`},
+		{name: "directory outside of the repository", src: Source{URL: repo.url(), Directory: "../catalog"}, expectErr: `directory "../catalog" must be a relative path within the repository`, expectTerminal: true},
+		{name: "missing repository", src: Source{URL: "file://" + filepath.Join(t.TempDir(), "missing")}, expectErr: "error listing references"},
+		{name: "ssh without a private key", src: Source{URL: "ssh://git@example.com/catalog.git", Auth: &Auth{Password: "secret"}}, expectErr: "an ssh private key is required", expectTerminal: true},
+		{name: "ssh without auth", src: Source{URL: "git@example.com:catalog.git"}, expectErr: "an auth Secret with an ssh private key and known_hosts is required", expectTerminal: true},
+		{name: "ssh without known_hosts", src: Source{URL: "ssh://git@example.com/catalog.git", Auth: &Auth{SSHPrivateKey: testSSHKey(t)}}, expectErr: "known_hosts entries are required", expectTerminal: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &GoGitPuller{BasePath: t.TempDir(), allowFile: true}
+			_, _, _, err := p.Pull(context.Background(), "test", tt.src)
+			require.ErrorContains(t, err, tt.expectErr)
+			assert.Equal(t, tt.expectTerminal, errors.Is(err, reconcile.TerminalError(nil)))
+		})
+	}
+}
+
+func TestGoGitPullerRejectsLocalRepositories(t *testing.T) {
+	repo := newTestRepo(t)
+	repo.commit(map[string]string{"index.yaml": "v1"})
+
+	for _, url := range []string{repo.url(), repo.path} {
+		p := &GoGitPuller{BasePath: t.TempDir()}
+		_, _, _, err := p.Pull(context.Background(), "test", Source{URL: url})
+		require.ErrorContains(t, err, `unsupported protocol "file"`)
+		assert.ErrorIs(t, err, reconcile.TerminalError(nil))
+	}
+}
+
+func TestAuthFromSecret(t *testing.T) {
+	secret := func(data map[string]string) *corev1.Secret {
+		s := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "olmv1-system", Name: "git-auth"}, Data: map[string][]byte{}}
+		for k, v := range data {
+			s.Data[k] = []byte(v)
+		}
+		return s
+	}
+
+	auth, err := AuthFromSecret(secret(map[string]string{"username": "user", "password": "token"}))
+	require.NoError(t, err)
+	assert.Equal(t, &Auth{Username: "user", Password: "token"}, auth)
+
+	auth, err = AuthFromSecret(secret(map[string]string{"ssh-privatekey": "key", "known_hosts": "hosts"}))
+	require.NoError(t, err)
+	assert.Equal(t, &Auth{SSHPrivateKey: []byte("key"), KnownHosts: []byte("hosts")}, auth)
+
+	_, err = AuthFromSecret(secret(map[string]string{"username": "user"}))
+	require.EqualError(t, err, `secret olmv1-system/git-auth must contain either the "password" or the "ssh-privatekey" key`)
+}
+
+func testSSHKey(t *testing.T) []byte {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKey(key, "")
+	require.NoError(t, err)
+	return pem.EncodeToMemory(block)
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/client (interfaces: Cache)
+// Source: github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/client (interfaces: Cache,PackageCache)
 //
 // Generated by this command:
 //
-//	mockgen -destination=catalogclient/mock_cache.go -package=catalogclient github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/client Cache
+//	mockgen -destination=catalogclient/mock_cache.go -package=catalogclient github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/client Cache,PackageCache
 //
 
 // Package catalogclient is a generated GoMock package.
@@ -70,3 +70,58 @@ func (mr *MockCacheMockRecorder) Put(catalogName, resolvedRef, source, errToCach
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockCache)(nil).Put), catalogName, resolvedRef, source, errToCache)
 }
+
+// MockPackageCache is a mock of PackageCache interface.
+type MockPackageCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockPackageCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockPackageCacheMockRecorder is the mock recorder for MockPackageCache.
+type MockPackageCacheMockRecorder struct {
+	mock *MockPackageCache
+}
+
+// NewMockPackageCache creates a new mock instance.
+func NewMockPackageCache(ctrl *gomock.Controller) *MockPackageCache {
+	mock := &MockPackageCache{ctrl: ctrl}
+	mock.recorder = &MockPackageCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPackageCache) EXPECT() *MockPackageCacheMockRecorder {
+	return m.recorder
+}
+
+// GetPackage mocks base method.
+func (m *MockPackageCache) GetPackage(catalogName, resolvedRef, pkgName string) (fs.FS, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPackage", catalogName, resolvedRef, pkgName)
+	ret0, _ := ret[0].(fs.FS)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPackage indicates an expected call of GetPackage.
+func (mr *MockPackageCacheMockRecorder) GetPackage(catalogName, resolvedRef, pkgName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPackage", reflect.TypeOf((*MockPackageCache)(nil).GetPackage), catalogName, resolvedRef, pkgName)
+}
+
+// PutPackage mocks base method.
+func (m *MockPackageCache) PutPackage(catalogName, resolvedRef, pkgName, etag string, source io.Reader) (fs.FS, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutPackage", catalogName, resolvedRef, pkgName, etag, source)
+	ret0, _ := ret[0].(fs.FS)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutPackage indicates an expected call of PutPackage.
+func (mr *MockPackageCacheMockRecorder) PutPackage(catalogName, resolvedRef, pkgName, etag, source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPackage", reflect.TypeOf((*MockPackageCache)(nil).PutPackage), catalogName, resolvedRef, pkgName, etag, source)
+}
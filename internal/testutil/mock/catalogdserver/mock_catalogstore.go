@@ -43,10 +43,10 @@ func (m *MockCatalogStore) EXPECT() *MockCatalogStoreMockRecorder {
 }
 
 // GetCatalogData mocks base method.
-func (m *MockCatalogStore) GetCatalogData(catalog string) (*os.File, os.FileInfo, error) {
+func (m *MockCatalogStore) GetCatalogData(catalog string) (server.CatalogFile, os.FileInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetCatalogData", catalog)
-	ret0, _ := ret[0].(*os.File)
+	ret0, _ := ret[0].(server.CatalogFile)
 	ret1, _ := ret[1].(os.FileInfo)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
@@ -58,6 +58,22 @@ func (mr *MockCatalogStoreMockRecorder) GetCatalogData(catalog any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogData", reflect.TypeOf((*MockCatalogStore)(nil).GetCatalogData), catalog)
 }
 
+// GetCatalogDiff mocks base method.
+func (m *MockCatalogStore) GetCatalogDiff(catalog string) (server.CatalogFile, os.FileInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogDiff", catalog)
+	ret0, _ := ret[0].(server.CatalogFile)
+	ret1, _ := ret[1].(os.FileInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCatalogDiff indicates an expected call of GetCatalogDiff.
+func (mr *MockCatalogStoreMockRecorder) GetCatalogDiff(catalog any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogDiff", reflect.TypeOf((*MockCatalogStore)(nil).GetCatalogDiff), catalog)
+}
+
 // GetCatalogFS mocks base method.
 func (m *MockCatalogStore) GetCatalogFS(catalog string) (fs.FS, error) {
 	m.ctrl.T.Helper()
@@ -15,6 +15,7 @@ import (
 
 	graphql "github.com/graphql-go/graphql"
 	graphql0 "github.com/operator-framework/operator-controller/internal/catalogd/graphql"
+	service "github.com/operator-framework/operator-controller/internal/catalogd/service"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -43,18 +44,18 @@ func (m *MockGraphQLService) EXPECT() *MockGraphQLServiceMockRecorder {
 }
 
 // ExecuteQuery mocks base method.
-func (m *MockGraphQLService) ExecuteQuery(catalog string, catalogFS fs.FS, query string) (*graphql.Result, error) {
+func (m *MockGraphQLService) ExecuteQuery(catalog string, catalogFS fs.FS, req service.QueryRequest) (*graphql.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExecuteQuery", catalog, catalogFS, query)
+	ret := m.ctrl.Call(m, "ExecuteQuery", catalog, catalogFS, req)
 	ret0, _ := ret[0].(*graphql.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ExecuteQuery indicates an expected call of ExecuteQuery.
-func (mr *MockGraphQLServiceMockRecorder) ExecuteQuery(catalog, catalogFS, query any) *gomock.Call {
+func (mr *MockGraphQLServiceMockRecorder) ExecuteQuery(catalog, catalogFS, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteQuery", reflect.TypeOf((*MockGraphQLService)(nil).ExecuteQuery), catalog, catalogFS, query)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteQuery", reflect.TypeOf((*MockGraphQLService)(nil).ExecuteQuery), catalog, catalogFS, req)
 }
 
 // GetSchema mocks base method.
@@ -83,3 +84,17 @@ func (mr *MockGraphQLServiceMockRecorder) InvalidateCache(catalog any) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateCache", reflect.TypeOf((*MockGraphQLService)(nil).InvalidateCache), catalog)
 }
+
+// RegisterPersistedQuery mocks base method.
+func (m *MockGraphQLService) RegisterPersistedQuery(name, query string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterPersistedQuery", name, query)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterPersistedQuery indicates an expected call of RegisterPersistedQuery.
+func (mr *MockGraphQLServiceMockRecorder) RegisterPersistedQuery(name, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterPersistedQuery", reflect.TypeOf((*MockGraphQLService)(nil).RegisterPersistedQuery), name, query)
+}
@@ -38,7 +38,7 @@ package mock
 //go:generate mockgen -destination=authorization/mock_authorization.go -package=authorization github.com/operator-framework/operator-controller/internal/operator-controller/authorization PreAuthorizer
 
 // Internal interfaces — operator-controller catalogmetadata
-//go:generate mockgen -destination=catalogclient/mock_cache.go -package=catalogclient github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/client Cache
+//go:generate mockgen -destination=catalogclient/mock_cache.go -package=catalogclient github.com/operator-framework/operator-controller/internal/operator-controller/catalogmetadata/client Cache,PackageCache
 
 // Internal interfaces — operator-controller config
 //go:generate mockgen -destination=config/mock_schemaprovider.go -package=config github.com/operator-framework/operator-controller/internal/operator-controller/config SchemaProvider
@@ -48,7 +48,7 @@ package mock
 //go:generate mockgen -destination=cmcache/mock_cache.go -package=cmcache github.com/operator-framework/operator-controller/internal/operator-controller/contentmanager/cache Cache,Watcher,CloserSyncingSource
 
 // Internal interfaces — operator-controller controllers
-//go:generate mockgen -destination=controllers/mock_controllers.go -package=controllers github.com/operator-framework/operator-controller/internal/operator-controller/controllers CatalogCache,CatalogCachePopulator,RevisionStatesGetter,Applier,RevisionEngine,RevisionEngineFactory
+//go:generate mockgen -destination=controllers/mock_controllers.go -package=controllers github.com/operator-framework/operator-controller/internal/operator-controller/controllers CatalogCache,CatalogCachePopulator,RevisionStatesGetter,Applier,Planner,RevisionEngine,RevisionEngineFactory
 
 // Internal interfaces — rukpak render
 //go:generate mockgen -destination=render/mock_certprovider.go -package=render github.com/operator-framework/operator-controller/internal/operator-controller/rukpak/render CertificateProvider